@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/samoslab/nebula/client/errcode"
+	"github.com/samoslab/nebula/client/progress"
+	"github.com/samoslab/nebula/client/service"
+)
+
+// exit code of errors happened before the daemon answered, code returned by daemon is used as is
+const (
+	exit_usage       = 64 // wrong command line
+	exit_rejected    = 65 // daemon refused the request, such as bad argument or not registered
+	exit_unavailable = 69 // daemon not running or not reachable
+)
+
+// cliError carry the exit code of a failed command
+type cliError struct {
+	code int
+	msg  string
+}
+
+func (e *cliError) Error() string {
+	return e.msg
+}
+
+func usageError(format string, a ...interface{}) error {
+	return &cliError{code: exit_usage, msg: fmt.Sprintf(format, a...)}
+}
+
+// exitCode map error to process exit code, daemon code is errcode.Status or grpc code,
+// http status code is returned by daemon when the request is invalid
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	ce, ok := err.(*cliError)
+	if !ok {
+		return int(errcode.RetUnknown)
+	}
+	if ce.code <= 0 || ce.code > 255 {
+		return exit_rejected
+	}
+	return ce.code
+}
+
+type apiClient struct {
+	base   string
+	client *http.Client
+	// transfer is used for file data, which has no timeout
+	transfer *http.Client
+}
+
+func newAPIClient(addr string) *apiClient {
+	return &apiClient{
+		base:     "http://" + addr + "/api/v1",
+		client:   &http.Client{Timeout: 60 * time.Second},
+		transfer: &http.Client{},
+	}
+}
+
+// call send request to the daemon, body is sent as json with POST, nil body means GET.
+// result is decoded from data of the response if not nil
+func (c *apiClient) call(path string, query url.Values, body interface{}, result interface{}) (*common.UnifiedResponse, error) {
+	u := c.base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	method, reader := http.MethodGet, io.Reader(nil)
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		method, reader = http.MethodPost, bytes.NewReader(buf)
+	}
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return nil, &cliError{code: exit_unavailable, msg: fmt.Sprintf("daemon is not reachable: %v", err)}
+	}
+	defer rsp.Body.Close()
+	res, err := common.DecodeUnifiedHTTPResponse(rsp)
+	if err != nil {
+		return nil, &cliError{code: exit_unavailable, msg: fmt.Sprintf("http status %d, %v", rsp.StatusCode, err)}
+	}
+	if res.Code != 0 {
+		return res, &cliError{code: res.Code, msg: res.Errmsg}
+	}
+	if result != nil && len(res.Data) > 0 {
+		if err = json.Unmarshal(res.Data, result); err != nil {
+			return res, fmt.Errorf("decode response data failed: %v", err)
+		}
+	}
+	return res, nil
+}
+
+// download post body as json and save octet stream response into file dest,
+// the daemon answers with a json response if failed
+func (c *apiClient) download(path string, body interface{}, dest string) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	rsp, err := c.transfer.Post(c.base+path, "application/json", bytes.NewReader(buf))
+	if err != nil {
+		return &cliError{code: exit_unavailable, msg: fmt.Sprintf("daemon is not reachable: %v", err)}
+	}
+	defer rsp.Body.Close()
+	if rsp.Header.Get("Content-Type") != "application/octet-stream" {
+		res, err := common.DecodeUnifiedHTTPResponse(rsp)
+		if err != nil {
+			return &cliError{code: exit_unavailable, msg: fmt.Sprintf("http status %d, %v", rsp.StatusCode, err)}
+		}
+		if res.Code == 0 {
+			return errors.New("daemon returned no data")
+		}
+		return &cliError{code: res.Code, msg: res.Errmsg}
+	}
+	file, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, rsp.Body)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// listAll list all pages of the remote folder
+func (c *apiClient) listAll(dir string, sno uint32, sortType string, asc bool) ([]*daemon.DownFile, error) {
+	files := make([]*daemon.DownFile, 0, list_page_size)
+	for pageNum := uint32(1); ; pageNum++ {
+		page := &daemon.FilePages{}
+		req := &service.ListReq{Path: dir, PageSize: list_page_size, PageNum: pageNum, SortType: sortType, AscOrder: asc, Sno: sno}
+		if _, err := c.call("/store/list", nil, req, page); err != nil {
+			return nil, err
+		}
+		files = append(files, page.Files...)
+		if len(page.Files) < list_page_size || uint32(len(files)) >= page.Total {
+			return files, nil
+		}
+	}
+}
+
+// waitTask poll task status until done, progress of files which local path accepted by match is reported
+func (c *apiClient) waitTask(taskID string, match func(local string) bool, report func(map[string]progress.ProgressCell)) error {
+	for {
+		var status string
+		if _, err := c.call("/task/status", nil, &service.TaskStatusReq{TaskID: taskID}, &status); err != nil {
+			return err
+		}
+		if report != nil {
+			pr := progress.ProgressReadable{}
+			if _, err := c.call("/store/progress", nil, &service.ProgressReq{}, &pr); err == nil {
+				cells := make(map[string]progress.ProgressCell, len(pr.Progress))
+				for k, v := range pr.Progress {
+					if match(v.Local) {
+						cells[k] = v
+					}
+				}
+				report(cells)
+			}
+		}
+		switch status {
+		case daemon.StatusDone.String():
+			return nil
+		case daemon.StatusGotTask.String():
+		default:
+			return errors.New("task status " + status)
+		}
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,610 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/samoslab/nebula/client/progress"
+	"github.com/samoslab/nebula/client/service"
+	"github.com/spf13/pflag"
+)
+
+const list_page_size = 100
+
+const usage = `Usage: nebula-cli [global options] <command> [options] [args]
+
+Commands:
+  register <email>                  register the client, --resend to send verify code again
+  verify-email <code>               verify email with the code
+  ls [remote-dir]                   list files, default is /
+  find [remote-dir]                 search files under folder, default is /
+  mkdir <remote-dir>                create folder
+  put <local> <remote-dir>          upload file, -r to upload directory
+  get <remote> <local-dir>          download file, -r to download directory
+  mv <remote-src> <remote-dest>     move or rename file
+  rm <remote>                       remove file, -r to remove folder recursively
+  mount <mountpoint>                mount space as filesystem on linux until interrupted
+  space password <password>         set password of privacy space
+  space verify <password>           verify password of privacy space
+  space status                      show whether password of privacy space is set
+  orders                            list orders, --expired to include expired ones
+  packages                          list packages
+  usage                             show usage amount
+
+Password of privacy space can be given by NEBULA_SPACE_PASSWORD instead of argument.
+Exit code is 0 on success, the error code returned by the daemon on failure,
+64 on wrong usage, 65 if the daemon rejected the request and 69 if the daemon is not reachable.
+
+Global options:
+`
+
+type cli struct {
+	api      *apiClient
+	jsonOut  bool
+	quiet    bool
+	lastResp *common.UnifiedResponse
+}
+
+func main() {
+	pflag.CommandLine.SetInterspersed(false)
+	serverAddr := pflag.StringP("server", "s", "127.0.0.1:7788", "address of client daemon http api ip:port")
+	jsonOut := pflag.BoolP("json", "j", false, "print response of daemon as json")
+	quiet := pflag.BoolP("quiet", "q", false, "do not print progress")
+	pflag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		pflag.PrintDefaults()
+	}
+	pflag.Parse()
+	args := pflag.Args()
+	if len(args) == 0 {
+		pflag.Usage()
+		os.Exit(exit_usage)
+	}
+	c := &cli{api: newAPIClient(*serverAddr), jsonOut: *jsonOut, quiet: *quiet}
+	err := c.run(args[0], args[1:])
+	c.printResult(err)
+	os.Exit(exitCode(err))
+}
+
+func (c *cli) run(cmd string, args []string) error {
+	switch cmd {
+	case "register":
+		return c.register(args)
+	case "verify-email":
+		return c.verifyEmail(args)
+	case "ls":
+		return c.list(args)
+	case "find":
+		return c.find(args)
+	case "mkdir":
+		return c.mkdir(args)
+	case "put":
+		return c.put(args)
+	case "get":
+		return c.get(args)
+	case "mv":
+		return c.move(args)
+	case "rm":
+		return c.remove(args)
+	case "space":
+		return c.space(args)
+	case "mount":
+		return c.mount(args)
+	case "orders":
+		return c.orders(args)
+	case "packages":
+		return c.simpleGet("packages", "/package/all", args)
+	case "usage":
+		return c.simpleGet("usage", "/usage/amount", args)
+	case "help":
+		pflag.Usage()
+		return nil
+	default:
+		return usageError("unknown command %s, run nebula-cli help for usage", cmd)
+	}
+}
+
+// printResult print the last response in json mode, or the error message
+func (c *cli) printResult(err error) {
+	if c.jsonOut {
+		rsp := c.lastResp
+		if rsp == nil || (err != nil && rsp.Code == 0) {
+			rsp = &common.UnifiedResponse{Code: exitCode(err), Data: json.RawMessage("null")}
+			if err != nil {
+				rsp.Errmsg = err.Error()
+			}
+		}
+		buf, _ := json.Marshal(rsp)
+		fmt.Println(string(buf))
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	}
+}
+
+func (c *cli) call(path string, query url.Values, body interface{}, result interface{}) error {
+	rsp, err := c.api.call(path, query, body, result)
+	if rsp != nil {
+		c.lastResp = rsp
+	}
+	return err
+}
+
+// printf print human readable output, suppressed in json mode
+func (c *cli) printf(format string, a ...interface{}) {
+	if !c.jsonOut {
+		fmt.Printf(format, a...)
+	}
+}
+
+func (c *cli) printData(v interface{}) {
+	if c.jsonOut {
+		return
+	}
+	buf, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Printf("%v\n", v)
+		return
+	}
+	fmt.Println(string(buf))
+}
+
+func newFlagSet(name string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Options of %s:\n", name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parse options of command and check the number of positional arguments
+func parseArgs(fs *pflag.FlagSet, args []string, min, max int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, usageError("%v", err)
+	}
+	rest := fs.Args()
+	if len(rest) < min || len(rest) > max {
+		fs.Usage()
+		return nil, usageError("wrong number of arguments")
+	}
+	return rest, nil
+}
+
+// remotePath clean the path of remote file, relative path is relative to root
+func remotePath(p string) string {
+	return path.Clean("/" + p)
+}
+
+func (c *cli) register(args []string) error {
+	fs := newFlagSet("register")
+	resend := fs.Bool("resend", false, "resend verify code to the email")
+	rest, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if err = c.call("/store/register", nil, &service.RegisterReq{Email: rest[0], Resend: *resend}, nil); err != nil {
+		return err
+	}
+	c.printf("verify code is sent to %s\n", rest[0])
+	return nil
+}
+
+func (c *cli) verifyEmail(args []string) error {
+	rest, err := parseArgs(newFlagSet("verify-email"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if err = c.call("/store/verifyemail", nil, &service.VerifyEmailReq{Code: rest[0]}, nil); err != nil {
+		return err
+	}
+	c.printf("email verified\n")
+	return nil
+}
+
+func (c *cli) list(args []string) error {
+	fs := newFlagSet("ls")
+	sno := fs.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	sortType := fs.String("sort", "name", "sort by name, size or modtime")
+	desc := fs.Bool("desc", false, "sort in descending order")
+	rest, err := parseArgs(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	dir := "/"
+	if len(rest) == 1 {
+		dir = remotePath(rest[0])
+	}
+	files, err := c.api.listAll(dir, *sno, *sortType, !*desc)
+	if err != nil {
+		return err
+	}
+	if c.jsonOut {
+		data, _ := json.Marshal(&daemon.FilePages{Total: uint32(len(files)), Files: files})
+		c.lastResp = &common.UnifiedResponse{Data: data}
+		return nil
+	}
+	for _, f := range files {
+		kind := "-"
+		if f.Folder {
+			kind = "d"
+		}
+		modTime := time.Unix(int64(f.ModTime), 0).Format("2006-01-02 15:04")
+		fmt.Printf("%s %12d %s %s\n", kind, f.FileSize, modTime, f.FileName)
+	}
+	return nil
+}
+
+// parseTime parse date like 2006-01-02, or duration before now like 72h
+func parseTime(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return uint64(time.Now().Add(-d).Unix()), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return 0, usageError("invalid time %s, use date like 2006-01-02 or duration like 72h", s)
+	}
+	return uint64(t.Unix()), nil
+}
+
+func (c *cli) find(args []string) error {
+	fs := newFlagSet("find")
+	sno := fs.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	name := fs.String("name", "", "glob of file name, such as *.pdf")
+	regex := fs.String("regex", "", "regular expression of file name")
+	fileType := fs.String("type", "", "prefix of MIME type, such as image/ or application/pdf")
+	minSize := fs.Uint64("min-size", 0, "min file size in bytes")
+	maxSize := fs.Uint64("max-size", 0, "max file size in bytes, 0 means no limit")
+	newer := fs.String("newer", "", "modified after date like 2006-01-02, or duration before now like 72h")
+	older := fs.String("older", "", "modified before date or duration before now")
+	flat := fs.Bool("flat", false, "do not search sub folders")
+	folders := fs.Bool("folders", false, "include folders matched by name")
+	sortType := fs.String("sort", "name", "sort by name, size or modtime")
+	desc := fs.Bool("desc", false, "sort in descending order")
+	rest, err := parseArgs(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	if *name != "" && *regex != "" {
+		return usageError("--name and --regex can not be used together")
+	}
+	req := &service.SearchReq{
+		Path:          "/",
+		Recursive:     !*flat,
+		Name:          *name,
+		FileType:      *fileType,
+		MinSize:       *minSize,
+		MaxSize:       *maxSize,
+		IncludeFolder: *folders,
+		PageSize:      list_page_size,
+		SortType:      *sortType,
+		AscOrder:      !*desc,
+		Sno:           *sno,
+	}
+	if len(rest) == 1 {
+		req.Path = remotePath(rest[0])
+	}
+	if *regex != "" {
+		req.Name, req.Regex = *regex, true
+	}
+	if req.MinModTime, err = parseTime(*newer); err != nil {
+		return err
+	}
+	if req.MaxModTime, err = parseTime(*older); err != nil {
+		return err
+	}
+	result := &daemon.SearchPages{Files: []*daemon.SearchedFile{}}
+	for req.PageNum = 1; ; req.PageNum++ {
+		page := &daemon.SearchPages{}
+		if err = c.call("/store/search", nil, req, page); err != nil {
+			return err
+		}
+		result.Files = append(result.Files, page.Files...)
+		result.Total, result.Local = page.Total, page.Local
+		if len(page.Files) < list_page_size || uint32(len(result.Files)) >= page.Total {
+			break
+		}
+	}
+	if c.jsonOut {
+		data, _ := json.Marshal(result)
+		c.lastResp = &common.UnifiedResponse{Data: data}
+		return nil
+	}
+	if result.Local {
+		fmt.Fprintln(os.Stderr, "tracker can not search, only folders listed before are searched")
+	}
+	for _, f := range result.Files {
+		kind := "-"
+		if f.Folder {
+			kind = "d"
+		}
+		modTime := time.Unix(int64(f.ModTime), 0).Format("2006-01-02 15:04")
+		fmt.Printf("%s %12d %s %s\n", kind, f.FileSize, modTime, f.Path)
+	}
+	return nil
+}
+
+func (c *cli) mkdir(args []string) error {
+	fs := newFlagSet("mkdir")
+	sno := fs.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	rest, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	dir := remotePath(rest[0])
+	if dir == "/" {
+		return usageError("can not create root folder")
+	}
+	parent, name := path.Split(dir)
+	var result bool
+	req := &service.MkfolderReq{Parent: remotePath(parent), Folders: []string{name}, Sno: *sno}
+	if err = c.call("/store/folder/add", nil, req, &result); err != nil {
+		return err
+	}
+	c.printf("folder %s created\n", dir)
+	return nil
+}
+
+// reportProgress return function which print progress of files when changed
+func (c *cli) reportProgress() func(map[string]progress.ProgressCell) {
+	if c.quiet || c.jsonOut {
+		return nil
+	}
+	last := map[string]float64{}
+	return func(cells map[string]progress.ProgressCell) {
+		keys := make([]string, 0, len(cells))
+		for k := range cells {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			cell := cells[k]
+			if rate, ok := last[k]; ok && rate == cell.Rate {
+				continue
+			}
+			last[k] = cell.Rate
+			fmt.Fprintf(os.Stderr, "%s %3.0f%% %s\n", cell.Type, cell.Rate*100, cell.Local)
+		}
+	}
+}
+
+// wait wait the task to finish, progress of local file or files under local directory is reported
+func (c *cli) wait(taskID string, local string, dir bool) error {
+	match := func(p string) bool {
+		if dir {
+			return strings.HasPrefix(p, local+string(filepath.Separator))
+		}
+		return p == local
+	}
+	return c.api.waitTask(taskID, match, c.reportProgress())
+}
+
+func (c *cli) put(args []string) error {
+	fs := newFlagSet("put")
+	recursive := fs.BoolP("recursive", "r", false, "upload directory")
+	sno := fs.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	encrypt := fs.Bool("encrypt", false, "encrypt file before upload")
+	newVersion := fs.Bool("new-version", false, "upload as new version if the file exists")
+	async := fs.Bool("async", false, "do not wait for the upload to finish, print the task id")
+	rest, err := parseArgs(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	local, err := filepath.Abs(rest[0])
+	if err != nil {
+		return usageError("%v", err)
+	}
+	fi, err := os.Stat(local)
+	if err != nil {
+		return usageError("%v", err)
+	}
+	if fi.IsDir() != *recursive {
+		if *recursive {
+			return usageError("%s is not a directory", local)
+		}
+		return usageError("%s is a directory, use -r to upload directory", local)
+	}
+	dest := remotePath(rest[1])
+	var taskID string
+	if *recursive {
+		req := &common.UploadDirReq{Parent: local, Dest: dest, NewVersion: *newVersion, Sno: *sno, IsEncrypt: *encrypt}
+		err = c.call("/task/uploaddir", nil, req, &taskID)
+	} else {
+		req := &common.UploadReq{Filename: local, Dest: dest, NewVersion: *newVersion, Sno: *sno, IsEncrypt: *encrypt}
+		err = c.call("/task/upload", nil, req, &taskID)
+	}
+	if err != nil {
+		return err
+	}
+	if *async {
+		c.printf("%s\n", taskID)
+		return nil
+	}
+	if err = c.wait(taskID, local, *recursive); err != nil {
+		return err
+	}
+	c.printf("uploaded %s to %s\n", local, dest)
+	return nil
+}
+
+func (c *cli) get(args []string) error {
+	fs := newFlagSet("get")
+	recursive := fs.BoolP("recursive", "r", false, "download folder")
+	sno := fs.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	async := fs.Bool("async", false, "do not wait for the download to finish, print the task id")
+	rest, err := parseArgs(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	remote := remotePath(rest[0])
+	local, err := filepath.Abs(rest[1])
+	if err != nil {
+		return usageError("%v", err)
+	}
+	if fi, err := os.Stat(local); err != nil || !fi.IsDir() {
+		return usageError("%s is not a directory", local)
+	}
+	var taskID string
+	if *recursive {
+		req := &common.DownloadDirReq{Parent: remote, Dest: local, Sno: *sno}
+		err = c.call("/task/downloaddir", nil, req, &taskID)
+	} else {
+		parent, name := path.Split(remote)
+		files, lerr := c.api.listAll(remotePath(parent), *sno, "name", true)
+		if lerr != nil {
+			return lerr
+		}
+		var file *daemon.DownFile
+		for _, f := range files {
+			if f.FileName == name {
+				file = f
+				break
+			}
+		}
+		if file == nil {
+			return usageError("%s not exists", remote)
+		}
+		if file.Folder {
+			return usageError("%s is a folder, use -r to download folder", remote)
+		}
+		req := &common.DownloadReq{FileHash: file.FileHash, FileSize: file.FileSize, FileName: remote, Dest: local, Sno: *sno}
+		err = c.call("/task/download", nil, req, &taskID)
+	}
+	if err != nil {
+		return err
+	}
+	if *async {
+		c.printf("%s\n", taskID)
+		return nil
+	}
+	watch := local
+	if !*recursive {
+		watch = filepath.Join(local, path.Base(remote))
+	}
+	if err = c.wait(taskID, watch, *recursive); err != nil {
+		return err
+	}
+	c.printf("downloaded %s to %s\n", remote, local)
+	return nil
+}
+
+func (c *cli) move(args []string) error {
+	fs := newFlagSet("mv")
+	sno := fs.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	rest, err := parseArgs(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	req := &service.RenameReq{Source: remotePath(rest[0]), Dest: remotePath(rest[1]), IsPath: true, Sno: *sno}
+	if err = c.call("/store/rename", nil, req, nil); err != nil {
+		return err
+	}
+	c.printf("moved %s to %s\n", req.Source, req.Dest)
+	return nil
+}
+
+func (c *cli) remove(args []string) error {
+	fs := newFlagSet("rm")
+	recursive := fs.BoolP("recursive", "r", false, "remove folder and all files in it")
+	sno := fs.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	rest, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	target := remotePath(rest[0])
+	if target == "/" {
+		return usageError("can not remove root folder")
+	}
+	req := &service.RemoveReq{Target: target, Recursion: *recursive, IsPath: true, Sno: *sno}
+	if err = c.call("/store/remove", nil, req, nil); err != nil {
+		return err
+	}
+	c.printf("removed %s\n", target)
+	return nil
+}
+
+func (c *cli) space(args []string) error {
+	if len(args) == 0 {
+		return usageError("need space subcommand: password, verify or status")
+	}
+	sub := args[0]
+	fs := newFlagSet("space " + sub)
+	sno := fs.Uint32("space", 1, "space number, 1 is the privacy space")
+	switch sub {
+	case "password", "verify":
+		rest, err := parseArgs(fs, args[1:], 0, 1)
+		if err != nil {
+			return err
+		}
+		password := os.Getenv("NEBULA_SPACE_PASSWORD")
+		if len(rest) == 1 {
+			password = rest[0]
+		}
+		if password == "" {
+			return usageError("need password argument or NEBULA_SPACE_PASSWORD")
+		}
+		if err = c.call("/space/"+sub, nil, &service.PasswordReq{Password: password, SpacoNo: *sno}, nil); err != nil {
+			return err
+		}
+		if sub == "password" {
+			c.printf("password of space %d is set\n", *sno)
+		} else {
+			c.printf("password of space %d is verified\n", *sno)
+		}
+		return nil
+	case "status":
+		if _, err := parseArgs(fs, args[1:], 0, 0); err != nil {
+			return err
+		}
+		var result interface{}
+		if err := c.call("/space/status", nil, &service.SpaceStatusReq{SpacoNo: *sno}, &result); err != nil {
+			return err
+		}
+		c.printData(result)
+		return nil
+	default:
+		return usageError("unknown space subcommand %s", sub)
+	}
+}
+
+func (c *cli) orders(args []string) error {
+	fs := newFlagSet("orders")
+	expired := fs.Bool("expired", false, "include expired orders")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+	var result interface{}
+	// expired parameter of the api means only not expired orders
+	query := url.Values{"expired": []string{strconv.FormatBool(!*expired)}}
+	if err := c.call("/order/all", query, nil, &result); err != nil {
+		return err
+	}
+	c.printData(result)
+	return nil
+}
+
+func (c *cli) simpleGet(name, apiPath string, args []string) error {
+	if _, err := parseArgs(newFlagSet(name), args, 0, 0); err != nil {
+		return err
+	}
+	var result interface{}
+	if err := c.call(apiPath, nil, nil, &result); err != nil {
+		return err
+	}
+	c.printData(result)
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/samoslab/nebula/client/service"
+	"github.com/stretchr/testify/require"
+)
+
+// listDaemon answer list of folder /docs with total files in pages requested, and chunk download
+func listDaemon(t *testing.T, total int, requested *[]service.ListReq) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/store/list", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		req := service.ListReq{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requested = append(*requested, req)
+		if req.Path != "/docs" {
+			rsp, _ := common.MakeUnifiedHTTPResponse(3, nil, "folder not exist")
+			json.NewEncoder(w).Encode(rsp)
+			return
+		}
+		page := &daemon.FilePages{Total: uint32(total), Files: []*daemon.DownFile{}}
+		for i := int(req.PageSize * (req.PageNum - 1)); i < total && len(page.Files) < int(req.PageSize); i++ {
+			page.Files = append(page.Files, &daemon.DownFile{FileName: fmt.Sprintf("file-%d", i), FileSize: uint64(i)})
+		}
+		rsp, _ := common.MakeUnifiedHTTPResponse(0, page, "")
+		json.NewEncoder(w).Encode(rsp)
+	})
+	mux.HandleFunc("/api/v1/store/chunk", func(w http.ResponseWriter, r *http.Request) {
+		req := service.ChunkReq{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("content of " + req.FileHash))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestListAllPages(t *testing.T) {
+	requested := []service.ListReq{}
+	server := listDaemon(t, list_page_size+50, &requested)
+	defer server.Close()
+	c := &cli{api: newAPIClient(strings.TrimPrefix(server.URL, "http://")), jsonOut: true}
+
+	err := c.run("ls", []string{"--sort", "size", "--desc", "docs/"})
+	require.NoError(t, err)
+	require.Len(t, requested, 2)
+	for i, req := range requested {
+		require.Equal(t, "/docs", req.Path)
+		require.Equal(t, uint32(i+1), req.PageNum)
+		require.Equal(t, "size", req.SortType)
+		require.False(t, req.AscOrder)
+	}
+	pages := &daemon.FilePages{}
+	require.NoError(t, json.Unmarshal(c.lastResp.Data, pages))
+	require.Equal(t, uint32(list_page_size+50), pages.Total)
+	require.Len(t, pages.Files, list_page_size+50)
+	require.Equal(t, "file-149", pages.Files[149].FileName)
+
+	// error code of daemon is the exit code
+	err = c.run("ls", []string{"/other"})
+	require.Error(t, err)
+	require.Equal(t, "folder not exist", err.Error())
+	require.Equal(t, 3, exitCode(err))
+}
+
+func TestExitCode(t *testing.T) {
+	c := &cli{api: newAPIClient("127.0.0.1:1")}
+	err := c.run("unknown", nil)
+	require.Equal(t, exit_usage, exitCode(err))
+	err = c.run("ls", []string{"/a", "/b"})
+	require.Equal(t, exit_usage, exitCode(err))
+	err = c.run("find", []string{"--name", "*.pdf", "--regex", "pdf$"})
+	require.Equal(t, exit_usage, exitCode(err))
+	err = c.run("ls", nil)
+	require.Equal(t, exit_unavailable, exitCode(err))
+	require.Equal(t, 0, exitCode(nil))
+}
+
+func TestDownload(t *testing.T) {
+	requested := []service.ListReq{}
+	server := listDaemon(t, 0, &requested)
+	defer server.Close()
+	api := newAPIClient(strings.TrimPrefix(server.URL, "http://"))
+	dir, err := ioutil.TempDir("", "nebula-cli")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dest := filepath.Join(dir, "file")
+	require.NoError(t, api.download("/store/chunk", &service.ChunkReq{FileHash: "abcd"}, dest))
+	data, err := ioutil.ReadFile(dest)
+	require.NoError(t, err)
+	require.Equal(t, "content of abcd", string(data))
+
+	// daemon answers json when it failed
+	err = api.download("/store/list", &service.ListReq{Path: "/other"}, filepath.Join(dir, "other"))
+	require.Equal(t, 3, exitCode(err))
+	_, err = os.Stat(filepath.Join(dir, "other"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,95 @@
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/client/filecache"
+	"github.com/samoslab/nebula/client/service"
+	"github.com/samoslab/nebula/util/file"
+)
+
+const (
+	mount_cache_size = 1024 * 1024 * 1024
+	mount_cache_dir  = "mount-cache"
+)
+
+// mount serve the space at mountpoint until it is unmounted or interrupted
+func (c *cli) mount(args []string) error {
+	fset := newFlagSet("mount")
+	sno := fset.Uint32("space", 0, "space number, 0 is the default space, 1 is the privacy space")
+	cacheDir := fset.String("cache-dir", "", "directory of chunk cache, default is under the client config directory")
+	cacheSize := fset.Int64("cache-size", mount_cache_size, "max bytes of chunk cache")
+	allowOther := fset.Bool("allow-other", false, "allow other users to access the mount")
+	rest, err := parseArgs(fset, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	mountpoint, err := filepath.Abs(rest[0])
+	if err != nil {
+		return usageError("%v", err)
+	}
+	if fi, err := os.Stat(mountpoint); err != nil || !fi.IsDir() {
+		return usageError("%s is not a directory", mountpoint)
+	}
+	if *sno > 0 {
+		if password := os.Getenv("NEBULA_SPACE_PASSWORD"); password != "" {
+			if err = c.call("/space/verify", nil, &service.PasswordReq{Password: password, SpacoNo: *sno}, nil); err != nil {
+				return err
+			}
+		}
+	}
+	dir := *cacheDir
+	if dir == "" {
+		dir = filepath.Join(file.UserHome(), config.DefaultConfigDir, mount_cache_dir)
+	}
+	if *sno > 0 {
+		// files of privacy space are not kept after unmounted
+		if dir, err = ioutil.TempDir("", "nebula-mount-"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+	}
+	cache, err := filecache.New(dir, *cacheSize)
+	if err != nil {
+		return err
+	}
+	stageDir, err := ioutil.TempDir("", "nebula-stage-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stageDir)
+
+	options := []fuse.MountOption{fuse.FSName("nebula"), fuse.Subtype("nebula")}
+	if *allowOther {
+		options = append(options, fuse.AllowOther())
+	}
+	conn, err := fuse.Mount(mountpoint, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		if err := fuse.Unmount(mountpoint); err != nil {
+			c.printf("unmount %s failed: %v\n", mountpoint, err)
+		}
+	}()
+	c.printf("space %d is mounted at %s, interrupt to unmount\n", *sno, mountpoint)
+	if err = fs.Serve(conn, newMountFS(c.api, *sno, cache, stageDir)); err != nil {
+		return err
+	}
+	<-conn.Ready
+	return conn.MountError
+}
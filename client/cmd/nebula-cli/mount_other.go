@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+func (c *cli) mount(args []string) error {
+	return usageError("mount is only supported on linux")
+}
@@ -0,0 +1,307 @@
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/samoslab/nebula/client/filecache"
+	"github.com/samoslab/nebula/client/service"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDaemon is a local stand-in of the daemon http api, files are kept in memory by path
+type fakeDaemon struct {
+	mutex      sync.Mutex
+	files      map[string][]byte
+	dirs       map[string]bool
+	chunkSize  int64
+	chunkReads int
+}
+
+func newFakeDaemon() *fakeDaemon {
+	return &fakeDaemon{files: map[string][]byte{}, dirs: map[string]bool{"/": true}, chunkSize: 4}
+}
+
+func hashOf(data []byte) string {
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// byHash find content of file by hash, caller hold the lock
+func (d *fakeDaemon) byHash(hash string) []byte {
+	for _, data := range d.files {
+		if hashOf(data) == hash {
+			return data
+		}
+	}
+	return nil
+}
+
+func (d *fakeDaemon) handler() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(p string, req interface{}, fn func() (interface{}, string)) {
+		mux.HandleFunc("/api/v1"+p, func(w http.ResponseWriter, r *http.Request) {
+			// requests are decoded into shared structs, so they are served one by one
+			d.mutex.Lock()
+			defer d.mutex.Unlock()
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			data, errmsg := fn()
+			if b, ok := data.([]byte); ok && errmsg == "" {
+				w.Header().Set("Content-Type", "application/octet-stream")
+				w.Write(b)
+				return
+			}
+			code := 0
+			if errmsg != "" {
+				code = 1
+			}
+			rsp, _ := common.MakeUnifiedHTTPResponse(code, data, errmsg)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(rsp)
+		})
+	}
+	list := &service.ListReq{}
+	handle("/store/list", list, func() (interface{}, string) {
+		if !d.dirs[list.Path] {
+			return nil, "folder not exists"
+		}
+		res := &daemon.FilePages{}
+		for p := range d.dirs {
+			if p != "/" && path.Dir(p) == list.Path {
+				res.Files = append(res.Files, &daemon.DownFile{FileName: path.Base(p), Folder: true})
+			}
+		}
+		for p, data := range d.files {
+			if path.Dir(p) == list.Path {
+				res.Files = append(res.Files, &daemon.DownFile{FileName: path.Base(p), FileSize: uint64(len(data)), FileHash: hashOf(data)})
+			}
+		}
+		res.Total = uint32(len(res.Files))
+		return res, ""
+	})
+	chunks := &service.ChunksReq{}
+	handle("/store/chunks", chunks, func() (interface{}, string) {
+		res := []daemon.Chunk{}
+		for off := int64(0); off < int64(chunks.FileSize); off += d.chunkSize {
+			size := d.chunkSize
+			if off+size > int64(chunks.FileSize) {
+				size = int64(chunks.FileSize) - off
+			}
+			res = append(res, daemon.Chunk{Offset: off, Size: size})
+		}
+		return res, ""
+	})
+	chunk := &service.ChunkReq{}
+	handle("/store/chunk", chunk, func() (interface{}, string) {
+		data := d.byHash(chunk.FileHash)
+		off := int64(chunk.Index) * d.chunkSize
+		if data == nil || off >= int64(len(data)) {
+			return nil, "chunk not exists"
+		}
+		d.chunkReads++
+		end := off + d.chunkSize
+		if end > int64(len(data)) {
+			end = int64(len(data))
+		}
+		return data[off:end], ""
+	})
+	upload := &common.UploadReq{}
+	handle("/task/upload", upload, func() (interface{}, string) {
+		data, err := ioutil.ReadFile(upload.Filename)
+		if err != nil {
+			return nil, err.Error()
+		}
+		d.files[path.Join(upload.Dest, path.Base(upload.Filename))] = data
+		return "task", ""
+	})
+	status := &service.TaskStatusReq{}
+	handle("/task/status", status, func() (interface{}, string) {
+		return daemon.StatusDone.String(), ""
+	})
+	mkdir := &service.MkfolderReq{}
+	handle("/store/folder/add", mkdir, func() (interface{}, string) {
+		for _, name := range mkdir.Folders {
+			d.dirs[path.Join(mkdir.Parent, name)] = true
+		}
+		return true, ""
+	})
+	rename := &service.RenameReq{}
+	handle("/store/rename", rename, func() (interface{}, string) {
+		data, ok := d.files[rename.Source]
+		if !ok {
+			return nil, "file not exists"
+		}
+		delete(d.files, rename.Source)
+		d.files[rename.Dest] = data
+		return "", ""
+	})
+	remove := &service.RemoveReq{}
+	handle("/store/remove", remove, func() (interface{}, string) {
+		delete(d.files, remove.Target)
+		delete(d.dirs, remove.Target)
+		return "", ""
+	})
+	return mux
+}
+
+func newTestMount(t *testing.T, d *fakeDaemon) (*mountFS, func()) {
+	server := httptest.NewServer(d.handler())
+	dir, err := ioutil.TempDir("", "mount-test")
+	require.NoError(t, err)
+	cache, err := filecache.New(dir+"/cache", 1024)
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(dir+"/stage", 0700))
+	m := newMountFS(newAPIClient(strings.TrimPrefix(server.URL, "http://")), 0, cache, dir+"/stage")
+	return m, func() {
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func lookup(t *testing.T, m *mountFS, p string) fs.Node {
+	node, err := m.Root()
+	require.NoError(t, err)
+	for _, name := range strings.Split(strings.Trim(p, "/"), "/") {
+		node, err = node.(fs.NodeStringLookuper).Lookup(context.Background(), name)
+		require.NoError(t, err)
+	}
+	return node
+}
+
+func readAll(t *testing.T, node fs.Node) string {
+	attr := fuse.Attr{}
+	require.NoError(t, node.Attr(context.Background(), &attr))
+	h, err := node.(fs.NodeOpener).Open(context.Background(), &fuse.OpenRequest{Flags: fuse.OpenReadOnly}, &fuse.OpenResponse{})
+	require.NoError(t, err)
+	resp := &fuse.ReadResponse{}
+	require.NoError(t, h.(fs.HandleReader).Read(context.Background(), &fuse.ReadRequest{Offset: 0, Size: int(attr.Size) + 10}, resp))
+	return string(resp.Data)
+}
+
+func TestMountRead(t *testing.T) {
+	d := newFakeDaemon()
+	d.dirs["/docs"] = true
+	d.files["/docs/a.txt"] = []byte("hello nebula")
+	m, cleanup := newTestMount(t, d)
+	defer cleanup()
+
+	root, _ := m.Root()
+	dirents, err := root.(fs.HandleReadDirAller).ReadDirAll(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []fuse.Dirent{{Name: "docs", Type: fuse.DT_Dir}}, dirents)
+
+	node := lookup(t, m, "/docs/a.txt")
+	require.Equal(t, "hello nebula", readAll(t, node))
+	require.Equal(t, 3, d.chunkReads)
+
+	// ranged read only download the chunks in range, which are cached
+	h, err := node.(fs.NodeOpener).Open(context.Background(), &fuse.OpenRequest{Flags: fuse.OpenReadOnly}, &fuse.OpenResponse{})
+	require.NoError(t, err)
+	resp := &fuse.ReadResponse{}
+	require.NoError(t, h.(fs.HandleReader).Read(context.Background(), &fuse.ReadRequest{Offset: 5, Size: 4}, resp))
+	require.Equal(t, " neb", string(resp.Data))
+	require.Equal(t, 3, d.chunkReads)
+
+	_, err = root.(fs.NodeStringLookuper).Lookup(context.Background(), "missing")
+	require.Equal(t, fuse.ENOENT, fuse.ToErrno(err))
+}
+
+func TestMountWrite(t *testing.T) {
+	d := newFakeDaemon()
+	d.files["/old.txt"] = []byte("old content")
+	m, cleanup := newTestMount(t, d)
+	defer cleanup()
+	ctx := context.Background()
+	root, _ := m.Root()
+	dir := root.(*dirNode)
+
+	// new file is uploaded when closed
+	node, h, err := dir.Create(ctx, &fuse.CreateRequest{Name: "new.txt", Flags: fuse.OpenWriteOnly | fuse.OpenCreate}, &fuse.CreateResponse{})
+	require.NoError(t, err)
+	wresp := &fuse.WriteResponse{}
+	require.NoError(t, h.(fs.HandleWriter).Write(ctx, &fuse.WriteRequest{Offset: 0, Data: []byte("new content")}, wresp))
+	require.Equal(t, 11, wresp.Size)
+	dirents, err := dir.ReadDirAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, dirents, 2)
+	_, ok := d.files["/new.txt"]
+	require.False(t, ok)
+	require.NoError(t, h.(fs.HandleFlusher).Flush(ctx, &fuse.FlushRequest{}))
+	require.NoError(t, h.(fs.HandleReleaser).Release(ctx, &fuse.ReleaseRequest{}))
+	require.Equal(t, "new content", string(d.files["/new.txt"]))
+	require.Equal(t, "new content", readAll(t, node))
+
+	// existing file is downloaded before modified
+	node = lookup(t, m, "/old.txt")
+	h, err = node.(fs.NodeOpener).Open(ctx, &fuse.OpenRequest{Flags: fuse.OpenReadWrite}, &fuse.OpenResponse{})
+	require.NoError(t, err)
+	require.NoError(t, h.(fs.HandleWriter).Write(ctx, &fuse.WriteRequest{Offset: 0, Data: []byte("OLD")}, wresp))
+	require.NoError(t, h.(fs.HandleFlusher).Flush(ctx, &fuse.FlushRequest{}))
+	require.NoError(t, h.(fs.HandleReleaser).Release(ctx, &fuse.ReleaseRequest{}))
+	require.Equal(t, "OLD content", string(d.files["/old.txt"]))
+
+	// truncate without open file is uploaded at once
+	require.NoError(t, node.(fs.NodeSetattrer).Setattr(ctx, &fuse.SetattrRequest{Valid: fuse.SetattrSize, Size: 3}, &fuse.SetattrResponse{}))
+	require.Equal(t, "OLD", string(d.files["/old.txt"]))
+	require.Empty(t, m.nodes)
+}
+
+func TestMountNamespace(t *testing.T) {
+	d := newFakeDaemon()
+	d.files["/a.txt"] = []byte("a")
+	d.files["/b.txt"] = []byte("b")
+	m, cleanup := newTestMount(t, d)
+	defer cleanup()
+	ctx := context.Background()
+	root, _ := m.Root()
+	dir := root.(*dirNode)
+
+	sub, err := dir.Mkdir(ctx, &fuse.MkdirRequest{Name: "sub"})
+	require.NoError(t, err)
+	_, err = dir.Mkdir(ctx, &fuse.MkdirRequest{Name: "sub"})
+	require.Equal(t, fuse.EEXIST, fuse.ToErrno(err))
+
+	require.NoError(t, dir.Rename(ctx, &fuse.RenameRequest{OldName: "a.txt", NewName: "c.txt"}, sub))
+	require.Equal(t, "a", string(d.files["/sub/c.txt"]))
+
+	// rename replace existing file
+	require.NoError(t, dir.Rename(ctx, &fuse.RenameRequest{OldName: "b.txt", NewName: "c.txt"}, sub))
+	require.Equal(t, "b", string(d.files["/sub/c.txt"]))
+
+	err = dir.Remove(ctx, &fuse.RemoveRequest{Name: "sub", Dir: true})
+	require.Equal(t, fuse.Errno(syscall.ENOTEMPTY), fuse.ToErrno(err))
+	require.NoError(t, sub.(*dirNode).Remove(ctx, &fuse.RemoveRequest{Name: "c.txt"}))
+	require.NoError(t, dir.Remove(ctx, &fuse.RemoveRequest{Name: "sub", Dir: true}))
+
+	names := []string{}
+	for p := range d.files {
+		names = append(names, p)
+	}
+	for p := range d.dirs {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+	require.Equal(t, []string{"/"}, names)
+}
@@ -0,0 +1,686 @@
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/samoslab/nebula/client/filecache"
+	"github.com/samoslab/nebula/client/service"
+)
+
+// listing of folder is reused for a while, file manager lookup every entry after reading directory
+const mount_list_ttl = 2 * time.Second
+
+// mountFS is a space of nebula served as fuse filesystem through the http api of daemon.
+// Files are read by chunk through the chunk cache, written files are staged locally and
+// uploaded when closed
+type mountFS struct {
+	api      *apiClient
+	sno      uint32
+	cache    *filecache.Cache
+	stageDir string
+	uid      uint32
+	gid      uint32
+
+	mutex    sync.Mutex
+	listings map[string]*listing
+	// nodes of files being written, they are shared by lookups until uploaded
+	nodes map[string]*fileNode
+}
+
+type listing struct {
+	files map[string]*daemon.DownFile
+	time  time.Time
+}
+
+func newMountFS(api *apiClient, sno uint32, cache *filecache.Cache, stageDir string) *mountFS {
+	return &mountFS{
+		api:      api,
+		sno:      sno,
+		cache:    cache,
+		stageDir: stageDir,
+		uid:      uint32(os.Getuid()),
+		gid:      uint32(os.Getgid()),
+		listings: make(map[string]*listing),
+		nodes:    make(map[string]*fileNode),
+	}
+}
+
+func (m *mountFS) Root() (fs.Node, error) {
+	return &dirNode{m: m, path: "/"}, nil
+}
+
+// errno map error of api to errno, detail is lost so it is printed
+func errno(op, p string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(syscall.Errno); ok {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s %s: %v\n", op, p, err)
+	if ce, ok := err.(*cliError); ok && ce.code == exit_unavailable {
+		return syscall.ENOTCONN
+	}
+	return syscall.EIO
+}
+
+// list return files of folder by name
+func (m *mountFS) list(dir string) (map[string]*daemon.DownFile, error) {
+	m.mutex.Lock()
+	l, ok := m.listings[dir]
+	m.mutex.Unlock()
+	if ok && time.Since(l.time) < mount_list_ttl {
+		return l.files, nil
+	}
+	files, err := m.api.listAll(dir, m.sno, "name", true)
+	if err != nil {
+		return nil, err
+	}
+	l = &listing{files: make(map[string]*daemon.DownFile, len(files)), time: time.Now()}
+	for _, f := range files {
+		l.files[f.FileName] = f
+	}
+	m.mutex.Lock()
+	m.listings[dir] = l
+	m.mutex.Unlock()
+	return l.files, nil
+}
+
+// invalidate drop listing of folders after they are changed
+func (m *mountFS) invalidate(dirs ...string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, dir := range dirs {
+		delete(m.listings, dir)
+	}
+}
+
+// stat return file of path, nil if not exists
+func (m *mountFS) stat(p string) (*daemon.DownFile, error) {
+	files, err := m.list(path.Dir(p))
+	if err != nil {
+		return nil, err
+	}
+	return files[path.Base(p)], nil
+}
+
+// node return node of file, node being written is returned if exists
+func (m *mountFS) node(p string, f *daemon.DownFile) *fileNode {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if n, ok := m.nodes[p]; ok {
+		return n
+	}
+	return &fileNode{m: m, path: p, file: f}
+}
+
+// stagedNames return names of files created in folder dir but not uploaded yet
+func (m *mountFS) stagedNames(dir string) []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	names := []string{}
+	for p, n := range m.nodes {
+		if path.Dir(p) == dir && n.file == nil {
+			names = append(names, path.Base(p))
+		}
+	}
+	return names
+}
+
+func (m *mountFS) dirAttr(a *fuse.Attr) {
+	a.Mode = os.ModeDir | 0755
+	a.Uid = m.uid
+	a.Gid = m.gid
+}
+
+type dirNode struct {
+	m    *mountFS
+	path string
+}
+
+func (d *dirNode) Attr(ctx context.Context, a *fuse.Attr) error {
+	d.m.dirAttr(a)
+	return nil
+}
+
+func (d *dirNode) Lookup(ctx context.Context, name string) (fs.Node, error) {
+	p := path.Join(d.path, name)
+	files, err := d.m.list(d.path)
+	if err != nil {
+		return nil, errno("list", d.path, err)
+	}
+	f, ok := files[name]
+	if ok && f.Folder {
+		return &dirNode{m: d.m, path: p}, nil
+	}
+	n := d.m.node(p, f)
+	if !ok && n.file == nil && !n.isStaged() {
+		return nil, syscall.ENOENT
+	}
+	return n, nil
+}
+
+func (d *dirNode) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
+	files, err := d.m.list(d.path)
+	if err != nil {
+		return nil, errno("list", d.path, err)
+	}
+	dirents := make([]fuse.Dirent, 0, len(files))
+	for name, f := range files {
+		de := fuse.Dirent{Name: name, Type: fuse.DT_File}
+		if f.Folder {
+			de.Type = fuse.DT_Dir
+		}
+		dirents = append(dirents, de)
+	}
+	for _, name := range d.m.stagedNames(d.path) {
+		if _, ok := files[name]; !ok {
+			dirents = append(dirents, fuse.Dirent{Name: name, Type: fuse.DT_File})
+		}
+	}
+	return dirents, nil
+}
+
+func (d *dirNode) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error) {
+	p := path.Join(d.path, req.Name)
+	if f, err := d.m.stat(p); err != nil {
+		return nil, errno("stat", p, err)
+	} else if f != nil {
+		return nil, syscall.EEXIST
+	}
+	var result bool
+	mreq := &service.MkfolderReq{Parent: d.path, Folders: []string{req.Name}, Sno: d.m.sno}
+	if _, err := d.m.api.call("/store/folder/add", nil, mreq, &result); err != nil {
+		return nil, errno("mkdir", p, err)
+	}
+	d.m.invalidate(d.path)
+	return &dirNode{m: d.m, path: p}, nil
+}
+
+func (d *dirNode) Create(ctx context.Context, req *fuse.CreateRequest, resp *fuse.CreateResponse) (fs.Node, fs.Handle, error) {
+	p := path.Join(d.path, req.Name)
+	f, err := d.m.stat(p)
+	if err != nil {
+		return nil, nil, errno("stat", p, err)
+	}
+	if f != nil && f.Folder {
+		return nil, nil, syscall.EISDIR
+	}
+	n := d.m.node(p, f)
+	h, err := n.openWrite(true)
+	if err != nil {
+		return nil, nil, errno("create", p, err)
+	}
+	return n, h, nil
+}
+
+func (d *dirNode) Remove(ctx context.Context, req *fuse.RemoveRequest) error {
+	p := path.Join(d.path, req.Name)
+	f, err := d.m.stat(p)
+	if err != nil {
+		return errno("stat", p, err)
+	}
+	if f == nil {
+		// created but not uploaded yet
+		if d.m.dropStaged(p) {
+			return nil
+		}
+		return syscall.ENOENT
+	}
+	if req.Dir {
+		if !f.Folder {
+			return syscall.ENOTDIR
+		}
+		files, err := d.m.list(p)
+		if err != nil {
+			return errno("list", p, err)
+		}
+		if len(files) > 0 {
+			return syscall.ENOTEMPTY
+		}
+	} else if f.Folder {
+		return syscall.EISDIR
+	}
+	rreq := &service.RemoveReq{Target: p, Recursion: f.Folder, IsPath: true, Sno: d.m.sno}
+	if _, err := d.m.api.call("/store/remove", nil, rreq, nil); err != nil {
+		return errno("remove", p, err)
+	}
+	d.m.dropStaged(p)
+	d.m.invalidate(d.path, p)
+	return nil
+}
+
+func (d *dirNode) Rename(ctx context.Context, req *fuse.RenameRequest, newDir fs.Node) error {
+	nd, ok := newDir.(*dirNode)
+	if !ok {
+		return syscall.EXDEV
+	}
+	src, dest := path.Join(d.path, req.OldName), path.Join(nd.path, req.NewName)
+	if src == dest {
+		return nil
+	}
+	f, err := d.m.stat(src)
+	if err != nil {
+		return errno("stat", src, err)
+	}
+	old, err := d.m.stat(dest)
+	if err != nil {
+		return errno("stat", dest, err)
+	}
+	if old != nil {
+		// rename replace the destination
+		if old.Folder {
+			return syscall.EEXIST
+		}
+		rreq := &service.RemoveReq{Target: dest, IsPath: true, Sno: d.m.sno}
+		if _, err := d.m.api.call("/store/remove", nil, rreq, nil); err != nil {
+			return errno("remove", dest, err)
+		}
+	}
+	if f != nil {
+		mreq := &service.RenameReq{Source: src, Dest: dest, IsPath: true, Sno: d.m.sno}
+		if _, err := d.m.api.call("/store/rename", nil, mreq, nil); err != nil {
+			return errno("rename", src, err)
+		}
+	}
+	if !d.m.moveStaged(src, dest) && f == nil {
+		return syscall.ENOENT
+	}
+	d.m.invalidate(d.path, nd.path)
+	return nil
+}
+
+// dropStaged forget the node being written, return true if it exists
+func (m *mountFS) dropStaged(p string) bool {
+	m.mutex.Lock()
+	n, ok := m.nodes[p]
+	delete(m.nodes, p)
+	m.mutex.Unlock()
+	if ok {
+		n.mutex.Lock()
+		n.dirty = false
+		n.mutex.Unlock()
+	}
+	return ok
+}
+
+// moveStaged move the node being written to new path, return true if it exists
+func (m *mountFS) moveStaged(src, dest string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	n, ok := m.nodes[src]
+	if !ok {
+		return false
+	}
+	delete(m.nodes, src)
+	m.nodes[dest] = n
+	n.mutex.Lock()
+	n.path = dest
+	n.mutex.Unlock()
+	return true
+}
+
+// fileNode is a file, file is nil if it is created but not uploaded yet
+type fileNode struct {
+	m     *mountFS
+	mutex sync.Mutex
+	path  string
+	file  *daemon.DownFile
+	// staged is the local copy being written, dirty if not uploaded
+	staged  *os.File
+	dirty   bool
+	writers int
+	modTime time.Time
+}
+
+func (n *fileNode) isStaged() bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.staged != nil
+}
+
+func (n *fileNode) Attr(ctx context.Context, a *fuse.Attr) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	a.Mode = 0644
+	a.Uid = n.m.uid
+	a.Gid = n.m.gid
+	if n.staged != nil {
+		fi, err := n.staged.Stat()
+		if err != nil {
+			return errno("stat", n.path, err)
+		}
+		a.Size = uint64(fi.Size())
+		a.Mtime = fi.ModTime()
+		return nil
+	}
+	if n.file != nil {
+		a.Size = n.file.FileSize
+		a.Mtime = time.Unix(int64(n.file.ModTime), 0)
+	}
+	return nil
+}
+
+func (n *fileNode) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenResponse) (fs.Handle, error) {
+	if req.Flags.IsReadOnly() {
+		return &fileHandle{n: n}, nil
+	}
+	h, err := n.openWrite(req.Flags&fuse.OpenTruncate != 0)
+	if err != nil {
+		return nil, errno("open", n.path, err)
+	}
+	return h, nil
+}
+
+func (n *fileNode) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse.SetattrResponse) error {
+	if req.Valid.Size() {
+		if err := n.truncate(int64(req.Size)); err != nil {
+			return errno("truncate", n.path, err)
+		}
+	}
+	return n.Attr(ctx, &resp.Attr)
+}
+
+func (n *fileNode) Fsync(ctx context.Context, req *fuse.FsyncRequest) error {
+	return errno("upload", n.path, n.commit())
+}
+
+// stage create the local copy of file, content is downloaded unless empty is true, caller hold the lock
+func (n *fileNode) stage(empty bool) error {
+	if n.staged != nil {
+		return nil
+	}
+	dir, err := ioutil.TempDir(n.m.stageDir, "stage-")
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, path.Base(n.path)), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	if !empty && n.file != nil && n.file.FileSize > 0 {
+		r := &chunkReader{m: n.m, file: n.file}
+		_, err = io.Copy(file, io.NewSectionReader(r, 0, int64(n.file.FileSize)))
+		if err != nil {
+			file.Close()
+			os.RemoveAll(dir)
+			return err
+		}
+	}
+	n.staged = file
+	n.m.mutex.Lock()
+	n.m.nodes[n.path] = n
+	n.m.mutex.Unlock()
+	return nil
+}
+
+// unstage remove the local copy if nobody is writing it, caller hold the lock
+func (n *fileNode) unstage() {
+	if n.staged == nil || n.writers > 0 || n.dirty {
+		return
+	}
+	n.staged.Close()
+	os.RemoveAll(filepath.Dir(n.staged.Name()))
+	n.staged = nil
+	n.m.mutex.Lock()
+	if n.m.nodes[n.path] == n {
+		delete(n.m.nodes, n.path)
+	}
+	n.m.mutex.Unlock()
+}
+
+func (n *fileNode) openWrite(truncate bool) (*fileHandle, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if err := n.stage(truncate); err != nil {
+		return nil, err
+	}
+	if truncate {
+		if err := n.staged.Truncate(0); err != nil {
+			return nil, err
+		}
+		n.dirty = true
+	}
+	if n.file == nil {
+		// new file is uploaded even if nothing is written
+		n.dirty = true
+	}
+	n.writers++
+	return &fileHandle{n: n, write: true}, nil
+}
+
+func (n *fileNode) truncate(size int64) error {
+	n.mutex.Lock()
+	if err := n.stage(size == 0); err != nil {
+		n.mutex.Unlock()
+		return err
+	}
+	err := n.staged.Truncate(size)
+	if err == nil {
+		n.dirty = true
+	}
+	writing := n.writers > 0
+	n.mutex.Unlock()
+	if err != nil || writing {
+		return err
+	}
+	// truncate without open file, such as truncate command
+	err = n.commit()
+	n.mutex.Lock()
+	n.unstage()
+	n.mutex.Unlock()
+	return err
+}
+
+// commit upload the local copy if it is changed
+func (n *fileNode) commit() error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if !n.dirty || n.staged == nil {
+		return nil
+	}
+	if err := n.staged.Sync(); err != nil {
+		return err
+	}
+	// upload use the base name of local file, which may be renamed after staged
+	local := n.staged.Name()
+	if path.Base(n.path) != filepath.Base(local) {
+		renamed := filepath.Join(filepath.Dir(local), path.Base(n.path))
+		if err := os.Rename(local, renamed); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(renamed, os.O_RDWR, 0600)
+		if err != nil {
+			return err
+		}
+		n.staged.Close()
+		n.staged, local = file, renamed
+	}
+	dir := path.Dir(n.path)
+	var taskID string
+	req := &common.UploadReq{Filename: local, Dest: dir, NewVersion: true, Sno: n.m.sno, IsEncrypt: n.m.sno > 0}
+	if _, err := n.m.api.call("/task/upload", nil, req, &taskID); err != nil {
+		return err
+	}
+	if err := n.m.api.waitTask(taskID, nil, nil); err != nil {
+		return err
+	}
+	n.dirty = false
+	n.m.invalidate(dir)
+	f, err := n.m.stat(n.path)
+	if err != nil {
+		return err
+	}
+	n.file = f
+	return nil
+}
+
+// fileHandle is an open file, writable handle read and write the local copy
+type fileHandle struct {
+	n     *fileNode
+	write bool
+	mutex sync.Mutex
+	r     *chunkReader
+}
+
+func (h *fileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
+	buf := make([]byte, req.Size)
+	h.n.mutex.Lock()
+	if h.n.staged != nil {
+		k, err := h.n.staged.ReadAt(buf, req.Offset)
+		h.n.mutex.Unlock()
+		if err != nil && err != io.EOF {
+			return errno("read", h.n.path, err)
+		}
+		resp.Data = buf[:k]
+		return nil
+	}
+	file := h.n.file
+	h.n.mutex.Unlock()
+	if file == nil {
+		return nil
+	}
+	h.mutex.Lock()
+	if h.r == nil || h.r.file != file {
+		h.r = &chunkReader{m: h.n.m, file: file}
+	}
+	r := h.r
+	h.mutex.Unlock()
+	k, err := r.ReadAt(buf, req.Offset)
+	if err != nil && err != io.EOF {
+		return errno("read", h.n.path, err)
+	}
+	resp.Data = buf[:k]
+	return nil
+}
+
+func (h *fileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.WriteResponse) error {
+	if !h.write {
+		return syscall.EBADF
+	}
+	h.n.mutex.Lock()
+	defer h.n.mutex.Unlock()
+	k, err := h.n.staged.WriteAt(req.Data, req.Offset)
+	resp.Size = k
+	if k > 0 {
+		h.n.dirty = true
+	}
+	return errno("write", h.n.path, err)
+}
+
+// Flush is called when file is closed, the file is uploaded so that error is returned by close
+func (h *fileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
+	if !h.write {
+		return nil
+	}
+	return errno("upload", h.n.path, h.n.commit())
+}
+
+func (h *fileHandle) Release(ctx context.Context, req *fuse.ReleaseRequest) error {
+	if !h.write {
+		return nil
+	}
+	h.n.mutex.Lock()
+	defer h.n.mutex.Unlock()
+	h.n.writers--
+	if h.n.dirty && h.n.writers == 0 {
+		fmt.Fprintf(os.Stderr, "%s is not uploaded, staged in %s\n", h.n.path, h.n.staged.Name())
+		return nil
+	}
+	h.n.unstage()
+	return nil
+}
+
+// chunkReader read remote file by chunk through the chunk cache
+type chunkReader struct {
+	m      *mountFS
+	file   *daemon.DownFile
+	mutex  sync.Mutex
+	chunks []daemon.Chunk
+}
+
+func (r *chunkReader) layout() ([]daemon.Chunk, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.chunks != nil {
+		return r.chunks, nil
+	}
+	var chunks []daemon.Chunk
+	req := &service.ChunksReq{FileHash: r.file.FileHash, FileSize: r.file.FileSize, Sno: r.m.sno}
+	if _, err := r.m.api.call("/store/chunks", nil, req, &chunks); err != nil {
+		return nil, err
+	}
+	r.chunks = chunks
+	return chunks, nil
+}
+
+// ReadAt read the chunks which overlap with buf at off
+func (r *chunkReader) ReadAt(buf []byte, off int64) (int, error) {
+	if off >= int64(r.file.FileSize) {
+		return 0, io.EOF
+	}
+	chunks, err := r.layout()
+	if err != nil {
+		return 0, err
+	}
+	read := 0
+	for i, chunk := range chunks {
+		pos := off + int64(read)
+		if read == len(buf) {
+			break
+		}
+		if pos < chunk.Offset || pos >= chunk.Offset+chunk.Size {
+			continue
+		}
+		key := fmt.Sprintf("%s.%d.%d", r.file.FileHash, r.m.sno, i)
+		index := i
+		local, err := r.m.cache.Get(key, func(dest string) error {
+			req := &service.ChunkReq{FileHash: r.file.FileHash, FileSize: r.file.FileSize, Sno: r.m.sno, Index: index}
+			return r.m.api.download("/store/chunk", req, dest)
+		})
+		if err != nil {
+			return read, err
+		}
+		k, err := readFileAt(local, buf[read:], pos-chunk.Offset)
+		read += k
+		if err != nil {
+			return read, err
+		}
+	}
+	if read < len(buf) {
+		return read, io.EOF
+	}
+	return read, nil
+}
+
+func readFileAt(name string, buf []byte, off int64) (int, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	k, err := file.ReadAt(buf, off)
+	if err == io.EOF {
+		// the rest is in next chunk, chunk of privacy space may also be
+		// shorter than its size, which is size of the encrypted file
+		err = nil
+	}
+	return k, err
+}
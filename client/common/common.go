@@ -22,6 +22,7 @@ type UploadReq struct {
 	NewVersion  bool   `json:"newversion"`
 	Sno         uint32 `json:"space_no"`
 	IsEncrypt   bool   `json:"is_encrypt"`
+	Compress    bool   `json:"compress"` // compress file with zstd before encryption unless it is of compressed format
 }
 
 // UploadDirReq request struct for upload directory
@@ -32,6 +33,7 @@ type UploadDirReq struct {
 	NewVersion  bool   `json:"newversion"`
 	Sno         uint32 `json:"space_no"`
 	IsEncrypt   bool   `json:"is_encrypt"`
+	Compress    bool   `json:"compress"`
 }
 
 // DownloadReq request struct for download file
@@ -50,6 +52,18 @@ type DownloadDirReq struct {
 	Sno    uint32 `json:"space_no"`
 }
 
+// BackupReq request struct for running backup job
+type BackupReq struct {
+	Job string `json:"job"`
+}
+
+// RestoreReq request struct for restoring snapshot of backup job
+type RestoreReq struct {
+	Job      string `json:"job"`
+	Snapshot string `json:"snapshot"`
+	Dest     string `json:"dest_dir"`
+}
+
 // UnifiedResponse for all reponse format
 type UnifiedResponse struct {
 	Errmsg string `json:"errmsg"`
@@ -16,6 +16,8 @@ var (
 	TaskUploadDirType    = "UploadDir"
 	TaskDownloadFileType = "DownloadFile"
 	TaskDownloadDirType  = "DownloadDir"
+	TaskBackupType       = "Backup"
+	TaskRestoreType      = "Restore"
 
 	TaskUploadProgressType   = "UploadProgress"
 	TaskDownloadProgressType = "DownloadProgress"
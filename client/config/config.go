@@ -43,6 +43,7 @@ type ClientConfig struct {
 	Root         string          `json:"root"`
 	Space        []ReadableSpace `json:"space"`
 	SelfFileName string          `json:"self_filename"`
+	Device       *DeviceKey      `json:"device,omitempty"` // key of enrolled device, private key of account is not kept then
 }
 
 // LoadConfig load config from config file
@@ -54,40 +55,59 @@ func LoadConfig(configDirPath string) (*ClientConfig, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err = verifyConfig(cc); err != nil {
+	if cc.Node, err = ParseNode(cc); err != nil {
 		return nil, ErrConfVerify
 	}
-	cc.Node = GetNodeFromConfig(cc)
 	return cc, nil
 }
 
-// GetNodeFromConfig get node from config
-func GetNodeFromConfig(conf *ClientConfig) *node.Node {
+// ParseNode parse keys of config into node, node id must match public key, for enrolled device
+// private key of node is key of the device and its delegation is verified
+func ParseNode(conf *ClientConfig) (*node.Node, error) {
 	pubKeyBytes, err := hex.DecodeString(conf.PublicKey)
 	if err != nil {
-		log.Fatalf("DecodeString Public Key failed: %s", err)
+		return nil, fmt.Errorf("DecodeString Public Key failed: %s", err)
 	}
 	if conf.NodeId != hex.EncodeToString(sha1Sum(pubKeyBytes)) {
-		log.Fatalln("NodeId is not match PublicKey")
+		return nil, errors.New("NodeId is not match PublicKey")
 	}
 	pubK, err := x509.ParsePKCS1PublicKey(pubKeyBytes)
 	if err != nil {
-		log.Fatalf("ParsePKCS1PublicKey failed: %s", err)
+		return nil, fmt.Errorf("ParsePKCS1PublicKey failed: %s", err)
+	}
+	nodeId, err := hex.DecodeString(conf.NodeId)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeString node id hex string failed: %s", err)
+	}
+	if conf.Device != nil {
+		priK, err := conf.Device.parse(nodeId, pubK)
+		if err != nil {
+			return nil, err
+		}
+		return &node.Node{NodeId: nodeId, PubKey: pubK, PriKey: priK, PubKeyBytes: pubKeyBytes}, nil
 	}
 	priKeyBytes, err := hex.DecodeString(conf.PrivateKey)
 	if err != nil {
-		log.Fatalf("DecodeString Private Key failed: %s", err)
+		return nil, fmt.Errorf("DecodeString Private Key failed: %s", err)
 	}
 	priK, err := x509.ParsePKCS1PrivateKey(priKeyBytes)
 	if err != nil {
-		log.Fatalf("ParsePKCS1PrivateKey failed: %s", err)
+		return nil, fmt.Errorf("ParsePKCS1PrivateKey failed: %s", err)
 	}
-	nodeId, err := hex.DecodeString(conf.NodeId)
-	if err != nil {
-		log.Fatalf("DecodeString node id hex string failed: %s", err)
+	if priK.PublicKey.N.Cmp(pubK.N) != 0 || priK.PublicKey.E != pubK.E {
+		return nil, errors.New("PrivateKey is not match PublicKey")
 	}
 
-	return &node.Node{NodeId: nodeId, PubKey: pubK, PriKey: priK, PubKeyBytes: pubKeyBytes}
+	return &node.Node{NodeId: nodeId, PubKey: pubK, PriKey: priK, PubKeyBytes: pubKeyBytes}, nil
+}
+
+// GetNodeFromConfig get node from config
+func GetNodeFromConfig(conf *ClientConfig) *node.Node {
+	no, err := ParseNode(conf)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return no
 }
 
 // SaveClientConfig create client config save to disk
@@ -101,10 +121,6 @@ func SaveClientConfig(configDirFile string, cc *ClientConfig) error {
 	return saveClientConfig(configDirFile, cc)
 }
 
-func verifyConfig(cc *ClientConfig) error {
-	return nil
-}
-
 func readConfig(configFilePath string) (*ClientConfig, error) {
 	// Open our jsonFile
 	jsonFile, err := os.Open(configFilePath)
@@ -135,7 +151,7 @@ func saveClientConfig(configPath string, cc *ClientConfig) error {
 	if err = json.Indent(&out, b, "", "  "); err != nil {
 		return err
 	}
-	if err = ioutil.WriteFile(configPath, out.Bytes(), 0644); err != nil {
+	if err = ioutil.WriteFile(configPath, out.Bytes(), 0600); err != nil {
 		return err
 	}
 	return nil
@@ -154,11 +170,29 @@ func GetConfigFile() (string, string) {
 	return defaultAppDir, defaultConfig
 }
 
-// ImportConfig import config file
-func ImportConfig(fileName, clientConfigFile string) error {
-	cfg, err := LoadConfig(fileName)
+// ImportConfig import config file exported by ExportConfig, plain config files of old exports are
+// accepted too, node id is verified against keys before the config replaces client config file
+func ImportConfig(fileName, clientConfigFile, passphrase string) (*ClientConfig, error) {
+	data, err := ioutil.ReadFile(fileName)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return SaveClientConfig(clientConfigFile, cfg)
+	var cc *ClientConfig
+	if IsSealedConfig(data) {
+		if cc, err = OpenConfig(data, passphrase); err != nil {
+			return nil, err
+		}
+	} else {
+		cc = new(ClientConfig)
+		if err = json.Unmarshal(data, cc); err != nil {
+			return nil, err
+		}
+		if cc.Node, err = ParseNode(cc); err != nil {
+			return nil, err
+		}
+	}
+	if err = SaveClientConfig(clientConfigFile, cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
 }
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/samoslab/nebula/provider/node"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	util_hash "github.com/samoslab/nebula/util/hash"
+)
+
+// DeviceKey own key of device and delegation of account to it signed by primary key, delegation is
+// empty until the device is enrolled by primary device
+type DeviceKey struct {
+	DeviceId   string `json:"device_id"`
+	Name       string `json:"name"`
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key"`
+	Created    uint64 `json:"created"`
+	Delegation string `json:"delegation"`
+}
+
+// DeviceRequest public part of device key which is taken to primary device for enrolling
+type DeviceRequest struct {
+	DeviceId  string `json:"device_id"`
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"`
+}
+
+// DeviceGrant answer of primary device to device request, it is taken back to the new device
+type DeviceGrant struct {
+	NodeId     string `json:"node_id"`
+	PublicKey  string `json:"public_key"`
+	Email      string `json:"email"`
+	DeviceId   string `json:"device_id"`
+	Name       string `json:"name"`
+	Created    uint64 `json:"created"`
+	Delegation string `json:"delegation"`
+}
+
+// NewDeviceKey new key of device not enrolled yet
+func NewDeviceKey(name string) (*DeviceKey, error) {
+	if name == "" {
+		return nil, errors.New("device name must not empty")
+	}
+	pk, err := rsa.GenerateKey(rand.Reader, node.RSA_KEY_BYTES*8)
+	if err != nil {
+		return nil, err
+	}
+	pubKeyBytes := x509.MarshalPKCS1PublicKey(&pk.PublicKey)
+	return &DeviceKey{
+		DeviceId:   hex.EncodeToString(util_hash.Sha1(pubKeyBytes)),
+		Name:       name,
+		PublicKey:  hex.EncodeToString(pubKeyBytes),
+		PrivateKey: hex.EncodeToString(x509.MarshalPKCS1PrivateKey(pk)),
+	}, nil
+}
+
+// Request public part of device key
+func (dk *DeviceKey) Request() *DeviceRequest {
+	return &DeviceRequest{DeviceId: dk.DeviceId, Name: dk.Name, PublicKey: dk.PublicKey}
+}
+
+// delegation delegation of account node id to device
+func (dk *DeviceKey) delegation(nodeId []byte) (*mpb.Delegation, error) {
+	pubKeyBytes, err := hex.DecodeString(dk.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeString device Public Key failed: %s", err)
+	}
+	deviceId, err := hex.DecodeString(dk.DeviceId)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeString device id failed: %s", err)
+	}
+	sign, err := hex.DecodeString(dk.Delegation)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeString delegation failed: %s", err)
+	}
+	return &mpb.Delegation{
+		NodeId:       nodeId,
+		DeviceId:     deviceId,
+		DevicePubKey: pubKeyBytes,
+		Name:         dk.Name,
+		Created:      dk.Created,
+		Sign:         sign,
+	}, nil
+}
+
+// parse private key of device, delegation must be signed by primary key of account
+func (dk *DeviceKey) parse(nodeId []byte, accountKey *rsa.PublicKey) (*rsa.PrivateKey, error) {
+	d, err := dk.delegation(nodeId)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.VerifyDelegation(accountKey); err != nil {
+		return nil, fmt.Errorf("delegation of device %s is invalid: %s", dk.DeviceId, err)
+	}
+	priKeyBytes, err := hex.DecodeString(dk.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeString device Private Key failed: %s", err)
+	}
+	priK, err := x509.ParsePKCS1PrivateKey(priKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePKCS1PrivateKey of device failed: %s", err)
+	}
+	if !bytes.Equal(x509.MarshalPKCS1PublicKey(&priK.PublicKey), d.DevicePubKey) {
+		return nil, errors.New("device PrivateKey is not match device PublicKey")
+	}
+	return priK, nil
+}
+
+// Delegate delegation of account of cc to device of request signed by primary key of cc
+func Delegate(cc *ClientConfig, req *DeviceRequest) (*mpb.Delegation, error) {
+	if cc.Device != nil {
+		return nil, errors.New("only primary device can enroll devices")
+	}
+	dk := &DeviceKey{DeviceId: req.DeviceId, Name: req.Name, PublicKey: req.PublicKey, Created: uint64(time.Now().Unix())}
+	d, err := dk.delegation(cc.Node.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(d.DeviceId, util_hash.Sha1(d.DevicePubKey)) {
+		return nil, errors.New("device id is not match device public key")
+	}
+	if _, err = x509.ParsePKCS1PublicKey(d.DevicePubKey); err != nil {
+		return nil, fmt.Errorf("ParsePKCS1PublicKey of device failed: %s", err)
+	}
+	if err = d.SignDelegation(cc.Node.PriKey); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewDeviceGrant grant of delegation given to device by account of cc
+func NewDeviceGrant(cc *ClientConfig, d *mpb.Delegation) *DeviceGrant {
+	return &DeviceGrant{
+		NodeId:     cc.NodeId,
+		PublicKey:  cc.PublicKey,
+		Email:      cc.Email,
+		DeviceId:   hex.EncodeToString(d.DeviceId),
+		Name:       d.Name,
+		Created:    d.Created,
+		Delegation: hex.EncodeToString(d.Sign),
+	}
+}
+
+// AcceptDeviceGrant config of device from grant of primary device for its pending key, delegation
+// is verified against public key of account
+func AcceptDeviceGrant(dk *DeviceKey, grant *DeviceGrant) (*ClientConfig, error) {
+	if grant.DeviceId != dk.DeviceId {
+		return nil, fmt.Errorf("grant is for device %s, not %s", grant.DeviceId, dk.DeviceId)
+	}
+	device := *dk
+	device.Name = grant.Name
+	device.Created = grant.Created
+	device.Delegation = grant.Delegation
+	cc := &ClientConfig{
+		NodeId:    grant.NodeId,
+		PublicKey: grant.PublicKey,
+		Email:     grant.Email,
+		Device:    &device,
+		Space: []ReadableSpace{
+			ReadableSpace{SpaceNo: 0, Home: "default", Name: "default"},
+			ReadableSpace{SpaceNo: 1, Home: "private1", Name: "privacy space"},
+		},
+	}
+	var err error
+	if cc.Node, err = ParseNode(cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// SaveDeviceKey save pending key of device which is not enrolled yet
+func SaveDeviceKey(fileName string, dk *DeviceKey) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(dk, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, b, 0600)
+}
+
+// LoadDeviceKey load pending key of device
+func LoadDeviceKey(fileName string) (*DeviceKey, error) {
+	b, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	dk := &DeviceKey{}
+	if err = json.Unmarshal(b, dk); err != nil {
+		return nil, err
+	}
+	return dk, nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceEnroll(t *testing.T) {
+	primary := testClientConfig()
+	var err error
+	primary.Node, err = ParseNode(primary)
+	require.NoError(t, err)
+
+	dk, err := NewDeviceKey("laptop")
+	require.NoError(t, err)
+	d, err := Delegate(primary, dk.Request())
+	require.NoError(t, err)
+	grant := NewDeviceGrant(primary, d)
+
+	cc, err := AcceptDeviceGrant(dk, grant)
+	require.NoError(t, err)
+	require.Equal(t, primary.NodeId, cc.NodeId)
+	require.Empty(t, cc.PrivateKey)
+	require.Equal(t, primary.Node.NodeId, cc.Node.NodeId)
+	// requests of device are signed by its own key
+	require.NotEqual(t, primary.Node.PriKey.N, cc.Node.PriKey.N)
+
+	dir, err := ioutil.TempDir("", "config-device")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.json")
+	require.NoError(t, SaveClientConfig(configFile, cc))
+	loaded, err := LoadConfig(configFile)
+	require.NoError(t, err)
+	require.Equal(t, cc.Device.DeviceId, loaded.Device.DeviceId)
+
+	// only primary device delegates
+	other, err := NewDeviceKey("server")
+	require.NoError(t, err)
+	_, err = Delegate(cc, other.Request())
+	require.Error(t, err)
+
+	// grant is only for the key it was made for
+	_, err = AcceptDeviceGrant(other, grant)
+	require.Error(t, err)
+
+	// delegation not signed by account key is refused
+	forged := *grant
+	forged.Delegation = strings.Repeat("00", 256)
+	_, err = AcceptDeviceGrant(dk, &forged)
+	require.Error(t, err)
+
+	// device id must be of device key
+	req := other.Request()
+	req.DeviceId = dk.DeviceId
+	_, err = Delegate(primary, req)
+	require.Error(t, err)
+
+	keyFile := filepath.Join(dir, "keys", DeviceKeyFile)
+	require.NoError(t, SaveDeviceKey(keyFile, other))
+	pending, err := LoadDeviceKey(keyFile)
+	require.NoError(t, err)
+	require.Equal(t, other, pending)
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/samoslab/nebula/provider/node"
+	util_aes "github.com/samoslab/nebula/util/aes"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// sealedVersion version of sealed config format
+	sealedVersion = 1
+	// kdfScrypt only key derivation function of sealed config
+	kdfScrypt = "scrypt"
+	// scrypt cost of new exports, about 32MB memory
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+	// scryptMaxN max cost accepted from export file
+	scryptMaxN = 1 << 20
+	// MinPassphraseLen min length of passphrase of export
+	MinPassphraseLen = 8
+	// recoveryEntropyBits entropy of recovery phrase, 24 words
+	recoveryEntropyBits = 256
+	// nodeDifficulty difficulty of node id as in register
+	nodeDifficulty = 10
+)
+
+var (
+	// ErrPassphrase error for wrong passphrase or damaged export file
+	ErrPassphrase = errors.New("wrong passphrase or damaged export file")
+
+	// ErrShortPassphrase error for passphrase too short
+	ErrShortPassphrase = fmt.Errorf("passphrase must have at least %d characters", MinPassphraseLen)
+
+	// ErrRecoveryPhrase error for invalid recovery phrase
+	ErrRecoveryPhrase = errors.New("invalid recovery phrase")
+)
+
+// KdfParams parameters of key derivation from passphrase
+type KdfParams struct {
+	Name string `json:"name"`
+	Salt string `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// SealedConfig client config encrypted under a key derived from passphrase, node id is in plain to
+// tell which node the file is for
+type SealedConfig struct {
+	Version int       `json:"version"`
+	NodeId  string    `json:"node_id"`
+	Kdf     KdfParams `json:"kdf"`
+	Nonce   string    `json:"nonce"`
+	Data    string    `json:"data"`
+}
+
+// sealedPayload content of sealed config, space passwords are only kept in exports
+type sealedPayload struct {
+	Config    *ClientConfig     `json:"config"`
+	Passwords map[uint32]string `json:"passwords"`
+}
+
+func (k KdfParams) key(passphrase string) ([]byte, error) {
+	if k.Name != kdfScrypt {
+		return nil, fmt.Errorf("unknown kdf %s", k.Name)
+	}
+	if k.N <= 1 || k.N > scryptMaxN || k.R <= 0 || k.P <= 0 || k.R*k.P >= 1<<30 {
+		return nil, fmt.Errorf("invalid kdf parameters n %d r %d p %d", k.N, k.R, k.P)
+	}
+	salt, err := hex.DecodeString(k.Salt)
+	if err != nil {
+		return nil, err
+	}
+	return scrypt.Key([]byte(passphrase), salt, k.N, k.R, k.P, 32)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SealConfig encrypt config with its space passwords under passphrase
+func SealConfig(cc *ClientConfig, passphrase string) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLen {
+		return nil, ErrShortPassphrase
+	}
+	payload := sealedPayload{Config: cc, Passwords: map[uint32]string{}}
+	for _, sp := range cc.Space {
+		if sp.Password != "" {
+			payload.Passwords[sp.SpaceNo] = sp.Password
+		}
+	}
+	plain, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	sc := &SealedConfig{
+		Version: sealedVersion,
+		NodeId:  cc.NodeId,
+		Kdf:     KdfParams{Name: kdfScrypt, Salt: hex.EncodeToString(salt), N: scryptN, R: scryptR, P: scryptP},
+	}
+	key, err := sc.Kdf.key(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sc.Nonce = hex.EncodeToString(nonce)
+	// node id is authenticated so it can not be swapped in file
+	sc.Data = hex.EncodeToString(aead.Seal(nil, nonce, plain, []byte(sc.NodeId)))
+	return json.MarshalIndent(sc, "", "  ")
+}
+
+// IsSealedConfig whether data is config sealed by SealConfig
+func IsSealedConfig(data []byte) bool {
+	sc := &SealedConfig{}
+	return json.Unmarshal(data, sc) == nil && sc.Version > 0 && sc.Kdf.Name != ""
+}
+
+// OpenConfig decrypt config sealed by SealConfig, node id of config is verified against its keys
+func OpenConfig(data []byte, passphrase string) (*ClientConfig, error) {
+	sc := &SealedConfig{}
+	if err := json.Unmarshal(data, sc); err != nil {
+		return nil, err
+	}
+	if sc.Version != sealedVersion {
+		return nil, fmt.Errorf("unsupported export version %d", sc.Version)
+	}
+	key, err := sc.Kdf.key(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(sc.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, ErrPassphrase
+	}
+	sealed, err := hex.DecodeString(sc.Data)
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	plain, err := aead.Open(nil, nonce, sealed, []byte(sc.NodeId))
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	payload := &sealedPayload{}
+	if err = json.Unmarshal(plain, payload); err != nil {
+		return nil, err
+	}
+	cc := payload.Config
+	if cc == nil || cc.NodeId != sc.NodeId {
+		return nil, ErrConfVerify
+	}
+	for i, sp := range cc.Space {
+		cc.Space[i].Password = payload.Passwords[sp.SpaceNo]
+	}
+	if cc.Node, err = ParseNode(cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// ExportConfig write config sealed under passphrase to file
+func ExportConfig(fileName string, cc *ClientConfig, passphrase string) error {
+	data, err := SealConfig(cc, passphrase)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, 0600)
+}
+
+// NewRecoveryPhrase new mnemonic phrase from which node key of a new config can be derived
+func NewRecoveryPhrase() (string, error) {
+	entropy, err := bip39.NewEntropy(recoveryEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NodeFromRecoveryPhrase derive node from recovery phrase, same phrase always gives same node key
+func NodeFromRecoveryPhrase(phrase string) (*node.Node, error) {
+	phrase = strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(phrase, "")
+	if err != nil {
+		return nil, ErrRecoveryPhrase
+	}
+	return node.NewNodeFromSeed(seed, nodeDifficulty)
+}
+
+// NewClientConfig config of node with default spaces
+func NewClientConfig(no *node.Node, email string) *ClientConfig {
+	return &ClientConfig{
+		PublicKey:  no.PublicKeyStr(),
+		PrivateKey: no.PrivateKeyStr(),
+		NodeId:     no.NodeIdStr(),
+		Email:      email,
+		Node:       no,
+		Space: []ReadableSpace{
+			ReadableSpace{SpaceNo: 0, Password: util_aes.RandStr(16), Home: "default", Name: "default"},
+			ReadableSpace{SpaceNo: 1, Password: "", Home: "private1", Name: "privacy space"},
+		},
+	}
+}
+
+// RecoverConfig config of node regenerated from recovery phrase
+func RecoverConfig(phrase, email string) (*ClientConfig, error) {
+	no, err := NodeFromRecoveryPhrase(phrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientConfig(no, email), nil
+}
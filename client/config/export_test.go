@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealConfig(t *testing.T) {
+	cc := testClientConfig()
+	_, err := SealConfig(cc, "short")
+	require.Equal(t, ErrShortPassphrase, err)
+
+	data, err := SealConfig(cc, "correct horse")
+	require.NoError(t, err)
+	require.True(t, IsSealedConfig(data))
+	require.False(t, strings.Contains(string(data), cc.PrivateKey[:64]))
+	require.False(t, strings.Contains(string(data), "abcdefg"))
+
+	opened, err := OpenConfig(data, "correct horse")
+	require.NoError(t, err)
+	require.Equal(t, cc.NodeId, opened.NodeId)
+	require.Equal(t, cc.PrivateKey, opened.PrivateKey)
+	require.Equal(t, "abcdefg", opened.Space[0].Password)
+	require.Equal(t, "1243", opened.Space[1].Password)
+	require.NotNil(t, opened.Node)
+
+	_, err = OpenConfig(data, "wrong horse")
+	require.Equal(t, ErrPassphrase, err)
+
+	// node id in plain is authenticated
+	sc := &SealedConfig{}
+	require.NoError(t, json.Unmarshal(data, sc))
+	sc.NodeId = strings.Repeat("0", 40)
+	tampered, err := json.Marshal(sc)
+	require.NoError(t, err)
+	_, err = OpenConfig(tampered, "correct horse")
+	require.Equal(t, ErrPassphrase, err)
+
+	plain, err := json.Marshal(cc)
+	require.NoError(t, err)
+	require.False(t, IsSealedConfig(plain))
+}
+
+func TestImportConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-import")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	exported := filepath.Join(dir, "export.json")
+	active := filepath.Join(dir, "config.json")
+
+	cc := testClientConfig()
+	require.NoError(t, ExportConfig(exported, cc, "correct horse"))
+	_, err = ImportConfig(exported, active, "wrong horse")
+	require.Error(t, err)
+	_, err = os.Stat(active)
+	require.True(t, os.IsNotExist(err))
+
+	imported, err := ImportConfig(exported, active, "correct horse")
+	require.NoError(t, err)
+	require.Equal(t, "1243", imported.Space[1].Password)
+	loaded, err := LoadConfig(active)
+	require.NoError(t, err)
+	require.Equal(t, cc.NodeId, loaded.NodeId)
+
+	// node id of plain config must match its key
+	bad := testClientConfig()
+	bad.NodeId = strings.Repeat("0", 40)
+	data, err := json.Marshal(bad)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(exported, data, 0600))
+	_, err = ImportConfig(exported, active, "")
+	require.Error(t, err)
+	loaded, err = LoadConfig(active)
+	require.NoError(t, err)
+	require.Equal(t, cc.NodeId, loaded.NodeId)
+}
+
+func TestRecoveryPhrase(t *testing.T) {
+	phrase, err := NewRecoveryPhrase()
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(phrase), 24)
+
+	cc, err := RecoverConfig(phrase, "test-email")
+	require.NoError(t, err)
+	_, err = ParseNode(cc)
+	require.NoError(t, err)
+
+	// words are matched regardless of case and spacing
+	again, err := RecoverConfig("  "+strings.ToUpper(phrase)+"\n", "test-email")
+	require.NoError(t, err)
+	require.Equal(t, cc.NodeId, again.NodeId)
+	require.Equal(t, cc.PrivateKey, again.PrivateKey)
+
+	// checksum word of all zero entropy is art
+	_, err = RecoverConfig(strings.Repeat("abandon ", 23)+"art", "test-email")
+	require.NoError(t, err)
+	_, err = RecoverConfig(strings.Repeat("abandon ", 24), "test-email")
+	require.Equal(t, ErrRecoveryPhrase, err)
+	_, err = RecoverConfig("not a phrase", "test-email")
+	require.Equal(t, ErrRecoveryPhrase, err)
+}
@@ -3,11 +3,14 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/robfig/cron"
 	"github.com/samoslab/nebula/util/file"
 )
 
@@ -16,6 +19,8 @@ const (
 	DefaultConfig = "config.json"
 	// DefaultConfigDir config directory
 	DefaultConfigDir = ".samos-nebula-client"
+	// DeviceKeyFile filename of key of device waiting for enrolling, in config directory
+	DeviceKeyFile = "device_key.json"
 	// DefaultTracker default tracker server
 	DefaultTracker = "127.0.0.1:6677"
 	// DefaultCollect default collect server
@@ -32,22 +37,118 @@ const (
 
 // Config config for web
 type Config struct {
-	TrackerServer    string        `json:"tracker_server"`
-	CollectServer    string        `json:"collect_server"`
-	ConfigDir        string        `json:"config_dir"`
-	ConfigFile       string        `json:"config_file"`
-	HTTPAddr         string        `json:"http_addr"`
-	HTTPPort         uint32        `json:"http_port"`
-	WSAddr           string        `json:"ws_addr"`
-	HTTPSAddr        string        `json:"https_addr"`
-	StaticDir        string        `json:"static_dir"`
-	AutoTLSHost      string        `json:"auto_tls_host"`
-	TLSCert          string        `json:"tls_cert"`
-	TLSKey           string        `json:"tls_key"`
-	ThrottleMax      int64         `json:"throttle_max"` // Maximum number of requests per duration
-	ThrottleDuration time.Duration `json:"throttle_duration"`
-	BehindProxy      bool          `json:"behind_proxy"`
-	APIEnabled       bool          `json:"api_enabled"`
+	TrackerServer    string             `json:"tracker_server"` // comma separated tracker addresses, more trackers are got from tracker
+	CollectServer    string             `json:"collect_server"`
+	ConfigDir        string             `json:"config_dir"`
+	ConfigFile       string             `json:"config_file"`
+	HTTPAddr         string             `json:"http_addr"`
+	HTTPPort         uint32             `json:"http_port"`
+	WSAddr           string             `json:"ws_addr"`
+	HTTPSAddr        string             `json:"https_addr"`
+	StaticDir        string             `json:"static_dir"`
+	AutoTLSHost      string             `json:"auto_tls_host"`
+	TLSCert          string             `json:"tls_cert"`
+	TLSKey           string             `json:"tls_key"`
+	ThrottleMax      int64              `json:"throttle_max"` // Maximum number of requests per duration
+	ThrottleDuration time.Duration      `json:"throttle_duration"`
+	BehindProxy      bool               `json:"behind_proxy"`
+	APIEnabled       bool               `json:"api_enabled"`
+	S3Addr           string             `json:"s3_addr"` // s3 gateway listen address, empty means disabled
+	S3Space          uint32             `json:"s3_space"`
+	S3AccessKeys     []S3AccessKey      `json:"s3_access_keys"`
+	S3MaxObjectSize  int64              `json:"s3_max_object_size"` // max bytes of object put through s3 gateway, it is spooled whole before upload, 0 means 5GB
+	DavAddr          string             `json:"dav_addr"`        // webdav listen address, empty means disabled
+	DavCacheSize     int64              `json:"dav_cache_size"`  // max bytes of webdav read cache
+	MetaCacheTTL     time.Duration      `json:"meta_cache_ttl"`  // age of cached folder listing before listed again
+	MetaRefresh      time.Duration      `json:"meta_refresh"`    // interval of refreshing cached folder listings
+	MetaWorkers      int                `json:"meta_workers"`    // number of metadata worker processes, 0 means number of cpus, 1 means generating one by one in daemon
+	AuditInterval    time.Duration      `json:"audit_interval"`  // interval of challenging providers of uploaded files, 0 means audit is disabled
+	ScratchDir       string             `json:"scratch_dir"`     // dir of task workspaces, it must not be shared by clients running at the same time
+	ScratchBudget    int64              `json:"scratch_budget"`  // max bytes of temporary files of running tasks, 0 means no limit
+	Redundancy       []RedundancyPolicy `json:"redundancy"`      // durability requested for files uploaded to space or folder, tracker decides if none matches
+	RepairDegraded   bool               `json:"repair_degraded"` // reconstructed blocks lost by providers are uploaded to new providers when file is downloaded
+	PackFiles        bool               `json:"pack_files"`      // small files of uploaded directory are packed into archives of 64MB
+	CompressSpaces   []uint32           `json:"compress_spaces"` // files uploaded to these spaces are compressed with zstd before encryption
+	Backups          []BackupJob        `json:"backups"`         // backup jobs of local directories which run by their schedules
+}
+
+// RedundancyPolicy durability of files uploaded to space, or under path prefix of space, either replicas
+// or data and parity shards are set, e.g. {"prefix":"/archive","data_shards":10,"parity_shards":6}
+type RedundancyPolicy struct {
+	SpaceNo      uint32 `json:"space_no"`
+	Prefix       string `json:"prefix"` // folder of space, empty means whole space
+	Replicas     uint32 `json:"replicas"`
+	DataShards   uint32 `json:"data_shards"`
+	ParityShards uint32 `json:"parity_shards"`
+}
+
+// Validate validate policy correctness
+func (p RedundancyPolicy) Validate() error {
+	if p.Prefix != "" && !strings.HasPrefix(p.Prefix, "/") {
+		return fmt.Errorf("redundancy prefix %s is not absolute", p.Prefix)
+	}
+	if p.Replicas > 0 {
+		if p.DataShards > 0 || p.ParityShards > 0 {
+			return fmt.Errorf("redundancy of %d:%s has both replicas and shards", p.SpaceNo, p.Prefix)
+		}
+		return nil
+	}
+	if p.DataShards == 0 || p.ParityShards == 0 {
+		return fmt.Errorf("redundancy of %d:%s needs replicas or data and parity shards", p.SpaceNo, p.Prefix)
+	}
+	if p.DataShards+p.ParityShards > 256 {
+		return fmt.Errorf("redundancy of %d:%s has more than 256 shards", p.SpaceNo, p.Prefix)
+	}
+	return nil
+}
+
+// BackupJob snapshots of source directory uploaded to dest folder of space, e.g. {"name":"docs","source":"/home/me/docs",
+// "dest":"/backup/docs","schedule":"0 0 2 * * *","exclude":["*.tmp"],"keep_daily":7,"keep_weekly":4}
+type BackupJob struct {
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	SpaceNo     uint32   `json:"space_no"`
+	Dest        string   `json:"dest"`         // folder of snapshots
+	Schedule    string   `json:"schedule"`     // cron spec with seconds, empty means the job is run by api only
+	Include     []string `json:"include"`      // globs of path relative to source or file name, empty means all files
+	Exclude     []string `json:"exclude"`      // globs of files not backed up even if they are included
+	KeepDaily   int      `json:"keep_daily"`   // the last snapshot of each of the last n days with snapshots is kept
+	KeepWeekly  int      `json:"keep_weekly"`  // the last snapshot of each of the last n weeks with snapshots is kept
+	KeepMonthly int      `json:"keep_monthly"` // the last snapshot of each of the last n months with snapshots is kept, all snapshots are kept if no keep is set
+	Compress    bool     `json:"compress"`     // files are compressed as uploaded with compress
+}
+
+// Validate validate backup job correctness
+func (j BackupJob) Validate() error {
+	if j.Name == "" || strings.ContainsAny(j.Name, "/\\@") {
+		return fmt.Errorf("backup name %q is empty or has / \\ @", j.Name)
+	}
+	if !filepath.IsAbs(j.Source) {
+		return fmt.Errorf("backup %s source %s is not absolute", j.Name, j.Source)
+	}
+	if !strings.HasPrefix(j.Dest, "/") {
+		return fmt.Errorf("backup %s dest %s is not absolute", j.Name, j.Dest)
+	}
+	if j.Schedule != "" {
+		if _, err := cron.Parse(j.Schedule); err != nil {
+			return fmt.Errorf("backup %s schedule %s: %v", j.Name, j.Schedule, err)
+		}
+	}
+	for _, glob := range append(append([]string{}, j.Include...), j.Exclude...) {
+		if _, err := filepath.Match(glob, ""); err != nil {
+			return fmt.Errorf("backup %s glob %s: %v", j.Name, glob, err)
+		}
+	}
+	if j.KeepDaily < 0 || j.KeepWeekly < 0 || j.KeepMonthly < 0 {
+		return fmt.Errorf("backup %s keeps negative number of snapshots", j.Name)
+	}
+	return nil
+}
+
+// S3AccessKey access key of s3 gateway
+type S3AccessKey struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
 }
 
 // SetDefault set default value
@@ -84,6 +185,24 @@ func (cfg *Config) Validate() error {
 	if cfg.ConfigDir == "" {
 		return errors.New("need config dir")
 	}
+	if cfg.S3Addr != "" && len(cfg.S3AccessKeys) == 0 {
+		return errors.New("need s3 access keys for s3 gateway")
+	}
+	for _, p := range cfg.Redundancy {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	names := map[string]bool{}
+	for _, j := range cfg.Backups {
+		if err := j.Validate(); err != nil {
+			return err
+		}
+		if names[j.Name] {
+			return fmt.Errorf("backup name %s is duplicated", j.Name)
+		}
+		names[j.Name] = true
+	}
 	return nil
 }
 
@@ -0,0 +1,586 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/sirupsen/logrus"
+
+	"github.com/samoslab/nebula/client/common"
+	client "github.com/samoslab/nebula/client/provider_client"
+	pb "github.com/samoslab/nebula/provider/pb"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/samoslab/nebula/util/dbutil"
+	"github.com/samoslab/nebula/util/por"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// audit bucket, key is space:hash of file
+	auditBkt = []byte("client_audit")
+
+	// AuditChunks number of chunks challenged in every block
+	AuditChunks = 8
+	// auditBatch max files audited in one round
+	auditBatch = 10
+
+	// ErrNoStoreNode block is not stored by any provider
+	ErrNoStoreNode = errors.New("block is not stored by any provider")
+)
+
+// auditBlock block of file with the PoR metadata generated when it was uploaded
+type auditBlock struct {
+	Hash      []byte        `json:"hash"`
+	Size      uint64        `json:"size"`
+	Checksum  bool          `json:"checksum"`
+	ChunkSize uint32        `json:"chunk_size"`
+	Metadata  *por.Metadata `json:"metadata"`
+}
+
+type auditPartition struct {
+	DataShards int           `json:"data_shards"`
+	Blocks     []*auditBlock `json:"blocks"`
+}
+
+// auditFile file uploaded when audit is enabled
+type auditFile struct {
+	FileHash   []byte            `json:"file_hash"`
+	FileSize   uint64            `json:"file_size"`
+	FileName   string            `json:"file_name"`
+	SpaceNo    uint32            `json:"space_no"`
+	Partitions []*auditPartition `json:"partitions"`
+	Report     *AuditReport      `json:"report,omitempty"`
+}
+
+// PartitionHealth blocks of partition which providers proved to keep
+type PartitionHealth struct {
+	DataShards int `json:"data_shards"`
+	Blocks     int `json:"blocks"`
+	Healthy    int `json:"healthy"`
+}
+
+// AuditReport result of the last audit of file
+type AuditReport struct {
+	FileHash   string            `json:"filehash"`
+	FileName   string            `json:"filename"`
+	SpaceNo    uint32            `json:"space_no"`
+	CheckedAt  uint64            `json:"checked_at"`
+	Partitions []PartitionHealth `json:"partitions"`
+	Degraded   bool              `json:"degraded"` // healthy blocks of some partition are less than data shards + 1
+	Err        string            `json:"err,omitempty"`
+}
+
+// newAuditFile audit file of upload request and stored partitions, blocks without metadata such as replicas are skipped
+func newAuditFile(req *mpb.CheckFileExistReq, partitions []*mpb.StorePartition) *auditFile {
+	name := req.GetFileName()
+	if p := req.GetParent().GetPath(); p != "" {
+		name = path.Join(p, name)
+	}
+	f := &auditFile{
+		FileHash: req.GetFileHash(),
+		FileSize: req.GetFileSize(),
+		FileName: name,
+		SpaceNo:  req.GetParent().GetSpaceNo(),
+	}
+	for _, partition := range partitions {
+		ap := &auditPartition{}
+		for _, block := range partition.GetBlock() {
+			if len(block.GetPhi()) == 0 {
+				continue
+			}
+			if !block.GetChecksum() {
+				ap.DataShards++
+			}
+			ap.Blocks = append(ap.Blocks, newAuditBlock(block))
+		}
+		if len(ap.Blocks) > 0 {
+			f.Partitions = append(f.Partitions, ap)
+		}
+	}
+	return f
+}
+
+func newAuditBlock(block *mpb.StoreBlock) *auditBlock {
+	return &auditBlock{
+		Hash:      block.GetHash(),
+		Size:      block.GetSize(),
+		Checksum:  block.GetChecksum(),
+		ChunkSize: block.GetChunkSize(),
+		Metadata: &por.Metadata{
+			ParamStr:  block.GetParamStr(),
+			Generator: block.GetGenerator(),
+			PubKey:    block.GetPubKey(),
+			Random:    block.GetRandom(),
+			Phi:       block.GetPhi(),
+		},
+	}
+}
+
+// replace blocks of bad hashes by repaired blocks in the same order, it returns number of blocks replaced
+func (f *auditFile) replace(badHash [][]byte, blocks []*mpb.StoreBlock) int {
+	replaced := 0
+	for i, hash := range badHash {
+		for _, p := range f.Partitions {
+			for j, b := range p.Blocks {
+				if bytes.Equal(b.Hash, hash) {
+					p.Blocks[j] = newAuditBlock(blocks[i])
+					replaced++
+				}
+			}
+		}
+	}
+	return replaced
+}
+
+// health count healthy blocks of every partition, file is degraded if any partition can not lose one more block
+func (f *auditFile) health(healthy func(b *auditBlock) bool) *AuditReport {
+	report := &AuditReport{
+		FileHash:  hex.EncodeToString(f.FileHash),
+		FileName:  f.FileName,
+		SpaceNo:   f.SpaceNo,
+		CheckedAt: uint64(time.Now().Unix()),
+	}
+	for _, p := range f.Partitions {
+		ph := PartitionHealth{DataShards: p.DataShards, Blocks: len(p.Blocks)}
+		ok := make([]bool, len(p.Blocks))
+		ccControl := NewCCController(common.CCDownloadGoNum)
+		for i, b := range p.Blocks {
+			ccControl.Add()
+			go func(i int, b *auditBlock) {
+				defer ccControl.Done()
+				ok[i] = healthy(b)
+			}(i, b)
+		}
+		ccControl.Wait()
+		for _, v := range ok {
+			if v {
+				ph.Healthy++
+			}
+		}
+		if ph.Healthy < ph.DataShards+1 {
+			report.Degraded = true
+		}
+		report.Partitions = append(report.Partitions, ph)
+	}
+	return report
+}
+
+// verifyBlock challenge random chunks of block and verify proof with metadata of the block
+func verifyBlock(b *auditBlock, prove func(ch por.Challenge, version uint32) ([]byte, error)) error {
+	if b.Metadata == nil {
+		return ErrNoMetaData
+	}
+	version, err := por.VersionOf(b.Metadata.ParamStr)
+	if err != nil {
+		return err
+	}
+	scheme, err := por.Lookup(version)
+	if err != nil {
+		return err
+	}
+	ch, err := scheme.Challenge(uint32(len(b.Metadata.Phi)), AuditChunks)
+	if err != nil {
+		return err
+	}
+	proof, err := prove(ch, version)
+	if err != nil {
+		return err
+	}
+	return scheme.Verify(b.Metadata, ch, proof)
+}
+
+// auditStore keep metadata of uploaded files and their audit reports
+type auditStore struct {
+	db  *bolt.DB
+	log logrus.FieldLogger
+}
+
+func newAuditStore(db *bolt.DB, log logrus.FieldLogger) (*auditStore, error) {
+	if db == nil {
+		return nil, errors.New("new audit store failed, db is nil")
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(auditBkt); err != nil {
+			return dbutil.NewCreateBucketFailedErr(auditBkt, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &auditStore{
+		db:  db,
+		log: log.WithField("prefix", "audit.store"),
+	}, nil
+}
+
+func auditKey(sno uint32, fileHash []byte) []byte {
+	return []byte(fmt.Sprintf("%d:%x", sno, fileHash))
+}
+
+// put save metadata of uploaded file, records of older versions of the same file are removed
+func (s *auditStore) put(f *auditFile) error {
+	v, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	key := auditKey(f.SpaceNo, f.FileHash)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(auditBkt)
+		old, err := s.match(bkt, func(saved *auditFile) bool {
+			return saved.SpaceNo == f.SpaceNo && saved.FileName == f.FileName
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range old {
+			if bytes.Equal(k, key) {
+				continue
+			}
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return bkt.Put(key, v)
+	})
+}
+
+// match keys of records accepted by fn
+func (s *auditStore) match(bkt *bolt.Bucket, fn func(*auditFile) bool) ([][]byte, error) {
+	keys := [][]byte{}
+	err := bkt.ForEach(func(k, v []byte) error {
+		f := &auditFile{}
+		if err := json.Unmarshal(v, f); err != nil {
+			s.log.WithError(err).Errorf("Unmarshal audit file %s failed", k)
+			return nil
+		}
+		if fn(f) {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// underPath return true if name is p or in folder p
+func underPath(name, p string) bool {
+	p = strings.TrimSuffix(p, "/")
+	return name == p || strings.HasPrefix(name, p+"/")
+}
+
+// remove drop records of file or files in folder p
+func (s *auditStore) remove(sno uint32, p string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(auditBkt)
+		keys, err := s.match(bkt, func(f *auditFile) bool {
+			return f.SpaceNo == sno && underPath(f.FileName, p)
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// drop remove record of file, it is called if the file is gone from tracker
+func (s *auditStore) drop(f *auditFile) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(auditBkt).Delete(auditKey(f.SpaceNo, f.FileHash))
+	})
+}
+
+// moved rename records of file or files in folder src to dest
+func (s *auditStore) moved(sno uint32, src, dest string) error {
+	src = strings.TrimSuffix(src, "/")
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(auditBkt)
+		keys, err := s.match(bkt, func(f *auditFile) bool {
+			return f.SpaceNo == sno && underPath(f.FileName, src)
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			f := &auditFile{}
+			if err := json.Unmarshal(bkt.Get(k), f); err != nil {
+				return err
+			}
+			f.FileName = dest + strings.TrimPrefix(f.FileName, src)
+			if f.Report != nil {
+				f.Report.FileName = f.FileName
+			}
+			v, err := json.Marshal(f)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// pick choose at most n random files
+func (s *auditStore) pick(n int) ([]*auditFile, error) {
+	picked := [][]byte{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		seen := 0
+		return tx.Bucket(auditBkt).ForEach(func(k, v []byte) error {
+			seen++
+			if len(picked) < n {
+				picked = append(picked, append([]byte(nil), v...))
+			} else if i := rand.Intn(seen); i < n {
+				picked[i] = append([]byte(nil), v...)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*auditFile, 0, len(picked))
+	for _, v := range picked {
+		f := &auditFile{}
+		if err := json.Unmarshal(v, f); err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func (s *auditStore) saveReport(f *auditFile, report *AuditReport) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(auditBkt)
+		key := auditKey(f.SpaceNo, f.FileHash)
+		v := bkt.Get(key)
+		if v == nil {
+			// removed from audit while auditing
+			return nil
+		}
+		saved := &auditFile{}
+		if err := json.Unmarshal(v, saved); err != nil {
+			return err
+		}
+		saved.Report = report
+		v, err := json.Marshal(saved)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(key, v)
+	})
+}
+
+// repaired replace metadata of blocks repaired by new providers
+func (s *auditStore) repaired(sno uint32, fileHash []byte, badHash [][]byte, blocks []*mpb.StoreBlock) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(auditBkt)
+		key := auditKey(sno, fileHash)
+		v := bkt.Get(key)
+		if v == nil {
+			// uploaded before audit is enabled
+			return nil
+		}
+		f := &auditFile{}
+		if err := json.Unmarshal(v, f); err != nil {
+			return err
+		}
+		if f.replace(badHash, blocks) == 0 {
+			return nil
+		}
+		v, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(key, v)
+	})
+}
+
+// reports reports of audited files, degraded files come first
+func (s *auditStore) reports() ([]*AuditReport, error) {
+	reports := []*AuditReport{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(auditBkt).ForEach(func(k, v []byte) error {
+			f := &auditFile{}
+			if err := json.Unmarshal(v, f); err != nil {
+				s.log.WithError(err).Errorf("Unmarshal audit file %s failed", k)
+				return nil
+			}
+			if f.Report != nil {
+				reports = append(reports, f.Report)
+			}
+			return nil
+		})
+	})
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Degraded && !reports[j].Degraded
+	})
+	return reports, err
+}
+
+// auditUploaded keep metadata of uploaded file for audit
+func (c *ClientManager) auditUploaded(req *mpb.CheckFileExistReq, partitions []*mpb.StorePartition) {
+	if c.audit == nil {
+		return
+	}
+	f := newAuditFile(req, partitions)
+	if len(f.Partitions) == 0 {
+		return
+	}
+	if err := c.audit.put(f); err != nil {
+		c.Log.WithError(err).Errorf("Save audit metadata of %s failed", f.FileName)
+	}
+}
+
+// auditRemoved stop auditing removed file or files in removed folder
+func (c *ClientManager) auditRemoved(sno uint32, p string) {
+	if c.audit == nil {
+		return
+	}
+	if err := c.audit.remove(sno, p); err != nil {
+		c.Log.WithError(err).Errorf("Remove audit records of %s failed", p)
+	}
+}
+
+// auditMoved keep audit records of moved file or folder under the new path
+func (c *ClientManager) auditMoved(sno uint32, src, dest string) {
+	if c.audit == nil {
+		return
+	}
+	if err := c.audit.moved(sno, src, dest); err != nil {
+		c.Log.WithError(err).Errorf("Move audit records of %s failed", src)
+	}
+}
+
+// AuditFiles challenge providers of random uploaded files periodically, it runs only if audit is enabled
+func (c *ClientManager) AuditFiles() {
+	if c.audit == nil {
+		return
+	}
+	log := c.Log.WithField("prefix", "audit")
+	ticker := time.NewTicker(c.webcfg.AuditInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.quit:
+			log.Info("Shutdown audit goroutine")
+			return
+		case <-ticker.C:
+		}
+		files, err := c.audit.pick(auditBatch)
+		if err != nil {
+			log.WithError(err).Error("Pick files to audit failed")
+			continue
+		}
+		for _, f := range files {
+			report, err := c.auditFile(log, f)
+			if err != nil {
+				if code := status.Code(err); code == codes.Unavailable || code == codes.DeadlineExceeded {
+					log.WithError(err).Info("Tracker unreachable, audit later")
+					break
+				}
+				if c.auditGone(log, f) {
+					continue
+				}
+				report = &AuditReport{
+					FileHash:  hex.EncodeToString(f.FileHash),
+					FileName:  f.FileName,
+					SpaceNo:   f.SpaceNo,
+					CheckedAt: uint64(time.Now().Unix()),
+					Err:       err.Error(),
+				}
+			}
+			if report.Degraded {
+				log.Warnf("File %s redundancy is degraded %+v", f.FileName, report.Partitions)
+			}
+			if err := c.audit.saveReport(f, report); err != nil {
+				log.WithError(err).Error("Save audit report failed")
+			}
+		}
+	}
+}
+
+// auditGone drop record of file if it failed to retrieve because the file is removed or replaced by a newer
+// version, return true if dropped
+func (c *ClientManager) auditGone(log logrus.FieldLogger, f *auditFile) bool {
+	df, err := c.StatFile(f.FileName, f.SpaceNo)
+	if err != nil && err != ErrFileNotExist {
+		return false
+	}
+	if err == nil && df.FileHash == hex.EncodeToString(f.FileHash) {
+		return false
+	}
+	log.Infof("File %s is removed or replaced, stop auditing it", f.FileName)
+	if err := c.audit.drop(f); err != nil {
+		log.WithError(err).Error("Drop audit record failed")
+	}
+	return true
+}
+
+// auditFile challenge every block of file, the retrieve auth got from tracker authorizes the challenges
+func (c *ClientManager) auditFile(log logrus.FieldLogger, f *auditFile) (*AuditReport, error) {
+	rsp, err := c.retrieveStorage(f.FileHash, f.FileSize, f.SpaceNo)
+	if err != nil {
+		return nil, err
+	}
+	nodes := map[string][]*mpb.RetrieveNode{}
+	for _, partition := range rsp.GetPartition() {
+		for _, block := range partition.GetBlock() {
+			key := hex.EncodeToString(block.GetHash())
+			nodes[key] = append(nodes[key], block.GetStoreNode()...)
+		}
+	}
+	return f.health(func(b *auditBlock) bool {
+		err := c.auditBlock(f, b, nodes[hex.EncodeToString(b.Hash)], rsp.GetTimestamp())
+		if err != nil {
+			log.WithError(err).Infof("Audit block %x of %s failed", b.Hash, f.FileName)
+			return false
+		}
+		return true
+	}), nil
+}
+
+// auditBlock block is healthy if any provider of it proves
+func (c *ClientManager) auditBlock(f *auditFile, b *auditBlock, nodes []*mpb.RetrieveNode, tm uint64) error {
+	err := ErrNoStoreNode
+	for _, node := range nodes {
+		server := fmt.Sprintf("%s:%d", node.GetServer(), node.GetPort())
+		conn, er := common.GrpcDial(server)
+		if er != nil {
+			err = er
+			continue
+		}
+		pclient := pb.NewProviderServiceClient(conn)
+		err = verifyBlock(b, func(ch por.Challenge, version uint32) ([]byte, error) {
+			return client.Prove(pclient, node.GetAuth(), node.GetTicket(), tm, f.FileHash, b.Hash, f.FileSize, b.Size, b.ChunkSize, ch, version)
+		})
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// AuditReports reports of audited files, degraded files come first
+func (c *ClientManager) AuditReports() ([]*AuditReport, error) {
+	if c.audit == nil {
+		return nil, errors.New("audit is not enabled")
+	}
+	return c.audit.reports()
+}
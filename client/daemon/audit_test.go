@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/samoslab/nebula/util/por"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := bolt.Open(filepath.Join(dir, ClientDBName), 0700, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	s, err := newAuditStore(db, logrus.New())
+	require.NoError(t, err)
+
+	req := &mpb.CheckFileExistReq{
+		FileHash: []byte("hash1"),
+		FileSize: 100,
+		FileName: "a.txt",
+		Parent:   &mpb.FilePath{OneOfPath: &mpb.FilePath_Path{Path: "/docs"}, SpaceNo: 1},
+	}
+	f := newAuditFile(req, []*mpb.StorePartition{{Block: []*mpb.StoreBlock{
+		{Hash: []byte("b1"), Phi: [][]byte{{1}}},
+		{Hash: []byte("b2"), Phi: [][]byte{{2}}},
+		{Hash: []byte("b3"), Checksum: true, Phi: [][]byte{{3}}},
+	}}})
+	require.Equal(t, "/docs/a.txt", f.FileName)
+	require.Equal(t, uint32(1), f.SpaceNo)
+	require.Len(t, f.Partitions, 1)
+	require.Equal(t, 2, f.Partitions[0].DataShards)
+	require.NoError(t, s.put(f))
+
+	// replicated file has no metadata
+	replica := newAuditFile(&mpb.CheckFileExistReq{FileHash: []byte("hash2")}, []*mpb.StorePartition{{Block: []*mpb.StoreBlock{{Hash: []byte("r")}}}})
+	require.Len(t, replica.Partitions, 0)
+	// data block without metadata is not audited, so it is not counted
+	partial := newAuditFile(&mpb.CheckFileExistReq{FileHash: []byte("hash4")}, []*mpb.StorePartition{{Block: []*mpb.StoreBlock{
+		{Hash: []byte("d1"), Phi: [][]byte{{1}}},
+		{Hash: []byte("d2")},
+		{Hash: []byte("d3"), Checksum: true, Phi: [][]byte{{3}}},
+	}}})
+	require.Equal(t, 1, partial.Partitions[0].DataShards)
+	require.False(t, partial.health(func(b *auditBlock) bool { return true }).Degraded)
+	g := newAuditFile(&mpb.CheckFileExistReq{FileHash: []byte("hash3"), FileName: "b.txt"}, []*mpb.StorePartition{{Block: []*mpb.StoreBlock{
+		{Hash: []byte("c1"), Phi: [][]byte{{1}}},
+		{Hash: []byte("c2"), Checksum: true, Phi: [][]byte{{2}}},
+	}}})
+	require.NoError(t, s.put(g))
+
+	files, err := s.pick(1)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	files, err = s.pick(10)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	reports, err := s.reports()
+	require.NoError(t, err)
+	require.Len(t, reports, 0)
+
+	require.NoError(t, s.saveReport(g, g.health(func(b *auditBlock) bool { return true })))
+	// one of data blocks is lost, partition can not lose one more block
+	report := f.health(func(b *auditBlock) bool { return !bytes.Equal(b.Hash, []byte("b2")) })
+	require.True(t, report.Degraded)
+	require.Equal(t, []PartitionHealth{{DataShards: 2, Blocks: 3, Healthy: 2}}, report.Partitions)
+	require.NoError(t, s.saveReport(f, report))
+	reports, err = s.reports()
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Equal(t, "/docs/a.txt", reports[0].FileName)
+	require.False(t, reports[1].Degraded)
+}
+
+func TestAuditStoreRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := bolt.Open(filepath.Join(dir, ClientDBName), 0700, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	s, err := newAuditStore(db, logrus.New())
+	require.NoError(t, err)
+
+	upload := func(hash, folder, name string) *auditFile {
+		f := newAuditFile(&mpb.CheckFileExistReq{
+			FileHash: []byte(hash),
+			FileName: name,
+			Parent:   &mpb.FilePath{OneOfPath: &mpb.FilePath_Path{Path: folder}, SpaceNo: 1},
+		}, []*mpb.StorePartition{{Block: []*mpb.StoreBlock{{Hash: []byte("b" + hash), Phi: [][]byte{{1}}}}}})
+		require.NoError(t, s.put(f))
+		return f
+	}
+	names := func() []string {
+		files, err := s.pick(10)
+		require.NoError(t, err)
+		names := []string{}
+		for _, f := range files {
+			names = append(names, f.FileName)
+		}
+		return names
+	}
+	upload("h1", "/docs", "a.txt")
+	upload("h2", "/docs/sub", "b.txt")
+	upload("h3", "/docs2", "c.txt")
+	// newer version replaces record of the older one
+	a := upload("h4", "/docs", "a.txt")
+	require.ElementsMatch(t, []string{"/docs/a.txt", "/docs/sub/b.txt", "/docs2/c.txt"}, names())
+	files, err := s.pick(10)
+	require.NoError(t, err)
+	for _, f := range files {
+		if f.FileName == "/docs/a.txt" {
+			require.Equal(t, []byte("h4"), f.FileHash)
+		}
+	}
+
+	require.NoError(t, s.moved(1, "/docs/sub", "/moved"))
+	require.ElementsMatch(t, []string{"/docs/a.txt", "/moved/b.txt", "/docs2/c.txt"}, names())
+	// other space is not touched
+	require.NoError(t, s.remove(2, "/docs"))
+	require.Len(t, names(), 3)
+	// removing folder does not remove folders sharing its prefix
+	require.NoError(t, s.remove(1, "/docs/"))
+	require.ElementsMatch(t, []string{"/moved/b.txt", "/docs2/c.txt"}, names())
+	require.NoError(t, s.remove(1, "/moved/b.txt"))
+	require.ElementsMatch(t, []string{"/docs2/c.txt"}, names())
+	// report of file removed while auditing is not saved
+	require.NoError(t, s.saveReport(a, &AuditReport{FileName: a.FileName}))
+	require.Len(t, names(), 1)
+}
+
+func TestVerifyBlock(t *testing.T) {
+	data := make([]byte, 10*1024+100)
+	rand.Read(data)
+	chunkSize := uint32(1024)
+	scheme := por.MACScheme{}
+	md, err := scheme.GenMetadata(bytes.NewReader(data), chunkSize)
+	require.NoError(t, err)
+	b := &auditBlock{Size: uint64(len(data)), ChunkSize: chunkSize, Metadata: md}
+
+	prove := func(data []byte) func(ch por.Challenge, version uint32) ([]byte, error) {
+		return func(ch por.Challenge, version uint32) ([]byte, error) {
+			require.Equal(t, por.VersionMAC, version)
+			require.Len(t, ch, AuditChunks)
+			s, err := por.Lookup(version)
+			require.NoError(t, err)
+			return s.Prove(bytes.NewReader(data), int64(len(data)), chunkSize, ch)
+		}
+	}
+	require.NoError(t, verifyBlock(b, prove(data)))
+
+	// every chunk is changed, so any challenge fails
+	lost := append([]byte(nil), data...)
+	for i := 0; i < len(lost); i += int(chunkSize) {
+		lost[i]++
+	}
+	require.Equal(t, por.ErrProofMismatch, verifyBlock(b, prove(lost)))
+
+	require.Equal(t, ErrNoMetaData, verifyBlock(&auditBlock{}, prove(data)))
+}
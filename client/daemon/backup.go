@@ -0,0 +1,674 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/util/dbutil"
+	util_hash "github.com/samoslab/nebula/util/hash"
+)
+
+const (
+	// backupManifestDir folder of snapshot manifests under dest of backup job
+	backupManifestDir = ".manifest"
+	// backupTimeFormat snapshot is named by the UTC time it is made
+	backupTimeFormat = "20060102T150405Z"
+)
+
+var (
+	// backup bucket, key is job/snapshot, value is manifest of the snapshot
+	backupBkt = []byte("client_backup")
+
+	// ErrBackupRunning backup job is running already
+	ErrBackupRunning = errors.New("backup job is running")
+)
+
+// BackupEntry file of snapshot, its content is uploaded in folder of snapshot it was changed
+type BackupEntry struct {
+	Path    string `json:"path"` // slash separated path relative to source
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"modtime"`
+	Hash    string `json:"hash"`   // sha1 of file
+	Stored  string `json:"stored"` // snapshot which file is uploaded in
+}
+
+// BackupManifest files of snapshot, it is uploaded to the manifest folder of job
+type BackupManifest struct {
+	Job       string        `json:"job"`
+	Snapshot  string        `json:"snapshot"`
+	Source    string        `json:"source"`
+	CreatedAt uint64        `json:"created_at"`
+	Files     []BackupEntry `json:"files"`
+}
+
+// BackupStatus backup job and its snapshots in net disk, oldest first
+type BackupStatus struct {
+	config.BackupJob
+	Running   bool     `json:"running"`
+	Snapshots []string `json:"snapshots"`
+	Err       string   `json:"err,omitempty"`
+}
+
+// backupStore keep manifests of snapshots made by the client, the last one is the index of unchanged files
+type backupStore struct {
+	db      *bolt.DB
+	log     logrus.FieldLogger
+	mutex   sync.Mutex
+	running map[string]bool
+}
+
+func newBackupStore(db *bolt.DB, log logrus.FieldLogger) (*backupStore, error) {
+	if db == nil {
+		return nil, errors.New("new backup store failed, db is nil")
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(backupBkt); err != nil {
+			return dbutil.NewCreateBucketFailedErr(backupBkt, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &backupStore{
+		db:      db,
+		log:     log.WithField("prefix", "backup.store"),
+		running: map[string]bool{},
+	}, nil
+}
+
+func backupKey(job, snapshot string) []byte {
+	return []byte(job + "/" + snapshot)
+}
+
+func (s *backupStore) put(m *BackupManifest) error {
+	v, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(backupBkt).Put(backupKey(m.Job, m.Snapshot), v)
+	})
+}
+
+// get manifest of snapshot, nil if it is not kept
+func (s *backupStore) get(job, snapshot string) (*BackupManifest, error) {
+	var m *BackupManifest
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(backupBkt).Get(backupKey(job, snapshot))
+		if v == nil {
+			return nil
+		}
+		m = &BackupManifest{}
+		return json.Unmarshal(v, m)
+	})
+	return m, err
+}
+
+func (s *backupStore) remove(job, snapshot string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(backupBkt).Delete(backupKey(job, snapshot))
+	})
+}
+
+// start mark job running, false if it is running already
+func (s *backupStore) start(job string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.running[job] {
+		return false
+	}
+	s.running[job] = true
+	return true
+}
+
+func (s *backupStore) finish(job string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.running, job)
+}
+
+func (s *backupStore) isRunning(job string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.running[job]
+}
+
+// globMatch any glob matches the relative path or the file name
+func globMatch(globs []string, rel string) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(glob, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// backupMatch file of slash separated relative path is backed up
+func backupMatch(job config.BackupJob, rel string) bool {
+	if len(job.Include) > 0 && !globMatch(job.Include, rel) {
+		return false
+	}
+	return !globMatch(job.Exclude, rel)
+}
+
+// scanBackup walk regular files of source and compare them with entries of previous snapshot, a file keeps
+// its entry if its size and mod time are not changed or its hash is the same, other files are stored in
+// snapshot and indexes of them are returned as changed
+func scanBackup(job config.BackupJob, prev *BackupManifest, snapshot string) ([]BackupEntry, []int, error) {
+	index := map[string]BackupEntry{}
+	if prev != nil {
+		for _, e := range prev.Files {
+			index[e.Path] = e
+		}
+	}
+	entries := []BackupEntry{}
+	changed := []int{}
+	err := filepath.Walk(job.Source, func(local string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(job.Source, local)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !backupMatch(job, rel) {
+			return nil
+		}
+		e := BackupEntry{Path: rel, Size: info.Size(), ModTime: info.ModTime().Unix()}
+		old, ok := index[rel]
+		if ok && old.Size == e.Size && old.ModTime == e.ModTime {
+			entries = append(entries, old)
+			return nil
+		}
+		hash, err := util_hash.Sha1File(local)
+		if err != nil {
+			return err
+		}
+		e.Hash = hex.EncodeToString(hash)
+		if ok && old.Hash == e.Hash {
+			e.Stored = old.Stored
+		} else {
+			e.Stored = snapshot
+			changed = append(changed, len(entries))
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return entries, changed, nil
+}
+
+// retainSnapshots split snapshots sorted by name into kept and pruned ones, the last snapshot of each of the
+// last days, weeks and months with snapshots is kept, the newest snapshot and snapshots not named by time
+// are always kept, and all are kept if retention is not set
+func retainSnapshots(snapshots []string, daily, weekly, monthly int) (keep, prune []string) {
+	if daily == 0 && weekly == 0 && monthly == 0 {
+		return snapshots, nil
+	}
+	days, weeks, months := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	kept := make([]bool, len(snapshots))
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		tm, err := time.Parse(backupTimeFormat, snapshots[i])
+		if err != nil || i == len(snapshots)-1 {
+			kept[i] = true
+		}
+		if err != nil {
+			continue
+		}
+		tm = tm.Local()
+		year, week := tm.ISOWeek()
+		for _, r := range []struct {
+			seen map[string]bool
+			max  int
+			key  string
+		}{
+			{days, daily, tm.Format("2006-01-02")},
+			{weeks, weekly, fmt.Sprintf("%d-%d", year, week)},
+			{months, monthly, tm.Format("2006-01")},
+		} {
+			if !r.seen[r.key] && len(r.seen) < r.max {
+				r.seen[r.key] = true
+				kept[i] = true
+			}
+		}
+	}
+	for i, s := range snapshots {
+		if kept[i] {
+			keep = append(keep, s)
+		} else {
+			prune = append(prune, s)
+		}
+	}
+	return keep, prune
+}
+
+// backupJob config of backup job by name
+func (c *ClientManager) backupJob(name string) (config.BackupJob, error) {
+	for _, job := range c.webcfg.Backups {
+		if job.Name == name {
+			return job, nil
+		}
+	}
+	return config.BackupJob{}, fmt.Errorf("backup job %s not exists", name)
+}
+
+// ensureFolder make folder and its parents which do not exist
+func (c *ClientManager) ensureFolder(folder string, sno uint32) error {
+	parent := "/"
+	for _, name := range strings.Split(strings.Trim(folder, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		p := path.Join(parent, name)
+		_, err := c.StatFile(p, sno)
+		if err == ErrFileNotExist {
+			if _, err = c.MkFolder(parent, []string{name}, false, sno); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		parent = p
+	}
+	return nil
+}
+
+// BackupSnapshots names of snapshots of job in net disk, oldest first
+func (c *ClientManager) BackupSnapshots(name string) ([]string, error) {
+	job, err := c.backupJob(name)
+	if err != nil {
+		return nil, err
+	}
+	manifestDir := path.Join(job.Dest, backupManifestDir)
+	if _, err := c.StatFile(manifestDir, job.SpaceNo); err == ErrFileNotExist {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	files, err := c.ListAllFiles(manifestDir, job.SpaceNo)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := []string{}
+	for _, f := range files {
+		if !f.Folder && strings.HasSuffix(f.FileName, ".json") {
+			snapshots = append(snapshots, strings.TrimSuffix(f.FileName, ".json"))
+		}
+	}
+	sort.Strings(snapshots)
+	return snapshots, nil
+}
+
+// BackupJobs backup jobs of config with their snapshots
+func (c *ClientManager) BackupJobs() []*BackupStatus {
+	jobs := []*BackupStatus{}
+	for _, job := range c.webcfg.Backups {
+		st := &BackupStatus{BackupJob: job, Running: c.backup.isRunning(job.Name)}
+		snapshots, err := c.BackupSnapshots(job.Name)
+		if err != nil {
+			st.Err = err.Error()
+		}
+		st.Snapshots = snapshots
+		jobs = append(jobs, st)
+	}
+	return jobs
+}
+
+// loadManifest manifest of snapshot kept by client, or downloaded from net disk if it is not kept
+func (c *ClientManager) loadManifest(job config.BackupJob, snapshot string) (*BackupManifest, error) {
+	m, err := c.backup.get(job.Name, snapshot)
+	if err != nil || m != nil {
+		return m, err
+	}
+	remote := path.Join(job.Dest, backupManifestDir, snapshot+".json")
+	f, err := c.StatFile(remote, job.SpaceNo)
+	if err != nil {
+		return nil, fmt.Errorf("manifest of snapshot %s: %v", snapshot, err)
+	}
+	// DownloadFile acquires its own workspace, manifest is downloaded into temp dir
+	dir, err := ioutil.TempDir(c.TempDir, "manifest")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := c.DownloadFile(remote, dir, f.FileHash, f.FileSize, job.SpaceNo); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, snapshot+".json"))
+	if err != nil {
+		return nil, err
+	}
+	m = &BackupManifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if err := c.backup.put(m); err != nil {
+		c.Log.WithError(err).Errorf("Keep manifest of snapshot %s failed", snapshot)
+	}
+	return m, nil
+}
+
+// Backup make snapshot of backup job, only files changed since the last snapshot are uploaded into folder of
+// the snapshot, then snapshots out of retention are pruned
+func (c *ClientManager) Backup(name string) error {
+	job, err := c.backupJob(name)
+	if err != nil {
+		return err
+	}
+	if !c.backup.start(name) {
+		return ErrBackupRunning
+	}
+	defer c.backup.finish(name)
+	log := c.Log.WithField("backup", name)
+	isEncrypt := job.SpaceNo > 0
+
+	manifestDir := path.Join(job.Dest, backupManifestDir)
+	if err := c.ensureFolder(manifestDir, job.SpaceNo); err != nil {
+		return err
+	}
+	snapshots, err := c.BackupSnapshots(name)
+	if err != nil {
+		return err
+	}
+	var prev *BackupManifest
+	if len(snapshots) > 0 {
+		if prev, err = c.loadManifest(job, snapshots[len(snapshots)-1]); err != nil {
+			log.WithError(err).Warn("Load manifest of the last snapshot failed, hash all files")
+		}
+	}
+	snapshot := time.Now().UTC().Format(backupTimeFormat)
+	entries, changed, err := scanBackup(job, prev, snapshot)
+	if err != nil {
+		return err
+	}
+	if prev != nil && sameEntries(entries, prev.Files) {
+		log.Infof("No file changed since snapshot %s", prev.Snapshot)
+		return nil
+	}
+	log.Infof("Snapshot %s has %d files, %d changed", snapshot, len(entries), len(changed))
+
+	snapshotDir := path.Join(job.Dest, snapshot)
+	folders := []DirPair{{Name: snapshot, Parent: job.Dest, Folder: true}}
+	seen := map[string]bool{}
+	files := make([]dirFile, 0, len(changed))
+	for _, i := range changed {
+		dir := path.Dir(entries[i].Path)
+		for _, d := range parentDirs(dir) {
+			if !seen[d] {
+				seen[d] = true
+				folders = append(folders, DirPair{Name: path.Base(d), Parent: path.Join(snapshotDir, path.Dir(d)), Folder: true})
+			}
+		}
+		files = append(files, dirFile{
+			local: filepath.Join(job.Source, filepath.FromSlash(entries[i].Path)),
+			dest:  path.Join(snapshotDir, dir),
+			size:  entries[i].Size,
+		})
+	}
+	if err := c.mkFolderTree(job.Dest, folders, false, job.SpaceNo); err != nil {
+		return err
+	}
+	failed := map[string]error{}
+	report := func(files []dirFile, errs []error) {
+		for i, f := range files {
+			if errs[i] != nil {
+				log.WithError(errs[i]).Errorf("Back up %s failed", f.local)
+				failed[f.local] = errs[i]
+			}
+		}
+	}
+	if err := c.uploadFiles(files, snapshotDir, false, true, isEncrypt, job.Compress, job.SpaceNo, report); err != nil {
+		return err
+	}
+
+	// files failed to upload are not in snapshot, so they are uploaded again by the next snapshot
+	m := &BackupManifest{Job: name, Snapshot: snapshot, Source: job.Source, CreatedAt: common.Now()}
+	for _, e := range entries {
+		if _, ok := failed[filepath.Join(job.Source, filepath.FromSlash(e.Path))]; !ok {
+			m.Files = append(m.Files, e)
+		}
+	}
+	if err := c.uploadManifest(job, m); err != nil {
+		return err
+	}
+	if err := c.pruneSnapshots(log, job, append(snapshots, snapshot)); err != nil {
+		log.WithError(err).Error("Prune snapshots failed")
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d changed files of snapshot %s failed to upload", len(failed), len(changed), snapshot)
+	}
+	return nil
+}
+
+// sameEntries files of snapshots are the same
+func sameEntries(a, b []BackupEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parentDirs dir and its parents, the top one first, empty if dir is "."
+func parentDirs(dir string) []string {
+	dirs := []string{}
+	for ; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
+}
+
+// uploadManifest upload manifest into manifest folder of job and keep it as index of the next snapshot
+func (c *ClientManager) uploadManifest(job config.BackupJob, m *BackupManifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	// UploadFile acquires its own workspace, manifest is written into temp dir
+	dir, err := ioutil.TempDir(c.TempDir, "manifest")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, m.Snapshot+".json")
+	if err := ioutil.WriteFile(fileName, data, 0600); err != nil {
+		return err
+	}
+	if err := c.UploadFile(fileName, path.Join(job.Dest, backupManifestDir), false, true, job.SpaceNo > 0, job.Compress, job.SpaceNo); err != nil {
+		return err
+	}
+	return c.backup.put(m)
+}
+
+// pruneSnapshots remove snapshots out of retention, files stored in their folders are removed unless kept
+// snapshots refer to them
+func (c *ClientManager) pruneSnapshots(log logrus.FieldLogger, job config.BackupJob, snapshots []string) error {
+	keep, prune := retainSnapshots(snapshots, job.KeepDaily, job.KeepWeekly, job.KeepMonthly)
+	if len(prune) == 0 {
+		return nil
+	}
+	// files and folders referred by kept snapshots
+	referred := map[string]bool{}
+	folders := map[string]bool{}
+	for _, s := range keep {
+		m, err := c.loadManifest(job, s)
+		if err != nil {
+			return err
+		}
+		for _, e := range m.Files {
+			referred[path.Join(e.Stored, e.Path)] = true
+			folders[e.Stored] = true
+		}
+	}
+	removed := map[string]bool{}
+	for _, s := range prune {
+		log.Infof("Prune snapshot %s", s)
+		m, err := c.loadManifest(job, s)
+		if err != nil {
+			return err
+		}
+		for _, e := range m.Files {
+			p := path.Join(e.Stored, e.Path)
+			switch {
+			case referred[p] || removed[p] || removed[e.Stored]:
+				continue
+			case !folders[e.Stored]:
+				// no kept snapshot refers to files of the folder
+				err = c.RemoveFile(path.Join(job.Dest, e.Stored), true, true, job.SpaceNo)
+				removed[e.Stored] = true
+			default:
+				err = c.RemoveFile(path.Join(job.Dest, p), false, true, job.SpaceNo)
+				removed[p] = true
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if !folders[s] && !removed[s] {
+			// folder of snapshot whose files are all stored in earlier snapshots
+			if _, err := c.StatFile(path.Join(job.Dest, s), job.SpaceNo); err == nil {
+				if err := c.RemoveFile(path.Join(job.Dest, s), true, true, job.SpaceNo); err != nil {
+					return err
+				}
+			}
+			removed[s] = true
+		}
+		if err := c.RemoveFile(path.Join(job.Dest, backupManifestDir, s+".json"), false, true, job.SpaceNo); err != nil {
+			return err
+		}
+		if err := c.backup.remove(job.Name, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSnapshot download files of snapshot of backup job into destDir with their relative paths
+func (c *ClientManager) RestoreSnapshot(name, snapshot, destDir string) error {
+	job, err := c.backupJob(name)
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(destDir) {
+		return fmt.Errorf("path %s must absolute", destDir)
+	}
+	log := c.Log.WithField("restore", name+"@"+snapshot)
+	m, err := c.loadManifest(job, snapshot)
+	if err != nil {
+		return err
+	}
+	log.Infof("Restore %d files into %s", len(m.Files), destDir)
+	var mutex sync.Mutex
+	errs := []error{}
+	ccControl := NewCCController(common.CCDownloadGoNum)
+	for _, e := range m.Files {
+		select {
+		case <-c.quit:
+			ccControl.Wait()
+			return context.Canceled
+		default:
+		}
+		ccControl.Add()
+		go func(e BackupEntry) {
+			defer ccControl.Done()
+			if err := c.restoreEntry(job, e, destDir); err != nil {
+				log.WithError(err).Errorf("Restore %s failed", e.Path)
+				mutex.Lock()
+				errs = append(errs, err)
+				mutex.Unlock()
+			}
+		}(e)
+	}
+	ccControl.Wait()
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d files failed to restore, first error: %v", len(errs), len(m.Files), errs[0])
+	}
+	return nil
+}
+
+// restoreEntry download file of snapshot from folder of snapshot it is stored
+func (c *ClientManager) restoreEntry(job config.BackupJob, e BackupEntry, destDir string) error {
+	remote := path.Join(job.Dest, e.Stored, e.Path)
+	f, err := c.StatFile(remote, job.SpaceNo)
+	if err != nil {
+		return fmt.Errorf("%s: %v", remote, err)
+	}
+	local := filepath.Join(destDir, filepath.FromSlash(e.Path))
+	dir := filepath.Dir(local)
+	if err := os.MkdirAll(dir, 0744); err != nil {
+		return err
+	}
+	if err := c.DownloadFile(remote, dir, f.FileHash, f.FileSize, job.SpaceNo); err != nil {
+		return err
+	}
+	mtime := time.Unix(e.ModTime, 0)
+	return os.Chtimes(local, mtime, mtime)
+}
+
+// ScheduleBackups add task of backup job by its schedule, job which is still running is skipped
+func (c *ClientManager) ScheduleBackups() {
+	runner := cron.New()
+	scheduled := 0
+	for _, job := range c.webcfg.Backups {
+		if job.Schedule == "" {
+			continue
+		}
+		name := job.Name
+		err := runner.AddFunc(job.Schedule, func() {
+			if c.backup.isRunning(name) {
+				c.Log.Infof("Backup %s is running, skip schedule", name)
+				return
+			}
+			select {
+			case <-c.quit:
+				return
+			default:
+			}
+			if _, err := c.AddTask(common.TaskBackupType, &common.BackupReq{Job: name}); err != nil {
+				c.Log.WithError(err).Errorf("Add backup task of %s failed", name)
+			}
+		})
+		if err != nil {
+			c.Log.WithError(err).Errorf("Schedule backup %s failed", name)
+			continue
+		}
+		scheduled++
+	}
+	if scheduled == 0 {
+		return
+	}
+	c.Log.Infof("Schedule %d backup jobs", scheduled)
+	runner.Start()
+	<-c.quit
+	runner.Stop()
+}
@@ -0,0 +1,110 @@
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/samoslab/nebula/client/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetainSnapshots(t *testing.T) {
+	snapshots := []string{}
+	for day := 1; day <= 19; day++ {
+		snapshots = append(snapshots, fmt.Sprintf("202610%02dT120000Z", day))
+	}
+	snapshots = append(snapshots[:18], "20261019T100000Z", snapshots[18])
+
+	keep, prune := retainSnapshots(snapshots, 3, 2, 0)
+	require.Equal(t, []string{"20261017T120000Z", "20261018T120000Z", "20261019T120000Z"}, keep)
+	require.Len(t, prune, len(snapshots)-3)
+
+	keep, prune = retainSnapshots(snapshots, 0, 0, 1)
+	require.Equal(t, []string{"20261019T120000Z"}, keep)
+	require.Len(t, prune, len(snapshots)-1)
+
+	keep, prune = retainSnapshots(snapshots, 0, 0, 0)
+	require.Equal(t, snapshots, keep)
+	require.Empty(t, prune)
+
+	// not named by time
+	keep, _ = retainSnapshots([]string{"manual", "20261018T120000Z", "20261019T120000Z"}, 0, 1, 0)
+	require.Equal(t, []string{"manual", "20261019T120000Z"}, keep)
+}
+
+func TestScanBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "doc"), 0700))
+	for name, content := range map[string]string{"a.txt": "a", "doc/b.txt": "b", "doc/c.tmp": "c"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(content), 0600))
+	}
+	job := config.BackupJob{Name: "test", Source: dir, Exclude: []string{"*.tmp"}}
+	entries, changed, err := scanBackup(job, nil, "s1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "a.txt", entries[0].Path)
+	require.Equal(t, "doc/b.txt", entries[1].Path)
+	require.Equal(t, []int{0, 1}, changed)
+	prev := &BackupManifest{Snapshot: "s1", Files: entries}
+
+	entries, changed, err = scanBackup(job, prev, "s2")
+	require.NoError(t, err)
+	require.Empty(t, changed)
+	require.True(t, sameEntries(entries, prev.Files))
+
+	// touched file with the same content keeps where it is stored
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "a.txt"), later, later))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "doc", "b.txt"), []byte("changed"), 0600))
+	entries, changed, err = scanBackup(job, prev, "s2")
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, changed)
+	require.Equal(t, "s1", entries[0].Stored)
+	require.Equal(t, "s2", entries[1].Stored)
+	require.False(t, sameEntries(entries, prev.Files))
+
+	job.Include = []string{"doc/*"}
+	entries, _, err = scanBackup(job, prev, "s2")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestBackupStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := bolt.Open(filepath.Join(dir, ClientDBName), 0700, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	s, err := newBackupStore(db, logrus.New())
+	require.NoError(t, err)
+
+	m := &BackupManifest{Job: "docs", Snapshot: "s1", Files: []BackupEntry{{Path: "a.txt", Stored: "s1"}}}
+	require.NoError(t, s.put(m))
+	got, err := s.get("docs", "s1")
+	require.NoError(t, err)
+	require.Equal(t, m, got)
+	require.NoError(t, s.remove("docs", "s1"))
+	got, err = s.get("docs", "s1")
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	require.True(t, s.start("docs"))
+	require.False(t, s.start("docs"))
+	require.True(t, s.isRunning("docs"))
+	s.finish("docs")
+	require.False(t, s.isRunning("docs"))
+}
+
+func TestParentDirs(t *testing.T) {
+	require.Empty(t, parentDirs("."))
+	require.Equal(t, []string{"a", "a/b", "a/b/c"}, parentDirs("a/b/c"))
+}
@@ -0,0 +1,458 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/errcode"
+	"github.com/samoslab/nebula/client/register"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/samoslab/nebula/util/aes"
+	"github.com/samoslab/nebula/util/filetype"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// dirBatchFiles max files checked or finished in one batch request
+	dirBatchFiles = 200
+	// dirBatchInline max data of tiny files sent in one batch request
+	dirBatchInline = int64(2 * 1024 * 1024)
+	// dirBatchFolders max folders made in one request
+	dirBatchFolders = 1000
+)
+
+// errBatchUnsupported tracker does not serve batch requests, single requests are sent instead
+var errBatchUnsupported = errors.New("tracker does not support batch requests")
+
+// dirFile file of uploaded directory, dest is its folder in net disk
+type dirFile struct {
+	local string
+	dest  string
+	size  int64
+}
+
+// batchFile file of batch which is checked and stored
+type batchFile struct {
+	dirFile
+	fileName   string // file checked, encrypted copy of file in privacy space
+	password   []byte
+	req        *mpb.CheckFileExistReq
+	partitions []*mpb.StorePartition
+}
+
+// inlineFile tiny file is saved by tracker with its data
+func inlineFile(size int64) bool {
+	return size < ReplicaFileSize
+}
+
+// splitBatches group files in order into batches of at most maxFiles files and maxInline bytes of tiny file data
+func splitBatches(files []dirFile, maxFiles int, maxInline int64) [][]dirFile {
+	batches := [][]dirFile{}
+	var batch []dirFile
+	inline := int64(0)
+	for _, f := range files {
+		size := int64(0)
+		if inlineFile(f.size) {
+			size = f.size
+		}
+		if len(batch) > 0 && (len(batch) >= maxFiles || inline+size > maxInline) {
+			batches = append(batches, batch)
+			batch, inline = nil, 0
+		}
+		batch = append(batch, f)
+		inline += size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// folderPaths paths of folders relative to dest, parent folder is before its children as they are walked
+func folderPaths(folders []DirPair, dest string) []string {
+	prefix := strings.TrimSuffix(dest, "/") + "/"
+	paths := make([]string, 0, len(folders))
+	for _, f := range folders {
+		paths = append(paths, strings.TrimPrefix(path.Join(f.Parent, f.Name), prefix))
+	}
+	return paths
+}
+
+// batchUnsupported tracker without batch requests answers unimplemented
+func batchUnsupported(err error) bool {
+	return status.Code(err) == codes.Unimplemented
+}
+
+// renewTrackerKey get tracker public key again if err says it is expired, true if it is renewed
+func (c *ClientManager) renewTrackerKey(err error) (bool, error) {
+	st, ok := status.FromError(err)
+	if !ok || (st.Code() != 500 && st.Message() != "tracker public key expired") {
+		return false, nil
+	}
+	rsaPubkey, pubkeyHash, err := register.GetPublicKeyByConn(c.serverConn)
+	if err != nil {
+		return false, err
+	}
+	c.PubkeyHash = pubkeyHash
+	c.TrackerPubkey = rsaPubkey
+	return true, nil
+}
+
+// MkFolders make folder tree under parent, paths are relative to parent and parent folder is before its children
+func (c *ClientManager) MkFolders(parent string, paths []string, interactive bool, sno uint32) error {
+	log := c.Log.WithField("folder parent", parent)
+	req := &mpb.MkFoldersReq{
+		Version:     common.Version,
+		NodeId:      c.NodeId,
+		Timestamp:   common.Now(),
+		Parent:      &mpb.FilePath{OneOfPath: &mpb.FilePath_Path{Path: parent}, SpaceNo: sno},
+		Path:        paths,
+		Interactive: interactive,
+	}
+	if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return common.NewStatus(errcode.RetSignFailed, err)
+	}
+	log.Infof("Make %d folders", len(paths))
+	rsp, err := c.mclient.MkFolders(context.Background(), req)
+	if err != nil {
+		if batchUnsupported(err) {
+			return errBatchUnsupported
+		}
+		return err
+	}
+	if rsp.GetCode() != 0 {
+		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	for _, p := range paths {
+		folder := &DownFile{FileName: path.Base(p), Folder: true, ModTime: common.Now()}
+		c.cacheMutation(c.meta.addFiles(sno, path.Join(parent, path.Dir(p)), folder))
+	}
+	return nil
+}
+
+// mkFolderTree make folders of uploaded directory, one by one if tracker can not make them in batch
+func (c *ClientManager) mkFolderTree(dest string, folders []DirPair, interactive bool, sno uint32) error {
+	paths := folderPaths(folders, dest)
+	for i := 0; i < len(paths); i += dirBatchFolders {
+		end := i + dirBatchFolders
+		if end > len(paths) {
+			end = len(paths)
+		}
+		err := c.MkFolders(dest, paths[i:end], interactive, sno)
+		if err == errBatchUnsupported {
+			c.Log.Info("Tracker can not make folder tree, make folders one by one")
+			for _, dpair := range folders[i:] {
+				if _, err := c.MkFolder(dpair.Parent, []string{dpair.Name}, interactive, sno); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFilesExist check files in one request, results are in the same order as reqs
+func (c *ClientManager) checkFilesExist(log logrus.FieldLogger, reqs []*mpb.CheckFileExistReq, interactive, newVersion bool) ([]*mpb.CheckFileExistResp, error) {
+	req := &mpb.CheckFilesExistReq{
+		Version:     common.Version,
+		NodeId:      c.NodeId,
+		Interactive: interactive,
+		NewVersion:  newVersion,
+	}
+	for _, r := range reqs {
+		req.File = append(req.File, &mpb.CheckFileItem{
+			Parent:      r.GetParent(),
+			FileHash:    r.GetFileHash(),
+			FileSize:    r.GetFileSize(),
+			FileType:    r.GetFileType(),
+			EncryptKey:  r.GetEncryptKey(),
+			FileName:    r.GetFileName(),
+			FileModTime: r.GetFileModTime(),
+			FileData:    r.GetFileData(),
+			Durability:  r.GetDurability(),
+		})
+	}
+	var rsp *mpb.CheckFilesExistResp
+	for retry := 0; ; retry++ {
+		req.Timestamp = common.Now()
+		req.PublicKeyHash = c.PubkeyHash
+		if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+			return nil, common.NewStatus(errcode.RetSignFailed, err)
+		}
+		log.Infof("Check %d files exist request", len(req.File))
+		var err error
+		rsp, err = c.mclient.CheckFilesExist(context.Background(), req)
+		if err == nil {
+			break
+		}
+		if batchUnsupported(err) {
+			return nil, errBatchUnsupported
+		}
+		if renewed, rerr := c.renewTrackerKey(err); rerr != nil || !renewed || retry > 0 {
+			return nil, err
+		}
+	}
+	if rsp.GetCode() != 0 {
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	if len(rsp.GetResult()) != len(reqs) {
+		return nil, fmt.Errorf("tracker checked %d of %d files", len(rsp.GetResult()), len(reqs))
+	}
+	return rsp.GetResult(), nil
+}
+
+// uploadFilesDone finish upload of stored files in one request, results are in the same order as files
+func (c *ClientManager) uploadFilesDone(log logrus.FieldLogger, files []*batchFile, interactive, newVersion bool) ([]*mpb.UploadFileDoneResp, error) {
+	req := &mpb.UploadFilesDoneReq{
+		Version:     common.Version,
+		NodeId:      c.NodeId,
+		Interactive: interactive,
+		NewVersion:  newVersion,
+	}
+	for _, f := range files {
+		req.File = append(req.File, &mpb.UploadFileDoneItem{
+			Parent:      f.req.GetParent(),
+			FileHash:    f.req.GetFileHash(),
+			FileSize:    f.req.GetFileSize(),
+			FileType:    f.req.GetFileType(),
+			EncryptKey:  f.req.GetEncryptKey(),
+			FileName:    f.req.GetFileName(),
+			FileModTime: f.req.GetFileModTime(),
+			Partition:   f.partitions,
+		})
+	}
+	var rsp *mpb.UploadFilesDoneResp
+	for retry := 0; ; retry++ {
+		req.Timestamp = common.Now()
+		req.PublicKeyHash = c.PubkeyHash
+		if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+			return nil, common.NewStatus(errcode.RetSignFailed, err)
+		}
+		log.Infof("Upload %d files done request", len(req.File))
+		var err error
+		rsp, err = c.mclient.UploadFilesDone(context.Background(), req)
+		if err == nil {
+			break
+		}
+		if batchUnsupported(err) {
+			return nil, errBatchUnsupported
+		}
+		if renewed, rerr := c.renewTrackerKey(err); rerr != nil || !renewed || retry > 0 {
+			return nil, err
+		}
+	}
+	if rsp.GetCode() != 0 {
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	if len(rsp.GetResult()) != len(files) {
+		return nil, fmt.Errorf("tracker finished %d of %d files", len(rsp.GetResult()), len(files))
+	}
+	return rsp.GetResult(), nil
+}
+
+// batchCheckReq request checking file of batch, tiny file of privacy space is encrypted into sub folder
+// seq of batch workspace first
+func (c *ClientManager) batchCheckReq(log logrus.FieldLogger, ws *Workspace, seq int, f dirFile, interactive, newVersion, isEncrypt bool, sno uint32) (*batchFile, error) {
+	password, encryptKey, err := c.uploadKeys(log, isEncrypt, sno)
+	if err != nil {
+		return nil, err
+	}
+	bf := &batchFile{dirFile: f, fileName: f.local, password: password}
+	if sno > 0 && isEncrypt {
+		dir := filepath.Join(ws.Dir, strconv.Itoa(seq))
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, err
+		}
+		bf.fileName = filepath.Join(dir, filepath.Base(f.local))
+		if err := aes.EncryptFile(f.local, password, bf.fileName); err != nil {
+			log.Errorf("Encrypt error %v", err)
+			return nil, err
+		}
+	}
+	bf.req, err = c.checkFileReq(log, bf.fileName, f.dest, interactive, newVersion, password, encryptKey, sno, filetype.FileType(f.local))
+	if err != nil {
+		return nil, err
+	}
+	return bf, nil
+}
+
+// storeBatchFile store file of batch which tracker allows to upload, held is the workspace of the batch
+func (c *ClientManager) storeBatchFile(held *Workspace, bf *batchFile, rsp *mpb.CheckFileExistResp, isEncrypt bool, sno uint32) error {
+	log := c.Log.WithField("upload file", bf.local)
+	ws, err := c.scratch.AcquireIn(held, "upload@"+common.ProgressKey(serverPath(bf.dest, bf.fileName), sno), uploadScratchSize(bf.size, isEncrypt))
+	if err != nil {
+		return err
+	}
+	defer ws.Release()
+	ctx, cancel := c.taskContext()
+	defer cancel()
+	bf.partitions, err = c.storeFile(ctx, log, ws, bf.fileName, bf.req, rsp, isEncrypt, bf.password, sno)
+	return err
+}
+
+// uploadBatch upload files with one check request and one done request, large files of privacy space and
+// files which are compressed are uploaded one by one as they are encoded whole before checked, and items
+// of batch check carry no encoding. They are uploaded after workspace of the batch is released, errors are
+// in the same order as files
+func (c *ClientManager) uploadBatch(files []dirFile, interactive, newVersion, isEncrypt, compress bool, sno uint32) ([]error, error) {
+	log := c.Log.WithField("upload batch", files[0].local)
+	errs := make([]error, len(files))
+	var ws *Workspace
+	release := func() {
+		if ws != nil {
+			ws.Release()
+			ws = nil
+		}
+	}
+	defer release()
+	if sno > 0 && isEncrypt {
+		// encrypted copies of files in batch, and files stored from them in workspaces nested in it
+		size := int64(0)
+		for _, f := range files {
+			if inlineFile(f.size) {
+				size += f.size + uploadScratchSize(f.size, isEncrypt)
+			}
+		}
+		var err error
+		ws, err = c.scratch.Acquire("upload@batch:"+common.ProgressKey(files[0].local, sno), size)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	checked := map[int]*batchFile{}
+	reqs := []*mpb.CheckFileExistReq{}
+	order := []int{}
+	solo := []int{}
+	for i, f := range files {
+		if sno > 0 && isEncrypt && !inlineFile(f.size) || c.shouldCompress(compress, sno, f.size, filetype.FileType(f.local)) {
+			solo = append(solo, i)
+			continue
+		}
+		bf, err := c.batchCheckReq(log, ws, i, f, interactive, newVersion, isEncrypt, sno)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		checked[i] = bf
+		reqs = append(reqs, bf.req)
+		order = append(order, i)
+	}
+	var rsps []*mpb.CheckFileExistResp
+	if len(reqs) > 0 {
+		var err error
+		rsps, err = c.checkFilesExist(log, reqs, interactive, newVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ccControl := NewCCController(common.CCUploadFileNum)
+	run := func(i int, upload func() error) {
+		ccControl.Add()
+		go func() {
+			done := make(chan struct{})
+			go HandleQuit(c.quit, done, ccControl)
+			defer func() {
+				close(done)
+				ccControl.Done()
+			}()
+			errs[i] = upload()
+		}()
+	}
+	held := ws
+	for k := range rsps {
+		i, rsp := order[k], rsps[k]
+		bf := checked[i]
+		switch rsp.GetCode() {
+		case 0:
+			sp := serverPath(bf.dest, bf.fileName)
+			c.PM.SetProgress(common.TaskUploadProgressType, common.ProgressKey(sp, sno), bf.req.FileSize, bf.req.FileSize, sno, bf.fileName)
+			c.cacheUploaded(bf.req)
+			log.Infof("Upload %s success", bf.local)
+			delete(checked, i)
+		case 1:
+			run(i, func() error { return c.storeBatchFile(held, bf, rsp, isEncrypt, sno) })
+		default:
+			errs[i] = common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+			delete(checked, i)
+		}
+	}
+	ccControl.Wait()
+	// files uploaded one by one acquire workspaces of their own, waiting for them while holding
+	// workspace of the batch could wait for the budget held by the batch itself
+	release()
+	for _, i := range solo {
+		f := files[i]
+		run(i, func() error { return c.UploadFile(f.local, f.dest, interactive, newVersion, isEncrypt, compress, sno) })
+	}
+	ccControl.Wait()
+
+	stored := []*batchFile{}
+	storedOrder := []int{}
+	for _, i := range order {
+		if bf, ok := checked[i]; ok && errs[i] == nil {
+			stored = append(stored, bf)
+			storedOrder = append(storedOrder, i)
+		}
+	}
+	if len(stored) == 0 {
+		return errs, nil
+	}
+	dones, err := c.uploadFilesDone(log, stored, interactive, newVersion)
+	if err == errBatchUnsupported {
+		log.Info("Tracker can not finish files in batch, finish them one by one")
+		for k, bf := range stored {
+			errs[storedOrder[k]] = c.UploadFileDone(bf.req, bf.partitions, bf.req.GetEncryptKey())
+		}
+		return errs, nil
+	}
+	for k, bf := range stored {
+		i := storedOrder[k]
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if done := dones[k]; done.GetCode() != 0 {
+			errs[i] = common.NewStatusErr(done.Code, done.ErrMsg)
+			continue
+		}
+		c.cacheUploaded(bf.req)
+		c.auditUploaded(bf.req, bf.partitions)
+	}
+	return errs, nil
+}
+
+// uploadEach upload files one by one concurrently, errors are in the same order as files
+func (c *ClientManager) uploadEach(files []dirFile, interactive, newVersion, isEncrypt, compress bool, sno uint32) []error {
+	errs := make([]error, len(files))
+	ccControl := NewCCController(common.CCUploadFileNum)
+	for i, f := range files {
+		c.Log.Infof("Upload file %+v", f)
+		ccControl.Add()
+		go func(i int, f dirFile) {
+			done := make(chan struct{})
+			go HandleQuit(c.quit, done, ccControl)
+			defer func() {
+				close(done)
+				ccControl.Done()
+			}()
+			errs[i] = c.UploadFile(f.local, f.dest, interactive, newVersion, isEncrypt, compress, sno)
+		}(i, f)
+	}
+	ccControl.Wait()
+	return errs
+}
@@ -0,0 +1,52 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBatches(t *testing.T) {
+	files := []dirFile{
+		{local: "a", size: 4096},
+		{local: "b", size: 4096},
+		{local: "c", size: 1 << 30},
+		{local: "d", size: 100},
+		{local: "e", size: 100},
+		{local: "f", size: 100},
+	}
+	batches := splitBatches(files, 3, 8192)
+	names := [][]string{}
+	for _, batch := range batches {
+		b := []string{}
+		for _, f := range batch {
+			b = append(b, f.local)
+		}
+		names = append(names, b)
+	}
+	// large file does not count for inline data, batch is cut by count and by inline bytes
+	require.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e", "f"}}, names)
+
+	batches = splitBatches(files[:2], 10, 6000)
+	require.Len(t, batches, 2)
+
+	// a single tiny file larger than the limit is still sent
+	batches = splitBatches(files[:1], 10, 1024)
+	require.Len(t, batches, 1)
+	require.Empty(t, splitBatches(nil, 10, 1024))
+}
+
+func TestFolderPaths(t *testing.T) {
+	dirs := dirAdjust([]DirPair{
+		{Name: "work", Parent: "/home/user/", Folder: true},
+		{Name: "doc", Parent: "/home/user/work/", Folder: true},
+		{Name: "img", Parent: "/home/user/work/doc/", Folder: true},
+	}, "/home/user/work", "/cloud", "linux")
+	require.Equal(t, []string{"work", "work/doc", "work/doc/img"}, folderPaths(dirs, "/cloud"))
+
+	dirs = dirAdjust([]DirPair{
+		{Name: "work", Parent: "/home/user/", Folder: true},
+		{Name: "doc", Parent: "/home/user/work/", Folder: true},
+	}, "/home/user/work", "/", "linux")
+	require.Equal(t, []string{"work", "work/doc"}, folderPaths(dirs, "/"))
+}
@@ -72,7 +72,7 @@ func GetBestReplicaProvider(pros []*mpb.ReplicaProvider, needNum int) ([]*mpb.Re
 		}
 	}
 
-	if len(wellPros) < MinReplicaNum {
+	if len(wellPros) < needNum {
 		return nil, nil, fmt.Errorf("Replica provider only %d", len(wellPros))
 	}
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
@@ -35,6 +36,7 @@ import (
 	"github.com/samoslab/nebula/util/filecheck"
 	util_hash "github.com/samoslab/nebula/util/hash"
 	rsalong "github.com/samoslab/nebula/util/rsa"
+	"github.com/samoslab/nebula/util/trackerpool"
 	"github.com/sirupsen/logrus"
 
 	"github.com/samoslab/nebula/client/register"
@@ -69,71 +71,61 @@ var (
 
 	RetryCount = 3
 
+	// MetaDataTimeout max time of waiting metadata of a block
+	MetaDataTimeout = time.Hour
+
 	ErrNoMetaData = errors.New("no metadata")
+
+	// ErrFileNotExist file or its parent folder not exists
+	ErrFileNotExist = errors.New("file not exists")
 )
 
 // DownFile list files format, used when download file
 type DownFile struct {
-	ID        string `json:"id"`
-	FileSize  uint64 `json:"filesize"`
-	FileName  string `json:"filename"`
-	FileHash  string `json:"filehash"`
-	Folder    bool   `json:"folder"`
-	FileType  string `json:"filetype"`
-	ModTime   uint64 `json:"modtime"`
-	Extension string `json:"extension"`
-}
-
-// FilePages list file
-type FilePages struct {
-	Total uint32      `json:"total"`
-	Files []*DownFile `json:"files"`
-}
-
-type MetaData struct {
-	paraStr   string
-	generator []byte
-	pubKey    []byte
-	random    []byte
-	phi       [][]byte
-	err       error
+	ID          string `json:"id"`
+	FileSize    uint64 `json:"filesize"`
+	FileName    string `json:"filename"`
+	FileHash    string `json:"filehash"`
+	Folder      bool   `json:"folder"`
+	FileType    string `json:"filetype"`
+	ModTime     uint64 `json:"modtime"`
+	Extension   string `json:"extension"`
+	Durability  string `json:"durability,omitempty"`  // effective durability, RS(data,parity) or number of replicas
+	Packed      bool   `json:"packed,omitempty"`      // stored in archive with other small files
+	Encoding    string `json:"encoding,omitempty"`    // zstd if file is compressed, filesize is the compressed size
+	DecodedSize uint64 `json:"decodedsize,omitempty"` // size of file before compression
 }
 
-type MetaDataMap struct {
-	md    map[string]MetaData
-	mutex sync.Mutex
-}
-
-func (m *MetaDataMap) Add(fileName, paraStr string, generator, pubKey, random []byte, phi [][]byte, err error) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-	m.md[fileName] = MetaData{paraStr: paraStr, generator: generator, pubKey: pubKey, random: random, phi: phi, err: err}
-}
-
-func (m *MetaDataMap) Get(fileName string) (paraStr string, generator, pubKey, random []byte, phi [][]byte, err error) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-	if md, ok := m.md[fileName]; ok {
-		delete(m.md, fileName)
-		return md.paraStr, md.generator, md.pubKey, md.random, md.phi, md.err
+// Size size of file content, it is the size before compression if file is compressed
+func (f *DownFile) Size() uint64 {
+	if f.Encoding != "" {
+		return f.DecodedSize
 	}
-	return "", nil, nil, nil, nil, ErrNoMetaData
+	return f.FileSize
 }
 
-type MetaKey struct {
-	FileName  string
-	ChunkSize uint32
+// FilePages list file
+type FilePages struct {
+	Total     uint32      `json:"total"`
+	Files     []*DownFile `json:"files"`
+	Stale     bool        `json:"stale,omitempty"`      // served from metadata cache because tracker is unreachable
+	UpdatedAt uint64      `json:"updated_at,omitempty"` // time listed from tracker if served from metadata cache
 }
 
 // ClientManager client manager
 type ClientManager struct {
 	NodeId        []byte
 	store         *store
+	meta          *metaCache
+	audit         *auditStore
+	backup        *backupStore
 	TempDir       string
+	scratch       *ScratchManager
 	PubkeyHash    []byte
 	Root          string
 	mutex         sync.Mutex
 	metaMutex     sync.Mutex
+	metaGen       *filecheck.Generator
 	MsgCount      uint32
 	MsgChan       chan string
 	done          chan struct{}
@@ -143,14 +135,14 @@ type ClientManager struct {
 	webcfg        config.Config
 	TrackerPubkey *rsa.PublicKey
 	serverConn    *grpc.ClientConn
+	trackers      *trackerpool.Pool
 	Log           logrus.FieldLogger
 	OM            *order.OrderManager
 	FileTypeMap   filetype.SupportType
 	cfg           *config.ClientConfig
 	PM            *progress.ProgressManager
 	mclient       mpb.MatadataServiceClient
-	mdm           *MetaDataMap
-	MetaChan      chan MetaKey
+	metaJobs      *metaJobs
 }
 
 // NewClientManager create manager
@@ -164,15 +156,24 @@ func NewClientManager(log logrus.FieldLogger, webcfg config.Config, cfg *config.
 	if cfg == nil {
 		return nil, errors.New("client config nil")
 	}
-	conn, err := common.GrpcDial(webcfg.TrackerServer)
+	trackers, err := trackerpool.New(log, trackerpool.Split(webcfg.TrackerServer), trackerpool.PingTracker,
+		func(ctx context.Context, conn *grpc.ClientConn) ([]string, error) {
+			return register.GetTrackerServer(ctx, conn, cfg.Node)
+		})
 	if err != nil {
 		log.Errorf("Rpc dial failed: %s", err.Error())
 		return nil, err
 	}
 	log.Infof("Tracker server %s", webcfg.TrackerServer)
+	if cfg.Device != nil {
+		log.Infof("Enrolled device %s of node %s", cfg.Device.DeviceId, cfg.NodeId)
+		trackers.SetHeader(mpb.DeviceIdHeader, cfg.Device.DeviceId)
+	}
+	conn := trackers.Conn()
 
-	rsaPubkey, pubkeyHash, err := register.GetPublicKey(webcfg.TrackerServer)
+	rsaPubkey, pubkeyHash, err := register.GetPublicKeyByConn(conn)
 	if err != nil {
+		trackers.Close()
 		return nil, err
 	}
 
@@ -197,13 +198,36 @@ func NewClientManager(log logrus.FieldLogger, webcfg config.Config, cfg *config.
 		log.WithError(err).Error("New store failed")
 		return nil, err
 	}
+	meta, err := newMetaCache(db, log)
+	if err != nil {
+		log.WithError(err).Error("New metadata cache failed")
+		return nil, err
+	}
+
+	backup, err := newBackupStore(db, log)
+	if err != nil {
+		log.WithError(err).Error("New backup store failed")
+		return nil, err
+	}
+
+	var audit *auditStore
+	if webcfg.AuditInterval > 0 {
+		if audit, err = newAuditStore(db, log); err != nil {
+			log.WithError(err).Error("New audit store failed")
+			return nil, err
+		}
+	}
 
 	c := &ClientManager{
 		OM:            om,
 		Log:           log,
 		cfg:           cfg,
 		serverConn:    conn,
+		trackers:      trackers,
 		store:         store,
+		meta:          meta,
+		audit:         audit,
+		backup:        backup,
 		SpaceM:        spaceM,
 		webcfg:        webcfg,
 		TrackerPubkey: rsaPubkey,
@@ -217,8 +241,17 @@ func NewClientManager(log logrus.FieldLogger, webcfg config.Config, cfg *config.
 		mclient:       mpb.NewMatadataServiceClient(conn),
 		MsgChan:       make(chan string, common.MsgQueueLen),
 		TaskChan:      make(chan TaskInfo, common.TaskQuqueLen),
-		MetaChan:      make(chan MetaKey, common.MetaQuqueLen),
-		mdm:           &MetaDataMap{md: map[string]MetaData{}},
+		metaJobs:      newMetaJobs(common.MetaQuqueLen),
+	}
+
+	workers := webcfg.MetaWorkers
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > 1 {
+		if c.metaGen, err = filecheck.NewGenerator(workers); err != nil {
+			log.WithError(err).Warn("Create metadata generator failed, gen metadata in order")
+		}
 	}
 
 	collectClient.NodePtr = cfg.Node
@@ -230,12 +263,29 @@ func NewClientManager(log logrus.FieldLogger, webcfg config.Config, cfg *config.
 			panic(err)
 		}
 	}
+	scratchDir := webcfg.ScratchDir
+	if scratchDir == "" {
+		scratchDir = filepath.Join(c.TempDir, "nebula-scratch-"+hex.EncodeToString(cfg.Node.NodeId)[:16])
+	}
+	if c.scratch, err = NewScratchManager(log, scratchDir, webcfg.ScratchBudget); err != nil {
+		log.WithError(err).Errorf("Create scratch dir %s failed", scratchDir)
+		return nil, err
+	}
 
 	collectClient.Start(webcfg.CollectServer)
+	trackers.Start()
 
 	go c.ExecuteTask()
 	go c.SendProgressMsg()
 	go c.GenMetadataInOrder()
+	go c.RefreshMetaCache()
+	go c.AuditFiles()
+	go c.ScheduleBackups()
+	go func() {
+		if err := c.loadSpaceKeys(); err != nil {
+			log.WithError(err).Warn("Load space keys of device failed")
+		}
+	}()
 
 	return c, nil
 }
@@ -265,10 +315,14 @@ func (c *ClientManager) AddDoneMsg(msg string) {
 
 // Shutdown shutdown tracker connection
 func (c *ClientManager) Shutdown() {
-	c.serverConn.Close()
+	c.trackers.Close()
 	collectClient.Stop()
 	close(c.quit)
+	c.scratch.Close()
 	<-c.done
+	if c.metaGen != nil {
+		c.metaGen.Close()
+	}
 	close(c.TaskChan)
 	close(c.MsgChan)
 }
@@ -284,6 +338,10 @@ func map2Req(taskInfo TaskInfo) (TaskInfo, error) {
 		req = &common.DownloadReq{}
 	case common.TaskDownloadDirType:
 		req = &common.DownloadDirReq{}
+	case common.TaskBackupType:
+		req = &common.BackupReq{}
+	case common.TaskRestoreType:
+		req = &common.RestoreReq{}
 	default:
 		return taskInfo, errors.New("unknown task type")
 	}
@@ -365,14 +423,14 @@ func (c *ClientManager) ExecuteTask() error {
 					switch task.Type {
 					case common.TaskUploadFileType:
 						req := task.Payload.(*common.UploadReq)
-						err = c.UploadFile(req.Filename, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Sno)
+						err = c.UploadFile(req.Filename, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Compress, req.Sno)
 						log.Infof("UploadFile result %v", err)
 						doneMsg.Key = common.ProgressKey(serverPath(req.Dest, req.Filename), req.Sno)
 						doneMsg.SpaceNo = req.Sno
 						doneMsg.Local = req.Filename
 					case common.TaskUploadDirType:
 						req := task.Payload.(*common.UploadDirReq)
-						err = c.UploadDir(req.Parent, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Sno)
+						err = c.UploadDir(req.Parent, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Compress, req.Sno)
 						doneMsg.Key = req.Dest
 						doneMsg.SpaceNo = req.Sno
 						doneMsg.Local = req.Parent
@@ -388,6 +446,15 @@ func (c *ClientManager) ExecuteTask() error {
 						doneMsg.Key = req.Parent
 						doneMsg.SpaceNo = req.Sno
 						doneMsg.Local = req.Dest
+					case common.TaskBackupType:
+						req := task.Payload.(*common.BackupReq)
+						err = c.Backup(req.Job)
+						doneMsg.Key = req.Job
+					case common.TaskRestoreType:
+						req := task.Payload.(*common.RestoreReq)
+						err = c.RestoreSnapshot(req.Job, req.Snapshot, req.Dest)
+						doneMsg.Key = req.Job + "@" + req.Snapshot
+						doneMsg.Local = req.Dest
 					default:
 						err = errors.New("unknown")
 					}
@@ -467,7 +534,8 @@ func (c *ClientManager) SendProgressMsg() error {
 	return nil
 }
 
-// GenMetadataInOrder gen metadata by single goroutine due to crash if runing by multi-goroutine
+// GenMetadataInOrder gen metadata by single goroutine due to crash if runing by multi-goroutine,
+// metadata is generated by worker processes in parallel if metadata generator is available
 func (c *ClientManager) GenMetadataInOrder() error {
 	log := c.Log
 	defer func() {
@@ -488,15 +556,12 @@ func (c *ClientManager) GenMetadataInOrder() error {
 			select {
 			case <-c.quit:
 				return
-			case mk := <-c.MetaChan:
-				t1 := time.Now()
-				log.Infof("gen %s metadata chunksize %d", mk.FileName, mk.ChunkSize)
-				c.metaMutex.Lock()
-				paraStr, generator, pubKey, random, phi, err := filecheck.GenMetadata(mk.FileName, mk.ChunkSize)
-				c.metaMutex.Unlock()
-				t2 := time.Now()
-				log.Infof("gen %s metadata time elapased %+v", mk.FileName, t2.Sub(t1).Seconds())
-				c.mdm.Add(mk.FileName, paraStr, generator, pubKey, random, phi, err)
+			case j := <-c.metaJobs.queue:
+				if c.metaGen != nil {
+					go c.runMetaJob(j)
+				} else {
+					c.runMetaJob(j)
+				}
 			}
 		}
 	}()
@@ -529,9 +594,14 @@ func (c *ClientManager) SetRoot(path string) error {
 }
 
 // SetPassword set user privacy space password
-func (c *ClientManager) SetPassword(sno uint32, password string) error {
-	var err error
+func (c *ClientManager) SetPassword(sno uint32, password string) (err error) {
 	log := c.Log
+	defer func() {
+		if err == nil {
+			// enrolled devices get the space key too
+			go c.shareSpaceKeys()
+		}
+	}()
 	password, err = passwordPadding(password, sno)
 	if err != nil {
 		return err
@@ -570,12 +640,17 @@ func (c *ClientManager) SetPassword(sno uint32, password string) error {
 		return err
 	}
 
-	return c.UploadFile(encryFile, "/", false, false, false, sno)
+	return c.UploadFile(encryFile, "/", false, false, false, false, sno)
 }
 
 // VerifyPassword set user privacy space password
-func (c *ClientManager) VerifyPassword(sno uint32, password string) error {
-	var err error
+func (c *ClientManager) VerifyPassword(sno uint32, password string) (err error) {
+	defer func() {
+		if err == nil {
+			// enrolled devices get the space key too
+			go c.shareSpaceKeys()
+		}
+	}()
 	password, err = passwordPadding(password, sno)
 	if err != nil {
 		return err
@@ -612,7 +687,7 @@ func (c *ClientManager) CheckSpaceStatus(sno uint32) error {
 }
 
 // UploadDir upload all files in dir to provider
-func (c *ClientManager) UploadDir(parent, dest string, interactive, newVersion, isEncrypt bool, sno uint32) error {
+func (c *ClientManager) UploadDir(parent, dest string, interactive, newVersion, isEncrypt, compress bool, sno uint32) error {
 	log := c.Log
 	if !filepath.IsAbs(parent) {
 		return fmt.Errorf("path %s must absolute", parent)
@@ -633,49 +708,105 @@ func (c *ClientManager) UploadDir(parent, dest string, interactive, newVersion,
 	log.Infof("Upload dirs %+v", dirs)
 	newDirs := dirAdjust(dirs, parent, dest, runtime.GOOS)
 	log.Infof("New upload dirs %+v", newDirs)
-	errArr := []error{}
-	var mutex sync.Mutex
-	ccControl := NewCCController(common.CCUploadFileNum)
+	folders := []DirPair{}
+	files := []dirFile{}
 	for _, dpair := range newDirs {
 		if dpair.Folder {
-			log.Infof("Mkfolder %+v", dpair)
-			_, err := c.MkFolder(dpair.Parent, []string{dpair.Name}, interactive, sno)
-			if err != nil {
-				return err
+			folders = append(folders, dpair)
+			continue
+		}
+		size, err := GetFileSize(dpair.Name)
+		if err != nil {
+			return err
+		}
+		files = append(files, dirFile{local: dpair.Name, dest: dpair.Parent, size: size})
+	}
+	log.Infof("Mkfolder %d folders", len(folders))
+	if err := c.mkFolderTree(dest, folders, interactive, sno); err != nil {
+		return err
+	}
+	errArr := []error{}
+	report := func(files []dirFile, errs []error) {
+		for i, f := range files {
+			doneMsg := common.MakeSuccDoneMsg(common.TaskUploadFileType, f.local, sno)
+			doneMsg.Local = f.local
+			if errs[i] != nil {
+				doneMsg.SetError(1, errs[i])
+				errArr = append(errArr, errs[i])
 			}
-		} else {
-			log.Infof("Upload file %+v", dpair)
-			ccControl.Add()
-			go func(dpair DirPair) {
-				done := make(chan struct{})
-				go HandleQuit(c.quit, done, ccControl)
-				defer func() {
-					close(done)
-					ccControl.Done()
-				}()
-				doneMsg := common.MakeSuccDoneMsg(common.TaskUploadFileType, dpair.Name, sno)
-				doneMsg.Local = dpair.Name
-				err := c.UploadFile(dpair.Name, dpair.Parent, interactive, newVersion, isEncrypt, sno)
-				if err != nil {
-					doneMsg.SetError(1, err)
-				}
-
-				c.AddDoneMsg(doneMsg.Serialize())
-				if err != nil {
-					mutex.Lock()
-					errArr = append(errArr, err)
-					mutex.Unlock()
-				}
-			}(dpair)
+			c.AddDoneMsg(doneMsg.Serialize())
 		}
 	}
-	ccControl.Wait()
+	if err := c.uploadFiles(files, dest, interactive, newVersion, isEncrypt, compress, sno, report); err != nil {
+		return err
+	}
 	if len(errArr) > 0 {
 		return errArr[0]
 	}
 	return nil
 }
 
+// uploadFiles upload files of directory into their folders which are made already, packed in archives if
+// pack_files is set and in batches if tracker supports, result of every file is reported
+func (c *ClientManager) uploadFiles(files []dirFile, dest string, interactive, newVersion, isEncrypt, compress bool, sno uint32, report func(files []dirFile, errs []error)) error {
+	log := c.Log
+	sameErr := func(n int, err error) []error {
+		errs := make([]error, n)
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	if c.webcfg.PackFiles {
+		packs, rest := splitPacks(files, packArchiveSize)
+		files = rest
+		for i, pack := range packs {
+			select {
+			case <-c.quit:
+				return context.Canceled
+			default:
+			}
+			errs, err := c.uploadPack(pack, dest, interactive, newVersion, isEncrypt, sno)
+			if err == errBatchUnsupported {
+				log.Info("Tracker can not pack files, upload them alone")
+				for _, p := range packs[i:] {
+					files = append(files, p...)
+				}
+				break
+			}
+			if err != nil {
+				errs = sameErr(len(pack), err)
+			}
+			report(pack, errs)
+		}
+	}
+	batched := true
+	for _, batch := range splitBatches(files, dirBatchFiles, dirBatchInline) {
+		select {
+		case <-c.quit:
+			return context.Canceled
+		default:
+		}
+		log.Infof("Upload %d files from %s", len(batch), batch[0].local)
+		var errs []error
+		if batched {
+			var err error
+			errs, err = c.uploadBatch(batch, interactive, newVersion, isEncrypt, compress, sno)
+			if err == errBatchUnsupported {
+				log.Info("Tracker can not check files in batch, upload files one by one")
+				batched = false
+			} else if err != nil {
+				errs = sameErr(len(batch), err)
+			}
+		}
+		if !batched {
+			errs = c.uploadEach(batch, interactive, newVersion, isEncrypt, compress, sno)
+		}
+		report(batch, errs)
+	}
+	return nil
+}
+
 func (c *ClientManager) getSpacePassword(sno uint32) ([]byte, error) {
 	log := c.Log
 	if sno == 0 {
@@ -722,10 +853,9 @@ func localPath(dest, fileName string) string {
 	return filepath.Join(dest, onlyFileName)
 }
 
-// UploadFile upload file to provider
-func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersion, isEncrypt bool, sno uint32) error {
-	var err error
-	var password, encryptKey []byte
+// UploadFile upload file to provider, it is compressed before encryption if compress is true or its space
+// is configured to be compressed
+func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersion, isEncrypt, compress bool, sno uint32) error {
 	log := c.Log.WithField("upload file", fileName)
 	defer func() {
 		if r := recover(); r != nil {
@@ -733,26 +863,52 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 			debug.PrintStack()
 		}
 	}()
-	if isEncrypt {
-		password, err = c.getSpacePassword(sno)
-		if err != nil {
-			log.WithError(err).Info("Get space password")
+	password, encryptKey, err := c.uploadKeys(log, isEncrypt, sno)
+	if err != nil {
+		return err
+	}
+
+	size, _ := GetFileSize(fileName)
+	fileType := filetype.FileType(fileName)
+	compress = c.shouldCompress(compress, sno, size, fileType)
+	budget := uploadScratchSize(size, isEncrypt)
+	if compress {
+		budget += size
+	}
+	ws, err := c.scratch.Acquire("upload@"+common.ProgressKey(serverPath(dest, fileName), sno), budget)
+	if err != nil {
+		return err
+	}
+	defer ws.Release()
+	ctx, cancel := c.taskContext()
+	defer cancel()
+
+	encoding, logicalSize := "", size
+	if compress {
+		// compressed copy is in sub folder, encrypted copy is made in workspace later
+		zstdDir := filepath.Join(ws.Dir, encodingZstd)
+		if err = os.MkdirAll(zstdDir, 0700); err != nil {
 			return err
 		}
-		if len(password) == 0 {
-			log.Info("Space password not set")
-			return fmt.Errorf("Password not set")
+		compressed := filepath.Join(zstdDir, filepath.Base(fileName))
+		stored, err := compressFile(fileName, compressed)
+		if err != nil {
+			log.Errorf("Compress error %v", err)
+			return err
 		}
-		if sno == 0 {
-			encryptKey, err = rsalong.EncryptLong(c.TrackerPubkey, password, 256)
-			if err != nil {
-				log.WithError(err).Info("Encrypt password")
-				return err
+		if stored < size {
+			log.Infof("Compressed %d bytes to %d", size, stored)
+			// mod time of uploaded file is of the original file
+			if info, err := os.Stat(fileName); err == nil {
+				os.Chtimes(compressed, info.ModTime(), info.ModTime())
 			}
+			fileName, encoding = compressed, encodingZstd
+		} else {
+			log.Infof("Compressed size %d is not less than %d, upload original file", stored, size)
+			deleteTemporaryFile(log, compressed)
 		}
 	}
 
-	fileType := filetype.FileType(fileName)
 	// privacy space need encryp file whole
 	if sno > 0 && isEncrypt {
 		if len(password) == 0 {
@@ -762,7 +918,7 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 		originFileName := fileName
 		// change fileName to encypted file avoid origin file modified
 		_, onlyFileName := filepath.Split(fileName)
-		fileName = filepath.Join(c.TempDir, onlyFileName)
+		fileName = filepath.Join(ws.Dir, onlyFileName)
 		err := aes.EncryptFile(originFileName, password, fileName)
 		if err != nil {
 			log.Errorf("Encrypt error %v", err)
@@ -771,15 +927,24 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 		log = log.WithField("encrypted file", fileName)
 	}
 
-	req, rsp, err := c.CheckFileExists(fileName, dest, interactive, newVersion, password, encryptKey, sno, fileType)
+	req, err := c.checkFileReq(log, fileName, dest, interactive, newVersion, password, encryptKey, sno, fileType)
+	if err != nil {
+		return err
+	}
+	if encoding != "" {
+		req.Encoding, req.DecodedSize = encoding, uint64(logicalSize)
+	}
+	progressKey := common.ProgressKey(serverPath(dest, fileName), sno)
+	c.PM.SetSizes(progressKey, uint64(logicalSize), req.FileSize)
+	req, rsp, err := c.sendCheckFile(log, req)
 	if err != nil {
 		return err
 	}
 
 	log.Infof("Check file exists resp code %d", rsp.GetCode())
 	if rsp.GetCode() == 0 {
-		sp := serverPath(dest, fileName)
-		c.PM.SetProgress(common.TaskUploadProgressType, common.ProgressKey(sp, sno), req.FileSize, req.FileSize, sno, fileName)
+		c.PM.SetProgress(common.TaskUploadProgressType, progressKey, req.FileSize, req.FileSize, sno, fileName)
+		c.cacheUploaded(req)
 		log.Infof("Upload %s success", fileName)
 		return nil
 	}
@@ -787,6 +952,49 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 	if rsp.GetCode() != 1 {
 		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
 	}
+	partitions, err := c.storeFile(ctx, log, ws, fileName, req, rsp, isEncrypt, password, sno)
+	if err != nil {
+		return err
+	}
+	return c.UploadFileDone(req, partitions, encryptKey)
+}
+
+// uploadKeys password to encrypt file and the password encrypted by tracker public key, password of default
+// space is random for every file and is only kept by tracker, both are nil if file is not encrypted
+func (c *ClientManager) uploadKeys(log logrus.FieldLogger, isEncrypt bool, sno uint32) (password, encryptKey []byte, err error) {
+	if !isEncrypt {
+		return nil, nil, nil
+	}
+	password, err = c.getSpacePassword(sno)
+	if err != nil {
+		log.WithError(err).Info("Get space password")
+		return nil, nil, err
+	}
+	if len(password) == 0 {
+		log.Info("Space password not set")
+		return nil, nil, fmt.Errorf("Password not set")
+	}
+	if sno == 0 {
+		encryptKey, err = rsalong.EncryptLong(c.TrackerPubkey, password, 256)
+		if err != nil {
+			log.WithError(err).Info("Encrypt password")
+			return nil, nil, err
+		}
+	}
+	return password, encryptKey, nil
+}
+
+// storeFile store file which tracker allows to upload to providers, partitions stored are returned
+// for finishing the upload
+func (c *ClientManager) storeFile(ctx context.Context, log logrus.FieldLogger, ws *Workspace, fileName string, req *mpb.CheckFileExistReq, rsp *mpb.CheckFileExistResp, isEncrypt bool, password []byte, sno uint32) ([]*mpb.StorePartition, error) {
+	dest := req.GetParent().GetPath()
+	if req.Durability != nil {
+		if rsp.GetDurabilityClamped() {
+			log.Warnf("Requested durability %s clamped to %s by tracker", durabilityString(req.Durability), durabilityString(effectiveDurability(rsp)))
+		} else {
+			log.Infof("Requested durability %s", durabilityString(req.Durability))
+		}
+	}
 
 	switch rsp.GetStoreType() {
 	case mpb.FileStoreType_MultiReplica:
@@ -796,21 +1004,21 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 		if sno == 0 && isEncrypt {
 			_, onlyFileName := filepath.Split(fileName)
 			// change fileName to encypted file avoid origin file modified
-			fileName = filepath.Join(c.TempDir, onlyFileName)
+			fileName = filepath.Join(ws.Dir, onlyFileName)
 			err := aes.EncryptFile(originFileName, password, fileName)
 			if err != nil {
 				log.Errorf("Encrypt error %v", err)
-				return err
+				return nil, err
 			}
 			defer func() {
 				deleteTemporaryFile(log, fileName)
 			}()
 		}
-		partitions, err := c.uploadFileByMultiReplica(originFileName, fileName, req, rsp, sno)
+		partitions, err := c.uploadFileByMultiReplica(ctx, originFileName, fileName, req, rsp, sno)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		return c.UploadFileDone(req, partitions, encryptKey)
+		return partitions, nil
 	case mpb.FileStoreType_ErasureCode:
 		log.Infof("Upload manner is erasure")
 		partFiles := []string{}
@@ -818,9 +1026,9 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 		fileSize := int64(req.GetFileSize())
 		if fileSize > PartitionMaxSize {
 			chunkSize, chunkNum := GetChunkSizeAndNum(fileSize, PartitionMaxSize)
-			partFiles, err = FileSplit(c.TempDir, fileName, fileSize, chunkSize, int64(chunkNum))
+			partFiles, err = FileSplit(ws.Dir, fileName, fileSize, chunkSize, int64(chunkNum))
 			if err != nil {
-				return err
+				return nil, err
 			}
 		} else {
 			partFiles = append(partFiles, fileName)
@@ -839,9 +1047,9 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 		sp := serverPath(dest, fileName)
 		uniqKey := common.ProgressKey(sp, sno)
 		for _, fname := range partFiles {
-			fileSlices, err := c.onlyFileSplit(fname, dataShards, verifyShards, isEncrypt, password, sno)
+			fileSlices, err := c.onlyFileSplit(ws.Dir, fname, dataShards, verifyShards, isEncrypt, password, sno)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			fileInfos = append(fileInfos, common.PartitionFile{
 				FileName:       fname,
@@ -875,22 +1083,21 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 
 		ufpr, err := c.createUploadPrepareRequest(req, len(partFiles), fileInfos)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		ctx := context.Background()
 		log.Info("Send prepare reques")
 		ufprsp, err := c.mclient.UploadFilePrepare(ctx, ufpr)
 		if err != nil {
 			log.Errorf("UploadFilePrepare error %v", err)
-			return err
+			return nil, err
 		}
 
 		rspPartitions := ufprsp.GetPartition()
 		log.Infof("Upload prepare response partitions count:%d", len(rspPartitions))
 
 		if len(rspPartitions) == 0 {
-			return fmt.Errorf("only 0 partitions, not correct")
+			return nil, fmt.Errorf("only 0 partitions, not correct")
 		}
 
 		for i, part := range rspPartitions {
@@ -902,19 +1109,19 @@ func (c *ClientManager) UploadFile(fileName, dest string, interactive, newVersio
 
 		partitions := []*mpb.StorePartition{}
 		for i, partInfo := range fileInfos {
-			partition, err := c.uploadFileBatchByErasure(ufpr, rspPartitions[i], partInfo, dataShards, rsp.GetChunkSize())
+			partition, err := c.uploadFileBatchByErasure(ctx, ufpr, rspPartitions[i], partInfo, dataShards, rsp.GetChunkSize())
 			if err != nil {
-				return err
+				return nil, err
 			}
 			log.Infof("Partition %d has %d store blocks", i, len(partition.GetBlock()))
 			partitions = append(partitions, partition)
 		}
 		log.Infof("There are %d store partitions", len(partitions))
 
-		return c.UploadFileDone(req, partitions, encryptKey)
+		return partitions, nil
 
 	}
-	return nil
+	return nil, fmt.Errorf("unknown store type %v", rsp.GetStoreType())
 }
 
 func (c *ClientManager) createUploadPrepareRequest(req *mpb.CheckFileExistReq, partFileCount int, fileInfos []common.PartitionFile) (*mpb.UploadFilePrepareReq, error) {
@@ -960,17 +1167,61 @@ func deleteTemporaryFile(log logrus.FieldLogger, fileName string) {
 // CheckFileExists check file exists or not in tracker
 func (c *ClientManager) CheckFileExists(fileName, dest string, interactive, newVersion bool, password, encryptKey []byte, sno uint32, fileType filetype.MIME) (*mpb.CheckFileExistReq, *mpb.CheckFileExistResp, error) {
 	log := c.Log.WithField("filename", fileName)
+	req, err := c.checkFileReq(log, fileName, dest, interactive, newVersion, password, encryptKey, sno, fileType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.sendCheckFile(log, req)
+}
+
+// sendCheckFile sign and send request checking file, tracker public key is got again if it is expired
+func (c *ClientManager) sendCheckFile(log logrus.FieldLogger, req *mpb.CheckFileExistReq) (*mpb.CheckFileExistReq, *mpb.CheckFileExistResp, error) {
+	ctx := context.Background()
+	err := req.SignReq(c.cfg.Node.PriKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Info("Check file exist request")
+	rsp, err := c.mclient.CheckFileExist(ctx, req)
+	if err != nil {
+		// tracker public key expired
+		st, ok := status.FromError(err)
+		if !ok {
+			return nil, nil, fmt.Errorf("get status error failed")
+		}
+		if st.Code() == 500 || st.Message() == "tracker public key expired" {
+			rsaPubkey, pubkeyHash, err := register.GetPublicKeyByConn(c.serverConn)
+			if err != nil {
+				return nil, nil, err
+			}
+			c.PubkeyHash = pubkeyHash
+			c.TrackerPubkey = rsaPubkey
+			req.Timestamp = common.Now()
+			req.PublicKeyHash = pubkeyHash
+			err = req.SignReq(c.cfg.Node.PriKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			log.Info("Check file exist request")
+			rsp, err := c.mclient.CheckFileExist(ctx, req)
+			return req, rsp, err
+		}
+	}
+	return req, rsp, err
+}
+
+// checkFileReq unsigned request checking file, data of tiny file is in it and its progress is finished
+func (c *ClientManager) checkFileReq(log logrus.FieldLogger, fileName, dest string, interactive, newVersion bool, password, encryptKey []byte, sno uint32, fileType filetype.MIME) (*mpb.CheckFileExistReq, error) {
 	// privacy space
 	hash, err := util_hash.Sha1File(fileName)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	fileSize, err := GetFileSize(fileName)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	_, fname := filepath.Split(fileName)
-	ctx := context.Background()
 	req := &mpb.CheckFileExistReq{
 		FileHash:      hash,
 		FileName:      fname,
@@ -984,23 +1235,24 @@ func (c *ClientManager) CheckFileExists(fileName, dest string, interactive, newV
 		Version:       common.Version,
 		FileSize:      uint64(fileSize),
 		Parent:        &mpb.FilePath{OneOfPath: &mpb.FilePath_Path{dest}, SpaceNo: sno},
+		Durability:    c.requestedDurability(dest, fileName, sno),
 	}
 	mtime, err := GetFileModTime(fileName)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	req.FileModTime = uint64(mtime)
 	if fileSize < ReplicaFileSize {
 		fileData, err := util_hash.GetFileData(fileName)
 		if err != nil {
 			log.Errorf("Read file data error %v", err)
-			return nil, nil, err
+			return nil, err
 		}
 		if sno == 0 && len(password) != 0 {
 			fileData, err = aes.Encrypt(fileData, password)
 			if err != nil {
 				log.Errorf("Encrypt file error %v", err)
-				return nil, nil, err
+				return nil, err
 			}
 		}
 		req.FileData = fileData
@@ -1008,37 +1260,7 @@ func (c *ClientManager) CheckFileExists(fileName, dest string, interactive, newV
 		c.PM.SetProgress(common.TaskUploadProgressType, common.ProgressKey(sp, sno), req.FileSize, req.FileSize, sno, fileName)
 		log.Infof("Origin filesize %d, encrypted size %d", req.FileSize, len(req.FileData))
 	}
-	err = req.SignReq(c.cfg.Node.PriKey)
-	if err != nil {
-		return nil, nil, err
-	}
-	log.Info("Check file exist request")
-	rsp, err := c.mclient.CheckFileExist(ctx, req)
-	if err != nil {
-		// tracker public key expired
-		st, ok := status.FromError(err)
-		if !ok {
-			return nil, nil, fmt.Errorf("get status error failed")
-		}
-		if st.Code() == 500 || st.Message() == "tracker public key expired" {
-			rsaPubkey, pubkeyHash, err := register.GetPublicKey(c.webcfg.TrackerServer)
-			if err != nil {
-				return nil, nil, err
-			}
-			c.PubkeyHash = pubkeyHash
-			c.TrackerPubkey = rsaPubkey
-			req.Timestamp = common.Now()
-			req.PublicKeyHash = pubkeyHash
-			err = req.SignReq(c.cfg.Node.PriKey)
-			if err != nil {
-				return nil, nil, err
-			}
-			log.Info("Check file exist request")
-			rsp, err := c.mclient.CheckFileExist(ctx, req)
-			return req, rsp, err
-		}
-	}
-	return req, rsp, err
+	return req, nil
 }
 
 // MkFolder create folder
@@ -1070,12 +1292,17 @@ func (c *ClientManager) MkFolder(filepath string, folders []string, interactive
 		return false, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
 	}
 	log.Info("Make folder success")
+	added := []*DownFile{}
+	for _, folder := range folders {
+		added = append(added, &DownFile{FileName: folder, Folder: true, ModTime: common.Now()})
+	}
+	c.cacheMutation(c.meta.addFiles(sno, filepath, added...))
 	return true, nil
 }
 
-func (c *ClientManager) onlyFileSplit(fileName string, dataNum, verifyNum int, isEncrypt bool, password []byte, sno uint32) ([]common.HashFile, error) {
+func (c *ClientManager) onlyFileSplit(dir, fileName string, dataNum, verifyNum int, isEncrypt bool, password []byte, sno uint32) ([]common.HashFile, error) {
 	log := c.Log.WithField("filesplit", fileName)
-	fileSlices, err := RsEncoder(c.Log, c.TempDir, fileName, dataNum, verifyNum)
+	fileSlices, err := RsEncoder(c.Log, dir, fileName, dataNum, verifyNum)
 	if err != nil {
 		log.Errorf("Reedsolomon encoder error %v", err)
 		return nil, err
@@ -1104,7 +1331,7 @@ func (c *ClientManager) onlyFileSplit(fileName string, dataNum, verifyNum int, i
 
 }
 
-func (c *ClientManager) uploadFileBatchByErasure(req *mpb.UploadFilePrepareReq, rspPartition *mpb.ErasureCodePartition, partFile common.PartitionFile, dataShards int, chunkSize uint32) (*mpb.StorePartition, error) {
+func (c *ClientManager) uploadFileBatchByErasure(ctx context.Context, req *mpb.UploadFilePrepareReq, rspPartition *mpb.ErasureCodePartition, partFile common.PartitionFile, dataShards int, chunkSize uint32) (*mpb.StorePartition, error) {
 	log := c.Log
 	partition := &mpb.StorePartition{}
 	partition.Block = []*mpb.StoreBlock{}
@@ -1136,7 +1363,7 @@ func (c *ClientManager) uploadFileBatchByErasure(req *mpb.UploadFilePrepareReq,
 			var block *mpb.StoreBlock
 			var err error
 			for {
-				block, err = c.uploadFileToErasureProvider(pro, tm, uploadParas, chunkSize)
+				block, err = c.uploadFileToErasureProvider(ctx, pro, tm, uploadParas, chunkSize)
 				if err != nil {
 					mutex.Lock()
 					newPro := ChooseBackupProvicer(pro.HashAuth[0].Hash, backupProMap)
@@ -1170,13 +1397,7 @@ func (c *ClientManager) uploadFileBatchByErasure(req *mpb.UploadFilePrepareReq,
 	return partition, nil
 }
 
-func (c *ClientManager) AddMetaKey(fileName string, chunkSize uint32) {
-	c.mutex.Lock()
-	c.MetaChan <- MetaKey{FileName: fileName, ChunkSize: chunkSize}
-	c.mutex.Unlock()
-}
-
-func (c *ClientManager) uploadFileToErasureProvider(pro *mpb.BlockProviderAuth, tm uint64, uploadPara *common.UploadParameter, chunkSize uint32) (*mpb.StoreBlock, error) {
+func (c *ClientManager) uploadFileToErasureProvider(ctx context.Context, pro *mpb.BlockProviderAuth, tm uint64, uploadPara *common.UploadParameter, chunkSize uint32) (*mpb.StoreBlock, error) {
 	server := fmt.Sprintf("%s:%d", pro.GetServer(), pro.GetPort())
 	log := c.Log.WithField("server", server).WithField("erasurefile", uploadPara.HF.FileName)
 	uploadPara.Provider = server
@@ -1194,24 +1415,22 @@ func (c *ClientManager) uploadFileToErasureProvider(pro *mpb.BlockProviderAuth,
 	}
 
 	t1 := time.Now()
-	c.AddMetaKey(uploadPara.HF.FileName, chunkSize)
+	// metadata job is canceled if the block is not stored
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	job, err := c.GenMetadata(jobCtx, uploadPara.OriginFileHash, uploadPara.HF.FileName, chunkSize)
+	if err != nil {
+		return nil, err
+	}
 
 	ha := pro.GetHashAuth()[0]
 	err = client.StorePiece(log, pclient, uploadPara, ha.GetAuth(), ha.GetTicket(), tm, c.PM)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
-	var paraStr string
-	var generator, pubKey, random []byte
-	var phi [][]byte
-	var waitCount int
-	paraStr, generator, pubKey, random, phi, err = c.mdm.Get(uploadPara.HF.FileName)
-	for err != nil && err == ErrNoMetaData && waitCount < 60*60 {
-		time.Sleep(1)
-		paraStr, generator, pubKey, random, phi, err = c.mdm.Get(uploadPara.HF.FileName)
-		waitCount++
-	}
+	md, err := waitMetadata(ctx, job)
 	t2 := time.Now()
 	log.Infof("upload %s time elapased %+v", uploadPara.HF.FileName, t2.Sub(t1).Seconds())
 
@@ -1226,11 +1445,11 @@ func (c *ClientManager) uploadFileToErasureProvider(pro *mpb.BlockProviderAuth,
 		Size:        uint64(uploadPara.HF.FileSize),
 		BlockSeq:    uint32(uploadPara.HF.SliceIndex),
 		ChunkSize:   chunkSize,
-		ParamStr:    paraStr,
-		Generator:   generator,
-		PubKey:      pubKey,
-		Random:      random,
-		Phi:         phi,
+		ParamStr:    md.ParamStr,
+		Generator:   md.Generator,
+		PubKey:      md.PubKey,
+		Random:      md.Random,
+		Phi:         md.Phi,
 	}
 	block.StoreNodeId = append(block.StoreNodeId, []byte(pro.GetNodeId()))
 	log.Infof("Upload to provider success")
@@ -1265,7 +1484,7 @@ func newActionLogFromUpload(fileName string) *tcppb.ActionLog {
 	}
 }
 
-func (c *ClientManager) uploadFileByMultiReplica(originFileName, fileName string, req *mpb.CheckFileExistReq, rsp *mpb.CheckFileExistResp, sno uint32) ([]*mpb.StorePartition, error) {
+func (c *ClientManager) uploadFileByMultiReplica(ctx context.Context, originFileName, fileName string, req *mpb.CheckFileExistReq, rsp *mpb.CheckFileExistResp, sno uint32) ([]*mpb.StorePartition, error) {
 	log := c.Log
 	hash, err := util_hash.Sha1File(fileName)
 	if err != nil {
@@ -1296,7 +1515,6 @@ func (c *ClientManager) uploadFileByMultiReplica(originFileName, fileName string
 		return nil, err
 	}
 
-	ctx := context.Background()
 	log = log.WithField("filename", req.GetFileName())
 	log.Infof("Send prepare request")
 	ufprsp, err := c.mclient.UploadFilePrepare(ctx, ufpr)
@@ -1320,18 +1538,11 @@ func (c *ClientManager) uploadFileByMultiReplica(originFileName, fileName string
 		return nil, fmt.Errorf("chunksize[%d] can not less than 0", rsp.GetChunkSize())
 	}
 
-	c.AddMetaKey(fileName, rsp.GetChunkSize())
-	var paraStr string
-	var generator, pubKey, random []byte
-	var phi [][]byte
-
-	var waitCount int
-	paraStr, generator, pubKey, random, phi, err = c.mdm.Get(fileName)
-	for err != nil && err == ErrNoMetaData && waitCount < 60*60 {
-		time.Sleep(1)
-		paraStr, generator, pubKey, random, phi, err = c.mdm.Get(fileName)
-		waitCount++
+	job, err := c.GenMetadata(ctx, req.FileHash, fileName, rsp.GetChunkSize())
+	if err != nil {
+		return nil, err
 	}
+	md, err := waitMetadata(ctx, job)
 
 	if err != nil {
 		return nil, err
@@ -1346,18 +1557,18 @@ func (c *ClientManager) uploadFileByMultiReplica(originFileName, fileName string
 		Size:        uint64(fileSlices[0].FileSize),
 		BlockSeq:    uint32(fileSlices[0].SliceIndex),
 		ChunkSize:   rsp.GetChunkSize(),
-		ParamStr:    paraStr,
-		Generator:   generator,
-		PubKey:      pubKey,
-		Random:      random,
-		Phi:         phi,
+		ParamStr:    md.ParamStr,
+		Generator:   md.Generator,
+		PubKey:      md.PubKey,
+		Random:      md.Random,
+		Phi:         md.Phi,
 	}
 
 	sp := serverPath(req.Parent.GetPath(), fileName)
 	uniqKey := common.ProgressKey(sp, sno)
 	c.PM.SetPartitionMap(fileName, uniqKey)
 
-	providers, backupPros, err := GetBestReplicaProvider(ufprsp.GetProvider(), MinReplicaNum)
+	providers, backupPros, err := GetBestReplicaProvider(ufprsp.GetProvider(), replicaNeed(rsp.GetReplicaCount()))
 	if err != nil {
 		return nil, err
 	}
@@ -1449,6 +1660,8 @@ func (c *ClientManager) UploadFileDone(reqCheck *mpb.CheckFileExistReq, partitio
 		Parent:        reqCheck.GetParent(),
 		Interactive:   reqCheck.GetInteractive(),
 		NewVersion:    reqCheck.GetNewVersion(),
+		Encoding:      reqCheck.GetEncoding(),
+		DecodedSize:   reqCheck.GetDecodedSize(),
 	}
 	err := req.SignReq(c.cfg.Node.PriKey)
 	if err != nil {
@@ -1465,7 +1678,7 @@ func (c *ClientManager) UploadFileDone(reqCheck *mpb.CheckFileExistReq, partitio
 			return err
 		}
 		if st.Code() == 500 || st.Message() == "tracker public key expired" {
-			rsaPubkey, pubkeyHash, err := register.GetPublicKey(c.webcfg.TrackerServer)
+			rsaPubkey, pubkeyHash, err := register.GetPublicKeyByConn(c.serverConn)
 			if err != nil {
 				return err
 			}
@@ -1488,10 +1701,32 @@ func (c *ClientManager) UploadFileDone(reqCheck *mpb.CheckFileExistReq, partitio
 	if ufdrsp.GetCode() != 0 {
 		return common.NewStatusErr(ufdrsp.Code, ufdrsp.ErrMsg)
 	}
+	c.cacheUploaded(reqCheck)
+	c.auditUploaded(reqCheck, partitions)
 
 	return nil
 }
 
+// cacheUploaded add uploaded file to metadata cache, its id is got when the folder is listed again
+func (c *ClientManager) cacheUploaded(req *mpb.CheckFileExistReq) {
+	parent := req.GetParent()
+	if parent.GetPath() == "" {
+		return
+	}
+	fileType, extension := c.FileTypeMap.GetTypeAndExtension(req.GetFileType())
+	f := &DownFile{
+		FileHash:    hex.EncodeToString(req.GetFileHash()),
+		FileSize:    req.GetFileSize(),
+		FileName:    req.GetFileName(),
+		FileType:    fileType,
+		Extension:   extension,
+		ModTime:     req.GetFileModTime(),
+		Encoding:    req.GetEncoding(),
+		DecodedSize: req.GetDecodedSize(),
+	}
+	c.cacheMutation(c.meta.addFiles(parent.GetSpaceNo(), parent.GetPath(), f))
+}
+
 // ListFiles list files on dir
 func (c *ClientManager) ListFiles(path string, pageSize, pageNum uint32, sortType string, ascOrder bool, sno uint32) (*FilePages, error) {
 	log := c.Log.WithField("list path", path)
@@ -1503,16 +1738,7 @@ func (c *ClientManager) ListFiles(path string, pageSize, pageNum uint32, sortTyp
 		Timestamp: common.Now(),
 		Version:   common.Version,
 	}
-	switch sortType {
-	case "name":
-		req.SortType = mpb.SortType_Name
-	case "size":
-		req.SortType = mpb.SortType_Size
-	case "modtime":
-		req.SortType = mpb.SortType_ModTime
-	default:
-		req.SortType = mpb.SortType_Name
-	}
+	req.SortType = toSortType(sortType)
 	req.AscOrder = ascOrder
 	req.Parent = &mpb.FilePath{OneOfPath: &mpb.FilePath_Path{path}, SpaceNo: sno}
 	err := req.SignReq(c.cfg.Node.PriKey)
@@ -1532,21 +1758,7 @@ func (c *ClientManager) ListFiles(path string, pageSize, pageNum uint32, sortTyp
 	}
 	fileLists := []*DownFile{}
 	for _, info := range rsp.GetFof() {
-		hash := hex.EncodeToString(info.GetFileHash())
-		id := hex.EncodeToString(info.GetId())
-		storedFileType := info.GetFileType()
-		fileType, extension := c.FileTypeMap.GetTypeAndExtension(storedFileType)
-		df := &DownFile{
-			ID:        id,
-			FileHash:  hash,
-			FileType:  fileType,
-			Extension: extension,
-			FileName:  info.GetName(),
-			Folder:    info.GetFolder(),
-			ModTime:   info.GetModTime(),
-			FileSize:  info.GetFileSize(),
-		}
-		fileLists = append(fileLists, df)
+		fileLists = append(fileLists, c.toDownFile(info))
 	}
 
 	return &FilePages{
@@ -1555,6 +1767,78 @@ func (c *ClientManager) ListFiles(path string, pageSize, pageNum uint32, sortTyp
 	}, nil
 }
 
+func toSortType(sortType string) mpb.SortType {
+	switch sortType {
+	case "size":
+		return mpb.SortType_Size
+	case "modtime":
+		return mpb.SortType_ModTime
+	default:
+		return mpb.SortType_Name
+	}
+}
+
+func (c *ClientManager) toDownFile(info *mpb.FileOrFolder) *DownFile {
+	fileType, extension := c.FileTypeMap.GetTypeAndExtension(info.GetFileType())
+	return &DownFile{
+		ID:          hex.EncodeToString(info.GetId()),
+		FileHash:    hex.EncodeToString(info.GetFileHash()),
+		FileType:    fileType,
+		Extension:   extension,
+		FileName:    info.GetName(),
+		Folder:      info.GetFolder(),
+		ModTime:     info.GetModTime(),
+		FileSize:    info.GetFileSize(),
+		Durability:  durabilityString(info.GetDurability()),
+		Packed:      info.GetPack() != nil,
+		Encoding:    info.GetEncoding(),
+		DecodedSize: info.GetDecodedSize(),
+	}
+}
+
+// ListAllFiles list all pages of the folder sorted by name
+func (c *ClientManager) ListAllFiles(path string, sno uint32) ([]*DownFile, error) {
+	pageSize := uint32(500)
+	files := make([]*DownFile, 0, pageSize)
+	for pageNum := uint32(1); ; pageNum++ {
+		page, err := c.ListFiles(path, pageSize, pageNum, "name", true, sno)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, page.Files...)
+		if uint32(len(page.Files)) < pageSize || uint32(len(files)) >= page.Total {
+			return files, nil
+		}
+	}
+}
+
+// StatFile find the file or folder of path by listing its parent through metadata cache,
+// ErrFileNotExist is returned if tracker rejected listing of the parent because it does not exist, other errors are returned as is
+func (c *ClientManager) StatFile(path string, sno uint32) (*DownFile, error) {
+	path = strings.TrimSuffix(path, "/")
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return nil, fmt.Errorf("path %s must absolute", path)
+	}
+	parent, name := path[:i], path[i+1:]
+	if parent == "" {
+		parent = "/"
+	}
+	cd, _, err := c.listDir(parent, sno)
+	if err != nil {
+		if isListRejected(err) && c.folderMissing(parent, sno) {
+			return nil, ErrFileNotExist
+		}
+		return nil, err
+	}
+	for _, f := range cd.Files {
+		if f.FileName == name {
+			return f, nil
+		}
+	}
+	return nil, ErrFileNotExist
+}
+
 // DownloadDir download dir
 func (c *ClientManager) DownloadDir(path, destDir string, sno uint32) error {
 	if !strings.HasPrefix(path, "/") {
@@ -1582,10 +1866,10 @@ func (c *ClientManager) startDownloadDir(path, destDir string, sno uint32) error
 	var mutex sync.Mutex
 	ccControl := NewCCController(common.CCDownloadGoNum)
 	for {
-		// list 1 page 100 items order by name
+		// list 1 page 100 items order by name, folder is listed from tracker only once
 		retry := 0
 	RETRY:
-		downFiles, err := c.ListFiles(path, 100, page, "name", true, sno)
+		downFiles, err := c.ListFilesCached(path, 100, page, "name", true, sno)
 		retry++
 		if err != nil {
 			code, _ := common.StatusErrFromError(err)
@@ -1671,28 +1955,8 @@ func (c *ClientManager) CheckFileExistsInLocal(downFileName string, filehash []b
 	return false
 }
 
-// DownloadFile download file
-func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fileSize uint64, sno uint32) error {
-	serverFile := downFileName
-	_, fileName := filepath.Split(downFileName)
-	downFileName = filepath.Join(destDir, fileName)
-	log := c.Log.WithField("download file", downFileName)
-	defer func() {
-		if r := recover(); r != nil {
-			log.Error("!!!!!get panic info, recover it %s", r)
-			debug.PrintStack()
-		}
-	}()
-	fileHash, err := hex.DecodeString(filehash)
-	if err != nil {
-		return err
-	}
-	exists := c.CheckFileExistsInLocal(downFileName, fileHash)
-	if exists {
-		log.Info("File exists in local")
-		c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), fileSize, fileSize, sno, downFileName)
-		return nil
-	}
+// retrieveStorage get storage of file and retrieve auth of its blocks from tracker
+func (c *ClientManager) retrieveStorage(fileHash []byte, fileSize uint64, sno uint32) (*mpb.RetrieveFileResp, error) {
 	req := &mpb.RetrieveFileReq{
 		SpaceNo:   sno,
 		NodeId:    c.NodeId,
@@ -1701,20 +1965,25 @@ func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fil
 		Timestamp: common.Now(),
 		Version:   common.Version,
 	}
-	err = req.SignReq(c.cfg.Node.PriKey)
+	err := req.SignReq(c.cfg.Node.PriKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), 0, req.FileSize, sno, downFileName)
-
-	ctx := context.Background()
-	log.Infof("Download request file hash %x, size %d", fileHash, fileSize)
-	rsp, err := c.mclient.RetrieveFile(ctx, req)
+	rsp, err := c.mclient.RetrieveFile(context.Background(), req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if rsp.GetCode() != 0 {
-		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	return rsp, nil
+}
+
+// retrieveFile get storage of file from tracker, and the key to decrypt it if encrypted
+func (c *ClientManager) retrieveFile(log logrus.FieldLogger, fileHash []byte, fileSize uint64, sno uint32) (*mpb.RetrieveFileResp, []byte, error) {
+	rsp, err := c.retrieveStorage(fileHash, fileSize, sno)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	password := []byte{}
@@ -1722,20 +1991,69 @@ func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fil
 		password, err = c.getSpacePassword(sno)
 		if err != nil {
 			log.WithError(err).Info("Get space password")
-			return err
+			return nil, nil, err
 		}
 		if len(password) == 0 {
 			log.Info("Space %d password not set", sno)
-			return fmt.Errorf("sno %d password not set", sno)
+			return nil, nil, fmt.Errorf("sno %d password not set", sno)
 		}
 	} else {
 		encryptKey := rsp.GetEncryptKey()
 		if len(encryptKey) > 0 {
 			password, err = rsalong.DecryptLong(c.cfg.Node.PriKey, encryptKey, 256)
 			if err != nil {
-				return err
+				return nil, nil, err
+			}
+		}
+	}
+	return rsp, password, nil
+}
+
+// DownloadFile download file, compressed file is decompressed after it is downloaded and decrypted
+func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fileSize uint64, sno uint32) (err error) {
+	serverFile := downFileName
+	_, fileName := filepath.Split(downFileName)
+	downFileName = filepath.Join(destDir, fileName)
+	log := c.Log.WithField("download file", downFileName)
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("!!!!!get panic info, recover it %s", r)
+			debug.PrintStack()
+		}
+	}()
+	fileHash, err := hex.DecodeString(filehash)
+	if err != nil {
+		return err
+	}
+	exists := c.CheckFileExistsInLocal(downFileName, fileHash)
+	if exists {
+		log.Info("File exists in local")
+		c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), fileSize, fileSize, sno, downFileName)
+		return nil
+	}
+	c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), 0, fileSize, sno, downFileName)
+	log.Infof("Download request file hash %x, size %d", fileHash, fileSize)
+	rsp, password, err := c.retrieveFile(log, fileHash, fileSize, sno)
+	if err != nil {
+		return err
+	}
+	if encoding := rsp.GetEncoding(); encoding != "" {
+		c.PM.SetSizes(common.ProgressKey(serverFile, sno), rsp.GetDecodedSize(), fileSize)
+		decodedFileName := downFileName
+		downFileName = downFileName + "." + encoding
+		log.Infof("File is encoded by %s, decode it after download", encoding)
+		defer func() {
+			if err == nil {
+				err = decodeFile(encoding, downFileName, decodedFileName)
 			}
+		}()
+	}
+	if pack := rsp.GetPack(); pack != nil {
+		if err := c.downloadPacked(log, pack, downFileName, fileHash, fileSize, sno); err != nil {
+			return err
 		}
+		c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), fileSize, fileSize, sno, downFileName)
+		return nil
 	}
 	// tiny file
 	if filedata := rsp.GetFileData(); filedata != nil {
@@ -1747,7 +2065,7 @@ func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fil
 			}
 		}
 		SaveFile(downFileName, filedata)
-		c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), req.FileSize, req.FileSize, sno, downFileName)
+		c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), fileSize, fileSize, sno, downFileName)
 		log.Info("Download tiny file")
 		return nil
 	}
@@ -1763,7 +2081,7 @@ func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fil
 			for _, block := range partitions[0].GetBlock() {
 				c.PM.SetPartitionMap(hex.EncodeToString(block.GetHash()), common.ProgressKey(serverFile, sno))
 			}
-			_, _, _, _, _, err := c.saveFileByPartition(downFileName, partitions[0], rsp.GetTimestamp(), req.FileHash, req.FileSize, true)
+			_, err := c.saveFileByPartition("", downFileName, partitions[0], rsp.GetTimestamp(), fileHash, fileSize, true)
 			if err != nil {
 				return err
 			}
@@ -1778,6 +2096,15 @@ func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fil
 
 	// erasure files handle by below codes
 
+	ws, err := c.scratch.Acquire("download@"+common.ProgressKey(serverFile, sno), downloadScratchSize(int64(fileSize)))
+	if err != nil {
+		return err
+	}
+	heals := []func(){}
+	defer func() {
+		c.healAfter(ws, heals)
+	}()
+
 	log.Info("This is erasure file")
 	// for progress stats
 	realSizeAfterRS := uint64(0)
@@ -1801,134 +2128,39 @@ func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fil
 	c.PM.SetProgress(common.TaskDownloadProgressType, common.ProgressKey(serverFile, sno), 0, realSizeAfterRS, sno, downFileName)
 
 	if len(partitions) == 1 {
-		partition := partitions[0]
-		datas, paritys, failedCount, middleFiles, allMiddleFiles, err := c.saveFileByPartition(downFileName, partition, rsp.GetTimestamp(), req.FileHash, req.FileSize, false)
-		if failedCount > paritys {
-			log.Error("File cannot be recoved!!!")
-			return err
-		}
-		log.Infof("DataShards %d, parityShards %d, failedCount %d, middlefile %d", datas, paritys, failedCount, len(middleFiles))
-		if err != nil {
-			log.Errorf("Save file by partition error %v, but file still can be recoverd", err)
-			for _, file := range allMiddleFiles {
-				deleted := true
-				for _, wellFile := range middleFiles {
-					if file == wellFile {
-						deleted = false
-					}
-				}
-				if deleted {
-					deleteTemporaryFile(log, file)
-				}
-			}
-		}
-		if len(middleFiles) < datas {
-			err := fmt.Errorf("need %d shards, but only download %d, so cannot reconstrct", datas, len(middleFiles))
-			log.Error(err)
-			return err
-		}
-
-		if sno == 0 && len(password) != 0 {
-			for _, file := range middleFiles {
-				log.Infof("middle file %s", file)
-				if err := aes.DecryptFile(file, password, file); err != nil {
-					return err
-				}
-			}
-		}
-
-		// delete middle files
-		defer func() {
-			for _, file := range allMiddleFiles {
-				deleteTemporaryFile(log, file)
-			}
-		}()
-
-		_, onlyFileName := filepath.Split(downFileName)
-		tempDownFileName := filepath.Join(c.TempDir, onlyFileName)
-		err = RsDecoder(log, tempDownFileName, "", int64(req.FileSize), datas, paritys)
+		tempDownFileName, heal, err := c.decodePartition(log, ws, downFileName, partitions[0], rsp.GetTimestamp(), fileHash, fileSize, int64(fileSize), sno, password)
+		heals = append(heals, heal)
 		if err != nil {
 			return err
 		}
-
 		defer func() {
 			// delete file in case rename failed
 			if util_file.Exists(tempDownFileName) {
 				deleteTemporaryFile(log, tempDownFileName)
 			}
 		}()
-
-		if sno > 0 && len(password) > 0 {
-			if err := aes.DecryptFile(tempDownFileName, password, tempDownFileName); err != nil {
-				return err
-			}
-		}
-
 		return RenameCrossOS(tempDownFileName, downFileName)
 	}
 	partFiles := []string{}
+	defer func() {
+		for _, file := range partFiles {
+			deleteTemporaryFile(log, file)
+		}
+	}()
 	for i, partition := range partitions {
 		partFileName := fmt.Sprintf("%s.%s.%d", downFileName, TEMP_NAMESPACE, i)
 		log := log.WithField("middle file", partFileName)
-		datas, paritys, failedCount, middleFiles, allMiddleFiles, err := c.saveFileByPartition(partFileName, partition, rsp.GetTimestamp(), req.FileHash, req.FileSize, false)
-		if failedCount > paritys {
-			log.Errorf("Middle file %s cannot be recoved!!!", partFileName)
-			return err
-		}
-		if err != nil {
-			log.WithError(err).Error("Save file by partition error, but file still can be recoverd")
-			for _, file := range allMiddleFiles {
-				deleted := true
-				for _, wellFile := range middleFiles {
-					if file == wellFile {
-						deleted = false
-					}
-				}
-				if deleted {
-					deleteTemporaryFile(log, file)
-				}
-			}
-		}
-		if sno == 0 && len(password) != 0 {
-			for _, file := range middleFiles {
-				log.Infof("middle file %s", file)
-				if err := aes.DecryptFile(file, password, file); err != nil {
-					log.WithError(err).Error("decrypt file failed")
-					return err
-				}
-			}
-		}
-		log.Infof("DataShards %d, parityShards %d, failedCount %d", datas, paritys, failedCount)
-		_, onlyFileName := filepath.Split(partFileName)
-		tempDownFileName := filepath.Join(c.TempDir, onlyFileName)
 		// file real size can be calcauted by filesize and partition number
-		partitionFileSize := ReverseCalcuatePartFileSize(int64(req.FileSize), len(partitions), i)
+		partitionFileSize := ReverseCalcuatePartFileSize(int64(fileSize), len(partitions), i)
 		log.Infof("Partition %d, size %d", i, partitionFileSize)
-		err = RsDecoder(log, tempDownFileName, "", int64(partitionFileSize), datas, paritys)
+		tempDownFileName, heal, err := c.decodePartition(log, ws, partFileName, partition, rsp.GetTimestamp(), fileHash, fileSize, partitionFileSize, sno, password)
+		heals = append(heals, heal)
 		if err != nil {
 			return err
 		}
-
-		if sno > 0 && len(password) > 0 {
-			if err := aes.DecryptFile(tempDownFileName, password, tempDownFileName); err != nil {
-				return err
-			}
-		}
-
 		partFiles = append(partFiles, tempDownFileName)
-		// delete middle files
-		for _, file := range allMiddleFiles {
-			deleteTemporaryFile(log, file)
-		}
-
 	}
 
-	defer func() {
-		for _, file := range partFiles {
-			deleteTemporaryFile(log, file)
-		}
-	}()
-
 	for _, f := range partFiles {
 		log.Infof("Part file %s for join", f)
 	}
@@ -1940,7 +2172,101 @@ func (c *ClientManager) DownloadFile(downFileName, destDir, filehash string, fil
 	return nil
 }
 
-func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.RetrievePartition, tm uint64, fileHash []byte, fileSize uint64, multiReplica bool) (int, int, int, []string, []string, error) {
+// decodePartition download blocks of erasure coded partition and decode them into a file in workspace ws,
+// which is named as the base name of partFileName. Bad blocks found are healed by the returned func,
+// it is called after the file is handed back, and shards reconstructed for healing are detached from ws
+// until then, so ws is released when the download finishes
+func (c *ClientManager) decodePartition(log logrus.FieldLogger, ws *Workspace, partFileName string, partition *mpb.RetrievePartition, tm uint64, fileHash []byte, fileSize uint64, partitionFileSize int64, sno uint32, password []byte) (string, func(), error) {
+	dir := ws.Dir
+	pd, err := c.saveFileByPartition(dir, partFileName, partition, tm, fileHash, fileSize, false)
+	datas, paritys, failedCount, middleFiles, allMiddleFiles := pd.dataShards, pd.parityShards, pd.failedCount, pd.middleFiles, pd.allMiddleFiles
+	_, onlyFileName := filepath.Split(partFileName)
+	tempDownFileName := filepath.Join(dir, onlyFileName)
+	deleteMiddleFiles := func() {
+		for _, file := range allMiddleFiles {
+			// shards detached for healing are not here
+			if util_file.Exists(file) {
+				deleteTemporaryFile(log, file)
+			}
+		}
+	}
+	// bad blocks are only reported if the partition is not decoded
+	var report func()
+	if len(pd.bad) > 0 {
+		report = func() {
+			c.healPartition(log, tempDownFileName, pd.bad, fileHash, fileSize, sno, password, false)
+		}
+	}
+	if failedCount > paritys {
+		log.Errorf("Middle file %s cannot be recoved!!!", partFileName)
+		deleteMiddleFiles()
+		return "", report, err
+	}
+	if err != nil {
+		log.WithError(err).Error("Save file by partition error, but file still can be recoverd")
+	}
+	// corrupt and partial block files are removed, so decoder reconstructs them instead of using them
+	for _, file := range allMiddleFiles {
+		retrieved := false
+		for _, wellFile := range middleFiles {
+			if file == wellFile {
+				retrieved = true
+			}
+		}
+		if !retrieved && util_file.Exists(file) {
+			deleteTemporaryFile(log, file)
+		}
+	}
+	log.Infof("DataShards %d, parityShards %d, failedCount %d, middlefile %d", datas, paritys, failedCount, len(middleFiles))
+	if len(middleFiles) < datas {
+		err := fmt.Errorf("need %d shards, but only download %d, so cannot reconstrct", datas, len(middleFiles))
+		log.Error(err)
+		deleteMiddleFiles()
+		return "", report, err
+	}
+	if sno == 0 && len(password) != 0 {
+		for _, file := range middleFiles {
+			log.Infof("middle file %s", file)
+			if err := aes.DecryptFile(file, password, file); err != nil {
+				log.WithError(err).Error("decrypt file failed")
+				deleteMiddleFiles()
+				return "", report, err
+			}
+		}
+	}
+
+	if err := RsDecoder(log, tempDownFileName, "", partitionFileSize, datas, paritys); err != nil {
+		deleteMiddleFiles()
+		return "", report, err
+	}
+	// lost shards are reconstructed by decoder
+	var heal func()
+	if len(pd.bad) > 0 {
+		heal = c.detachHeal(log, ws, tempDownFileName, pd.bad, fileHash, fileSize, sno, password)
+	}
+	deleteMiddleFiles()
+	if sno > 0 && len(password) > 0 {
+		if err := aes.DecryptFile(tempDownFileName, password, tempDownFileName); err != nil {
+			deleteTemporaryFile(log, tempDownFileName)
+			return "", heal, err
+		}
+	}
+	return tempDownFileName, heal, nil
+}
+
+// partitionDownload blocks of partition downloaded by saveFileByPartition
+type partitionDownload struct {
+	dataShards     int
+	parityShards   int
+	failedCount    int
+	middleFiles    []string        // files of blocks retrieved
+	allMiddleFiles []string        // files of all blocks
+	bad            []*mpb.BadBlock // blocks lost or corrupt, blocks given up due to quit are not included
+}
+
+// saveFileByPartition download blocks of partition, block of multi replica file is saved as fileName,
+// blocks of erasure coded partition are saved in dir and checked against their hash
+func (c *ClientManager) saveFileByPartition(dir, fileName string, partition *mpb.RetrievePartition, tm uint64, fileHash []byte, fileSize uint64, multiReplica bool) (*partitionDownload, error) {
 	log := c.Log.WithField("filename", fileName)
 	log.Infof("There is %d blocks", len(partition.GetBlock()))
 	dataShards := 0
@@ -1950,6 +2276,7 @@ func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.Retr
 	middleFiles := []string{}
 	allMiddleFiles := []string{}
 	errArray := []string{}
+	bad := []*mpb.BadBlock{}
 	var mutex sync.Mutex
 	ccControl := NewCCController(common.CCDownloadGoNum)
 	for _, block := range partition.GetBlock() {
@@ -1961,7 +2288,7 @@ func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.Retr
 		tempFileName := fileName
 		if !multiReplica {
 			_, onlyFileName := filepath.Split(fileName)
-			tempFileName = filepath.Join(c.TempDir, fmt.Sprintf("%s.%d", onlyFileName, block.GetBlockSeq()))
+			tempFileName = filepath.Join(dir, fmt.Sprintf("%s.%d", onlyFileName, block.GetBlockSeq()))
 		}
 		allMiddleFiles = append(allMiddleFiles, tempFileName)
 	}
@@ -1989,6 +2316,28 @@ func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.Retr
 	//al := newActionLogFromUpload(fileName)
 	//defer collectClient.Collect(al)
 
+	// failed block is bad unless it is given up due to quit
+	failed := func(block *mpb.RetrieveBlock, node *mpb.RetrieveNode, corrupt bool, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		failedCount++
+		errArray = append(errArray, err.Error())
+		select {
+		case <-currQuit:
+			return
+		case <-c.quit:
+			return
+		default:
+		}
+		bad = append(bad, &mpb.BadBlock{
+			Hash:        block.GetHash(),
+			BlockSeq:    block.GetBlockSeq(),
+			Checksum:    block.GetChecksum(),
+			Corrupt:     corrupt,
+			StoreNodeId: node.GetNodeId(),
+		})
+	}
+
 	for _, block := range partition.GetBlock() {
 		ccControl.Add()
 		go func(log logrus.FieldLogger, block *mpb.RetrieveBlock, fileName string) {
@@ -1997,10 +2346,7 @@ func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.Retr
 			conn, err := common.GrpcDial(server)
 			if err != nil {
 				log.Errorf("Rpc dial %s failed, error %v", server, err)
-				mutex.Lock()
-				failedCount++
-				errArray = append(errArray, err.Error())
-				mutex.Unlock()
+				failed(block, node, false, err)
 				ccControl.Done()
 				//client.SetActionLog(err, al)
 				return
@@ -2015,7 +2361,7 @@ func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.Retr
 			tempFileName := fileName
 			if !multiReplica {
 				_, onlyFileName := filepath.Split(fileName)
-				tempFileName = filepath.Join(c.TempDir, fmt.Sprintf("%s.%d", onlyFileName, block.GetBlockSeq()))
+				tempFileName = filepath.Join(dir, fmt.Sprintf("%s.%d", onlyFileName, block.GetBlockSeq()))
 			}
 			log = log.WithField("part file", tempFileName).WithField("provider", server)
 			log.Infof("Retrieve Hash %x", block.GetHash())
@@ -2025,20 +2371,30 @@ func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.Retr
 			if err != nil {
 				conn.Close()
 				log.Error("Retrieve failed")
-				mutex.Lock()
-				failedCount++
-				errArray = append(errArray, err.Error())
-				mutex.Unlock()
+				failed(block, node, false, err)
 				//client.SetActionLog(err, al)
 				return
 			}
+			conn.Close()
+			if !multiReplica {
+				hash, err := util_hash.Sha1File(tempFileName)
+				if err != nil {
+					log.WithError(err).Error("Hash retrieved block failed")
+					failed(block, node, false, err)
+					return
+				}
+				if !bytes.Equal(hash, block.GetHash()) {
+					err := fmt.Errorf("block %x retrieved from %s is corrupt", block.GetHash(), server)
+					log.Error(err)
+					failed(block, node, true, err)
+					return
+				}
+			}
 			log.Info("Retrieve success")
 			mutex.Lock()
 			middleFiles = append(middleFiles, tempFileName)
 			successCount++
 			mutex.Unlock()
-			conn.Close()
-
 		}(log, block, fileName)
 	}
 
@@ -2047,12 +2403,20 @@ func (c *ClientManager) saveFileByPartition(fileName string, partition *mpb.Retr
 		close(currQuit)
 	}
 
+	pd := &partitionDownload{
+		dataShards:     dataShards,
+		parityShards:   parityShards,
+		failedCount:    failedCount,
+		middleFiles:    middleFiles,
+		allMiddleFiles: allMiddleFiles,
+		bad:            bad,
+	}
 	if len(errArray) > 0 {
 		fmt.Printf("download goroutine failed %d\n", len(errArray))
 		errRtn := fmt.Errorf("%s", strings.Join(errArray, "\n"))
-		return dataShards, parityShards, failedCount, middleFiles, allMiddleFiles, errRtn
+		return pd, errRtn
 	}
-	return dataShards, parityShards, failedCount, middleFiles, allMiddleFiles, nil
+	return pd, nil
 }
 
 // RemoveFile remove file
@@ -2089,6 +2453,10 @@ func (c *ClientManager) RemoveFile(target string, recursive bool, isPath bool, s
 	if rsp.GetCode() != 0 {
 		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
 	}
+	if p := c.cachedPath(target, isPath, sno); p != "" {
+		c.cacheMutation(c.meta.removeFile(sno, p))
+		c.auditRemoved(sno, p)
+	}
 	return nil
 
 }
@@ -2127,10 +2495,28 @@ func (c *ClientManager) MoveFile(source, dest string, isPath bool, sno uint32) e
 	if rsp.GetCode() != 0 {
 		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
 	}
+	if p := c.cachedPath(source, isPath, sno); p != "" {
+		if !strings.HasPrefix(dest, "/") {
+			// dest is new name in the same folder
+			dest = path.Join(path.Dir(p), dest)
+		}
+		c.cacheMutation(c.meta.moveFile(sno, p, dest))
+		c.auditMoved(sno, p, dest)
+	}
 	return nil
 
 }
 
+// cachedPath return path of target which is path or file id, empty if id is not in metadata cache
+func (c *ClientManager) cachedPath(target string, isPath bool, sno uint32) string {
+	if isPath {
+		return target
+	}
+	p, err := c.meta.findID(sno, target)
+	c.cacheMutation(err)
+	return p
+}
+
 // GetSpaceSysFileData get space password data
 func (c *ClientManager) GetSpaceSysFileData(sno uint32) ([]byte, error) {
 	log := c.Log
@@ -2157,14 +2543,44 @@ func (c *ClientManager) GetProgress(files []string) (progress.ProgressReadable,
 	return c.PM.GetProgress(files)
 }
 
-// ExportConfig export config file
-func (c *ClientManager) ExportConfig(fileName string) error {
-	return config.SaveClientConfig(fileName, c.cfg)
+// sealedConfig config with space passwords set in this session sealed under passphrase
+func (c *ClientManager) sealedConfig() *config.ClientConfig {
+	cc := *c.cfg
+	cc.Space = make([]config.ReadableSpace, len(c.cfg.Space))
+	copy(cc.Space, c.cfg.Space)
+	for i, sp := range cc.Space {
+		if sp.SpaceNo < c.SpaceM.Count && c.SpaceM.AS[sp.SpaceNo].Password != "" {
+			cc.Space[i].Password = c.SpaceM.AS[sp.SpaceNo].Password
+		}
+	}
+	return &cc
+}
+
+// SealConfig config with space passwords encrypted under passphrase
+func (c *ClientManager) SealConfig(passphrase string) ([]byte, error) {
+	return config.SealConfig(c.sealedConfig(), passphrase)
+}
+
+// ExportConfig export config file encrypted under passphrase
+func (c *ClientManager) ExportConfig(fileName, passphrase string) error {
+	return config.ExportConfig(fileName, c.sealedConfig(), passphrase)
 }
 
-// ExportFile export config file
-func (c *ClientManager) ExportFile() string {
-	return c.cfg.SelfFileName
+// RestorePasswords verify and set space passwords of imported config of this node
+func (c *ClientManager) RestorePasswords(cc *config.ClientConfig) error {
+	if cc.NodeId != c.cfg.NodeId {
+		return fmt.Errorf("imported config is of node %s, not %s", cc.NodeId, c.cfg.NodeId)
+	}
+	for _, sp := range cc.Space {
+		if sp.Password == "" || sp.SpaceNo == 0 {
+			continue
+		}
+		if err := c.VerifyPassword(sp.SpaceNo, sp.Password); err != nil {
+			c.Log.Errorf("Restore password of space %d failed %v", sp.SpaceNo, err)
+			return err
+		}
+	}
+	return nil
 }
 
 // TaskStatus get task status
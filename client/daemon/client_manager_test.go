@@ -0,0 +1,53 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samoslab/nebula/util/por"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaJobs(t *testing.T) {
+	m := newMetaJobs(4)
+	ctx := context.Background()
+	a, err := m.submit(ctx, "origin", "block1", 64)
+	require.NoError(t, err)
+	// block of the same name gets its own job
+	b, err := m.submit(ctx, "origin", "block1", 64)
+	require.NoError(t, err)
+	require.NotEqual(t, a.ID, b.ID)
+	require.Equal(t, MetaJobProgress{Queued: 2}, m.progress())
+
+	require.True(t, m.start(<-m.queue))
+	require.Equal(t, MetaJobRunning, a.State())
+	require.Equal(t, MetaJobProgress{Queued: 1, Running: 1}, m.progress())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		m.finish(a, &por.Metadata{ParamStr: "a"}, nil)
+	}()
+	md, err := a.Wait(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "a", md.ParamStr)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = b.Wait(waitCtx)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.True(t, m.start(<-m.queue))
+	m.finish(b, nil, ErrNoMetaData)
+	_, err = b.Wait(ctx)
+	require.Equal(t, ErrNoMetaData, err)
+
+	// job canceled before it runs
+	taskCtx, taskCancel := context.WithCancel(ctx)
+	c, err := m.submit(taskCtx, "origin", "block2", 64)
+	require.NoError(t, err)
+	taskCancel()
+	require.False(t, m.start(<-m.queue))
+	_, err = c.Wait(ctx)
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, MetaJobDone, c.State())
+	require.Equal(t, MetaJobProgress{Finished: 1, Failed: 2}, m.progress())
+}
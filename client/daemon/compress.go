@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/samoslab/nebula/util/filetype"
+)
+
+// encodingZstd encoding of file compressed with zstd before encryption
+const encodingZstd = "zstd"
+
+// shouldCompress file is compressed if compression is asked for the upload or configured for the
+// space, tiny files and files of compressed formats are not
+func (c *ClientManager) shouldCompress(compress bool, sno uint32, size int64, fileType filetype.MIME) bool {
+	if !compress {
+		for _, s := range c.webcfg.CompressSpaces {
+			if s == sno {
+				compress = true
+				break
+			}
+		}
+	}
+	return compress && size >= ReplicaFileSize && fileType.Compressible()
+}
+
+// compressFile compress src into dest with zstd, size of dest is returned
+func compressFile(src, dest string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+	enc, err := zstd.NewWriter(out)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = enc.ReadFrom(in); err != nil {
+		enc.Close()
+		return 0, err
+	}
+	if err = enc.Close(); err != nil {
+		return 0, err
+	}
+	if err = out.Close(); err != nil {
+		return 0, err
+	}
+	return GetFileSize(dest)
+}
+
+// decodeFile decode src of encoding into dest, src is removed if it is decoded
+func decodeFile(encoding, src, dest string) error {
+	if encoding != encodingZstd {
+		return fmt.Errorf("unknown encoding %s of file", encoding)
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	dec, err := zstd.NewReader(in)
+	if err != nil {
+		return err
+	}
+	defer dec.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err = dec.WriteTo(out); err != nil {
+		out.Close()
+		os.Remove(dest)
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
@@ -0,0 +1,47 @@
+package daemon
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/util/filetype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldCompress(t *testing.T) {
+	c := &ClientManager{webcfg: config.Config{CompressSpaces: []uint32{1}}}
+	text := filetype.MIME{Value: "unknown"}
+	require.True(t, c.shouldCompress(true, 0, ReplicaFileSize, text))
+	require.False(t, c.shouldCompress(false, 0, ReplicaFileSize, text))
+	require.True(t, c.shouldCompress(false, 1, ReplicaFileSize, text))
+	require.False(t, c.shouldCompress(true, 0, ReplicaFileSize-1, text))
+	require.False(t, c.shouldCompress(true, 1, ReplicaFileSize, filetype.MIME{Type: "video", Value: "video/mp4"}))
+}
+
+func TestCompressFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compress")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "a.txt")
+	data := bytes.Repeat([]byte("nebula compress "), 10000)
+	require.NoError(t, ioutil.WriteFile(src, data, 0600))
+
+	encoded := filepath.Join(dir, "a.txt.zst")
+	size, err := compressFile(src, encoded)
+	require.NoError(t, err)
+	require.True(t, size < int64(len(data)))
+
+	decoded := filepath.Join(dir, "b.txt")
+	require.NoError(t, decodeFile(encodingZstd, encoded, decoded))
+	got, err := ioutil.ReadFile(decoded)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+	_, err = os.Stat(encoded)
+	require.True(t, os.IsNotExist(err))
+
+	require.Error(t, decodeFile("gzip", decoded, encoded))
+}
@@ -0,0 +1,221 @@
+package daemon
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/client/errcode"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	rsalong "github.com/samoslab/nebula/util/rsa"
+)
+
+// errNotPrimary error for device management asked on enrolled device
+var errNotPrimary = errors.New("only primary device can manage devices")
+
+// DeviceInfo enrolled device of account
+type DeviceInfo struct {
+	DeviceId    string `json:"device_id"`
+	Name        string `json:"name"`
+	Created     uint64 `json:"created"`
+	Revoked     bool   `json:"revoked"`
+	RevokedTime uint64 `json:"revoked_time,omitempty"`
+	LastSeen    uint64 `json:"last_seen,omitempty"`
+	Current     bool   `json:"current"`
+}
+
+// IsPrimary whether client holds primary key of account
+func (c *ClientManager) IsPrimary() bool {
+	return c.cfg.Device == nil
+}
+
+// wrapSpaceKeys space passwords set in this session encrypted to public key of device
+func (c *ClientManager) wrapSpaceKeys(d *mpb.Delegation) ([]*mpb.WrappedSpaceKey, error) {
+	pubKey, err := x509.ParsePKCS1PublicKey(d.DevicePubKey)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*mpb.WrappedSpaceKey{}
+	for _, sp := range c.SpaceM.AS {
+		if sp.SpaceNo == 0 || sp.Password == "" {
+			continue
+		}
+		wrapped, err := rsalong.EncryptLong(pubKey, []byte(sp.Password), 256)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, &mpb.WrappedSpaceKey{SpaceNo: sp.SpaceNo, Key: wrapped})
+	}
+	return keys, nil
+}
+
+// sendDelegation enroll device of delegation with space keys of this session
+func (c *ClientManager) sendDelegation(d *mpb.Delegation) error {
+	keys, err := c.wrapSpaceKeys(d)
+	if err != nil {
+		return err
+	}
+	req := &mpb.EnrollDeviceReq{
+		Version:    common.Version,
+		NodeId:     c.NodeId,
+		Timestamp:  common.Now(),
+		Delegation: d,
+		SpaceKey:   keys,
+	}
+	if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return common.NewStatus(errcode.RetSignFailed, err)
+	}
+	c.Log.Infof("Enroll device %x with %d space keys", d.DeviceId, len(keys))
+	rsp, err := c.mclient.EnrollDevice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	if rsp.GetCode() != 0 {
+		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	return nil
+}
+
+// EnrollDevice delegate account to key of device request, grant returned is accepted on the device
+func (c *ClientManager) EnrollDevice(req *config.DeviceRequest) (*config.DeviceGrant, error) {
+	if !c.IsPrimary() {
+		return nil, errNotPrimary
+	}
+	d, err := config.Delegate(c.cfg, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.sendDelegation(d); err != nil {
+		return nil, err
+	}
+	return config.NewDeviceGrant(c.cfg, d), nil
+}
+
+func (c *ClientManager) listDevices() ([]*mpb.Device, error) {
+	req := &mpb.ListDevicesReq{
+		Version:   common.Version,
+		NodeId:    c.NodeId,
+		Timestamp: common.Now(),
+	}
+	if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return nil, common.NewStatus(errcode.RetSignFailed, err)
+	}
+	rsp, err := c.mclient.ListDevices(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.GetCode() != 0 {
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	return rsp.GetDevice(), nil
+}
+
+// ListDevices devices enrolled to account, revoked ones included
+func (c *ClientManager) ListDevices() ([]DeviceInfo, error) {
+	devices, err := c.listDevices()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]DeviceInfo, 0, len(devices))
+	for _, d := range devices {
+		deviceId := hex.EncodeToString(d.GetDelegation().GetDeviceId())
+		infos = append(infos, DeviceInfo{
+			DeviceId:    deviceId,
+			Name:        d.GetDelegation().GetName(),
+			Created:     d.GetDelegation().GetCreated(),
+			Revoked:     d.GetRevoked(),
+			RevokedTime: d.GetRevokedTime(),
+			LastSeen:    d.GetLastSeen(),
+			Current:     c.cfg.Device != nil && c.cfg.Device.DeviceId == deviceId,
+		})
+	}
+	return infos, nil
+}
+
+// RevokeDevice revoke delegation of device, its requests are refused by tracker then
+func (c *ClientManager) RevokeDevice(deviceId string) error {
+	if !c.IsPrimary() {
+		return errNotPrimary
+	}
+	id, err := hex.DecodeString(deviceId)
+	if err != nil {
+		return fmt.Errorf("invalid device id %s", deviceId)
+	}
+	req := &mpb.RevokeDeviceReq{
+		Version:   common.Version,
+		NodeId:    c.NodeId,
+		Timestamp: common.Now(),
+		DeviceId:  id,
+	}
+	if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return common.NewStatus(errcode.RetSignFailed, err)
+	}
+	c.Log.Infof("Revoke device %s", deviceId)
+	rsp, err := c.mclient.RevokeDevice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	if rsp.GetCode() != 0 {
+		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	return nil
+}
+
+// shareSpaceKeys wrap space keys of this session to every device not revoked, after a space
+// password is set on primary device
+func (c *ClientManager) shareSpaceKeys() {
+	if !c.IsPrimary() {
+		return
+	}
+	devices, err := c.listDevices()
+	if err != nil {
+		c.Log.WithError(err).Warn("List devices for sharing space keys failed")
+		return
+	}
+	for _, d := range devices {
+		if d.GetRevoked() || d.GetDelegation() == nil {
+			continue
+		}
+		if err := c.sendDelegation(d.GetDelegation()); err != nil {
+			c.Log.WithError(err).Warnf("Share space keys to device %x failed", d.GetDelegation().GetDeviceId())
+		}
+	}
+}
+
+// loadSpaceKeys set space passwords wrapped to this device by primary device
+func (c *ClientManager) loadSpaceKeys() error {
+	if c.IsPrimary() {
+		return nil
+	}
+	req := &mpb.DeviceSpaceKeysReq{
+		Version:   common.Version,
+		NodeId:    c.NodeId,
+		Timestamp: common.Now(),
+	}
+	if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return common.NewStatus(errcode.RetSignFailed, err)
+	}
+	rsp, err := c.mclient.DeviceSpaceKeys(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	if rsp.GetCode() != 0 {
+		return common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	for _, k := range rsp.GetSpaceKey() {
+		password, err := rsalong.DecryptLong(c.cfg.Node.PriKey, k.GetKey(), 256)
+		if err != nil {
+			return err
+		}
+		if err = c.VerifyPassword(k.GetSpaceNo(), string(password)); err != nil {
+			c.Log.WithError(err).Warnf("Space %d key of device is not valid", k.GetSpaceNo())
+			continue
+		}
+		c.Log.Infof("Space %d password set by key of device", k.GetSpaceNo())
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	rsalong "github.com/samoslab/nebula/util/rsa"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapSpaceKeys(t *testing.T) {
+	deviceKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	require.NoError(t, err)
+	spaceM := NewSpaceManager()
+	spaceM.AddSpace(0, "default-password", "default")
+	spaceM.AddSpace(1, "", "private1")
+	c := &ClientManager{SpaceM: spaceM}
+	d := &mpb.Delegation{DevicePubKey: x509.MarshalPKCS1PublicKey(&deviceKey.PublicKey)}
+
+	// password of space 0 is not shared, privacy space without password has no key
+	keys, err := c.wrapSpaceKeys(d)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	password, err := passwordPadding("secret", 1)
+	require.NoError(t, err)
+	require.NoError(t, spaceM.SetSpacePasswd(1, password))
+	keys, err = c.wrapSpaceKeys(d)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, uint32(1), keys[0].SpaceNo)
+	unwrapped, err := rsalong.DecryptLong(deviceKey, keys[0].Key, 256)
+	require.NoError(t, err)
+	require.Equal(t, password, string(unwrapped))
+
+	_, err = c.wrapSpaceKeys(&mpb.Delegation{DevicePubKey: []byte("bad key")})
+	require.Error(t, err)
+}
@@ -0,0 +1,582 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/sirupsen/logrus"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/errcode"
+	"github.com/samoslab/nebula/util/dbutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// metadata cache bucket, key is space:path of folder
+	metaCacheBkt = []byte("client_meta_cache")
+
+	// MetaCacheTTL listing of folder older than it is listed again from tracker
+	MetaCacheTTL = 5 * time.Minute
+	// MetaRefreshInterval interval of refreshing old listings in background
+	MetaRefreshInterval = 10 * time.Minute
+	// metaRefreshBatch max folders listed in one refresh
+	metaRefreshBatch = 100
+)
+
+// cachedDir listing of folder kept in metadata cache
+type cachedDir struct {
+	Files     []*DownFile `json:"files"`
+	UpdatedAt uint64      `json:"updated_at"` // time listed from tracker
+	Dirty     bool        `json:"dirty"`      // changed locally after listed, list again when tracker is reachable
+}
+
+// CacheFilter filter of search in metadata cache, zero value matches all files
+type CacheFilter struct {
+	Name       string                 // part of file name, case insensitive
+	Match      func(name string) bool // nil matches all names
+	FileType   string                 // prefix of file type, such as image or image/png
+	MinSize    uint64
+	MaxSize    uint64 // 0 means no limit
+	MinModTime uint64
+	MaxModTime uint64 // 0 means no limit
+	Folder     bool   // include folders, they are matched by name only
+}
+
+func (flt CacheFilter) match(f *DownFile) bool {
+	if flt.Name != "" && !strings.Contains(strings.ToLower(f.FileName), strings.ToLower(flt.Name)) {
+		return false
+	}
+	if flt.Match != nil && !flt.Match(f.FileName) {
+		return false
+	}
+	if f.Folder {
+		return flt.Folder
+	}
+	if flt.FileType != "" && !strings.HasPrefix(f.FileType, flt.FileType) {
+		return false
+	}
+	if f.FileSize < flt.MinSize || (flt.MaxSize > 0 && f.FileSize > flt.MaxSize) {
+		return false
+	}
+	if f.ModTime < flt.MinModTime || (flt.MaxModTime > 0 && f.ModTime > flt.MaxModTime) {
+		return false
+	}
+	return true
+}
+
+// metaCache keep listings of folders so that browsing does not wait for tracker, and works offline
+type metaCache struct {
+	db  *bolt.DB
+	log logrus.FieldLogger
+}
+
+func newMetaCache(db *bolt.DB, log logrus.FieldLogger) (*metaCache, error) {
+	if db == nil {
+		return nil, errors.New("new metadata cache failed, db is nil")
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(metaCacheBkt); err != nil {
+			return dbutil.NewCreateBucketFailedErr(metaCacheBkt, err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &metaCache{
+		db:  db,
+		log: log.WithField("prefix", "meta.cache"),
+	}, nil
+}
+
+func cleanDir(dir string) string {
+	return path.Clean("/" + dir)
+}
+
+func metaKey(sno uint32, dir string) []byte {
+	return []byte(fmt.Sprintf("%d:%s", sno, cleanDir(dir)))
+}
+
+func parseMetaKey(k []byte) (uint32, string, error) {
+	var sno uint32
+	i := bytes.IndexByte(k, ':')
+	if i < 0 {
+		return 0, "", fmt.Errorf("invalid metadata cache key %s", k)
+	}
+	if _, err := fmt.Sscanf(string(k[:i]), "%d", &sno); err != nil {
+		return 0, "", err
+	}
+	return sno, string(k[i+1:]), nil
+}
+
+// treePrefix is the key prefix of folders under dir
+func treePrefix(sno uint32, dir string) []byte {
+	dir = cleanDir(dir)
+	if dir == "/" {
+		return metaKey(sno, dir)
+	}
+	return metaKey(sno, dir+"/")
+}
+
+func getCachedDir(tx *bolt.Tx, sno uint32, dir string) (*cachedDir, error) {
+	v := tx.Bucket(metaCacheBkt).Get(metaKey(sno, dir))
+	if v == nil {
+		return nil, nil
+	}
+	cd := &cachedDir{}
+	if err := json.Unmarshal(v, cd); err != nil {
+		return nil, err
+	}
+	return cd, nil
+}
+
+func putCachedDir(tx *bolt.Tx, sno uint32, dir string, cd *cachedDir) error {
+	v, err := json.Marshal(cd)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(metaCacheBkt).Put(metaKey(sno, dir), v)
+}
+
+// deleteTree delete listing of dir and folders under it
+func deleteTree(tx *bolt.Tx, sno uint32, dir string) error {
+	bkt := tx.Bucket(metaCacheBkt)
+	if err := bkt.Delete(metaKey(sno, dir)); err != nil {
+		return err
+	}
+	prefix := treePrefix(sno, dir)
+	keys := [][]byte{}
+	cur := bkt.Cursor()
+	for k, _ := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cur.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err := bkt.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// get return listing of dir, nil if not cached
+func (m *metaCache) get(sno uint32, dir string) (*cachedDir, error) {
+	var cd *cachedDir
+	err := m.db.View(func(tx *bolt.Tx) error {
+		var err error
+		cd, err = getCachedDir(tx, sno, dir)
+		return err
+	})
+	return cd, err
+}
+
+// put save listing of dir got from tracker, folders which are not listed any more are dropped
+func (m *metaCache) put(sno uint32, dir string, files []*DownFile) (*cachedDir, error) {
+	cd := &cachedDir{Files: files, UpdatedAt: common.Now()}
+	err := m.db.Update(func(tx *bolt.Tx) error {
+		old, err := getCachedDir(tx, sno, dir)
+		if err != nil {
+			return err
+		}
+		if old != nil {
+			folders := map[string]bool{}
+			for _, f := range files {
+				if f.Folder {
+					folders[f.FileName] = true
+				}
+			}
+			for _, f := range old.Files {
+				if f.Folder && !folders[f.FileName] {
+					if err := deleteTree(tx, sno, path.Join(dir, f.FileName)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return putCachedDir(tx, sno, dir, cd)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cd, nil
+}
+
+// update change the listing of dir by local mutation, nothing is done if dir is not cached
+func (m *metaCache) update(sno uint32, dir string, change func(files []*DownFile) []*DownFile) error {
+	return m.db.Update(func(tx *bolt.Tx) error {
+		cd, err := getCachedDir(tx, sno, dir)
+		if err != nil || cd == nil {
+			return err
+		}
+		cd.Files = change(cd.Files)
+		cd.Dirty = true
+		return putCachedDir(tx, sno, dir, cd)
+	})
+}
+
+// dropTree forget dir and folders under it
+func (m *metaCache) dropTree(sno uint32, dir string) error {
+	return m.db.Update(func(tx *bolt.Tx) error {
+		return deleteTree(tx, sno, dir)
+	})
+}
+
+// addFiles put files into listing of dir, file with same name is replaced
+func (m *metaCache) addFiles(sno uint32, dir string, added ...*DownFile) error {
+	return m.update(sno, dir, func(files []*DownFile) []*DownFile {
+		for _, a := range added {
+			files = append(withoutFile(files, a.FileName), a)
+		}
+		return files
+	})
+}
+
+// removeFile remove file or folder of path from listing of its parent
+func (m *metaCache) removeFile(sno uint32, p string) error {
+	p = cleanDir(p)
+	if err := m.dropTree(sno, p); err != nil {
+		return err
+	}
+	return m.update(sno, path.Dir(p), func(files []*DownFile) []*DownFile {
+		return withoutFile(files, path.Base(p))
+	})
+}
+
+// moveFile move file or folder of path src to dest, file is added to dest folder if it is cached
+func (m *metaCache) moveFile(sno uint32, src, dest string) error {
+	src, dest = cleanDir(src), cleanDir(dest)
+	var moved *DownFile
+	err := m.update(sno, path.Dir(src), func(files []*DownFile) []*DownFile {
+		for _, f := range files {
+			if f.FileName == path.Base(src) {
+				copied := *f
+				moved = &copied
+			}
+		}
+		return withoutFile(files, path.Base(src))
+	})
+	if err != nil {
+		return err
+	}
+	if err = m.dropTree(sno, src); err != nil {
+		return err
+	}
+	if moved == nil {
+		// nothing to add, dest folder is only marked to be listed again
+		return m.update(sno, path.Dir(dest), func(files []*DownFile) []*DownFile { return files })
+	}
+	moved.FileName = path.Base(dest)
+	return m.addFiles(sno, path.Dir(dest), moved)
+}
+
+// findID return path of file with id, empty if not cached
+func (m *metaCache) findID(sno uint32, id string) (string, error) {
+	found := ""
+	prefix := treePrefix(sno, "/")
+	err := m.db.View(func(tx *bolt.Tx) error {
+		cur := tx.Bucket(metaCacheBkt).Cursor()
+		for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+			cd := &cachedDir{}
+			if err := json.Unmarshal(v, cd); err != nil {
+				return err
+			}
+			for _, f := range cd.Files {
+				if f.ID == id {
+					_, dir, err := parseMetaKey(k)
+					found = path.Join(dir, f.FileName)
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	return found, err
+}
+
+type cachedDirKey struct {
+	sno uint32
+	dir string
+}
+
+// staleDirs return at most limit folders which are changed locally or listed before time
+func (m *metaCache) staleDirs(before uint64, limit int) ([]cachedDirKey, error) {
+	type stale struct {
+		cachedDirKey
+		updatedAt uint64
+	}
+	dirs := []stale{}
+	err := m.db.View(func(tx *bolt.Tx) error {
+		return dbutil.ForEach(tx, metaCacheBkt, func(k, v []byte) error {
+			cd := &cachedDir{}
+			if err := json.Unmarshal(v, cd); err != nil {
+				return err
+			}
+			if !cd.Dirty && cd.UpdatedAt >= before {
+				return nil
+			}
+			sno, dir, err := parseMetaKey(k)
+			if err != nil {
+				return err
+			}
+			updatedAt := cd.UpdatedAt
+			if cd.Dirty {
+				updatedAt = 0
+			}
+			dirs = append(dirs, stale{cachedDirKey{sno: sno, dir: dir}, updatedAt})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(dirs, func(i, j int) bool { return dirs[i].updatedAt < dirs[j].updatedAt })
+	keys := []cachedDirKey{}
+	for i := 0; i < len(dirs) && i < limit; i++ {
+		keys = append(keys, dirs[i].cachedDirKey)
+	}
+	return keys, nil
+}
+
+// search find files matching filter in cached dir, and folders under it if recursive
+func (m *metaCache) search(sno uint32, dir string, recursive bool, flt CacheFilter, limit int) ([]*SearchedFile, error) {
+	dir = cleanDir(dir)
+	result := []*SearchedFile{}
+	err := m.db.View(func(tx *bolt.Tx) error {
+		visit := func(dir string, v []byte) error {
+			cd := &cachedDir{}
+			if err := json.Unmarshal(v, cd); err != nil {
+				return err
+			}
+			for _, f := range cd.Files {
+				if len(result) >= limit && limit > 0 {
+					return nil
+				}
+				if flt.match(f) {
+					result = append(result, &SearchedFile{Path: path.Join(dir, f.FileName), DownFile: f})
+				}
+			}
+			return nil
+		}
+		bkt := tx.Bucket(metaCacheBkt)
+		if v := bkt.Get(metaKey(sno, dir)); v != nil {
+			if err := visit(dir, v); err != nil {
+				return err
+			}
+		}
+		if !recursive {
+			return nil
+		}
+		prefix := treePrefix(sno, dir)
+		cur := bkt.Cursor()
+		for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+			_, sub, err := parseMetaKey(k)
+			if err != nil {
+				return err
+			}
+			if sub == dir {
+				continue
+			}
+			if err := visit(sub, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+func withoutFile(files []*DownFile, name string) []*DownFile {
+	kept := files[:0]
+	for _, f := range files {
+		if f.FileName != name {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// sortFiles sort files like tracker by name, size or modtime
+func sortFiles(files []*DownFile, sortType string, ascOrder bool) {
+	less := func(a, b *DownFile) bool { return a.FileName < b.FileName }
+	switch sortType {
+	case "size":
+		less = func(a, b *DownFile) bool {
+			if a.FileSize != b.FileSize {
+				return a.FileSize < b.FileSize
+			}
+			return a.FileName < b.FileName
+		}
+	case "modtime":
+		less = func(a, b *DownFile) bool {
+			if a.ModTime != b.ModTime {
+				return a.ModTime < b.ModTime
+			}
+			return a.FileName < b.FileName
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		if ascOrder {
+			return less(files[i], files[j])
+		}
+		return less(files[j], files[i])
+	})
+}
+
+func isTrackerFailed(err error) bool {
+	return status.Code(err) == codes.Code(errcode.RetTrackerFailed)
+}
+
+// listRejected code of ListFilesResp if tracker fails the listing, it is also the answer if the folder does not exist
+const listRejected = 1
+
+// isListRejected return true if tracker answered the listing with failure code
+func isListRejected(err error) bool {
+	return status.Code(err) == codes.Code(listRejected)
+}
+
+// folderMissing return true if dir is not a folder in the listing of its parent, rejected listing of dir
+// is caused by missing folder only if it returns true
+func (c *ClientManager) folderMissing(dir string, sno uint32) bool {
+	if strings.TrimSuffix(dir, "/") == "" {
+		return false
+	}
+	f, err := c.StatFile(dir, sno)
+	if err != nil {
+		return err == ErrFileNotExist
+	}
+	return !f.Folder
+}
+
+func (c *ClientManager) metaCacheTTL() time.Duration {
+	if c.webcfg.MetaCacheTTL > 0 {
+		return c.webcfg.MetaCacheTTL
+	}
+	return MetaCacheTTL
+}
+
+// listDir return listing of folder from metadata cache, it is listed from tracker if it is old or
+// changed locally. Old listing is returned as stale if tracker is not reachable
+func (c *ClientManager) listDir(dir string, sno uint32) (*cachedDir, bool, error) {
+	log := c.Log.WithField("list dir", dir)
+	cd, err := c.meta.get(sno, dir)
+	if err != nil {
+		log.WithError(err).Error("Get metadata cache failed")
+		cd = nil
+	}
+	if cd != nil && !cd.Dirty && time.Since(time.Unix(int64(cd.UpdatedAt), 0)) < c.metaCacheTTL() {
+		return cd, false, nil
+	}
+	files, err := c.ListAllFiles(dir, sno)
+	if err != nil {
+		if isTrackerFailed(err) {
+			if cd != nil {
+				log.WithError(err).Info("Tracker unreachable, use cached listing")
+				return cd, true, nil
+			}
+		} else if isListRejected(err) && c.folderMissing(dir, sno) {
+			// folder is removed by others
+			if err := c.meta.dropTree(sno, dir); err != nil {
+				log.WithError(err).Error("Drop metadata cache failed")
+			}
+		}
+		return nil, false, err
+	}
+	fresh, err := c.meta.put(sno, dir, files)
+	if err != nil {
+		log.WithError(err).Error("Save metadata cache failed")
+		return &cachedDir{Files: files, UpdatedAt: common.Now()}, false, nil
+	}
+	return fresh, false, nil
+}
+
+// ListFilesCached list files on dir like ListFiles, the page is served from metadata cache
+func (c *ClientManager) ListFilesCached(dir string, pageSize, pageNum uint32, sortType string, ascOrder bool, sno uint32) (*FilePages, error) {
+	cd, stale, err := c.listDir(dir, sno)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*DownFile, len(cd.Files))
+	copy(files, cd.Files)
+	sortFiles(files, sortType, ascOrder)
+	start, end := pageRange(len(files), pageSize, pageNum)
+	return &FilePages{Total: uint32(len(files)), Files: files[start:end], Stale: stale, UpdatedAt: cd.UpdatedAt}, nil
+}
+
+// pageRange return range of 1-based page in n items, page size 0 means all
+func pageRange(n int, pageSize, pageNum uint32) (int, int) {
+	if pageSize == 0 {
+		return 0, n
+	}
+	if pageNum == 0 {
+		pageNum = 1
+	}
+	start := uint64(pageSize) * uint64(pageNum-1)
+	if start >= uint64(n) {
+		return n, n
+	}
+	end := start + uint64(pageSize)
+	if end > uint64(n) {
+		end = uint64(n)
+	}
+	return int(start), int(end)
+}
+
+// SearchCachedFiles find files under dir in metadata cache, tracker is not needed. Only folders
+// which have been listed are searched
+func (c *ClientManager) SearchCachedFiles(dir string, sno uint32, flt CacheFilter, limit int) ([]*SearchedFile, error) {
+	return c.meta.search(sno, dir, true, flt, limit)
+}
+
+// RefreshMetaCache list old and locally changed folders again in background until shutdown
+func (c *ClientManager) RefreshMetaCache() {
+	log := c.Log.WithField("prefix", "meta.refresh")
+	interval := c.webcfg.MetaRefresh
+	if interval <= 0 {
+		interval = MetaRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.quit:
+			log.Info("Shutdown metadata cache refresh goroutine")
+			return
+		case <-ticker.C:
+		}
+		before := uint64(time.Now().Add(-c.metaCacheTTL()).Unix())
+		dirs, err := c.meta.staleDirs(before, metaRefreshBatch)
+		if err != nil {
+			log.WithError(err).Error("Get stale folders failed")
+			continue
+		}
+		for _, d := range dirs {
+			files, err := c.ListAllFiles(d.dir, d.sno)
+			if err != nil {
+				if isTrackerFailed(err) {
+					log.WithError(err).Info("Tracker unreachable, refresh later")
+					break
+				}
+				log.WithError(err).Infof("List %s failed, drop it", d.dir)
+				c.meta.dropTree(d.sno, d.dir)
+				continue
+			}
+			if _, err := c.meta.put(d.sno, d.dir, files); err != nil {
+				log.WithError(err).Error("Save metadata cache failed")
+			}
+		}
+	}
+}
+
+// cacheMutation apply mutation to metadata cache, failure only make the cache old
+func (c *ClientManager) cacheMutation(err error) {
+	if err != nil {
+		c.Log.WithError(err).Error("Update metadata cache failed")
+	}
+}
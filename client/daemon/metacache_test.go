@@ -0,0 +1,201 @@
+package daemon
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/client/errcode"
+	"github.com/samoslab/nebula/provider/node"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+func newTestMetaCache(t *testing.T) (*metaCache, func()) {
+	dir, err := ioutil.TempDir("", "metacache")
+	require.NoError(t, err)
+	db, err := bolt.Open(filepath.Join(dir, ClientDBName), 0700, nil)
+	require.NoError(t, err)
+	m, err := newMetaCache(db, logrus.New())
+	require.NoError(t, err)
+	return m, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func fileNames(files []*DownFile) []string {
+	names := []string{}
+	for _, f := range files {
+		names = append(names, f.FileName)
+	}
+	return names
+}
+
+func TestMetaCache(t *testing.T) {
+	m, cleanup := newTestMetaCache(t)
+	defer cleanup()
+
+	cd, err := m.get(0, "/")
+	require.NoError(t, err)
+	require.Nil(t, cd)
+
+	_, err = m.put(0, "/", []*DownFile{
+		{ID: "1", FileName: "docs", Folder: true},
+		{ID: "2", FileName: "photo.jpg", FileSize: 2000, FileType: "image/jpeg"},
+	})
+	require.NoError(t, err)
+	_, err = m.put(0, "/docs", []*DownFile{
+		{ID: "3", FileName: "Report.pdf", FileSize: 500, FileType: "application/pdf"},
+		{ID: "4", FileName: "old", Folder: true},
+	})
+	require.NoError(t, err)
+	_, err = m.put(0, "/docs/old", []*DownFile{{ID: "5", FileName: "report-2017.txt", FileSize: 10, FileType: "text/plain"}})
+	require.NoError(t, err)
+	_, err = m.put(1, "/", []*DownFile{{ID: "6", FileName: "secret-report.txt", FileSize: 10}})
+	require.NoError(t, err)
+
+	found, err := m.search(0, "/", true, CacheFilter{Name: "report"}, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, "/docs/Report.pdf", found[0].Path)
+	require.Equal(t, "/docs/old/report-2017.txt", found[1].Path)
+
+	found, err = m.search(0, "/docs/old", true, CacheFilter{Name: "report"}, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	found, err = m.search(0, "/", true, CacheFilter{FileType: "image", MinSize: 1000}, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "/photo.jpg", found[0].Path)
+	found, err = m.search(0, "/", true, CacheFilter{MaxSize: 100, Folder: true}, 1)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	p, err := m.findID(0, "5")
+	require.NoError(t, err)
+	require.Equal(t, "/docs/old/report-2017.txt", p)
+
+	// listing without a folder drop cache of the folder
+	_, err = m.put(0, "/docs", []*DownFile{{ID: "3", FileName: "Report.pdf"}})
+	require.NoError(t, err)
+	cd, err = m.get(0, "/docs/old")
+	require.NoError(t, err)
+	require.Nil(t, cd)
+
+	// local mutations mark folder to be listed again
+	dirs, err := m.staleDirs(0, 10)
+	require.NoError(t, err)
+	require.Empty(t, dirs)
+	require.NoError(t, m.addFiles(0, "/docs", &DownFile{FileName: "new.txt"}))
+	require.NoError(t, m.moveFile(0, "/photo.jpg", "/docs/photo.jpg"))
+	cd, err = m.get(0, "/docs")
+	require.NoError(t, err)
+	require.True(t, cd.Dirty)
+	require.Equal(t, []string{"Report.pdf", "new.txt", "photo.jpg"}, fileNames(cd.Files))
+	cd, err = m.get(0, "/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"docs"}, fileNames(cd.Files))
+	dirs, err = m.staleDirs(0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []cachedDirKey{{sno: 0, dir: "/"}, {sno: 0, dir: "/docs"}}, dirs)
+
+	require.NoError(t, m.removeFile(0, "/docs"))
+	cd, err = m.get(0, "/docs")
+	require.NoError(t, err)
+	require.Nil(t, cd)
+	cd, err = m.get(0, "/")
+	require.NoError(t, err)
+	require.Empty(t, cd.Files)
+
+	// other space is not touched
+	found, err = m.search(1, "/", true, CacheFilter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+}
+
+func TestSortFiles(t *testing.T) {
+	files := []*DownFile{
+		{FileName: "b", FileSize: 1, ModTime: 3},
+		{FileName: "c", FileSize: 1, ModTime: 1},
+		{FileName: "a", FileSize: 2, ModTime: 2},
+	}
+	sortFiles(files, "name", true)
+	require.Equal(t, []string{"a", "b", "c"}, fileNames(files))
+	sortFiles(files, "size", false)
+	require.Equal(t, []string{"a", "c", "b"}, fileNames(files))
+	sortFiles(files, "modtime", true)
+	require.Equal(t, []string{"c", "a", "b"}, fileNames(files))
+}
+
+func TestFolderMissing(t *testing.T) {
+	m, cleanup := newTestMetaCache(t)
+	defer cleanup()
+	c := &ClientManager{meta: m, Log: logrus.New()}
+	_, err := m.put(0, "/", []*DownFile{
+		{FileName: "docs", Folder: true},
+		{FileName: "a.pdf"},
+	})
+	require.NoError(t, err)
+	_, err = m.put(0, "/docs", []*DownFile{{FileName: "sub", Folder: true}})
+	require.NoError(t, err)
+
+	require.False(t, c.folderMissing("/", 0))
+	require.False(t, c.folderMissing("/docs", 0))
+	require.False(t, c.folderMissing("/docs/sub/", 0))
+	require.True(t, c.folderMissing("/gone", 0))
+	require.True(t, c.folderMissing("/docs/gone", 0))
+	// file is not a folder
+	require.True(t, c.folderMissing("/a.pdf", 0))
+	require.True(t, isListRejected(common.NewStatusErr(listRejected, "path is not exists")))
+	require.False(t, isListRejected(common.NewStatus(errcode.RetTrackerFailed, errors.New("connection refused"))))
+}
+
+// fakeListClient tracker which rejects listing of folders not in its tree, like it does for missing ones
+type fakeListClient struct {
+	mpb.MatadataServiceClient
+	tree map[string][]*mpb.FileOrFolder
+}
+
+func (f *fakeListClient) ListFiles(ctx context.Context, in *mpb.ListFilesReq, opts ...grpc.CallOption) (*mpb.ListFilesResp, error) {
+	fof, ok := f.tree[in.GetParent().GetPath()]
+	if !ok {
+		return &mpb.ListFilesResp{Code: 1, ErrMsg: "path is not exists"}, nil
+	}
+	return &mpb.ListFilesResp{Fof: fof, TotalRecord: uint32(len(fof))}, nil
+}
+
+func TestStatFileMissingParent(t *testing.T) {
+	m, cleanup := newTestMetaCache(t)
+	defer cleanup()
+	c := &ClientManager{
+		meta: m,
+		Log:  logrus.New(),
+		cfg:  &config.ClientConfig{Node: node.NewNode(1)},
+		mclient: &fakeListClient{tree: map[string][]*mpb.FileOrFolder{
+			"/":     {{Name: "docs", Folder: true}, {Name: "busy", Folder: true}, {Name: "a.txt"}},
+			"/docs": {{Name: "b.txt"}},
+		}},
+	}
+	f, err := c.StatFile("/docs/b.txt", 0)
+	require.NoError(t, err)
+	require.Equal(t, "b.txt", f.FileName)
+	_, err = c.StatFile("/docs/c.txt", 0)
+	require.Equal(t, ErrFileNotExist, err)
+	_, err = c.StatFile("/gone/c.txt", 0)
+	require.Equal(t, ErrFileNotExist, err)
+	_, err = c.StatFile("/gone/sub/c.txt", 0)
+	require.Equal(t, ErrFileNotExist, err)
+
+	// listing of existing folder rejected for other reasons is not reported as missing
+	_, err = c.StatFile("/busy/b.txt", 0)
+	require.True(t, isListRejected(err))
+}
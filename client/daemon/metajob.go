@@ -0,0 +1,208 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samoslab/nebula/util/filecheck"
+	"github.com/samoslab/nebula/util/por"
+)
+
+// MetaJobState state of metadata generation job
+type MetaJobState int32
+
+const (
+	// MetaJobQueued job is waiting for generator
+	MetaJobQueued MetaJobState = iota
+	// MetaJobRunning metadata is being generated
+	MetaJobRunning
+	// MetaJobDone metadata is generated, failed or canceled
+	MetaJobDone
+)
+
+func (s MetaJobState) String() string {
+	switch s {
+	case MetaJobQueued:
+		return "queued"
+	case MetaJobRunning:
+		return "running"
+	case MetaJobDone:
+		return "done"
+	}
+	return "unknown"
+}
+
+// MetaJob generation of PoR metadata of a block file, its result is got by Wait
+type MetaJob struct {
+	ID        uint64
+	Origin    string // hash of file which the block belongs to
+	FileName  string
+	ChunkSize uint32
+	ctx       context.Context
+	state     int32
+	done      chan struct{}
+	md        *por.Metadata
+	err       error
+}
+
+// State current state of job
+func (j *MetaJob) State() MetaJobState {
+	return MetaJobState(atomic.LoadInt32(&j.state))
+}
+
+// Done closed when job is done
+func (j *MetaJob) Done() <-chan struct{} {
+	return j.done
+}
+
+// Wait wait until metadata is generated, it returns error of ctx if ctx is done first
+func (j *MetaJob) Wait(ctx context.Context) (*por.Metadata, error) {
+	select {
+	case <-j.done:
+		return j.md, j.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// MetaJobProgress numbers of metadata generation jobs
+type MetaJobProgress struct {
+	Queued   int    `json:"queued"`
+	Running  int    `json:"running"`
+	Finished uint64 `json:"finished"`
+	Failed   uint64 `json:"failed"`
+}
+
+// metaJobs queue of metadata generation jobs, every job has its own id and result, so jobs of blocks
+// with the same name never get the result of each other
+type metaJobs struct {
+	nextID   uint64
+	queue    chan *MetaJob
+	mutex    sync.Mutex
+	jobs     map[uint64]*MetaJob
+	finished uint64
+	failed   uint64
+}
+
+func newMetaJobs(size int) *metaJobs {
+	return &metaJobs{queue: make(chan *MetaJob, size), jobs: map[uint64]*MetaJob{}}
+}
+
+// submit queue job, ctx cancels the job if it is not running yet, and the result is dropped if it is running
+func (m *metaJobs) submit(ctx context.Context, origin string, fileName string, chunkSize uint32) (*MetaJob, error) {
+	j := &MetaJob{
+		ID:        atomic.AddUint64(&m.nextID, 1),
+		Origin:    origin,
+		FileName:  fileName,
+		ChunkSize: chunkSize,
+		ctx:       ctx,
+		done:      make(chan struct{}),
+	}
+	m.mutex.Lock()
+	m.jobs[j.ID] = j
+	m.mutex.Unlock()
+	select {
+	case m.queue <- j:
+		return j, nil
+	case <-ctx.Done():
+		m.finish(j, nil, ctx.Err())
+		return nil, ctx.Err()
+	}
+}
+
+// start mark job running, it returns false if job is canceled
+func (m *metaJobs) start(j *MetaJob) bool {
+	if err := j.ctx.Err(); err != nil {
+		m.finish(j, nil, err)
+		return false
+	}
+	atomic.StoreInt32(&j.state, int32(MetaJobRunning))
+	return true
+}
+
+func (m *metaJobs) finish(j *MetaJob, md *por.Metadata, err error) {
+	if err == nil {
+		err = j.ctx.Err()
+	}
+	m.mutex.Lock()
+	delete(m.jobs, j.ID)
+	if err != nil {
+		m.failed++
+	} else {
+		m.finished++
+	}
+	m.mutex.Unlock()
+	j.md, j.err = md, err
+	atomic.StoreInt32(&j.state, int32(MetaJobDone))
+	close(j.done)
+}
+
+func (m *metaJobs) progress() MetaJobProgress {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	p := MetaJobProgress{Finished: m.finished, Failed: m.failed}
+	for _, j := range m.jobs {
+		if j.State() == MetaJobRunning {
+			p.Running++
+		} else {
+			p.Queued++
+		}
+	}
+	return p
+}
+
+// runMetaJob gen metadata of job, by generator worker processes if available
+func (c *ClientManager) runMetaJob(j *MetaJob) {
+	if !c.metaJobs.start(j) {
+		return
+	}
+	t1 := time.Now()
+	c.Log.Infof("gen %s metadata job %d chunksize %d", j.FileName, j.ID, j.ChunkSize)
+	md := &por.Metadata{}
+	var err error
+	if c.metaGen != nil {
+		md.ParamStr, md.Generator, md.PubKey, md.Random, md.Phi, err = c.metaGen.Gen(j.Origin, j.FileName, j.ChunkSize)
+	} else {
+		c.metaMutex.Lock()
+		md.ParamStr, md.Generator, md.PubKey, md.Random, md.Phi, err = filecheck.GenMetadata(j.FileName, j.ChunkSize)
+		c.metaMutex.Unlock()
+	}
+	c.Log.Infof("gen %s metadata time elapased %+v", j.FileName, time.Since(t1).Seconds())
+	if err != nil {
+		md = nil
+	}
+	c.metaJobs.finish(j, md, err)
+}
+
+// GenMetadata queue metadata generation of block file, origin is hash of the file which the block belongs to
+func (c *ClientManager) GenMetadata(ctx context.Context, origin []byte, fileName string, chunkSize uint32) (*MetaJob, error) {
+	return c.metaJobs.submit(ctx, hex.EncodeToString(origin), fileName, chunkSize)
+}
+
+// MetaJobProgress progress of metadata generation
+func (c *ClientManager) MetaJobProgress() MetaJobProgress {
+	return c.metaJobs.progress()
+}
+
+// waitMetadata wait result of job at most MetaDataTimeout
+func waitMetadata(ctx context.Context, job *MetaJob) (*por.Metadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, MetaDataTimeout)
+	defer cancel()
+	return job.Wait(ctx)
+}
+
+// taskContext context of upload task, it is canceled when client shuts down
+func (c *ClientManager) taskContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-c.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
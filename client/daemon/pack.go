@@ -0,0 +1,327 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/errcode"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/samoslab/nebula/util/aes"
+	"github.com/samoslab/nebula/util/filetype"
+	util_hash "github.com/samoslab/nebula/util/hash"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// packMemberMax max size of file packed into archive, larger files are uploaded alone
+	packMemberMax = int64(512 * 1024)
+	// packArchiveSize max size of archive of packed files
+	packArchiveSize = int64(64 * 1024 * 1024)
+)
+
+// packMember file packed into archive at offset
+type packMember struct {
+	dirFile
+	hash     []byte
+	offset   int64
+	modTime  int64
+	fileType filetype.MIME
+}
+
+// packable file is packed into archive in packing mode, tiny files are sent with their data instead
+func packable(size int64) bool {
+	return !inlineFile(size) && size <= packMemberMax
+}
+
+// splitPacks group packable files in order into archives of at most maxSize bytes, other files and
+// files which would be alone in archive are returned as rest
+func splitPacks(files []dirFile, maxSize int64) (packs [][]dirFile, rest []dirFile) {
+	var pack []dirFile
+	size := int64(0)
+	closePack := func() {
+		if len(pack) > 1 {
+			packs = append(packs, pack)
+		} else {
+			rest = append(rest, pack...)
+		}
+		pack, size = nil, 0
+	}
+	for _, f := range files {
+		if !packable(f.size) {
+			rest = append(rest, f)
+			continue
+		}
+		if len(pack) > 0 && size+f.size > maxSize {
+			closePack()
+		}
+		pack = append(pack, f)
+		size += f.size
+	}
+	closePack()
+	return packs, rest
+}
+
+// writeArchive join files into archive, hash and offset of every member is recorded
+func writeArchive(archive string, files []dirFile) ([]packMember, error) {
+	out, err := os.Create(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+	members := make([]packMember, 0, len(files))
+	offset := int64(0)
+	for _, f := range files {
+		m := packMember{dirFile: f, offset: offset, fileType: filetype.FileType(f.local)}
+		if m.modTime, err = GetFileModTime(f.local); err != nil {
+			return nil, err
+		}
+		in, err := os.Open(f.local)
+		if err != nil {
+			return nil, err
+		}
+		hasher := sha1.New()
+		m.size, err = io.Copy(io.MultiWriter(out, hasher), in)
+		in.Close()
+		if err != nil {
+			return nil, err
+		}
+		m.hash = hasher.Sum(nil)
+		members = append(members, m)
+		offset += m.size
+	}
+	return members, out.Close()
+}
+
+// overlapChunks index of chunks holding bytes of range at offset
+func overlapChunks(chunks []Chunk, offset, size int64) []int {
+	indexes := []int{}
+	for i, chunk := range chunks {
+		if chunk.Offset < offset+size && offset < chunk.Offset+chunk.Size {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// appendRange append bytes of range at offset which are in chunk file to out
+func appendRange(out io.Writer, chunkFile string, chunk Chunk, offset, size int64) error {
+	start, end := offset, offset+size
+	if start < chunk.Offset {
+		start = chunk.Offset
+	}
+	if chunkEnd := chunk.Offset + chunk.Size; end > chunkEnd {
+		end = chunkEnd
+	}
+	in, err := os.Open(chunkFile)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if _, err = in.Seek(start-chunk.Offset, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.CopyN(out, in, end-start)
+	return err
+}
+
+// packFilesDone record archive stored with partitions, empty if it exists, and add its members to folders
+func (c *ClientManager) packFilesDone(log logrus.FieldLogger, reqCheck *mpb.CheckFileExistReq, partitions []*mpb.StorePartition, members []packMember, sno uint32) ([]*mpb.UploadFileDoneResp, error) {
+	req := &mpb.PackFilesDoneReq{
+		Version:     common.Version,
+		NodeId:      c.NodeId,
+		SpaceNo:     sno,
+		PackHash:    reqCheck.GetFileHash(),
+		PackSize:    reqCheck.GetFileSize(),
+		EncryptKey:  reqCheck.GetEncryptKey(),
+		Partition:   partitions,
+		Interactive: reqCheck.GetInteractive(),
+		NewVersion:  reqCheck.GetNewVersion(),
+	}
+	for _, m := range members {
+		req.Member = append(req.Member, &mpb.PackMember{
+			Parent:      &mpb.FilePath{OneOfPath: &mpb.FilePath_Path{Path: m.dest}, SpaceNo: sno},
+			FileName:    filepath.Base(m.local),
+			FileHash:    m.hash,
+			FileSize:    uint64(m.size),
+			FileType:    m.fileType.Value,
+			FileModTime: uint64(m.modTime),
+			Offset:      uint64(m.offset),
+		})
+	}
+	var rsp *mpb.PackFilesDoneResp
+	for retry := 0; ; retry++ {
+		req.Timestamp = common.Now()
+		req.PublicKeyHash = c.PubkeyHash
+		if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+			return nil, common.NewStatus(errcode.RetSignFailed, err)
+		}
+		log.Infof("Pack %d files done request", len(req.Member))
+		var err error
+		rsp, err = c.mclient.PackFilesDone(context.Background(), req)
+		if err == nil {
+			break
+		}
+		if batchUnsupported(err) {
+			return nil, errBatchUnsupported
+		}
+		if renewed, rerr := c.renewTrackerKey(err); rerr != nil || !renewed || retry > 0 {
+			return nil, err
+		}
+	}
+	if rsp.GetCode() != 0 {
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	if len(rsp.GetResult()) != len(members) {
+		return nil, fmt.Errorf("tracker added %d of %d packed files", len(rsp.GetResult()), len(members))
+	}
+	return rsp.GetResult(), nil
+}
+
+// uploadPack pack files into one archive and upload it as a file which is not in any folder, then add
+// the files to their folders as ranges of it, errors are in the same order as files
+func (c *ClientManager) uploadPack(files []dirFile, dest string, interactive, newVersion, isEncrypt bool, sno uint32) ([]error, error) {
+	log := c.Log.WithField("upload pack", files[0].local)
+	password, encryptKey, err := c.uploadKeys(log, isEncrypt, sno)
+	if err != nil {
+		return nil, err
+	}
+	size := int64(0)
+	for _, f := range files {
+		size += f.size
+	}
+	ws, err := c.scratch.Acquire("upload@pack:"+common.ProgressKey(files[0].local, sno), size+uploadScratchSize(size, isEncrypt))
+	if err != nil {
+		return nil, err
+	}
+	defer ws.Release()
+	ctx, cancel := c.taskContext()
+	defer cancel()
+
+	// encrypted copy of archive is made in workspace by storing it, so archive is in sub folder
+	packDir := filepath.Join(ws.Dir, "pack")
+	if err = os.MkdirAll(packDir, 0700); err != nil {
+		return nil, err
+	}
+	archive := filepath.Join(packDir, fmt.Sprintf("%x.pack", util_hash.Sha1([]byte(files[0].local))))
+	members, err := writeArchive(archive, files)
+	if err != nil {
+		return nil, err
+	}
+	defer deleteTemporaryFile(log, archive)
+	log.Infof("Packed %d files into archive %s", len(members), archive)
+	// privacy space need encrypt archive whole
+	if sno > 0 && isEncrypt {
+		if err = aes.EncryptFile(archive, password, archive); err != nil {
+			log.Errorf("Encrypt error %v", err)
+			return nil, err
+		}
+	}
+
+	req, err := c.checkFileReq(log, archive, dest, interactive, newVersion, password, encryptKey, sno, filetype.FileType(archive))
+	if err != nil {
+		return nil, err
+	}
+	req.Pack = true
+	req, rsp, err := c.sendCheckFile(log, req)
+	if err != nil {
+		return nil, err
+	}
+	var partitions []*mpb.StorePartition
+	switch rsp.GetCode() {
+	case 0:
+		log.Info("Archive exists")
+	case 1:
+		if partitions, err = c.storeFile(ctx, log, ws, archive, req, rsp, isEncrypt, password, sno); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	results, err := c.packFilesDone(log, req, partitions, members, sno)
+	if err != nil {
+		return nil, err
+	}
+	if len(partitions) > 0 {
+		c.auditUploaded(req, partitions)
+	}
+	errs := make([]error, len(files))
+	for i, m := range members {
+		if results[i].GetCode() != 0 {
+			errs[i] = common.NewStatusErr(results[i].Code, results[i].ErrMsg)
+			continue
+		}
+		sp := serverPath(m.dest, m.local)
+		c.PM.SetProgress(common.TaskUploadProgressType, common.ProgressKey(sp, sno), uint64(m.size), uint64(m.size), sno, m.local)
+		fileType, extension := c.FileTypeMap.GetTypeAndExtension(m.fileType.Value)
+		c.cacheMutation(c.meta.addFiles(sno, m.dest, &DownFile{
+			FileHash:  hex.EncodeToString(m.hash),
+			FileSize:  uint64(m.size),
+			FileName:  filepath.Base(m.local),
+			FileType:  fileType,
+			Extension: extension,
+			ModTime:   uint64(m.modTime),
+			Packed:    true,
+		}))
+	}
+	return errs, nil
+}
+
+// downloadPacked download file packed in archive by reading its range of the archive
+func (c *ClientManager) downloadPacked(log logrus.FieldLogger, pack *mpb.PackRange, dest string, fileHash []byte, fileSize uint64, sno uint32) error {
+	f, err := c.OpenRemoteFile(hex.EncodeToString(pack.GetPackHash()), pack.GetPackSize(), sno)
+	if err != nil {
+		return err
+	}
+	offset, size := int64(pack.GetOffset()), int64(fileSize)
+	indexes := overlapChunks(f.Chunks, offset, size)
+	log.Infof("File is packed at %d of archive %x, read %d chunks", offset, pack.GetPackHash(), len(indexes))
+	// chunks are downloaded one by one, they are decoded in workspaces nested in the workspace of the file
+	budget, scratch := int64(0), int64(0)
+	for _, i := range indexes {
+		budget += f.Chunks[i].Size
+		if size := f.chunkScratchSize(i); size > scratch {
+			scratch = size
+		}
+	}
+	ws, err := c.scratch.Acquire(fmt.Sprintf("download@pack:%x", fileHash), budget+scratch)
+	if err != nil {
+		return err
+	}
+	defer ws.Release()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	for _, i := range indexes {
+		chunkFile := filepath.Join(ws.Dir, strconv.Itoa(i))
+		if err := c.downloadChunkIn(ws, f, i, chunkFile); err != nil {
+			return err
+		}
+		err := appendRange(out, chunkFile, f.Chunks[i], offset, size)
+		deleteTemporaryFile(log, chunkFile)
+		if err != nil {
+			return err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	hash, err := util_hash.Sha1File(dest)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, fileHash) {
+		deleteTemporaryFile(log, dest)
+		return fmt.Errorf("hash of file read from archive is %x, not %x", hash, fileHash)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	util_hash "github.com/samoslab/nebula/util/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPacks(t *testing.T) {
+	files := []dirFile{
+		{local: "tiny", size: 100},
+		{local: "a", size: 100 * 1024},
+		{local: "b", size: 200 * 1024},
+		{local: "large", size: 1024 * 1024},
+		{local: "c", size: 300 * 1024},
+		{local: "d", size: 400 * 1024},
+	}
+	packs, rest := splitPacks(files, 600*1024)
+	names := func(files []dirFile) []string {
+		ns := []string{}
+		for _, f := range files {
+			ns = append(ns, f.local)
+		}
+		return ns
+	}
+	require.Len(t, packs, 1)
+	require.Equal(t, []string{"a", "b", "c"}, names(packs[0]))
+	// d would be alone in the last archive
+	require.Equal(t, []string{"tiny", "large", "d"}, names(rest))
+}
+
+func TestArchiveRange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pack")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	files := []dirFile{}
+	for i, content := range []string{"first file", "second", "the third file"} {
+		name := filepath.Join(dir, string('a'+rune(i)))
+		require.NoError(t, ioutil.WriteFile(name, []byte(content), 0600))
+		files = append(files, dirFile{local: name, size: int64(len(content))})
+	}
+	archive := filepath.Join(dir, "archive.pack")
+	members, err := writeArchive(archive, files)
+	require.NoError(t, err)
+	require.Len(t, members, 3)
+	require.Equal(t, int64(16), members[2].offset)
+	require.Equal(t, util_hash.Sha1([]byte("second")), members[1].hash)
+
+	// archive is read by chunks of 7 bytes
+	chunks := []Chunk{}
+	for offset := int64(0); offset < 30; offset += 7 {
+		size := int64(7)
+		if offset+size > 30 {
+			size = 30 - offset
+		}
+		chunks = append(chunks, Chunk{Offset: offset, Size: size})
+	}
+	data, err := ioutil.ReadFile(archive)
+	require.NoError(t, err)
+	m := members[1]
+	indexes := overlapChunks(chunks, m.offset, m.size)
+	require.Equal(t, []int{1, 2}, indexes)
+	out, err := os.Create(filepath.Join(dir, "out"))
+	require.NoError(t, err)
+	for _, i := range indexes {
+		chunkFile := filepath.Join(dir, "chunk")
+		chunk := chunks[i]
+		require.NoError(t, ioutil.WriteFile(chunkFile, data[chunk.Offset:chunk.Offset+chunk.Size], 0600))
+		require.NoError(t, appendRange(out, chunkFile, chunk, m.offset, m.size))
+	}
+	require.NoError(t, out.Close())
+	got, err := ioutil.ReadFile(out.Name())
+	require.NoError(t, err)
+	require.Equal(t, "second", string(got))
+}
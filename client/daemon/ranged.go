@@ -0,0 +1,315 @@
+package daemon
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/samoslab/nebula/client/common"
+	client "github.com/samoslab/nebula/client/provider_client"
+	pb "github.com/samoslab/nebula/provider/pb"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/samoslab/nebula/util/aes"
+	"github.com/sirupsen/logrus"
+)
+
+// Chunk is a range of file which is downloaded as a whole. Every data block of erasure coded
+// file in default space is a chunk, other files are downloaded whole
+type Chunk struct {
+	Offset int64 `json:"offset"`
+	Size   int64 `json:"size"`
+}
+
+const (
+	tinyFileSource  = -1 // file data is stored in tracker
+	wholeFileSource = -2 // all partitions are needed
+)
+
+// chunkSource tell where the chunk is stored
+type chunkSource struct {
+	partition     int   // index of partition, or tinyFileSource, wholeFileSource
+	shard         int   // data shard of the partition, -1 means the whole partition
+	offset        int64 // offset in the partition
+	partitionSize int64
+}
+
+// RemoteFile is the storage of a file got from tracker, it is used to download the file by chunk.
+// Auth of providers expires, so RemoteFile should not be kept long. Compressed file is one chunk
+// of its size before compression
+type RemoteFile struct {
+	Chunks   []Chunk
+	encoding string
+	fileHash []byte
+	fileSize uint64
+	sno      uint32
+	rsp      *mpb.RetrieveFileResp
+	password []byte
+	sources  []chunkSource
+}
+
+func isMultiReplica(partitions []*mpb.RetrievePartition) bool {
+	return len(partitions) == 1 && len(partitions[0].GetBlock()) == 1
+}
+
+// splitChunks split file into chunks by storage. RS encoder store data of partition in data blocks
+// in order, so data block is a chunk alone if it can be decrypted alone, which is true in default
+// space. File in privacy space is encrypted as a whole before erasure coding
+func splitChunks(fileSize int64, partitions []*mpb.RetrievePartition, sno uint32) ([]Chunk, []chunkSource) {
+	if len(partitions) == 0 {
+		return []Chunk{{Offset: 0, Size: fileSize}}, []chunkSource{{partition: tinyFileSource, shard: -1, partitionSize: fileSize}}
+	}
+	if isMultiReplica(partitions) {
+		return []Chunk{{Offset: 0, Size: fileSize}}, []chunkSource{{partition: 0, shard: -1, partitionSize: fileSize}}
+	}
+	if sno > 0 {
+		return []Chunk{{Offset: 0, Size: fileSize}}, []chunkSource{{partition: wholeFileSource, shard: -1, partitionSize: fileSize}}
+	}
+	chunks := []Chunk{}
+	sources := []chunkSource{}
+	offset := int64(0)
+	for i, partition := range partitions {
+		partitionSize := fileSize
+		if len(partitions) > 1 {
+			partitionSize = ReverseCalcuatePartFileSize(fileSize, len(partitions), i)
+		}
+		dataShards := 0
+		for _, block := range partition.GetBlock() {
+			if !block.GetChecksum() {
+				dataShards++
+			}
+		}
+		if dataShards == 0 {
+			chunks = append(chunks, Chunk{Offset: offset, Size: partitionSize})
+			sources = append(sources, chunkSource{partition: i, shard: -1, partitionSize: partitionSize})
+			offset += partitionSize
+			continue
+		}
+		perShard := (partitionSize + int64(dataShards) - 1) / int64(dataShards)
+		for j := 0; j < dataShards && int64(j)*perShard < partitionSize; j++ {
+			size := perShard
+			if rest := partitionSize - int64(j)*perShard; rest < size {
+				size = rest
+			}
+			chunks = append(chunks, Chunk{Offset: offset + int64(j)*perShard, Size: size})
+			sources = append(sources, chunkSource{partition: i, shard: j, offset: int64(j) * perShard, partitionSize: partitionSize})
+		}
+		offset += partitionSize
+	}
+	return chunks, sources
+}
+
+// OpenRemoteFile get storage of file for downloading it by chunk
+func (c *ClientManager) OpenRemoteFile(filehash string, fileSize uint64, sno uint32) (*RemoteFile, error) {
+	fileHash, err := hex.DecodeString(filehash)
+	if err != nil {
+		return nil, err
+	}
+	log := c.Log.WithField("remote file", filehash)
+	rsp, password, err := c.retrieveFile(log, fileHash, fileSize, sno)
+	if err != nil {
+		return nil, err
+	}
+	f := &RemoteFile{fileHash: fileHash, fileSize: fileSize, sno: sno, rsp: rsp, password: password}
+	partitions := rsp.GetPartition()
+	if rsp.GetFileData() != nil {
+		partitions = nil
+	}
+	f.Chunks, f.sources = splitChunks(int64(fileSize), partitions, sno)
+	if f.encoding = rsp.GetEncoding(); f.encoding != "" {
+		// data blocks are not decoded alone
+		if f.sources[0].partition != tinyFileSource && !isMultiReplica(partitions) {
+			f.sources = []chunkSource{{partition: wholeFileSource, shard: -1, partitionSize: int64(fileSize)}}
+		}
+		f.Chunks = []Chunk{{Offset: 0, Size: int64(rsp.GetDecodedSize())}}
+	}
+	return f, nil
+}
+
+// DownloadChunk download chunk of the remote file into dest
+func (c *ClientManager) DownloadChunk(f *RemoteFile, index int, dest string) error {
+	return c.downloadChunkIn(nil, f, index, dest)
+}
+
+// chunkScratchSize disk needed to download chunk besides the chunk itself
+func (f *RemoteFile) chunkScratchSize(index int) int64 {
+	src := f.sources[index]
+	switch {
+	case src.partition == tinyFileSource || isMultiReplica(f.rsp.GetPartition()):
+		return 0
+	case src.partition == wholeFileSource:
+		return downloadScratchSize(int64(f.fileSize))
+	}
+	return downloadScratchSize(src.partitionSize)
+}
+
+// downloadChunkIn download chunk of the remote file into dest, partitions are decoded in workspace
+// nested in held if the caller holds one
+func (c *ClientManager) downloadChunkIn(held *Workspace, f *RemoteFile, index int, dest string) error {
+	if index < 0 || index >= len(f.Chunks) {
+		return fmt.Errorf("chunk %d out of range", index)
+	}
+	if f.encoding == "" {
+		return c.downloadChunk(held, f, index, dest)
+	}
+	encoded := dest + "." + f.encoding
+	if err := c.downloadChunk(held, f, index, encoded); err != nil {
+		return err
+	}
+	return decodeFile(f.encoding, encoded, dest)
+}
+
+// downloadChunk download chunk as it is stored
+func (c *ClientManager) downloadChunk(held *Workspace, f *RemoteFile, index int, dest string) error {
+	log := c.Log.WithField("chunk", fmt.Sprintf("%x.%d", f.fileHash, index))
+	src := f.sources[index]
+	if src.partition == tinyFileSource {
+		filedata := f.rsp.GetFileData()
+		if len(f.password) != 0 {
+			var err error
+			if filedata, err = aes.Decrypt(filedata, f.password); err != nil {
+				return err
+			}
+		}
+		return SaveFile(dest, filedata)
+	}
+	partitions := f.rsp.GetPartition()
+	// chunk download is not a task, its blocks are not reported in progress
+	for _, partition := range partitions {
+		for _, block := range partition.GetBlock() {
+			c.PM.SetPartitionMapIfAbsent(hex.EncodeToString(block.GetHash()), "")
+		}
+	}
+	if src.partition == wholeFileSource {
+		ws, err := c.scratch.AcquireIn(held, fmt.Sprintf("chunk@%x.%d", f.fileHash, index), f.chunkScratchSize(index))
+		if err != nil {
+			return err
+		}
+		heals, err := c.downloadWhole(log, ws, f, dest)
+		c.healAfter(ws, heals)
+		return err
+	}
+	partition := partitions[src.partition]
+	if isMultiReplica(partitions) {
+		if _, err := c.saveFileByPartition("", dest, partition, f.rsp.GetTimestamp(), f.fileHash, f.fileSize, true); err != nil {
+			return err
+		}
+		if len(f.password) != 0 {
+			return aes.DecryptFile(dest, f.password, dest)
+		}
+		return nil
+	}
+	if src.shard >= 0 {
+		err := c.downloadShard(log, f, partition, src.shard, f.Chunks[index].Size, dest)
+		if err == nil {
+			return nil
+		}
+		log.WithError(err).Info("Download data block failed, decode the partition")
+	}
+	ws, err := c.scratch.AcquireIn(held, fmt.Sprintf("chunk@%x.%d", f.fileHash, index), f.chunkScratchSize(index))
+	if err != nil {
+		return err
+	}
+	tempFile, heal, err := c.decodePartition(log, ws, dest+"."+TEMP_NAMESPACE, partition, f.rsp.GetTimestamp(), f.fileHash, f.fileSize, src.partitionSize, f.sno, f.password)
+	defer c.healAfter(ws, []func(){heal})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := os.Stat(tempFile); err == nil {
+			deleteTemporaryFile(log, tempFile)
+		}
+	}()
+	if src.shard < 0 {
+		return RenameCrossOS(tempFile, dest)
+	}
+	return copyRange(tempFile, dest, src.offset, f.Chunks[index].Size)
+}
+
+// downloadWhole decode all partitions in workspace ws and decrypt the joined file, it returns heals of the partitions
+func (c *ClientManager) downloadWhole(log logrus.FieldLogger, ws *Workspace, f *RemoteFile, dest string) ([]func(), error) {
+	partitions := f.rsp.GetPartition()
+	partFiles := []string{}
+	heals := []func(){}
+	defer func() {
+		for _, file := range partFiles {
+			deleteTemporaryFile(log, file)
+		}
+	}()
+	for i, partition := range partitions {
+		partitionSize := int64(f.fileSize)
+		if len(partitions) > 1 {
+			partitionSize = ReverseCalcuatePartFileSize(int64(f.fileSize), len(partitions), i)
+		}
+		// decrypt after join, so password is not given
+		partFile, heal, err := c.decodePartition(log, ws, fmt.Sprintf("%s.%s.%d", dest, TEMP_NAMESPACE, i), partition, f.rsp.GetTimestamp(), f.fileHash, f.fileSize, partitionSize, f.sno, nil)
+		heals = append(heals, heal)
+		if err != nil {
+			return heals, err
+		}
+		partFiles = append(partFiles, partFile)
+	}
+	if err := FileJoin(dest, partFiles); err != nil {
+		return heals, err
+	}
+	if len(f.password) != 0 {
+		return heals, aes.DecryptFile(dest, f.password, dest)
+	}
+	return heals, nil
+}
+
+// downloadShard download the data block of partition, padding of the last block is truncated
+func (c *ClientManager) downloadShard(log logrus.FieldLogger, f *RemoteFile, partition *mpb.RetrievePartition, shard int, size int64, dest string) error {
+	var block *mpb.RetrieveBlock
+	for _, b := range partition.GetBlock() {
+		if !b.GetChecksum() && b.GetBlockSeq() == uint32(shard) {
+			block = b
+			break
+		}
+	}
+	if block == nil {
+		return fmt.Errorf("data block %d not found", shard)
+	}
+	node := BestRetrieveNode(block.GetStoreNode())
+	if node == nil {
+		return fmt.Errorf("no provider of data block %d", shard)
+	}
+	server := fmt.Sprintf("%s:%d", node.GetServer(), node.GetPort())
+	conn, err := common.GrpcDial(server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	pclient := pb.NewProviderServiceClient(conn)
+	err = client.Retrieve(log, pclient, dest, node.GetAuth(), node.GetTicket(), f.rsp.GetTimestamp(), f.fileHash, block.GetHash(), f.fileSize, block.GetSize(), c.PM, server)
+	if err != nil {
+		return err
+	}
+	if len(f.password) != 0 {
+		if err = aes.DecryptFile(dest, f.password, dest); err != nil {
+			return err
+		}
+	}
+	return os.Truncate(dest, size)
+}
+
+// copyRange copy size bytes at offset of src into new file dest
+func copyRange(src, dest string, offset, size int64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, io.NewSectionReader(in, offset, size))
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dest)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package daemon
+
+import (
+	"testing"
+
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func erasurePartition(dataShards, parityShards int) *mpb.RetrievePartition {
+	partition := &mpb.RetrievePartition{}
+	for i := 0; i < dataShards+parityShards; i++ {
+		partition.Block = append(partition.Block, &mpb.RetrieveBlock{BlockSeq: uint32(i), Checksum: i >= dataShards})
+	}
+	return partition
+}
+
+func TestSplitChunks(t *testing.T) {
+	chunks, sources := splitChunks(100, nil, 0)
+	require.Equal(t, []Chunk{{Offset: 0, Size: 100}}, chunks)
+	require.Equal(t, tinyFileSource, sources[0].partition)
+
+	replica := []*mpb.RetrievePartition{{Block: []*mpb.RetrieveBlock{{}}}}
+	chunks, sources = splitChunks(100, replica, 0)
+	require.Equal(t, []Chunk{{Offset: 0, Size: 100}}, chunks)
+	require.Equal(t, chunkSource{partition: 0, shard: -1, partitionSize: 100}, sources[0])
+
+	// 10 bytes in 4 data shards is 3, 3, 3, 1
+	chunks, sources = splitChunks(10, []*mpb.RetrievePartition{erasurePartition(4, 2)}, 0)
+	require.Equal(t, []Chunk{{0, 3}, {3, 3}, {6, 3}, {9, 1}}, chunks)
+	require.Equal(t, chunkSource{partition: 0, shard: 3, offset: 9, partitionSize: 10}, sources[3])
+
+	// 3 bytes in 4 data shards, the last shard is padding only
+	chunks, _ = splitChunks(3, []*mpb.RetrievePartition{erasurePartition(4, 2)}, 0)
+	require.Equal(t, []Chunk{{0, 1}, {1, 1}, {2, 1}}, chunks)
+
+	// partitions of 11 bytes are 5 and 6 bytes
+	chunks, sources = splitChunks(11, []*mpb.RetrievePartition{erasurePartition(2, 1), erasurePartition(2, 1)}, 0)
+	require.Equal(t, []Chunk{{0, 3}, {3, 2}, {5, 3}, {8, 3}}, chunks)
+	require.Equal(t, chunkSource{partition: 1, shard: 1, offset: 3, partitionSize: 6}, sources[3])
+
+	chunks, sources = splitChunks(11, []*mpb.RetrievePartition{erasurePartition(2, 1), erasurePartition(2, 1)}, 1)
+	require.Equal(t, []Chunk{{0, 11}}, chunks)
+	require.Equal(t, wholeFileSource, sources[0].partition)
+}
+
+func TestChunkScratchSize(t *testing.T) {
+	partitions := []*mpb.RetrievePartition{erasurePartition(2, 1), erasurePartition(2, 1)}
+	f := &RemoteFile{fileSize: 11, rsp: &mpb.RetrieveFileResp{Partition: partitions}}
+	f.Chunks, f.sources = splitChunks(11, partitions, 0)
+	require.Equal(t, downloadScratchSize(6), f.chunkScratchSize(3))
+	f.Chunks, f.sources = splitChunks(11, partitions, 1)
+	require.Equal(t, downloadScratchSize(11), f.chunkScratchSize(0))
+
+	tiny := &RemoteFile{fileSize: 100, rsp: &mpb.RetrieveFileResp{}}
+	tiny.Chunks, tiny.sources = splitChunks(100, nil, 0)
+	require.Zero(t, tiny.chunkScratchSize(0))
+}
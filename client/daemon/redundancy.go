@@ -0,0 +1,81 @@
+package daemon
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/samoslab/nebula/client/config"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+)
+
+// underPrefix file path is prefix itself or under folder prefix
+func underPrefix(filePath, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	prefix = path.Clean(prefix)
+	if prefix == "/" || filePath == prefix {
+		return true
+	}
+	return strings.HasPrefix(filePath, prefix+"/")
+}
+
+// matchRedundancy policy of the longest prefix containing file of space, nil if no policy matches
+func matchRedundancy(policies []config.RedundancyPolicy, sno uint32, filePath string) *config.RedundancyPolicy {
+	var best *config.RedundancyPolicy
+	for i, p := range policies {
+		if p.SpaceNo != sno || !underPrefix(filePath, p.Prefix) {
+			continue
+		}
+		if best == nil || len(path.Clean("/"+p.Prefix)) > len(path.Clean("/"+best.Prefix)) {
+			best = &policies[i]
+		}
+	}
+	return best
+}
+
+// toDurability durability requested by policy, nil if policy is nil
+func toDurability(p *config.RedundancyPolicy) *mpb.Durability {
+	if p == nil {
+		return nil
+	}
+	if p.Replicas > 0 {
+		return &mpb.Durability{StoreType: mpb.FileStoreType_MultiReplica, ReplicaCount: p.Replicas}
+	}
+	return &mpb.Durability{StoreType: mpb.FileStoreType_ErasureCode, DataPieceCount: p.DataShards, VerifyPieceCount: p.ParityShards}
+}
+
+// effectiveDurability durability which tracker decides for uploading file
+func effectiveDurability(rsp *mpb.CheckFileExistResp) *mpb.Durability {
+	return &mpb.Durability{
+		StoreType:        rsp.GetStoreType(),
+		DataPieceCount:   rsp.GetDataPieceCount(),
+		VerifyPieceCount: rsp.GetVerifyPieceCount(),
+		ReplicaCount:     rsp.GetReplicaCount(),
+	}
+}
+
+// durabilityString readable durability, RS(data,parity) or number of replicas, empty if d is nil
+func durabilityString(d *mpb.Durability) string {
+	if d == nil {
+		return ""
+	}
+	if d.GetStoreType() == mpb.FileStoreType_MultiReplica {
+		return fmt.Sprintf("%d replicas", d.GetReplicaCount())
+	}
+	return fmt.Sprintf("RS(%d,%d)", d.GetDataPieceCount(), d.GetVerifyPieceCount())
+}
+
+// requestedDurability durability of policy matching file uploaded to dest of space
+func (c *ClientManager) requestedDurability(dest, fileName string, sno uint32) *mpb.Durability {
+	return toDurability(matchRedundancy(c.webcfg.Redundancy, sno, serverPath(dest, fileName)))
+}
+
+// replicaNeed number of replica providers to upload, tracker default if not given
+func replicaNeed(replicaCount uint32) int {
+	if replicaCount == 0 {
+		return MinReplicaNum
+	}
+	return int(replicaCount)
+}
@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/samoslab/nebula/client/config"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRedundancy(t *testing.T) {
+	policies := []config.RedundancyPolicy{
+		{SpaceNo: 0, Replicas: 4},
+		{SpaceNo: 0, Prefix: "/archive", DataShards: 10, ParityShards: 6},
+		{SpaceNo: 0, Prefix: "/archive/hot/", Replicas: 3},
+		{SpaceNo: 1, Prefix: "/", DataShards: 4, ParityShards: 2},
+	}
+	for _, tc := range []struct {
+		sno  uint32
+		path string
+		want string
+	}{
+		{0, "/a.txt", "4 replicas"},
+		{0, "/archive/a.txt", "RS(10,6)"},
+		{0, "/archived/a.txt", "4 replicas"},
+		{0, "/archive/hot/a.txt", "3 replicas"},
+		{0, "/archive/hotter/a.txt", "RS(10,6)"},
+		{1, "/archive/a.txt", "RS(4,2)"},
+		{2, "/a.txt", ""},
+	} {
+		require.Equal(t, tc.want, durabilityString(toDurability(matchRedundancy(policies, tc.sno, tc.path))), tc.path)
+	}
+	require.Nil(t, matchRedundancy(nil, 0, "/a.txt"))
+
+	rsp := &mpb.CheckFileExistResp{StoreType: mpb.FileStoreType_ErasureCode, DataPieceCount: 8, VerifyPieceCount: 4}
+	require.Equal(t, "RS(8,4)", durabilityString(effectiveDurability(rsp)))
+	require.Equal(t, MinReplicaNum, replicaNeed(0))
+	require.Equal(t, 5, replicaNeed(5))
+}
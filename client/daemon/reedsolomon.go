@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,6 +13,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// ErrShardsCorrupted shards do not verify after reconstruction, some of them are corrupted
+var ErrShardsCorrupted = errors.New("shards are corrupted after reconstruction")
+
 // RsEncoder reedsolomon stream encoder file
 func RsEncoder(log logrus.FieldLogger, outDir, fName string, dataShards, parShards int) ([]common.HashFile, error) {
 	enc, err := reedsolomon.NewStream(dataShards, parShards)
@@ -155,13 +159,17 @@ func RsDecoder(log logrus.FieldLogger, fName, outFname string, filesize int64, d
 			}
 		}
 		shards, _, err = openInput(log, dataShards, parShards, fName)
-		ok, err = enc.Verify(shards)
-		if !ok {
-			log.Infof("Verification failed after reconstruction, data likely corrupted", err)
+		if err != nil {
+			return err
 		}
+		ok, err = enc.Verify(shards)
 		if err != nil {
 			return err
 		}
+		if !ok {
+			log.Error("Verification failed after reconstruction, data likely corrupted")
+			return ErrShardsCorrupted
+		}
 	}
 
 	// Join the shards and write them
@@ -103,3 +103,35 @@ func TestOddFileSize(t *testing.T) {
 	}
 
 }
+
+func TestDecodeCorruptedShard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	log, err := logger.NewLogger("", false)
+	require.NoError(t, err)
+	fname := filepath.Join(dir, "file")
+	data := []byte("data of the file which is coded into five shards")
+	require.NoError(t, ioutil.WriteFile(fname, data, 0600))
+	_, err = RsEncoder(log, "", fname, 3, 2)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(fname))
+
+	corrupted := fname + ".1"
+	shard, err := ioutil.ReadFile(corrupted)
+	require.NoError(t, err)
+	shard[0] ^= 1
+	require.NoError(t, ioutil.WriteFile(corrupted, shard, 0600))
+	require.Equal(t, ErrShardsCorrupted, RsDecoder(log, fname, "", int64(len(data)), 3, 2))
+
+	// shard removed is reconstructed
+	require.NoError(t, os.Remove(corrupted))
+	require.NoError(t, RsDecoder(log, fname, "", int64(len(data)), 3, 2))
+	decoded, err := ioutil.ReadFile(fname)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+	shard[0] ^= 1
+	reconstructed, err := ioutil.ReadFile(corrupted)
+	require.NoError(t, err)
+	require.Equal(t, shard, reconstructed)
+}
@@ -0,0 +1,220 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/samoslab/nebula/client/common"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/samoslab/nebula/util/aes"
+	util_hash "github.com/samoslab/nebula/util/hash"
+	"github.com/sirupsen/logrus"
+)
+
+// repairedPieces pieces to store for bad blocks, shards reconstructed by RsDecoder are named as base with
+// block sequence, they are encrypted again into new files if blocks are encrypted one by one
+func repairedPieces(base string, bad []*mpb.BadBlock, sno uint32, password []byte) ([]common.HashFile, error) {
+	pieces := make([]common.HashFile, 0, len(bad))
+	for _, b := range bad {
+		fileName := fmt.Sprintf("%s.%d", base, b.GetBlockSeq())
+		if sno == 0 && len(password) != 0 {
+			encrypted := fileName + ".repair"
+			if err := aes.EncryptFile(fileName, password, encrypted); err != nil {
+				return pieces, err
+			}
+			fileName = encrypted
+		}
+		hf := common.HashFile{FileName: fileName, SliceIndex: int(b.GetBlockSeq())}
+		pieces = append(pieces, hf)
+		hash, err := util_hash.Sha1File(fileName)
+		if err != nil {
+			return pieces, err
+		}
+		size, err := GetFileSize(fileName)
+		if err != nil {
+			return pieces, err
+		}
+		pieces[len(pieces)-1].FileHash, pieces[len(pieces)-1].FileSize = hash, size
+	}
+	return pieces, nil
+}
+
+// reportBlocks report bad blocks of file to tracker, providers are allocated for repaired pieces if given
+func (c *ClientManager) reportBlocks(fileHash []byte, fileSize uint64, sno uint32, bad []*mpb.BadBlock, pieces []common.HashFile) (*mpb.ReportBlocksResp, error) {
+	req := &mpb.ReportBlocksReq{
+		Version:   common.Version,
+		NodeId:    c.NodeId,
+		Timestamp: common.Now(),
+		SpaceNo:   sno,
+		FileHash:  fileHash,
+		FileSize:  fileSize,
+		Block:     bad,
+	}
+	for _, p := range pieces {
+		req.Repaired = append(req.Repaired, &mpb.PieceHashAndSize{Hash: p.FileHash, Size: uint32(p.FileSize)})
+	}
+	if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return nil, err
+	}
+	rsp, err := c.mclient.ReportBlocks(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.GetCode() != 0 {
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	return rsp, nil
+}
+
+// repairBlocks upload repaired pieces to providers allocated by tracker, and replace bad blocks by them
+func (c *ClientManager) repairBlocks(log logrus.FieldLogger, fileHash []byte, fileSize uint64, sno uint32, bad []*mpb.BadBlock, pieces []common.HashFile, rsp *mpb.ReportBlocksResp) error {
+	partition := rsp.GetPartition()
+	providers, backupPros := UsingBestProvider(partition.GetProviderAuth())
+	if len(providers) < len(pieces) {
+		return fmt.Errorf("tracker allocated %d providers for %d repaired blocks", len(providers), len(pieces))
+	}
+	backupProMap := CreateBackupProvicer(backupPros)
+	ctx, cancel := c.taskContext()
+	defer cancel()
+
+	req := &mpb.RepairBlocksDoneReq{
+		Version:  common.Version,
+		NodeId:   c.NodeId,
+		SpaceNo:  sno,
+		FileHash: fileHash,
+		FileSize: fileSize,
+	}
+	for i, piece := range pieces {
+		uploadPara := &common.UploadParameter{
+			Checksum:       bad[i].GetChecksum(),
+			HF:             piece,
+			OriginFileHash: fileHash,
+			OriginFileSize: fileSize,
+		}
+		pro := providers[i].Pro
+		block, err := c.uploadFileToErasureProvider(ctx, pro, partition.GetTimestamp(), uploadPara, rsp.GetChunkSize())
+		for err != nil {
+			newPro := ChooseBackupProvicer(piece.FileHash, backupProMap)
+			if newPro == nil {
+				break
+			}
+			log.Infof("Choose provider success new %s:%d", newPro.Pro.Server, newPro.Pro.Port)
+			block, err = c.uploadFileToErasureProvider(ctx, newPro.Pro, partition.GetTimestamp(), uploadPara, rsp.GetChunkSize())
+		}
+		if err != nil {
+			log.WithError(err).Errorf("Upload repaired block %x failed", bad[i].GetHash())
+			continue
+		}
+		req.BadHash = append(req.BadHash, bad[i].GetHash())
+		req.Block = append(req.Block, block)
+	}
+	if len(req.Block) == 0 {
+		return errors.New("no block repaired")
+	}
+	req.Timestamp = common.Now()
+	if err := req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return err
+	}
+	done, err := c.mclient.RepairBlocksDone(ctx, req)
+	if err != nil {
+		return err
+	}
+	if done.GetCode() != 0 {
+		return common.NewStatusErr(done.Code, done.ErrMsg)
+	}
+	log.Infof("Repaired %d of %d bad blocks", len(req.Block), len(bad))
+	if c.audit != nil {
+		if err := c.audit.repaired(sno, fileHash, req.BadHash, req.Block); err != nil {
+			log.WithError(err).Error("Save audit metadata of repaired blocks failed")
+		}
+	}
+	return nil
+}
+
+// healAfter release download workspace ws and heal partitions in background after the downloaded file is
+// handed back, shards reconstructed for healing are detached from ws by the heals
+func (c *ClientManager) healAfter(ws *Workspace, heals []func()) {
+	ws.Release()
+	pending := []func(){}
+	for _, heal := range heals {
+		if heal != nil {
+			pending = append(pending, heal)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+	go func() {
+		for _, heal := range pending {
+			heal()
+		}
+	}()
+}
+
+// detachHeal move shards reconstructed for bad blocks from ws into a heal workspace which is kept until
+// the returned heal finishes, bad blocks are only reported if the shards can not be kept
+func (c *ClientManager) detachHeal(log logrus.FieldLogger, ws *Workspace, base string, bad []*mpb.BadBlock, fileHash []byte, fileSize uint64, sno uint32, password []byte) func() {
+	var hws *Workspace
+	if c.webcfg.RepairDegraded {
+		files := make([]string, 0, len(bad))
+		size := int64(0)
+		for _, b := range bad {
+			fileName := fmt.Sprintf("%s.%d", base, b.GetBlockSeq())
+			files = append(files, fileName)
+			if s, err := GetFileSize(fileName); err == nil {
+				size += s
+			}
+		}
+		var err error
+		if hws, err = c.scratch.Detach(ws, fmt.Sprintf("heal@%x.%s", fileHash, filepath.Base(base)), size, files); err != nil {
+			log.WithError(err).Error("Keep reconstructed shards failed, report bad blocks only")
+		}
+	}
+	return func() {
+		if hws == nil {
+			c.healPartition(log, base, bad, fileHash, fileSize, sno, password, false)
+			return
+		}
+		defer hws.Release()
+		c.healPartition(log, filepath.Join(hws.Dir, filepath.Base(base)), bad, fileHash, fileSize, sno, password, true)
+	}
+}
+
+// healPartition report bad blocks of partition found by downloading it, and repair them with shards
+// reconstructed from base if the owner enables repair, failure of healing does not fail the download
+func (c *ClientManager) healPartition(log logrus.FieldLogger, base string, bad []*mpb.BadBlock, fileHash []byte, fileSize uint64, sno uint32, password []byte, reconstructed bool) {
+	if len(bad) == 0 {
+		return
+	}
+	var pieces []common.HashFile
+	if reconstructed && c.webcfg.RepairDegraded {
+		prepared, err := repairedPieces(base, bad, sno, password)
+		// pieces encrypted again are not removed with the shards
+		defer func() {
+			for _, p := range prepared {
+				if p.FileName != fmt.Sprintf("%s.%d", base, p.SliceIndex) {
+					deleteTemporaryFile(log, p.FileName)
+				}
+			}
+		}()
+		if err != nil {
+			log.WithError(err).Error("Prepare repaired blocks failed, report bad blocks only")
+		} else {
+			pieces = prepared
+		}
+	}
+	rsp, err := c.reportBlocks(fileHash, fileSize, sno, bad, pieces)
+	if err != nil {
+		log.WithError(err).Errorf("Report %d bad blocks failed", len(bad))
+		return
+	}
+	log.Infof("Reported %d bad blocks", len(bad))
+	if len(pieces) == 0 {
+		return
+	}
+	if err := c.repairBlocks(log, fileHash, fileSize, sno, bad, pieces, rsp); err != nil {
+		log.WithError(err).Error("Repair bad blocks failed")
+	}
+}
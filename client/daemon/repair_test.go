@@ -0,0 +1,97 @@
+package daemon
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/samoslab/nebula/util/aes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairedPieces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "repair")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	data := make([]byte, 10000)
+	rand.Read(data)
+	fname := filepath.Join(dir, "part")
+	require.NoError(t, ioutil.WriteFile(fname, data, 0600))
+	log := logrus.New()
+	shards, err := RsEncoder(log, dir, fname, 4, 2)
+	require.NoError(t, err)
+
+	// data shard is lost and parity shard is corrupt, decoder reconstructs them
+	require.NoError(t, os.Remove(shards[1].FileName))
+	require.NoError(t, os.Remove(shards[5].FileName))
+	require.NoError(t, RsDecoder(log, fname, filepath.Join(dir, "joined"), int64(len(data)), 4, 2))
+	bad := []*mpb.BadBlock{
+		{Hash: shards[1].FileHash, BlockSeq: 1},
+		{Hash: shards[5].FileHash, BlockSeq: 5, Checksum: true, Corrupt: true},
+	}
+	pieces, err := repairedPieces(fname, bad, 1, []byte("password"))
+	require.NoError(t, err)
+	require.Len(t, pieces, 2)
+	for i, p := range pieces {
+		require.Equal(t, shards[int(bad[i].BlockSeq)], p)
+	}
+
+	// blocks of default space are encrypted one by one
+	password := []byte("0123456789abcdef")
+	pieces, err = repairedPieces(fname, bad[:1], 0, password)
+	require.NoError(t, err)
+	require.Len(t, pieces, 1)
+	require.Equal(t, shards[1].FileName+".repair", pieces[0].FileName)
+	require.NotEqual(t, shards[1].FileHash, pieces[0].FileHash)
+	decrypted := filepath.Join(dir, "decrypted")
+	require.NoError(t, aes.DecryptFile(pieces[0].FileName, password, decrypted))
+	want, err := ioutil.ReadFile(shards[1].FileName)
+	require.NoError(t, err)
+	got, err := ioutil.ReadFile(decrypted)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	_, err = repairedPieces(fname, []*mpb.BadBlock{{BlockSeq: 9}}, 1, nil)
+	require.Error(t, err)
+}
+
+func TestAuditFileReplace(t *testing.T) {
+	f := newAuditFile(&mpb.CheckFileExistReq{FileHash: []byte("hash")}, []*mpb.StorePartition{{Block: []*mpb.StoreBlock{
+		{Hash: []byte("b1"), Phi: [][]byte{{1}}},
+		{Hash: []byte("b2"), Checksum: true, Phi: [][]byte{{2}}},
+	}}})
+	n := f.replace([][]byte{[]byte("b2"), []byte("b9")}, []*mpb.StoreBlock{
+		{Hash: []byte("b2"), Checksum: true, Phi: [][]byte{{3}}},
+		{Hash: []byte("b10"), Phi: [][]byte{{4}}},
+	})
+	require.Equal(t, 1, n)
+	require.Equal(t, [][]byte{{3}}, f.Partitions[0].Blocks[1].Metadata.Phi)
+	require.Equal(t, [][]byte{{1}}, f.Partitions[0].Blocks[0].Metadata.Phi)
+}
+
+func TestHealAfterReleasesWorkspace(t *testing.T) {
+	root, err := ioutil.TempDir("", "repair")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	m, err := NewScratchManager(logrus.New(), root, 100)
+	require.NoError(t, err)
+	c := &ClientManager{scratch: m}
+	ws, err := m.Acquire("download@a", 100)
+	require.NoError(t, err)
+
+	started, done := make(chan struct{}), make(chan struct{})
+	c.healAfter(ws, []func(){nil, func() {
+		close(started)
+		<-done
+	}})
+	<-started
+	// download workspace is released while healing runs
+	require.Equal(t, int64(0), m.Used())
+	_, err = os.Stat(ws.Dir)
+	require.True(t, os.IsNotExist(err))
+	close(done)
+}
@@ -0,0 +1,255 @@
+package daemon
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrScratchClosed scratch manager is closed while waiting for workspace
+var ErrScratchClosed = errors.New("scratch manager closed")
+
+// Workspace directory of a task, temporary files of the task are written in it and removed with it
+type Workspace struct {
+	Dir  string
+	key  string
+	size int64
+	m    *ScratchManager
+	sub  bool // nested in workspace of the caller, it takes no budget of its own
+}
+
+// Release remove the workspace and return its disk budget
+func (w *Workspace) Release() {
+	if err := os.RemoveAll(w.Dir); err != nil {
+		w.m.log.WithError(err).Errorf("Remove workspace %s failed", w.Dir)
+	}
+	if w.sub {
+		return
+	}
+	m := w.m
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.used -= w.size
+	delete(m.active, w.key)
+	m.cond.Broadcast()
+}
+
+type scratchWaiter struct {
+	key  string
+	size int64
+}
+
+// ScratchManager give every task its own workspace under root, so temporary files of tasks never
+// clash. Tasks which would exceed the disk budget wait in order until running tasks release space,
+// and a task runs alone if it needs more than the whole budget
+type ScratchManager struct {
+	root   string
+	budget int64 // 0 means no limit
+	log    logrus.FieldLogger
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	used   int64
+	active map[string]bool
+	queue  []*scratchWaiter
+	closed bool
+}
+
+// NewScratchManager create scratch manager, workspaces left by crashed process under root are removed
+func NewScratchManager(log logrus.FieldLogger, root string, budget int64) (*ScratchManager, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	m := &ScratchManager{
+		root:   root,
+		budget: budget,
+		log:    log.WithField("prefix", "scratch"),
+		active: map[string]bool{},
+	}
+	m.cond = sync.NewCond(&m.mutex)
+	m.sweep()
+	return m, nil
+}
+
+func (m *ScratchManager) sweep() {
+	infos, err := ioutil.ReadDir(m.root)
+	if err != nil {
+		m.log.WithError(err).Errorf("Read scratch dir %s failed", m.root)
+		return
+	}
+	for _, info := range infos {
+		path := filepath.Join(m.root, info.Name())
+		m.log.Infof("Remove orphaned workspace %s", path)
+		if err := os.RemoveAll(path); err != nil {
+			m.log.WithError(err).Errorf("Remove orphaned workspace %s failed", path)
+		}
+	}
+}
+
+// workspaceName readable name of task key, hash of the key keeps names of different keys apart
+func workspaceName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, key)
+	if len(name) > 64 {
+		name = name[len(name)-64:]
+	}
+	sum := sha1.Sum([]byte(key))
+	return name + "-" + hex.EncodeToString(sum[:4])
+}
+
+func (m *ScratchManager) fits(size int64) bool {
+	return m.budget <= 0 || m.used == 0 || m.used+size <= m.budget
+}
+
+// admissible waiter can run if its key is not running, it fits in budget, and no earlier waiter can run
+func (m *ScratchManager) admissible(w *scratchWaiter) bool {
+	if m.active[w.key] || !m.fits(w.size) {
+		return false
+	}
+	for _, e := range m.queue {
+		if e == w {
+			return true
+		}
+		if !m.active[e.key] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *ScratchManager) dequeue(w *scratchWaiter) {
+	for i, e := range m.queue {
+		if e == w {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			break
+		}
+	}
+	m.cond.Broadcast()
+}
+
+// Acquire get workspace of task key which needs size bytes of disk, it waits while task of the same
+// key is running or the budget is exhausted. Acquire must not be nested: a task holding a workspace
+// would wait for the budget it holds itself, nested steps get their workspace by AcquireIn instead
+func (m *ScratchManager) Acquire(key string, size int64) (*Workspace, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	w := &scratchWaiter{key: key, size: size}
+	m.queue = append(m.queue, w)
+	for !m.closed && !m.admissible(w) {
+		m.cond.Wait()
+	}
+	m.dequeue(w)
+	if m.closed {
+		return nil, ErrScratchClosed
+	}
+	dir := filepath.Join(m.root, workspaceName(key))
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	m.active[key] = true
+	m.used += size
+	return &Workspace{Dir: dir, key: key, size: size, m: m}, nil
+}
+
+// AcquireIn get workspace of nested step key inside held workspace of the caller, its files count in
+// the budget of held. Without held workspace it is Acquire
+func (m *ScratchManager) AcquireIn(held *Workspace, key string, size int64) (*Workspace, error) {
+	if held == nil {
+		return m.Acquire(key, size)
+	}
+	dir := filepath.Join(held.Dir, workspaceName(key))
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Workspace{Dir: dir, key: key, m: m, sub: true}, nil
+}
+
+// Detach move files of held workspace into a new workspace of key, which is kept after held is released.
+// Its budget is taken from held without waiting, so it never waits for the budget held itself; workspace
+// nested in another one has no budget to give, and the detached one takes none then
+func (m *ScratchManager) Detach(held *Workspace, key string, size int64, files []string) (*Workspace, error) {
+	m.mutex.Lock()
+	if m.active[key] {
+		m.mutex.Unlock()
+		return nil, fmt.Errorf("workspace %s is in use", key)
+	}
+	if held.sub {
+		size = 0
+	} else if size > held.size {
+		size = held.size
+	}
+	held.size -= size
+	m.active[key] = true
+	m.mutex.Unlock()
+	w := &Workspace{Dir: filepath.Join(m.root, workspaceName(key)), key: key, size: size, m: m}
+	err := os.RemoveAll(w.Dir)
+	if err == nil {
+		err = os.MkdirAll(w.Dir, 0700)
+	}
+	for _, file := range files {
+		if err != nil {
+			break
+		}
+		err = os.Rename(file, filepath.Join(w.Dir, filepath.Base(file)))
+	}
+	if err != nil {
+		w.Release()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Used disk budget taken by running tasks
+func (m *ScratchManager) Used() int64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.used
+}
+
+// Close wake up tasks waiting for workspace, they get ErrScratchClosed
+func (m *ScratchManager) Close() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.closed = true
+	m.cond.Broadcast()
+}
+
+// uploadScratchSize estimated temporary files of uploading file, shards with parity take at most twice
+// the file, partitions of big file and encrypted copy take one more
+func uploadScratchSize(fileSize int64, isEncrypt bool) int64 {
+	size := 2 * fileSize
+	if fileSize > PartitionMaxSize {
+		size += fileSize
+	}
+	if isEncrypt {
+		size += fileSize
+	}
+	return size
+}
+
+// downloadScratchSize estimated temporary files of downloading file, partitions are decoded one by one
+// and kept until they are joined
+func downloadScratchSize(fileSize int64) int64 {
+	partition := fileSize
+	if partition > PartitionMaxSize {
+		partition = PartitionMaxSize
+	}
+	return fileSize + 2*partition
+}
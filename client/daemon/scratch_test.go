@@ -0,0 +1,150 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceName(t *testing.T) {
+	a := workspaceName("upload@0@/docs/report.pdf")
+	b := workspaceName("upload@0@/tmp/report.pdf")
+	require.NotEqual(t, a, b)
+	require.Equal(t, a, workspaceName("upload@0@/docs/report.pdf"))
+	require.NotContains(t, a, "/")
+}
+
+func acquired(ch <-chan *Workspace) *Workspace {
+	select {
+	case ws := <-ch:
+		return ws
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestScratchManager(t *testing.T) {
+	root, err := ioutil.TempDir("", "scratch")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	orphan := filepath.Join(root, "orphan")
+	require.NoError(t, os.MkdirAll(orphan, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(orphan, "a.1"), []byte("a"), 0600))
+
+	m, err := NewScratchManager(logrus.New(), root, 100)
+	require.NoError(t, err)
+	_, err = os.Stat(orphan)
+	require.True(t, os.IsNotExist(err))
+
+	a, err := m.Acquire("a", 60)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(a.Dir, "a.1"), []byte("a"), 0600))
+	b, err := m.Acquire("b", 40)
+	require.NoError(t, err)
+	require.NotEqual(t, a.Dir, b.Dir)
+
+	acquire := func(key string, size int64) <-chan *Workspace {
+		ch := make(chan *Workspace, 1)
+		go func() {
+			ws, err := m.Acquire(key, size)
+			if err == nil {
+				ch <- ws
+			}
+			close(ch)
+		}()
+		return ch
+	}
+	// budget exhausted, c waits, d of the same key as b waits for b
+	c := acquire("c", 35)
+	require.Nil(t, acquired(c))
+	d := acquire("b", 10)
+	require.Nil(t, acquired(d))
+
+	// d fits after b is released, but it is queued after c
+	b.Release()
+	require.Nil(t, acquired(d))
+
+	a.Release()
+	_, err = os.Stat(a.Dir)
+	require.True(t, os.IsNotExist(err))
+	wc := acquired(c)
+	require.NotNil(t, wc)
+	wd := acquired(d)
+	require.NotNil(t, wd)
+	require.Equal(t, int64(45), m.Used())
+	wc.Release()
+	wd.Release()
+
+	// task bigger than budget runs alone
+	big, err := m.Acquire("big", 1000)
+	require.NoError(t, err)
+	e := acquire("e", 1)
+	require.Nil(t, acquired(e))
+	big.Release()
+	we := acquired(e)
+	require.NotNil(t, we)
+	we.Release()
+
+	f, err := m.Acquire("f", 100)
+	require.NoError(t, err)
+	// nested step of f runs in workspace of f although budget is exhausted
+	nested, err := m.AcquireIn(f, "f.1", 100)
+	require.NoError(t, err)
+	require.Equal(t, f.Dir, filepath.Dir(nested.Dir))
+	require.Equal(t, int64(100), m.Used())
+	nested.Release()
+	_, err = os.Stat(nested.Dir)
+	require.True(t, os.IsNotExist(err))
+	require.Equal(t, int64(100), m.Used())
+	g := acquire("g", 1)
+	require.Nil(t, acquired(g))
+	m.Close()
+	_, ok := <-g
+	require.False(t, ok)
+	f.Release()
+	require.Equal(t, int64(0), m.Used())
+}
+
+func TestScratchDetach(t *testing.T) {
+	root, err := ioutil.TempDir("", "scratch")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	m, err := NewScratchManager(logrus.New(), root, 100)
+	require.NoError(t, err)
+
+	a, err := m.Acquire("a", 100)
+	require.NoError(t, err)
+	shard := filepath.Join(a.Dir, "f.1")
+	require.NoError(t, ioutil.WriteFile(shard, []byte("s"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(a.Dir, "f.2"), []byte("s"), 0600))
+	// budget is exhausted, detach does not wait for it
+	h, err := m.Detach(a, "heal@a", 30, []string{shard})
+	require.NoError(t, err)
+	_, err = m.Detach(a, "heal@a", 10, nil)
+	require.Error(t, err)
+	a.Release()
+	require.Equal(t, int64(30), m.Used())
+	_, err = os.Stat(filepath.Join(h.Dir, "f.1"))
+	require.NoError(t, err)
+	h.Release()
+	require.Equal(t, int64(0), m.Used())
+
+	// nested workspace has no budget to give
+	b, err := m.Acquire("b", 50)
+	require.NoError(t, err)
+	nested, err := m.AcquireIn(b, "b.1", 50)
+	require.NoError(t, err)
+	nh, err := m.Detach(nested, "heal@b", 20, nil)
+	require.NoError(t, err)
+	nested.Release()
+	b.Release()
+	require.Equal(t, int64(0), m.Used())
+	_, err = os.Stat(nh.Dir)
+	require.NoError(t, err)
+	nh.Release()
+}
@@ -0,0 +1,145 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/errcode"
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchedFile file found by search with its path
+type SearchedFile struct {
+	Path string `json:"path"`
+	*DownFile
+}
+
+// SearchPages search result
+type SearchPages struct {
+	Total uint32          `json:"total"`
+	Files []*SearchedFile `json:"files"`
+	Local bool            `json:"local,omitempty"` // searched in metadata cache because tracker can not search
+}
+
+// SearchParam filters of search, zero value of filter matches all files
+type SearchParam struct {
+	Path          string
+	Recursive     bool
+	NamePattern   string // glob of name, or regular expression if Regex
+	Regex         bool
+	FileType      string // prefix of MIME type
+	MinSize       uint64
+	MaxSize       uint64 // 0 means no limit
+	MinModTime    uint64
+	MaxModTime    uint64 // 0 means no limit
+	IncludeFolder bool
+	PageSize      uint32
+	PageNum       uint32
+	SortType      string
+	AscOrder      bool
+}
+
+// nameMatcher return matcher of name pattern, nil if pattern is empty
+func (p *SearchParam) nameMatcher() (func(string) bool, error) {
+	if p.NamePattern == "" {
+		return nil, nil
+	}
+	if p.Regex {
+		re, err := regexp.Compile(p.NamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name pattern %s: %v", p.NamePattern, err)
+		}
+		return re.MatchString, nil
+	}
+	if _, err := path.Match(p.NamePattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid name pattern %s: %v", p.NamePattern, err)
+	}
+	return func(name string) bool {
+		ok, _ := path.Match(p.NamePattern, name)
+		return ok
+	}, nil
+}
+
+// SearchFiles search files under path by tracker, folders which have been listed are searched in
+// metadata cache if tracker is not reachable or does not support search
+func (c *ClientManager) SearchFiles(param SearchParam, sno uint32) (*SearchPages, error) {
+	log := c.Log.WithField("search path", param.Path)
+	match, err := param.nameMatcher()
+	if err != nil {
+		return nil, err
+	}
+	req := &mpb.SearchFilesReq{
+		NodeId:        c.NodeId,
+		Timestamp:     common.Now(),
+		Version:       common.Version,
+		Parent:        &mpb.FilePath{OneOfPath: &mpb.FilePath_Path{Path: param.Path}, SpaceNo: sno},
+		Recursive:     param.Recursive,
+		NamePattern:   param.NamePattern,
+		Regex:         param.Regex,
+		FileType:      param.FileType,
+		MinSize:       param.MinSize,
+		MaxSize:       param.MaxSize,
+		MinModTime:    param.MinModTime,
+		MaxModTime:    param.MaxModTime,
+		IncludeFolder: param.IncludeFolder,
+		PageSize:      param.PageSize,
+		PageNum:       param.PageNum,
+		SortType:      toSortType(param.SortType),
+		AscOrder:      param.AscOrder,
+	}
+	if err = req.SignReq(c.cfg.Node.PriKey); err != nil {
+		return nil, common.NewStatus(errcode.RetSignFailed, err)
+	}
+	log.Infof("Search request %+v", param)
+	rsp, err := c.mclient.SearchFiles(context.Background(), req)
+	if err != nil {
+		if code := status.Code(err); code != codes.Unimplemented && code != codes.Unavailable && code != codes.DeadlineExceeded {
+			return nil, common.NewStatus(errcode.RetTrackerFailed, err)
+		}
+		log.WithError(err).Info("Tracker can not search, search metadata cache")
+		return c.searchLocal(param, match, sno)
+	}
+	if rsp.GetCode() != 0 {
+		return nil, common.NewStatusErr(rsp.Code, rsp.ErrMsg)
+	}
+	files := []*SearchedFile{}
+	for _, sf := range rsp.GetFile() {
+		df := c.toDownFile(sf.GetFof())
+		files = append(files, &SearchedFile{Path: path.Join(sf.GetParent(), df.FileName), DownFile: df})
+	}
+	return &SearchPages{Total: rsp.GetTotalRecord(), Files: files}, nil
+}
+
+func (c *ClientManager) searchLocal(param SearchParam, match func(string) bool, sno uint32) (*SearchPages, error) {
+	flt := CacheFilter{
+		Match:      match,
+		FileType:   param.FileType,
+		MinSize:    param.MinSize,
+		MaxSize:    param.MaxSize,
+		MinModTime: param.MinModTime,
+		MaxModTime: param.MaxModTime,
+		Folder:     param.IncludeFolder,
+	}
+	found, err := c.meta.search(sno, param.Path, param.Recursive, flt, 0)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*DownFile, len(found))
+	paths := make(map[*DownFile]string, len(found))
+	for i, f := range found {
+		files[i] = f.DownFile
+		paths[f.DownFile] = f.Path
+	}
+	sortFiles(files, param.SortType, param.AscOrder)
+	start, end := pageRange(len(files), param.PageSize, param.PageNum)
+	result := &SearchPages{Total: uint32(len(files)), Files: []*SearchedFile{}, Local: true}
+	for _, f := range files[start:end] {
+		result.Files = append(result.Files, &SearchedFile{Path: paths[f], DownFile: f})
+	}
+	return result, nil
+}
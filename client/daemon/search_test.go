@@ -0,0 +1,78 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameMatcher(t *testing.T) {
+	match, err := (&SearchParam{}).nameMatcher()
+	require.NoError(t, err)
+	require.Nil(t, match)
+
+	match, err = (&SearchParam{NamePattern: "*.pdf"}).nameMatcher()
+	require.NoError(t, err)
+	require.True(t, match("report.pdf"))
+	require.False(t, match("report.pdf.txt"))
+
+	match, err = (&SearchParam{NamePattern: `^report-\d+\.txt$`, Regex: true}).nameMatcher()
+	require.NoError(t, err)
+	require.True(t, match("report-2017.txt"))
+	require.False(t, match("report.txt"))
+
+	_, err = (&SearchParam{NamePattern: "[a-"}).nameMatcher()
+	require.Error(t, err)
+	_, err = (&SearchParam{NamePattern: "(a", Regex: true}).nameMatcher()
+	require.Error(t, err)
+}
+
+func TestSearchLocal(t *testing.T) {
+	m, cleanup := newTestMetaCache(t)
+	defer cleanup()
+	c := &ClientManager{meta: m, Log: logrus.New()}
+	_, err := m.put(0, "/", []*DownFile{
+		{FileName: "docs", Folder: true},
+		{FileName: "a.pdf", FileSize: 300, ModTime: 100},
+	})
+	require.NoError(t, err)
+	_, err = m.put(0, "/docs", []*DownFile{
+		{FileName: "b.pdf", FileSize: 100, ModTime: 200},
+		{FileName: "c.pdf", FileSize: 200, ModTime: 300},
+		{FileName: "d.txt", FileSize: 400, ModTime: 400},
+	})
+	require.NoError(t, err)
+
+	param := SearchParam{Path: "/", Recursive: true, NamePattern: "*.pdf", SortType: "size", AscOrder: true, PageSize: 2, PageNum: 1}
+	match, err := param.nameMatcher()
+	require.NoError(t, err)
+	result, err := c.searchLocal(param, match, 0)
+	require.NoError(t, err)
+	require.True(t, result.Local)
+	require.Equal(t, uint32(3), result.Total)
+	require.Len(t, result.Files, 2)
+	require.Equal(t, "/docs/b.pdf", result.Files[0].Path)
+	require.Equal(t, "/docs/c.pdf", result.Files[1].Path)
+
+	param.PageNum = 2
+	result, err = c.searchLocal(param, match, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	require.Equal(t, "/a.pdf", result.Files[0].Path)
+
+	// flat search and mtime range
+	param = SearchParam{Path: "/", MinModTime: 50, MaxModTime: 250}
+	result, err = c.searchLocal(param, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	require.Equal(t, "/a.pdf", result.Files[0].Path)
+
+	param = SearchParam{Path: "/", Recursive: true, NamePattern: "do*", IncludeFolder: true}
+	match, err = param.nameMatcher()
+	require.NoError(t, err)
+	result, err = c.searchLocal(param, match, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	require.True(t, result.Files[0].Folder)
+}
@@ -22,6 +22,10 @@ func NewTask(tp string, req interface{}) Task {
 		t.Payload = req.(*common.DownloadReq)
 	case common.TaskDownloadDirType:
 		t.Payload = req.(*common.DownloadDirReq)
+	case common.TaskBackupType:
+		t.Payload = req.(*common.BackupReq)
+	case common.TaskRestoreType:
+		t.Payload = req.(*common.RestoreReq)
 	}
 	return t
 }
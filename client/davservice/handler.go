@@ -0,0 +1,602 @@
+// Package davservice serves nebula spaces over WebDAV so that they can be mounted as network drive.
+// Spaces are the top-level collections, default for space 0 and privacy for space 1. Privacy space
+// is unlocked by its space password given as password of Basic auth.
+package davservice
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/samoslab/nebula/client/filecache"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	shutdownTimeout = time.Second * 5
+	// upload and download of large file take long, so only header reading is limited
+	readHeaderTimeout = time.Second * 30
+	idleTimeout       = time.Second * 120
+
+	spoolDirName = "nebula-dav"
+	cacheDirName = "dav-cache"
+	// DefaultCacheSize default max size of read cache, 1GB
+	DefaultCacheSize = 1024 * 1024 * 1024
+
+	lockTimeout = 3600
+)
+
+// spaces exposed as top-level collections, index is the space number
+var spaceNames = []string{"default", "privacy"}
+
+var errNotRegistered = errors.New("client is not registered")
+
+// Server is the WebDAV http listener
+type Server struct {
+	log       logrus.FieldLogger
+	cm        **daemon.ClientManager
+	cacheDir  string
+	cacheSize int64
+	cache     *filecache.Cache
+	cacheOnce sync.Once
+	server    *http.Server
+
+	unlockMutex sync.Mutex
+	unlocked    map[uint32][sha256.Size]byte // hash of verified space password
+}
+
+// NewServer create WebDAV server, cm is shared with http service because it is created after register
+func NewServer(log logrus.FieldLogger, cfg config.Config, cm **daemon.ClientManager) *Server {
+	cacheSize := cfg.DavCacheSize
+	if cacheSize <= 0 {
+		cacheSize = DefaultCacheSize
+	}
+	return &Server{
+		log:       log.WithField("prefix", "webdav"),
+		cm:        cm,
+		cacheDir:  filepath.Join(cfg.ConfigDir, cacheDirName),
+		cacheSize: cacheSize,
+		unlocked:  make(map[uint32][sha256.Size]byte),
+	}
+}
+
+// Run listen on addr and serve until Shutdown
+func (s *Server) Run(addr string) error {
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: readHeaderTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+	if cm, err := s.clientManager(); err == nil {
+		os.RemoveAll(filepath.Join(cm.TempDir, spoolDirName))
+	}
+	s.log.Infof("webdav listen on %s", addr)
+	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stop the listener
+func (s *Server) Shutdown() {
+	if s.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.log.Errorf("webdav shutdown error %v", err)
+	}
+}
+
+func (s *Server) clientManager() (*daemon.ClientManager, error) {
+	if s.cm == nil || *s.cm == nil {
+		return nil, errNotRegistered
+	}
+	return *s.cm, nil
+}
+
+func (s *Server) readCache() (*filecache.Cache, error) {
+	var err error
+	s.cacheOnce.Do(func() {
+		s.cache, err = filecache.New(s.cacheDir, s.cacheSize)
+	})
+	if s.cache == nil && err == nil {
+		err = errors.New("read cache is not available")
+	}
+	return s.cache, err
+}
+
+// parsePath split url path /space/a/b into space number and nebula path /a/b,
+// space is -1 for the root collection
+func parsePath(p string) (sno int, remote string, ok bool) {
+	p = path.Clean("/" + p)
+	if p == "/" {
+		return -1, "/", true
+	}
+	name := strings.TrimPrefix(p, "/")
+	remote = "/"
+	if i := strings.Index(name, "/"); i >= 0 {
+		name, remote = name[:i], name[i:]
+	}
+	for i, n := range spaceNames {
+		if n == name {
+			return i, remote, true
+		}
+	}
+	return 0, "", false
+}
+
+// href return url path of nebula path in the space, collection ends with slash
+func href(sno int, remote string, collection bool) string {
+	p := "/"
+	if sno >= 0 {
+		p = path.Join("/", spaceNames[sno], remote)
+	}
+	if collection && !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return (&url.URL{Path: p}).EscapedPath()
+}
+
+// unlock check the space password of Basic auth, verified password is remembered
+func (s *Server) unlock(cm *daemon.ClientManager, sno uint32, r *http.Request) bool {
+	if sno == 0 {
+		return true
+	}
+	_, password, ok := r.BasicAuth()
+	if !ok || password == "" {
+		return false
+	}
+	hash := sha256.Sum256([]byte(password))
+	s.unlockMutex.Lock()
+	verified, ok := s.unlocked[sno]
+	s.unlockMutex.Unlock()
+	if ok && verified == hash {
+		return true
+	}
+	if err := cm.VerifyPassword(sno, password); err != nil {
+		s.log.Infof("verify password of space %d failed, %v", sno, err)
+		return false
+	}
+	s.unlockMutex.Lock()
+	s.unlocked[sno] = hash
+	s.unlockMutex.Unlock()
+	return true
+}
+
+// request hold the state of one WebDAV request
+type request struct {
+	s      *Server
+	cm     *daemon.ClientManager
+	w      http.ResponseWriter
+	r      *http.Request
+	sno    int
+	remote string
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := s.log.WithField("path", r.URL.Path)
+	if r.Method == http.MethodOptions {
+		w.Header().Set("DAV", "1, 2")
+		w.Header().Set("MS-Author-Via", "DAV")
+		w.Header().Set("Allow", "OPTIONS, PROPFIND, PROPPATCH, GET, HEAD, PUT, MKCOL, MOVE, DELETE, LOCK, UNLOCK")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	cm, err := s.clientManager()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	sno, remote, ok := parsePath(r.URL.Path)
+	if !ok {
+		http.Error(w, "no such space", http.StatusNotFound)
+		return
+	}
+	if sno > 0 && !s.unlock(cm, uint32(sno), r) {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Basic realm="nebula %s space"`, spaceNames[sno]))
+		http.Error(w, "space password required", http.StatusUnauthorized)
+		return
+	}
+	req := &request{s: s, cm: cm, w: w, r: r, sno: sno, remote: remote}
+	status, err := req.serve()
+	if err != nil {
+		log.Errorf("%s failed, %v", r.Method, err)
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		http.Error(w, err.Error(), status)
+	}
+}
+
+// serve handle the request, return http status and error if failed
+func (req *request) serve() (int, error) {
+	switch req.r.Method {
+	case "PROPFIND":
+		return req.propfind()
+	case "PROPPATCH":
+		return req.proppatch()
+	case "LOCK":
+		return req.lock()
+	case "UNLOCK":
+		req.w.WriteHeader(http.StatusNoContent)
+		return 0, nil
+	}
+	// methods below are not allowed on the root and space collections
+	if req.sno < 0 || req.remote == "/" {
+		if req.r.Method == http.MethodGet || req.r.Method == http.MethodHead {
+			return http.StatusMethodNotAllowed, errors.New("collection can not be read")
+		}
+		return http.StatusForbidden, errors.New("space can not be modified")
+	}
+	switch req.r.Method {
+	case http.MethodGet, http.MethodHead:
+		return req.get()
+	case http.MethodPut:
+		return req.put()
+	case "MKCOL":
+		return req.mkcol()
+	case "MOVE":
+		return req.move()
+	case http.MethodDelete:
+		return req.delete()
+	case "COPY":
+		return http.StatusNotImplemented, errors.New("copy is not supported")
+	default:
+		return http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", req.r.Method)
+	}
+}
+
+// stat return the file of path, nil if not exists
+func (req *request) stat(remote string) (*daemon.DownFile, error) {
+	f, err := req.cm.StatFile(remote, uint32(req.sno))
+	if err == daemon.ErrFileNotExist {
+		return nil, nil
+	}
+	return f, err
+}
+
+// parentExists return true if parent folder of remote exists
+func (req *request) parentExists(remote string) (bool, error) {
+	parent := path.Dir(remote)
+	if parent == "/" {
+		return true, nil
+	}
+	f, err := req.stat(parent)
+	return f != nil && f.Folder, err
+}
+
+func modTime(f *daemon.DownFile) time.Time {
+	return time.Unix(int64(f.ModTime), 0)
+}
+
+func fileProps(f *daemon.DownFile) props {
+	p := props{DisplayName: f.FileName, GetLastModified: modTime(f).UTC().Format(http.TimeFormat)}
+	if f.Folder {
+		p.ResourceType.Collection = &struct{}{}
+	} else {
+		size := f.Size()
+		p.GetContentLength = &size
+		p.GetETag = `"` + f.FileHash + `"`
+	}
+	return p
+}
+
+func okPropstat(p interface{}) []propstat {
+	return []propstat{{Prop: p, Status: "HTTP/1.1 200 OK"}}
+}
+
+func collectionResponse(sno int, remote, name string) response {
+	return response{Href: href(sno, remote, true), Propstat: okPropstat(props{DisplayName: name, ResourceType: resourceType{Collection: &struct{}{}}})}
+}
+
+// propfind return properties of the resource and its members if depth is not 0,
+// infinity depth is served as 1
+func (req *request) propfind() (int, error) {
+	depth := req.r.Header.Get("Depth")
+	res := &multistatus{XmlnsD: "DAV:"}
+	if req.sno < 0 {
+		res.Responses = append(res.Responses, collectionResponse(-1, "/", ""))
+		if depth != "0" {
+			for i, name := range spaceNames {
+				res.Responses = append(res.Responses, collectionResponse(i, "/", name))
+			}
+		}
+		writeXML(req.w, http.StatusMultiStatus, res)
+		return 0, nil
+	}
+	sno := uint32(req.sno)
+	folder := true
+	if req.remote == "/" {
+		res.Responses = append(res.Responses, collectionResponse(req.sno, "/", spaceNames[req.sno]))
+	} else {
+		f, err := req.stat(req.remote)
+		if err != nil {
+			return 0, err
+		}
+		if f == nil {
+			return http.StatusNotFound, errors.New("not found")
+		}
+		folder = f.Folder
+		res.Responses = append(res.Responses, response{Href: href(req.sno, req.remote, f.Folder), Propstat: okPropstat(fileProps(f))})
+	}
+	if folder && depth != "0" {
+		files, err := req.cm.ListAllFiles(req.remote, sno)
+		if err != nil {
+			return 0, err
+		}
+		for _, f := range files {
+			res.Responses = append(res.Responses, response{Href: href(req.sno, path.Join(req.remote, f.FileName), f.Folder), Propstat: okPropstat(fileProps(f))})
+		}
+	}
+	writeXML(req.w, http.StatusMultiStatus, res)
+	return 0, nil
+}
+
+// proppatch refuse every property, dead properties are not stored in nebula
+func (req *request) proppatch() (int, error) {
+	names, err := parsePropNames(req.r.Body)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	res := &multistatus{XmlnsD: "DAV:", Responses: []response{{
+		Href:     href(req.sno, req.remote, false),
+		Propstat: []propstat{{Prop: anyProps{Props: names}, Status: "HTTP/1.1 403 Forbidden"}},
+	}}}
+	writeXML(req.w, http.StatusMultiStatus, res)
+	return 0, nil
+}
+
+// lock grant a lock without enforcing it, some clients only write to servers support locking
+func (req *request) lock() (int, error) {
+	id, err := randomID()
+	if err != nil {
+		return 0, err
+	}
+	token := "opaquelocktoken:" + id
+	if t := req.r.Header.Get("If"); t != "" && req.r.ContentLength == 0 {
+		// refresh of the lock
+		token = strings.Trim(t, "()<>")
+	}
+	res := &lockDiscovery{XmlnsD: "DAV:", ActiveLock: activeLock{
+		Depth:     "0",
+		Timeout:   fmt.Sprintf("Second-%d", lockTimeout),
+		LockToken: token,
+		LockRoot:  href(req.sno, req.remote, false),
+	}}
+	req.w.Header().Set("Lock-Token", "<"+token+">")
+	writeXML(req.w, http.StatusOK, res)
+	return 0, nil
+}
+
+func randomID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// spoolDir create a directory for file data in temporary directory of client
+func (req *request) spoolDir() (string, error) {
+	id, err := randomID()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(req.cm.TempDir, spoolDirName, id)
+	return dir, os.MkdirAll(dir, 0700)
+}
+
+// download the file into dir, named as the base name of remote
+func (req *request) download(f *daemon.DownFile, dir string) (string, error) {
+	if err := req.cm.DownloadFile(req.remote, dir, f.FileHash, f.FileSize, uint32(req.sno)); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, path.Base(req.remote)), nil
+}
+
+// get serve the file from read cache, files of privacy space are never cached
+func (req *request) get() (int, error) {
+	f, err := req.stat(req.remote)
+	if err != nil {
+		return 0, err
+	}
+	if f == nil {
+		return http.StatusNotFound, errors.New("not found")
+	}
+	if f.Folder {
+		return http.StatusMethodNotAllowed, errors.New("collection can not be read")
+	}
+	var fileName string
+	if req.sno == 0 {
+		cache, err := req.s.readCache()
+		if err != nil {
+			return 0, err
+		}
+		fileName, err = cache.Get(f.FileHash, func(dest string) error {
+			dir, err := req.spoolDir()
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(dir)
+			downloaded, err := req.download(f, dir)
+			if err != nil {
+				return err
+			}
+			return os.Rename(downloaded, dest)
+		})
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		dir, err := req.spoolDir()
+		if err != nil {
+			return 0, err
+		}
+		defer os.RemoveAll(dir)
+		if fileName, err = req.download(f, dir); err != nil {
+			return 0, err
+		}
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	req.w.Header().Set("ETag", `"`+f.FileHash+`"`)
+	http.ServeContent(req.w, req.r, f.FileName, modTime(f), file)
+	return 0, nil
+}
+
+// put spool the body and upload it, file of default space is kept in read cache after uploaded
+func (req *request) put() (int, error) {
+	if ok, err := req.parentExists(req.remote); err != nil {
+		return 0, err
+	} else if !ok {
+		return http.StatusConflict, errors.New("parent collection not exists")
+	}
+	old, err := req.stat(req.remote)
+	if err != nil {
+		return 0, err
+	}
+	if old != nil && old.Folder {
+		return http.StatusMethodNotAllowed, errors.New("collection can not be written")
+	}
+	dir, err := req.spoolDir()
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, path.Base(req.remote))
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+	hash := sha1.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), req.r.Body)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err != nil {
+		return 0, err
+	}
+	sno := uint32(req.sno)
+	if err = req.cm.UploadFile(fileName, path.Dir(req.remote), false, true, sno > 0, false, sno); err != nil {
+		return 0, err
+	}
+	if sno == 0 {
+		if cache, err := req.s.readCache(); err == nil {
+			if err = cache.Put(hex.EncodeToString(hash.Sum(nil)), fileName); err != nil {
+				req.s.log.Warnf("keep %s in read cache failed, %v", req.remote, err)
+			}
+		}
+	}
+	if old != nil {
+		req.w.WriteHeader(http.StatusNoContent)
+	} else {
+		req.w.WriteHeader(http.StatusCreated)
+	}
+	return 0, nil
+}
+
+func (req *request) mkcol() (int, error) {
+	if req.r.ContentLength > 0 {
+		return http.StatusUnsupportedMediaType, errors.New("body of MKCOL is not supported")
+	}
+	if f, err := req.stat(req.remote); err != nil {
+		return 0, err
+	} else if f != nil {
+		return http.StatusMethodNotAllowed, errors.New("already exists")
+	}
+	if ok, err := req.parentExists(req.remote); err != nil {
+		return 0, err
+	} else if !ok {
+		return http.StatusConflict, errors.New("parent collection not exists")
+	}
+	if _, err := req.cm.MkFolder(path.Dir(req.remote), []string{path.Base(req.remote)}, false, uint32(req.sno)); err != nil {
+		return 0, err
+	}
+	req.w.WriteHeader(http.StatusCreated)
+	return 0, nil
+}
+
+func (req *request) delete() (int, error) {
+	f, err := req.stat(req.remote)
+	if err != nil {
+		return 0, err
+	}
+	if f == nil {
+		return http.StatusNotFound, errors.New("not found")
+	}
+	if err = req.cm.RemoveFile(req.remote, f.Folder, true, uint32(req.sno)); err != nil {
+		return 0, err
+	}
+	req.w.WriteHeader(http.StatusNoContent)
+	return 0, nil
+}
+
+// move rename file or collection in the same space, existing destination is replaced unless Overwrite is F
+func (req *request) move() (int, error) {
+	dest, err := url.Parse(req.r.Header.Get("Destination"))
+	if err != nil || dest.Path == "" {
+		return http.StatusBadRequest, errors.New("invalid destination")
+	}
+	sno, remote, ok := parsePath(dest.Path)
+	if !ok || sno != req.sno || remote == "/" {
+		return http.StatusForbidden, errors.New("destination must in the same space")
+	}
+	if remote == req.remote || strings.HasPrefix(remote, req.remote+"/") {
+		return http.StatusForbidden, errors.New("destination is the source or inside it")
+	}
+	src, err := req.stat(req.remote)
+	if err != nil {
+		return 0, err
+	}
+	if src == nil {
+		return http.StatusNotFound, errors.New("not found")
+	}
+	if ok, err := req.parentExists(remote); err != nil {
+		return 0, err
+	} else if !ok {
+		return http.StatusConflict, errors.New("parent collection of destination not exists")
+	}
+	old, err := req.stat(remote)
+	if err != nil {
+		return 0, err
+	}
+	if old != nil {
+		if req.r.Header.Get("Overwrite") == "F" {
+			return http.StatusPreconditionFailed, errors.New("destination exists")
+		}
+		if err = req.cm.RemoveFile(remote, old.Folder, true, uint32(req.sno)); err != nil {
+			return 0, err
+		}
+	}
+	if err = req.cm.MoveFile(req.remote, remote, true, uint32(req.sno)); err != nil {
+		return 0, err
+	}
+	if old != nil {
+		req.w.WriteHeader(http.StatusNoContent)
+	} else {
+		req.w.WriteHeader(http.StatusCreated)
+	}
+	return 0, nil
+}
@@ -0,0 +1,51 @@
+package davservice
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	sno, remote, ok := parsePath("/")
+	require.True(t, ok)
+	require.Equal(t, -1, sno)
+	require.Equal(t, "/", remote)
+
+	sno, remote, ok = parsePath("/default")
+	require.True(t, ok)
+	require.Equal(t, 0, sno)
+	require.Equal(t, "/", remote)
+
+	sno, remote, ok = parsePath("/privacy/a/b.txt/")
+	require.True(t, ok)
+	require.Equal(t, 1, sno)
+	require.Equal(t, "/a/b.txt", remote)
+
+	_, _, ok = parsePath("/other/a")
+	require.False(t, ok)
+
+	require.Equal(t, "/default/a%20b/", href(0, "/a b", true))
+	require.Equal(t, "/privacy/c.txt", href(1, "/c.txt", false))
+	require.Equal(t, "/", href(-1, "/", true))
+}
+
+func TestParsePropNames(t *testing.T) {
+	body := `<?xml version="1.0"?>
+<D:propertyupdate xmlns:D="DAV:" xmlns:Z="urn:z">
+  <D:set><D:prop><Z:author><Z:name>x</Z:name></Z:author></D:prop></D:set>
+  <D:remove><D:prop><D:displayname/></D:prop></D:remove>
+</D:propertyupdate>`
+	names, err := parsePropNames(strings.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, []anyProp{
+		{XMLName: xml.Name{Space: "urn:z", Local: "author"}},
+		{XMLName: xml.Name{Space: "DAV:", Local: "displayname"}},
+	}, names)
+
+	buf, err := xml.Marshal(anyProps{Props: names})
+	require.NoError(t, err)
+	require.Contains(t, string(buf), `<author xmlns="urn:z"></author>`)
+}
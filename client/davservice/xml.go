@@ -0,0 +1,102 @@
+package davservice
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+)
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"D:multistatus"`
+	XmlnsD    string     `xml:"xmlns:D,attr"`
+	Responses []response `xml:"D:response"`
+}
+
+type response struct {
+	Href     string     `xml:"D:href"`
+	Propstat []propstat `xml:"D:propstat"`
+}
+
+type propstat struct {
+	Prop   interface{} `xml:"D:prop"`
+	Status string      `xml:"D:status"`
+}
+
+type resourceType struct {
+	Collection *struct{} `xml:"D:collection,omitempty"`
+}
+
+// props returned by PROPFIND, all of them are returned whatever requested
+type props struct {
+	DisplayName      string       `xml:"D:displayname"`
+	ResourceType     resourceType `xml:"D:resourcetype"`
+	GetContentLength *uint64      `xml:"D:getcontentlength,omitempty"`
+	GetLastModified  string       `xml:"D:getlastmodified,omitempty"`
+	GetETag          string       `xml:"D:getetag,omitempty"`
+}
+
+// anyProp is a property named in PROPPATCH request
+type anyProp struct {
+	XMLName xml.Name
+}
+
+type anyProps struct {
+	Props []anyProp
+}
+
+type activeLock struct {
+	LockType  struct{} `xml:"D:locktype>D:write"`
+	LockScope struct{} `xml:"D:lockscope>D:exclusive"`
+	Depth     string   `xml:"D:depth"`
+	Timeout   string   `xml:"D:timeout"`
+	LockToken string   `xml:"D:locktoken>D:href"`
+	LockRoot  string   `xml:"D:lockroot>D:href"`
+}
+
+type lockDiscovery struct {
+	XMLName    xml.Name   `xml:"D:prop"`
+	XmlnsD     string     `xml:"xmlns:D,attr"`
+	ActiveLock activeLock `xml:"D:lockdiscovery>D:activelock"`
+}
+
+// parsePropNames return names of properties in set and remove elements of PROPPATCH body
+func parsePropNames(r io.Reader) ([]anyProp, error) {
+	dec := xml.NewDecoder(r)
+	names := make([]anyProp, 0, 4)
+	depth, propDepth := 0, -1
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if propDepth < 0 && t.Name.Space == "DAV:" && t.Name.Local == "prop" {
+				propDepth = depth
+			} else if propDepth > 0 && depth == propDepth+1 {
+				names = append(names, anyProp{XMLName: t.Name})
+			}
+		case xml.EndElement:
+			if depth == propDepth {
+				propDepth = -1
+			}
+			depth--
+		}
+	}
+}
+
+func writeXML(w http.ResponseWriter, status int, v interface{}) {
+	buf, err := xml.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	w.Write(buf)
+}
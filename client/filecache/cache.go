@@ -0,0 +1,111 @@
+// Package filecache keep downloaded files in a local directory limited by total size
+package filecache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache keep downloaded files by key, least recently read files are evicted when total
+// size exceed maxSize. Key should change with content, such as file hash, so entry never stale
+type Cache struct {
+	dir     string
+	maxSize int64
+	mutex   sync.Mutex
+	loading map[string]*loadCall
+}
+
+type loadCall struct {
+	done chan struct{}
+	err  error
+}
+
+// New create cache in dir
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir, maxSize: maxSize, loading: make(map[string]*loadCall)}, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+// Get return local file of key, load is called to create file dest if missed,
+// concurrent reads of the same key wait the first one
+func (c *Cache) Get(key string, load func(dest string) error) (string, error) {
+	p := c.path(key)
+	c.mutex.Lock()
+	if call, ok := c.loading[key]; ok {
+		c.mutex.Unlock()
+		<-call.done
+		if call.err != nil {
+			return "", call.err
+		}
+		return p, nil
+	}
+	if _, err := os.Stat(p); err == nil {
+		c.mutex.Unlock()
+		now := time.Now()
+		os.Chtimes(p, now, now)
+		return p, nil
+	}
+	call := &loadCall{done: make(chan struct{})}
+	c.loading[key] = call
+	c.mutex.Unlock()
+
+	call.err = load(p)
+	c.mutex.Lock()
+	delete(c.loading, key)
+	c.mutex.Unlock()
+	close(call.done)
+	if call.err != nil {
+		os.Remove(p)
+		return "", call.err
+	}
+	c.evict(key)
+	return p, nil
+}
+
+// Put move the file into cache as key
+func (c *Cache) Put(key string, fileName string) error {
+	if err := os.Rename(fileName, c.path(key)); err != nil {
+		return err
+	}
+	c.evict(key)
+	return nil
+}
+
+// evict remove least recently read files until total size under limit, keep is never removed
+func (c *Cache) evict(keep string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	files, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	var total int64
+	for _, f := range files {
+		total += f.Size()
+	}
+	if total <= c.maxSize {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime().Before(files[j].ModTime()) })
+	for _, f := range files {
+		if total <= c.maxSize {
+			return
+		}
+		if f.Name() == keep || c.loading[f.Name()] != nil {
+			continue
+		}
+		if os.Remove(filepath.Join(c.dir, f.Name())) == nil {
+			total -= f.Size()
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package filecache
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dav-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cache, err := New(dir, 10)
+	require.NoError(t, err)
+
+	var loads int32
+	load := func(data string) func(string) error {
+		return func(dest string) error {
+			atomic.AddInt32(&loads, 1)
+			time.Sleep(time.Millisecond * 10)
+			return ioutil.WriteFile(dest, []byte(data), 0600)
+		}
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := cache.Get("a", load("aaaa"))
+			require.NoError(t, err)
+			buf, err := ioutil.ReadFile(p)
+			require.NoError(t, err)
+			require.Equal(t, "aaaa", string(buf))
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), loads)
+
+	_, err = cache.Get("b", func(dest string) error { return errors.New("download failed") })
+	require.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, "b"))
+	require.True(t, os.IsNotExist(err))
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "a"), old, old))
+	_, err = cache.Get("c", load("cccc"))
+	require.NoError(t, err)
+	src := filepath.Join(dir, "..", filepath.Base(dir)+"-d")
+	require.NoError(t, ioutil.WriteFile(src, []byte("dddd"), 0600))
+	require.NoError(t, cache.Put("d", src))
+	// a is least recently read and evicted to keep total size under 10
+	_, err = os.Stat(filepath.Join(dir, "a"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "c"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "d"))
+	require.NoError(t, err)
+}
@@ -7,22 +7,30 @@ import (
 	"time"
 
 	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/client/davservice"
+	"github.com/samoslab/nebula/client/s3service"
 	"github.com/samoslab/nebula/client/service"
 	"github.com/samoslab/nebula/client/wsservice"
 	"github.com/samoslab/nebula/util/apputil"
 	"github.com/samoslab/nebula/util/browser"
 	"github.com/samoslab/nebula/util/file"
+	"github.com/samoslab/nebula/util/filecheck"
 	"github.com/samoslab/nebula/util/logger"
 	"github.com/spf13/pflag"
 )
 
 func main() {
+	// started as metadata worker by daemon
+	filecheck.ServeWorker()
+
 	configFile := pflag.StringP("conf", "c", "config.json", "config file")
 	serverAddr := pflag.StringP("server", "s", "127.0.0.1:7788", "listen address ip:port")
 	wsAddr := pflag.StringP("wsaddr", "w", "127.0.0.1:7799", "websocket listen address ip:port")
 	collectAddr := pflag.StringP("collect", "", "", "collect server format is ip:port")
-	trackerAddr := pflag.StringP("tracker", "", "", "tracker server format is ip:port")
+	trackerAddr := pflag.StringP("tracker", "", "", "tracker server format is ip:port, multiple servers are separated by comma")
 	webDir := pflag.StringP("webdir", "d", "./web/build", "web static directory")
+	s3Addr := pflag.StringP("s3addr", "", "", "s3 gateway listen address ip:port, access keys are set in config file")
+	davAddr := pflag.StringP("davaddr", "", "", "webdav listen address ip:port")
 	launchBrowser := pflag.BoolP("launch-browser", "l", false, "launch system default webbrowser at client startup")
 	pflag.Parse()
 	defaultAppDir, _ := config.GetConfigFile()
@@ -60,6 +68,12 @@ func main() {
 	if *trackerAddr != "" {
 		webcfg.TrackerServer = *trackerAddr
 	}
+	if *s3Addr != "" {
+		webcfg.S3Addr = *s3Addr
+	}
+	if *davAddr != "" {
+		webcfg.DavAddr = *davAddr
+	}
 
 	quit := make(chan struct{})
 	go apputil.CatchInterrupt(quit)
@@ -86,6 +100,34 @@ func main() {
 		}
 	}()
 
+	var gateway *s3service.Gateway
+	if webcfg.S3Addr != "" {
+		gateway, err = s3service.NewGateway(log, *webcfg, server.GetClientManager())
+		if err != nil {
+			log.Errorf("s3 gateway disabled, %v", err)
+		} else {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := gateway.Run(webcfg.S3Addr); err != nil {
+					log.Errorf("s3 gateway run failed, %v", err)
+				}
+			}()
+		}
+	}
+
+	var dav *davservice.Server
+	if webcfg.DavAddr != "" {
+		dav = davservice.NewServer(log, *webcfg, server.GetClientManager())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := dav.Run(webcfg.DavAddr); err != nil {
+				log.Errorf("webdav run failed, %v", err)
+			}
+		}()
+	}
+
 	if *launchBrowser {
 		wg.Add(1)
 		go func() {
@@ -109,5 +151,11 @@ func main() {
 	}
 	server.Shutdown()
 	ws.Shutdown()
+	if gateway != nil {
+		gateway.Shutdown()
+	}
+	if dav != nil {
+		dav.Shutdown()
+	}
 	wg.Wait()
 }
@@ -10,15 +10,17 @@ import (
 
 // ProgressCell for progress bar
 type ProgressCell struct {
-	Sended     bool    `json:"-"`
-	Type       string  `json:"type"`
-	Total      uint64  `json:"-"`
-	Current    uint64  `json:"-"`
-	Time       uint64  `json:"-"`
-	Rate       float64 `json:"rate"`
-	Local      string  `json:"local"`
-	LastReaded bool    `json:"-"`
-	SpaceNo    int     `json:"spaco_no"`
+	Sended      bool    `json:"-"`
+	Type        string  `json:"type"`
+	Total       uint64  `json:"-"`
+	Current     uint64  `json:"-"`
+	Time        uint64  `json:"-"`
+	Rate        float64 `json:"rate"`
+	Local       string  `json:"local"`
+	LastReaded  bool    `json:"-"`
+	SpaceNo     int     `json:"spaco_no"`
+	LogicalSize uint64  `json:"logical_size,omitempty"` // size of file content
+	StoredSize  uint64  `json:"stored_size,omitempty"`  // size of file stored, less than logical size if file is compressed
 }
 
 func calRate(current, total uint64) float64 {
@@ -56,7 +58,23 @@ func NewProgressManager() *ProgressManager {
 func (pm *ProgressManager) SetProgress(tp, fileName string, currentSize, totalSize uint64, sno uint32, local string) {
 	pm.Mutex.Lock()
 	defer pm.Mutex.Unlock()
-	pm.Progress[fileName] = ProgressCell{Type: tp, Total: totalSize, Current: currentSize, Rate: calRate(currentSize, totalSize), Time: common.Now(), SpaceNo: int(sno), Local: local}
+	cell := ProgressCell{Type: tp, Total: totalSize, Current: currentSize, Rate: calRate(currentSize, totalSize), Time: common.Now(), SpaceNo: int(sno), Local: local}
+	if old, ok := pm.Progress[fileName]; ok {
+		cell.LogicalSize, cell.StoredSize = old.LogicalSize, old.StoredSize
+	}
+	pm.Progress[fileName] = cell
+}
+
+// SetSizes set logical size and stored size of file, they are kept when progress is set
+func (pm *ProgressManager) SetSizes(fileName string, logicalSize, storedSize uint64) {
+	pm.Mutex.Lock()
+	defer pm.Mutex.Unlock()
+	cell, ok := pm.Progress[fileName]
+	if !ok {
+		cell = ProgressCell{Time: common.Now()}
+	}
+	cell.LogicalSize, cell.StoredSize = logicalSize, storedSize
+	pm.Progress[fileName] = cell
 }
 
 // SetPartitionMap set progress file map
@@ -66,6 +84,15 @@ func (pm *ProgressManager) SetPartitionMap(fileName, originFile string) {
 	pm.PartitionToOriginMap[fileName] = originFile
 }
 
+// SetPartitionMapIfAbsent set progress file map unless the partition is mapped already
+func (pm *ProgressManager) SetPartitionMapIfAbsent(fileName, originFile string) {
+	pm.Mutex.Lock()
+	defer pm.Mutex.Unlock()
+	if _, ok := pm.PartitionToOriginMap[fileName]; !ok {
+		pm.PartitionToOriginMap[fileName] = originFile
+	}
+}
+
 // SetIncrement set increment
 func (pm *ProgressManager) SetIncrement(fileName string, increment uint64) error {
 	pm.Mutex.Lock()
@@ -123,6 +150,10 @@ func (pm *ProgressManager) GetProgressingMsg(files []string) ([]string, error) {
 		if !match(mp, k) {
 			continue
 		}
+		// only sizes are known, task is not started
+		if v.Type == "" {
+			continue
+		}
 		// skip already sended
 		if !v.Sended && !v.LastReaded {
 			msg := common.MakeSuccProgressMsg(v.Type, k, v.Rate, v.SpaceNo, v.Local)
@@ -0,0 +1,25 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSizes(t *testing.T) {
+	pm := NewProgressManager()
+	pm.SetSizes("a.txt", 1000, 400)
+	msgs, err := pm.GetProgressingMsg(nil)
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+
+	pm.SetProgress("upload", "a.txt", 100, 400, 0, "/tmp/a.txt")
+	pm.SetIncrement("a.txt", 100)
+	cell := pm.Progress["a.txt"]
+	require.Equal(t, uint64(1000), cell.LogicalSize)
+	require.Equal(t, uint64(400), cell.StoredSize)
+	require.Equal(t, 0.5, cell.Rate)
+
+	pm.SetProgress("upload", "b.txt", 0, 400, 0, "/tmp/b.txt")
+	require.Zero(t, pm.Progress["b.txt"].LogicalSize)
+}
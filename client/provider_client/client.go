@@ -290,3 +290,25 @@ func Retrieve(log logrus.FieldLogger, client pb.ProviderServiceClient, filePath
 	al.Success, al.EndTime, al.TransportSize = true, now(), uint64(fileSize)
 	return nil
 }
+
+// Prove challenge provider to prove that it keeps the block, auth and ticket are the retrieve auth of the block
+func Prove(client pb.ProviderServiceClient, auth []byte, ticket string, tm uint64, fileKey, blockKey []byte, fileSize, blockSize uint64, chunkSize uint32, chunkSeq map[uint32][]byte, schemeVersion uint32) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	resp, err := client.Prove(ctx, &pb.ProveReq{
+		Timestamp:     tm,
+		Auth:          auth,
+		Ticket:        ticket,
+		FileKey:       fileKey,
+		FileSize:      fileSize,
+		BlockKey:      blockKey,
+		BlockSize:     blockSize,
+		ChunkSize:     chunkSize,
+		ChunkSeq:      chunkSeq,
+		SchemeVersion: schemeVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
@@ -15,8 +15,8 @@ import (
 	"github.com/samoslab/nebula/provider/node"
 	pb "github.com/samoslab/nebula/tracker/register/client/pb"
 	regpb "github.com/samoslab/nebula/tracker/register/client/pb"
-	"github.com/samoslab/nebula/util/aes"
 	rsalong "github.com/samoslab/nebula/util/rsa"
+	"github.com/samoslab/nebula/util/trackerpool"
 	"github.com/sirupsen/logrus"
 )
 
@@ -114,33 +114,36 @@ func resendVerifyCode(client pb.ClientRegisterServiceClient, node *node.Node) (s
 	return rsp.Success, nil
 }
 
-// RegisterClient register client info to tracker
-func RegisterClient(log logrus.FieldLogger, configFile, trackerServer, emailAddress string) error {
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+// RegisterClient register client info to tracker, key of a new config is derived from recovery phrase
+// if phrase is not empty
+func RegisterClient(log logrus.FieldLogger, configFile, trackerServer, emailAddress, phrase string) error {
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		log.Fatalf("Rpc dial failed: %s", err.Error())
 		return err
 	}
-	defer conn.Close()
+	defer trackers.Close()
 
-	registerClient := regpb.NewClientRegisterServiceClient(conn)
+	registerClient := regpb.NewClientRegisterServiceClient(trackers.Conn())
 
 	cc, err := config.LoadConfig(configFile)
-	if err != nil {
+	if err == config.ErrNoConf {
 		log.Errorf("Load config error %v", err)
 		log.Errorf("Generate config")
-		no := node.NewNode(10)
-		cc = &config.ClientConfig{
-			PublicKey:  no.PublicKeyStr(),
-			PrivateKey: no.PrivateKeyStr(),
-			NodeId:     no.NodeIdStr(),
-			Email:      emailAddress,
-			Node:       no,
-			Space: []config.ReadableSpace{
-				config.ReadableSpace{SpaceNo: 0, Password: aes.RandStr(16), Home: "default", Name: "default"},
-				config.ReadableSpace{SpaceNo: 1, Password: "", Home: "private1", Name: "privacy space"},
-			},
+		var no *node.Node
+		if phrase != "" {
+			if no, err = config.NodeFromRecoveryPhrase(phrase); err != nil {
+				return err
+			}
+		} else {
+			no = node.NewNode(10)
 		}
+		cc = config.NewClientConfig(no, emailAddress)
+	} else if err != nil {
+		log.Errorf("Load config error %v", err)
+		return err
+	} else if phrase != "" {
+		return fmt.Errorf("config exists, recovery phrase is only for new config")
 	}
 
 	_, err = DoRegister(registerClient, cc)
@@ -176,13 +179,13 @@ func VerifyEmail(configFile string, trackerServer string, verifyCode string) err
 		fmt.Println("failed to load config, can not verify email: " + err.Error())
 		return err
 	}
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("RPC Dial failed: %s\n", err.Error())
 		return err
 	}
-	defer conn.Close()
-	registerClient := regpb.NewClientRegisterServiceClient(conn)
+	defer trackers.Close()
+	registerClient := regpb.NewClientRegisterServiceClient(trackers.Conn())
 	err = VerifyContactEmail(registerClient, verifyCode, cc.Node)
 	if err != nil {
 		fmt.Printf("verifyEmail failed: %s\n", err.Error())
@@ -206,13 +209,13 @@ func ResendVerifyCode(configFile string, trackerServer string) error {
 		fmt.Println("failed to load config, can not resend verify code email: " + err.Error())
 		return err
 	}
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("RPC Dial failed: %s\n", err.Error())
 		return err
 	}
-	defer conn.Close()
-	crsc := regpb.NewClientRegisterServiceClient(conn)
+	defer trackers.Close()
+	crsc := regpb.NewClientRegisterServiceClient(trackers.Conn())
 	success, err := resendVerifyCode(crsc, cc.Node)
 	if err != nil {
 		fmt.Printf("resendVerifyCode failed: %s\n", err.Error())
@@ -227,13 +230,19 @@ func ResendVerifyCode(configFile string, trackerServer string) error {
 	return nil
 }
 
+// GetPublicKey get public key of tracker
 func GetPublicKey(trackerServer string) (*rsa.PublicKey, []byte, error) {
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("Rpc dial failed: %s\n", err.Error())
 		return nil, nil, err
 	}
-	defer conn.Close()
+	defer trackers.Close()
+	return GetPublicKeyByConn(trackers.Conn())
+}
+
+// GetPublicKeyByConn get public key of tracker by connection
+func GetPublicKeyByConn(conn *grpc.ClientConn) (*rsa.PublicKey, []byte, error) {
 	registClient := regpb.NewClientRegisterServiceClient(conn)
 	pubkey, pubkeyHash, err := doGetPubkey(registClient)
 	if err != nil {
@@ -246,3 +255,24 @@ func GetPublicKey(trackerServer string) (*rsa.PublicKey, []byte, error) {
 
 	return rsaPubkey, pubkeyHash, err
 }
+
+// GetTrackerServer get addresses of all trackers
+func GetTrackerServer(ctx context.Context, conn *grpc.ClientConn, no *node.Node) ([]string, error) {
+	req := &regpb.GetTrackerServerReq{
+		Version:   common.Version,
+		NodeId:    no.NodeId,
+		Timestamp: common.Now(),
+	}
+	if err := req.SignReq(no.PriKey); err != nil {
+		return nil, err
+	}
+	rsp, err := regpb.NewClientRegisterServiceClient(conn).GetTrackerServer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	servers := make([]string, 0, len(rsp.GetServer()))
+	for _, s := range rsp.GetServer() {
+		servers = append(servers, fmt.Sprintf("%s:%d", s.GetServer(), s.GetPort()))
+	}
+	return servers, nil
+}
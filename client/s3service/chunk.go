@@ -0,0 +1,112 @@
+package s3service
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/samoslab/nebula/client/daemon"
+)
+
+// chunkReader read remote file chunk by chunk for http.ServeContent, the chunk being read is
+// downloaded into dir when needed and the one before is removed, so only chunks covering the
+// requested range are downloaded and one chunk at most is kept on disk
+type chunkReader struct {
+	chunks   []daemon.Chunk
+	download func(index int, dest string) error
+	dir      string
+	size     int64
+	pos      int64
+	index    int
+	file     *os.File
+	err      error // download error, ServeContent does not return it
+}
+
+func newChunkReader(chunks []daemon.Chunk, dir string, download func(index int, dest string) error) *chunkReader {
+	r := &chunkReader{chunks: chunks, download: download, dir: dir, index: -1}
+	if len(chunks) > 0 {
+		last := chunks[len(chunks)-1]
+		r.size = last.Offset + last.Size
+	}
+	return r
+}
+
+func (r *chunkReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.pos = offset
+	return offset, nil
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+	if r.file == nil || !r.inChunk(r.index, r.pos) {
+		if err := r.load(); err != nil {
+			r.err = err
+			return 0, err
+		}
+	}
+	chunk := r.chunks[r.index]
+	if remain := chunk.Offset + chunk.Size - r.pos; int64(len(p)) > remain {
+		p = p[:remain]
+	}
+	n, err := r.file.ReadAt(p, r.pos-chunk.Offset)
+	r.pos += int64(n)
+	if err == io.EOF && n == len(p) {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *chunkReader) inChunk(index int, pos int64) bool {
+	return pos >= r.chunks[index].Offset && pos < r.chunks[index].Offset+r.chunks[index].Size
+}
+
+// load download the chunk at current position
+func (r *chunkReader) load() error {
+	r.closeChunk()
+	for i := range r.chunks {
+		if !r.inChunk(i, r.pos) {
+			continue
+		}
+		dest := filepath.Join(r.dir, strconv.Itoa(i))
+		if err := r.download(i, dest); err != nil {
+			return err
+		}
+		file, err := os.Open(dest)
+		if err != nil {
+			return err
+		}
+		r.file, r.index = file, i
+		return nil
+	}
+	return io.ErrUnexpectedEOF
+}
+
+func (r *chunkReader) closeChunk() {
+	if r.file != nil {
+		r.file.Close()
+		os.Remove(r.file.Name())
+		r.file, r.index = nil, -1
+	}
+}
+
+// Close remove the chunk downloaded
+func (r *chunkReader) Close() error {
+	r.closeChunk()
+	return nil
+}
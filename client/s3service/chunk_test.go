@@ -0,0 +1,64 @@
+package s3service
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "s3-chunk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	content := bytes.Repeat([]byte("0123456789"), 3)
+	chunks := []daemon.Chunk{{Offset: 0, Size: 10}, {Offset: 10, Size: 10}, {Offset: 20, Size: 10}}
+	downloaded := []int{}
+	download := func(index int, dest string) error {
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, files, "chunk read before should be removed")
+		downloaded = append(downloaded, index)
+		c := chunks[index]
+		return ioutil.WriteFile(dest, content[c.Offset:c.Offset+c.Size], 0600)
+	}
+
+	serve := func(rangeHeader string) *httptest.ResponseRecorder {
+		r := newChunkReader(chunks, dir, download)
+		defer r.Close()
+		req := httptest.NewRequest(http.MethodGet, "/bucket/key", nil)
+		if rangeHeader != "" {
+			req.Header.Set("Range", rangeHeader)
+		}
+		w := httptest.NewRecorder()
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, req, "key", time.Now(), r)
+		require.NoError(t, r.err)
+		return w
+	}
+
+	w := serve("bytes=12-15")
+	require.Equal(t, http.StatusPartialContent, w.Code)
+	require.Equal(t, "2345", w.Body.String())
+	require.Equal(t, []int{1}, downloaded)
+
+	downloaded = downloaded[:0]
+	w = serve("bytes=8-21")
+	require.Equal(t, string(content[8:22]), w.Body.String())
+	require.Equal(t, []int{0, 1, 2}, downloaded)
+
+	downloaded = downloaded[:0]
+	w = serve("")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, content, w.Body.Bytes())
+	require.Equal(t, []int{0, 1, 2}, downloaded)
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
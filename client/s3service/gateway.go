@@ -0,0 +1,543 @@
+// Package s3service serves a subset of the S3 API in front of a nebula space.
+// Buckets are the top-level folders of the space and object keys are the paths under them.
+// Upload of nebula hashes and erasure codes whole files, so object data of put object and upload
+// part is spooled to a file in the temporary directory of the client before it is uploaded, it is
+// not streamed to the space. Objects larger than the configured max object size are rejected with
+// EntityTooLarge, before spooling if the request declares its length. Get object is sent chunk by chunk of the
+// remote file, only chunks covering the requested range are downloaded and one is kept at a time;
+// a compressed file, and an erasure coded file of privacy space, is a single chunk, and a file
+// packed in an archive is downloaded whole, those are still spooled whole.
+package s3service
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/client/daemon"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	shutdownTimeout = time.Second * 5
+	// upload and download of large object take long, so only header reading is limited
+	readHeaderTimeout = time.Second * 30
+	idleTimeout       = time.Second * 120
+
+	spoolDirName = "nebula-s3"
+
+	// DefaultMaxObjectSize max size of object if not configured, it is the limit of single put of s3
+	DefaultMaxObjectSize = int64(5) << 30
+)
+
+// Gateway is the s3 http listener
+type Gateway struct {
+	log      logrus.FieldLogger
+	cm       **daemon.ClientManager
+	sno      uint32
+	verifier *verifier
+	server   *http.Server
+	uploads  *uploadRegistry
+	// maxObjectSize max bytes of object spooled, for multipart upload it is the sum of parts
+	maxObjectSize int64
+}
+
+// NewGateway create s3 gateway of the space configured, cm is shared with http service
+// because it is created after register
+func NewGateway(log logrus.FieldLogger, cfg config.Config, cm **daemon.ClientManager) (*Gateway, error) {
+	if len(cfg.S3AccessKeys) == 0 {
+		return nil, errors.New("no s3 access key configured")
+	}
+	keys := make(map[string]string, len(cfg.S3AccessKeys))
+	for _, k := range cfg.S3AccessKeys {
+		keys[k.AccessKey] = k.SecretKey
+	}
+	maxObjectSize := cfg.S3MaxObjectSize
+	if maxObjectSize <= 0 {
+		maxObjectSize = DefaultMaxObjectSize
+	}
+	return &Gateway{
+		log:           log.WithField("prefix", "s3"),
+		cm:            cm,
+		sno:           cfg.S3Space,
+		verifier:      newVerifier(keys),
+		uploads:       newUploadRegistry(),
+		maxObjectSize: maxObjectSize,
+	}, nil
+}
+
+// Run listen on addr and serve until Shutdown
+func (g *Gateway) Run(addr string) error {
+	g.server = &http.Server{
+		Addr:              addr,
+		Handler:           g,
+		ReadHeaderTimeout: readHeaderTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+	// spool files left by last run are useless, unfinished multipart uploads are lost on restart
+	if cm, err := g.clientManager(); err == nil {
+		os.RemoveAll(filepath.Join(cm.TempDir, spoolDirName))
+	}
+	g.log.Infof("s3 gateway listen on %s, space %d", addr, g.sno)
+	if err := g.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stop the listener
+func (g *Gateway) Shutdown() {
+	if g.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := g.server.Shutdown(ctx); err != nil {
+		g.log.Errorf("s3 gateway shutdown error %v", err)
+	}
+}
+
+func (g *Gateway) clientManager() (*daemon.ClientManager, error) {
+	if g.cm == nil || *g.cm == nil {
+		return nil, errNotRegistered
+	}
+	return *g.cm, nil
+}
+
+// ServeHTTP route path style request: / is service, /bucket is bucket, /bucket/key is object
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := g.log.WithField("path", r.URL.Path)
+	_, payloadHash, err := g.verifier.verify(r)
+	if err != nil {
+		log.Infof("%s reject, %v", r.Method, err)
+		writeError(w, r, err)
+		return
+	}
+	cm, err := g.clientManager()
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	bucketName, key := splitBucketKey(r.URL.Path)
+	req := &request{g: g, cm: cm, w: w, r: r, bucket: bucketName, key: key, payloadHash: payloadHash}
+	if err = req.serve(); err != nil {
+		log.Errorf("%s %s failed, %v", r.Method, r.URL.RawQuery, err)
+		writeError(w, r, err)
+	}
+}
+
+// splitBucketKey split path /bucket/key/of/object into bucket and key
+func splitBucketKey(p string) (bucketName, key string) {
+	p = strings.TrimPrefix(p, "/")
+	if i := strings.Index(p, "/"); i >= 0 {
+		return p[:i], p[i+1:]
+	}
+	return p, ""
+}
+
+// validKey return false if the key can not map to nebula path
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "//") {
+		return false
+	}
+	for _, seg := range strings.Split(strings.TrimSuffix(key, "/"), "/") {
+		if seg == "." || seg == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// request hold the state of one s3 request
+type request struct {
+	g           *Gateway
+	cm          *daemon.ClientManager
+	w           http.ResponseWriter
+	r           *http.Request
+	bucket      string
+	key         string
+	payloadHash string
+}
+
+func (req *request) remotePath(key string) string {
+	return "/" + req.bucket + "/" + strings.TrimSuffix(key, "/")
+}
+
+func (req *request) serve() error {
+	query := req.r.URL.Query()
+	if req.bucket == "" {
+		if req.r.Method != http.MethodGet {
+			return errMethodNotAllowed
+		}
+		return req.listBuckets()
+	}
+	if req.key == "" {
+		switch req.r.Method {
+		case http.MethodGet:
+			if query.Get("list-type") != "2" {
+				return errNotImplemented
+			}
+			return req.listObjectsV2()
+		case http.MethodHead:
+			_, err := req.findBucket()
+			return err
+		case http.MethodPut:
+			return req.createBucket()
+		default:
+			return errMethodNotAllowed
+		}
+	}
+	if !validKey(req.key) {
+		return errInvalidKey
+	}
+	if _, err := req.findBucket(); err != nil {
+		return err
+	}
+	_, hasUploads := query["uploads"]
+	uploadID := query.Get("uploadId")
+	switch req.r.Method {
+	case http.MethodGet:
+		return req.getObject()
+	case http.MethodHead:
+		return req.headObject()
+	case http.MethodPut:
+		if uploadID != "" {
+			return req.uploadPart(uploadID, query.Get("partNumber"))
+		}
+		if req.r.Header.Get("X-Amz-Copy-Source") != "" {
+			return req.copyObject()
+		}
+		return req.putObject()
+	case http.MethodDelete:
+		if uploadID != "" {
+			return req.abortMultipartUpload(uploadID)
+		}
+		return req.deleteObject()
+	case http.MethodPost:
+		if hasUploads {
+			return req.createMultipartUpload()
+		}
+		if uploadID != "" {
+			return req.completeMultipartUpload(uploadID)
+		}
+		return errNotImplemented
+	default:
+		return errMethodNotAllowed
+	}
+}
+
+// lookup find the file or folder by path, notFound is returned if it or its parent not exists
+func (req *request) lookup(p string, notFound error) (*daemon.DownFile, error) {
+	f, err := req.cm.StatFile(p, req.g.sno)
+	if err == daemon.ErrFileNotExist {
+		return nil, notFound
+	}
+	return f, err
+}
+
+func (req *request) findBucket() (*daemon.DownFile, error) {
+	f, err := req.lookup("/"+req.bucket, errNoSuchBucket)
+	if err != nil {
+		return nil, err
+	}
+	if !f.Folder {
+		return nil, errNoSuchBucket
+	}
+	return f, nil
+}
+
+// findObject return the file of the key, folders are not objects
+func (req *request) findObject() (*daemon.DownFile, error) {
+	f, err := req.lookup(req.remotePath(req.key), errNoSuchKey)
+	if err != nil {
+		return nil, err
+	}
+	if f.Folder {
+		return nil, errNoSuchKey
+	}
+	return f, nil
+}
+
+func checkContentMD5(contentMD5 string, md5sum []byte) bool {
+	expected, err := base64.StdEncoding.DecodeString(contentMD5)
+	return err == nil && bytes.Equal(expected, md5sum)
+}
+
+func etag(hash string) string {
+	return `"` + hash + `"`
+}
+
+func modTime(f *daemon.DownFile) time.Time {
+	return time.Unix(int64(f.ModTime), 0)
+}
+
+func (req *request) listBuckets() error {
+	files, err := req.cm.ListAllFiles("/", req.g.sno)
+	if err != nil {
+		return err
+	}
+	res := &listAllMyBucketsResult{Xmlns: s3Namespace, Buckets: make([]bucket, 0, len(files))}
+	for _, f := range files {
+		if f.Folder {
+			res.Buckets = append(res.Buckets, bucket{Name: f.FileName, CreationDate: formatTime(modTime(f))})
+		}
+	}
+	writeXML(req.w, http.StatusOK, res)
+	return nil
+}
+
+func (req *request) createBucket() error {
+	if !validKey(req.bucket) {
+		return errInvalidArgument
+	}
+	if _, err := req.cm.MkFolder("/", []string{req.bucket}, false, req.g.sno); err != nil {
+		return err
+	}
+	req.w.Header().Set("Location", "/"+req.bucket)
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// mkdirAll create folders of the path level by level, existing folder is not an error
+func (req *request) mkdirAll(dir string) error {
+	parent := "/"
+	for _, name := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		if _, err := req.cm.MkFolder(parent, []string{name}, false, req.g.sno); err != nil {
+			return err
+		}
+		parent = path.Join(parent, name)
+	}
+	return nil
+}
+
+func randomID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// spoolDir create a directory for object data in temporary directory of client
+func (req *request) spoolDir() (string, error) {
+	id, err := randomID()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(req.cm.TempDir, spoolDirName, id)
+	return dir, os.MkdirAll(dir, 0700)
+}
+
+// spool write request body to file, the md5 of data is returned, sha256 is checked if payload signed,
+// errEntityTooLarge is returned if body is larger than limit
+func (req *request) spool(fileName string, limit int64) (md5sum []byte, size int64, err error) {
+	if req.r.ContentLength > limit {
+		return nil, 0, errEntityTooLarge
+	}
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	md5Hash := md5.New()
+	var shaHash hash.Hash
+	writers := []io.Writer{f, md5Hash}
+	if req.payloadHash != "" {
+		shaHash = sha256.New()
+		writers = append(writers, shaHash)
+	}
+	size, err = io.Copy(io.MultiWriter(writers...), io.LimitReader(req.r.Body, limit+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	if size > limit {
+		return nil, 0, errEntityTooLarge
+	}
+	if shaHash != nil && hex.EncodeToString(shaHash.Sum(nil)) != req.payloadHash {
+		return nil, 0, errContentSHA256
+	}
+	md5sum = md5Hash.Sum(nil)
+	if contentMD5 := req.r.Header.Get("Content-Md5"); contentMD5 != "" && !checkContentMD5(contentMD5, md5sum) {
+		return nil, 0, errBadDigest
+	}
+	return md5sum, size, f.Sync()
+}
+
+// upload the spooled file to the folder of key, encrypted if it is privacy space, the uploaded file is
+// returned so ETag of put is the file hash which head, get and list return too
+func (req *request) upload(fileName string, key string) (*daemon.DownFile, error) {
+	dest := path.Dir(req.remotePath(key))
+	if err := req.mkdirAll(dest); err != nil {
+		return nil, err
+	}
+	if err := req.cm.UploadFile(fileName, dest, false, true, req.g.sno > 0, false, req.g.sno); err != nil {
+		return nil, err
+	}
+	return req.lookup(req.remotePath(key), errNoSuchKey)
+}
+
+func (req *request) putObject() error {
+	if strings.HasSuffix(req.key, "/") {
+		// folder marker object
+		if req.r.ContentLength > 0 {
+			return errInvalidKey
+		}
+		if err := req.mkdirAll(req.remotePath(req.key)); err != nil {
+			return err
+		}
+		req.w.Header().Set("ETag", etag(hex.EncodeToString(md5.New().Sum(nil))))
+		req.w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	dir, err := req.spoolDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, path.Base(req.key))
+	if _, _, err = req.spool(fileName, req.g.maxObjectSize); err != nil {
+		return err
+	}
+	f, err := req.upload(fileName, req.key)
+	if err != nil {
+		return err
+	}
+	req.w.Header().Set("ETag", etag(f.FileHash))
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (req *request) headObject() error {
+	f, err := req.findObject()
+	if err != nil {
+		return err
+	}
+	h := req.w.Header()
+	h.Set("ETag", etag(f.FileHash))
+	h.Set("Last-Modified", modTime(f).UTC().Format(http.TimeFormat))
+	h.Set("Content-Length", fmt.Sprintf("%d", f.Size()))
+	h.Set("Accept-Ranges", "bytes")
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (req *request) getObject() error {
+	f, err := req.findObject()
+	if err != nil {
+		return err
+	}
+	dir, err := req.spoolDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if mime.TypeByExtension(path.Ext(req.key)) == "" {
+		// ServeContent sniff the type from content otherwise, which downloads the first chunk for any range
+		req.w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	if f.Packed {
+		// small file read from its archive, it is downloaded whole
+		if err = req.cm.DownloadFile(req.remotePath(req.key), dir, f.FileHash, f.FileSize, req.g.sno); err != nil {
+			return err
+		}
+		file, err := os.Open(filepath.Join(dir, path.Base(req.key)))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		req.w.Header().Set("ETag", etag(f.FileHash))
+		http.ServeContent(req.w, req.r, path.Base(req.key), modTime(f), file)
+		return nil
+	}
+	rf, err := req.cm.OpenRemoteFile(f.FileHash, f.FileSize, req.g.sno)
+	if err != nil {
+		return err
+	}
+	r := newChunkReader(rf.Chunks, dir, func(index int, dest string) error {
+		return req.cm.DownloadChunk(rf, index, dest)
+	})
+	defer r.Close()
+	req.w.Header().Set("ETag", etag(f.FileHash))
+	// ServeContent handle Range and conditional headers
+	http.ServeContent(req.w, req.r, path.Base(req.key), modTime(f), r)
+	if r.err != nil {
+		// response is sent partly, client sees the short body
+		req.g.log.WithError(r.err).Warnf("Send object %s/%s failed", req.bucket, req.key)
+	}
+	return nil
+}
+
+// deleteObject remove the file, deleting key not exists is success like s3
+func (req *request) deleteObject() error {
+	if _, err := req.findObject(); err != nil {
+		if err == errNoSuchKey {
+			req.w.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+		return err
+	}
+	if err := req.cm.RemoveFile(req.remotePath(req.key), false, true, req.g.sno); err != nil {
+		return err
+	}
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// copyObject move the source object to the key. Nebula has no copy of file, so the source is
+// moved, which is what s3 clients do for rename: copy then delete the source
+func (req *request) copyObject() error {
+	src, err := parseCopySource(req.r.Header.Get("X-Amz-Copy-Source"))
+	if err != nil {
+		return err
+	}
+	srcBucket, srcKey := splitBucketKey(src)
+	if srcBucket == "" || !validKey(srcKey) || strings.HasSuffix(srcKey, "/") {
+		return errInvalidArgument
+	}
+	srcReq := &request{g: req.g, cm: req.cm, bucket: srcBucket, key: srcKey}
+	f, err := srcReq.findObject()
+	if err != nil {
+		return err
+	}
+	dest := req.remotePath(req.key)
+	if err = req.mkdirAll(path.Dir(dest)); err != nil {
+		return err
+	}
+	if err = req.cm.MoveFile(srcReq.remotePath(srcKey), dest, true, req.g.sno); err != nil {
+		return err
+	}
+	writeXML(req.w, http.StatusOK, &copyObjectResult{LastModified: formatTime(modTime(f)), ETag: etag(f.FileHash)})
+	return nil
+}
+
+func parseCopySource(src string) (string, error) {
+	if i := strings.Index(src, "?"); i >= 0 {
+		// version of source is not supported
+		return "", errNotImplemented
+	}
+	p, err := url.PathUnescape(src)
+	if err != nil {
+		return "", errInvalidArgument
+	}
+	return strings.TrimPrefix(p, "/"), nil
+}
@@ -0,0 +1,43 @@
+package s3service
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpoolLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "s3-spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "object")
+	body := bytes.Repeat([]byte("a"), 100)
+
+	req := &request{r: httptest.NewRequest("PUT", "/bucket/object", bytes.NewReader(body))}
+	_, size, err := req.spool(fileName, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), size)
+
+	// declared length is rejected before reading body
+	req = &request{r: httptest.NewRequest("PUT", "/bucket/object", bytes.NewReader(body))}
+	_, _, err = req.spool(fileName, 99)
+	require.Equal(t, errEntityTooLarge, err)
+
+	// body of unknown length is limited while spooled
+	r := httptest.NewRequest("PUT", "/bucket/object", bytes.NewReader(body))
+	r.ContentLength = -1
+	req = &request{r: r}
+	_, _, err = req.spool(fileName, 99)
+	require.Equal(t, errEntityTooLarge, err)
+}
+
+func TestPartSizeExcept(t *testing.T) {
+	u := &multipartUpload{parts: map[int]uploadPart{1: {size: 10}, 2: {size: 20}}}
+	require.Equal(t, int64(30), u.sizeExcept(3))
+	require.Equal(t, int64(10), u.sizeExcept(2))
+}
@@ -0,0 +1,137 @@
+package s3service
+
+import (
+	"encoding/base64"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samoslab/nebula/client/daemon"
+)
+
+const defaultMaxKeys = 1000
+
+// listEntry is an object or a common prefix of listing
+type listEntry struct {
+	key    string
+	file   *daemon.DownFile
+	prefix bool
+}
+
+// collect list folder dir of bucket, which key prefix is keyPrefix, sub folders are listed
+// recursively unless delimited, in which case they are common prefixes
+func (req *request) collect(dir string, keyPrefix string, delimited bool, entries []listEntry) ([]listEntry, error) {
+	files, err := req.cm.ListAllFiles(dir, req.g.sno)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		key := keyPrefix + f.FileName
+		if !f.Folder {
+			entries = append(entries, listEntry{key: key, file: f})
+		} else if delimited {
+			entries = append(entries, listEntry{key: key + "/", prefix: true})
+		} else if entries, err = req.collect(path.Join(dir, f.FileName), key+"/", false, entries); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// pageEntries return entries after marker which match prefix, at most maxKeys, and whether truncated
+func pageEntries(entries []listEntry, prefix, marker string, maxKeys int) ([]listEntry, bool) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	res := make([]listEntry, 0, len(entries))
+	for _, e := range entries {
+		if !strings.HasPrefix(e.key, prefix) || e.key <= marker {
+			continue
+		}
+		if len(res) == maxKeys {
+			return res, true
+		}
+		res = append(res, e)
+	}
+	return res, false
+}
+
+func (req *request) listObjectsV2() error {
+	query := req.r.URL.Query()
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+	if delimiter != "" && delimiter != "/" {
+		return errNotImplemented
+	}
+	maxKeys := defaultMaxKeys
+	if s := query.Get("max-keys"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return errInvalidArgument
+		}
+		if n < maxKeys {
+			maxKeys = n
+		}
+	}
+	marker := query.Get("start-after")
+	token := query.Get("continuation-token")
+	if token != "" {
+		buf, err := base64.URLEncoding.DecodeString(token)
+		if err != nil {
+			return errInvalidArgument
+		}
+		marker = string(buf)
+	}
+	if _, err := req.findBucket(); err != nil {
+		return err
+	}
+	// only the folder which contains the prefix need to be listed
+	dirKey := ""
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dirKey = prefix[:i+1]
+	}
+	var entries []listEntry
+	if dirKey == "" || validKey(dirKey) {
+		dir := path.Clean("/" + req.bucket + "/" + dirKey)
+		_, err := req.cm.StatFile(dir, req.g.sno)
+		if err == nil {
+			entries, err = req.collect(dir, dirKey, delimiter != "", nil)
+		} else if err == daemon.ErrFileNotExist {
+			// folder of the prefix not exists, the result is empty
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	page, truncated := pageEntries(entries, prefix, marker, maxKeys)
+	res := &listBucketResultV2{
+		Xmlns:             s3Namespace,
+		Name:              req.bucket,
+		Prefix:            prefix,
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: token,
+		KeyCount:          len(page),
+		MaxKeys:           maxKeys,
+		Delimiter:         delimiter,
+		IsTruncated:       truncated,
+	}
+	for _, e := range page {
+		if e.prefix {
+			res.CommonPrefixes = append(res.CommonPrefixes, commonPrefix{Prefix: e.key})
+			continue
+		}
+		res.Contents = append(res.Contents, object{
+			Key:          e.key,
+			LastModified: formatTime(modTime(e.file)),
+			ETag:         etag(e.file.FileHash),
+			Size:         e.file.Size(),
+			StorageClass: "STANDARD",
+		})
+	}
+	if truncated && len(page) > 0 {
+		res.NextContinuationToken = base64.URLEncoding.EncodeToString([]byte(page[len(page)-1].key))
+	}
+	writeXML(req.w, http.StatusOK, res)
+	return nil
+}
@@ -0,0 +1,46 @@
+package s3service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func keys(entries []listEntry) []string {
+	res := make([]string, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, e.key)
+	}
+	return res
+}
+
+func TestPageEntries(t *testing.T) {
+	entries := []listEntry{{key: "b/"}, {key: "a.txt"}, {key: "b.txt"}, {key: "c/d.txt"}, {key: "ab.txt"}}
+	page, truncated := pageEntries(entries, "", "", 2)
+	require.Equal(t, []string{"a.txt", "ab.txt"}, keys(page))
+	require.True(t, truncated)
+
+	page, truncated = pageEntries(entries, "", "ab.txt", 2)
+	require.Equal(t, []string{"b.txt", "b/"}, keys(page))
+	require.True(t, truncated)
+
+	page, truncated = pageEntries(entries, "", "b/", 2)
+	require.Equal(t, []string{"c/d.txt"}, keys(page))
+	require.False(t, truncated)
+
+	page, truncated = pageEntries(entries, "a", "", 10)
+	require.Equal(t, []string{"a.txt", "ab.txt"}, keys(page))
+	require.False(t, truncated)
+}
+
+func TestValidKey(t *testing.T) {
+	require.True(t, validKey("a/b.txt"))
+	require.True(t, validKey("a/b/"))
+	require.False(t, validKey(""))
+	require.False(t, validKey("/a"))
+	require.False(t, validKey("a//b"))
+	require.False(t, validKey("a/../b"))
+	bucketName, key := splitBucketKey("/bucket/a/b.txt")
+	require.Equal(t, "bucket", bucketName)
+	require.Equal(t, "a/b.txt", key)
+}
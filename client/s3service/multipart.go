@@ -0,0 +1,216 @@
+package s3service
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const maxPartNumber = 10000
+
+type uploadPart struct {
+	etag string
+	size int64
+}
+
+// multipartUpload parts are spooled in dir until the upload completed or aborted
+type multipartUpload struct {
+	bucket string
+	key    string
+	dir    string
+	mutex  sync.Mutex
+	parts  map[int]uploadPart
+}
+
+func (u *multipartUpload) partFile(number int) string {
+	return filepath.Join(u.dir, fmt.Sprintf("part-%05d", number))
+}
+
+// sizeExcept total size of parts other than number, mutex must be held
+func (u *multipartUpload) sizeExcept(number int) int64 {
+	var size int64
+	for n, p := range u.parts {
+		if n != number {
+			size += p.size
+		}
+	}
+	return size
+}
+
+// uploadRegistry keep multipart uploads in progress
+type uploadRegistry struct {
+	mutex   sync.Mutex
+	uploads map[string]*multipartUpload
+}
+
+func newUploadRegistry() *uploadRegistry {
+	return &uploadRegistry{uploads: make(map[string]*multipartUpload)}
+}
+
+func (r *uploadRegistry) add(id string, u *multipartUpload) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.uploads[id] = u
+}
+
+// get return the upload of the object, nil if not exists
+func (r *uploadRegistry) get(id, bucket, key string) *multipartUpload {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	u, ok := r.uploads[id]
+	if !ok || u.bucket != bucket || u.key != key {
+		return nil
+	}
+	return u
+}
+
+// remove the upload, return false if it was removed already
+func (r *uploadRegistry) remove(id string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.uploads[id]; !ok {
+		return false
+	}
+	delete(r.uploads, id)
+	return true
+}
+
+func (req *request) createMultipartUpload() error {
+	if strings.HasSuffix(req.key, "/") {
+		return errInvalidKey
+	}
+	dir, err := req.spoolDir()
+	if err != nil {
+		return err
+	}
+	id := filepath.Base(dir)
+	req.g.uploads.add(id, &multipartUpload{bucket: req.bucket, key: req.key, dir: dir, parts: make(map[int]uploadPart)})
+	writeXML(req.w, http.StatusOK, &initiateMultipartUploadResult{Xmlns: s3Namespace, Bucket: req.bucket, Key: req.key, UploadID: id})
+	return nil
+}
+
+func (req *request) uploadPart(uploadID string, partNumber string) error {
+	number, err := strconv.Atoi(partNumber)
+	if err != nil || number < 1 || number > maxPartNumber {
+		return errInvalidArgument
+	}
+	u := req.g.uploads.get(uploadID, req.bucket, req.key)
+	if u == nil {
+		return errNoSuchUpload
+	}
+	// part uploaded again replace the old one, so it is spooled to another file first
+	id, err := randomID()
+	if err != nil {
+		return err
+	}
+	tmp := u.partFile(number) + "." + id
+	// object is limited as a whole, so part is limited by the size left by other parts
+	u.mutex.Lock()
+	limit := req.g.maxObjectSize - u.sizeExcept(number)
+	u.mutex.Unlock()
+	md5sum, size, err := req.spool(tmp, limit)
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	u.mutex.Lock()
+	if size > req.g.maxObjectSize-u.sizeExcept(number) {
+		// other parts are uploaded at the same time
+		err = errEntityTooLarge
+	} else if err = os.Rename(tmp, u.partFile(number)); err == nil {
+		u.parts[number] = uploadPart{etag: etag(hex.EncodeToString(md5sum)), size: size}
+	}
+	u.mutex.Unlock()
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	req.w.Header().Set("ETag", etag(hex.EncodeToString(md5sum)))
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (req *request) abortMultipartUpload(uploadID string) error {
+	u := req.g.uploads.get(uploadID, req.bucket, req.key)
+	if u == nil || !req.g.uploads.remove(uploadID) {
+		return errNoSuchUpload
+	}
+	os.RemoveAll(u.dir)
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// completeMultipartUpload concatenate the parts in order and upload it as the object
+func (req *request) completeMultipartUpload(uploadID string) error {
+	u := req.g.uploads.get(uploadID, req.bucket, req.key)
+	if u == nil {
+		return errNoSuchUpload
+	}
+	complete := &completeMultipartUpload{}
+	if err := xml.NewDecoder(req.r.Body).Decode(complete); err != nil || len(complete.Parts) == 0 {
+		return errMalformedXML
+	}
+	if !req.g.uploads.remove(uploadID) {
+		return errNoSuchUpload
+	}
+	defer os.RemoveAll(u.dir)
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	last := 0
+	files := make([]string, 0, len(complete.Parts))
+	for _, p := range complete.Parts {
+		if p.PartNumber <= last {
+			return errInvalidPartOrder
+		}
+		last = p.PartNumber
+		part, ok := u.parts[p.PartNumber]
+		if !ok || strings.Trim(p.ETag, `"`) != strings.Trim(part.etag, `"`) {
+			return errInvalidPart
+		}
+		files = append(files, u.partFile(p.PartNumber))
+	}
+	objectDir := filepath.Join(u.dir, "object")
+	if err := os.Mkdir(objectDir, 0700); err != nil {
+		return err
+	}
+	fileName := filepath.Join(objectDir, path.Base(req.key))
+	if err := concatFiles(fileName, files); err != nil {
+		return err
+	}
+	f, err := req.upload(fileName, req.key)
+	if err != nil {
+		return err
+	}
+	writeXML(req.w, http.StatusOK, &completeMultipartUploadResult{Xmlns: s3Namespace, Bucket: req.bucket, Key: req.key, ETag: etag(f.FileHash)})
+	return nil
+}
+
+// concatFiles write files in order to dest, each part file is deleted after copied to save space
+func concatFiles(dest string, files []string) error {
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	for _, fileName := range files {
+		in, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, in)
+		in.Close()
+		if err != nil {
+			return err
+		}
+		os.Remove(fileName)
+	}
+	return out.Sync()
+}
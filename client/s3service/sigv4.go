@@ -0,0 +1,258 @@
+package s3service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signAlgorithm   = "AWS4-HMAC-SHA256"
+	amzDateFormat   = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// max difference between request time and local time
+	maxClockSkew = 15 * time.Minute
+	// max X-Amz-Expires of presigned url, 7 days
+	maxPresignExpires = 7 * 24 * 3600
+)
+
+// credential parsed from Authorization header or presigned url query
+type credential struct {
+	accessKey     string
+	date          string
+	region        string
+	service       string
+	signedHeaders []string
+	signature     string
+	amzDate       time.Time
+	payloadHash   string
+	presigned     bool
+}
+
+func (c *credential) scope() string {
+	return strings.Join([]string{c.date, c.region, c.service, "aws4_request"}, "/")
+}
+
+// verifier check AWS signature version 4 of requests with local access keys
+type verifier struct {
+	keys map[string]string // access key -> secret key
+	now  func() time.Time
+}
+
+func newVerifier(keys map[string]string) *verifier {
+	return &verifier{keys: keys, now: time.Now}
+}
+
+// verify check signature of the request, return the access key and hash of payload
+// which should be checked after body read, empty if the payload is not signed
+func (v *verifier) verify(r *http.Request) (accessKey string, payloadHash string, err error) {
+	var cred *credential
+	if r.URL.Query().Get("X-Amz-Algorithm") != "" {
+		cred, err = parsePresigned(r.URL.Query())
+	} else if auth := r.Header.Get("Authorization"); auth != "" {
+		cred, err = parseAuthorization(auth, r.Header)
+	} else {
+		return "", "", errAccessDenied
+	}
+	if err != nil {
+		return "", "", err
+	}
+	secret, ok := v.keys[cred.accessKey]
+	if !ok {
+		return "", "", errInvalidAccessKey
+	}
+	now := v.now()
+	if cred.presigned {
+		expires, _ := strconv.Atoi(r.URL.Query().Get("X-Amz-Expires"))
+		if now.Before(cred.amzDate.Add(-maxClockSkew)) || now.After(cred.amzDate.Add(time.Duration(expires)*time.Second)) {
+			return "", "", errRequestExpired
+		}
+	} else if d := now.Sub(cred.amzDate); d > maxClockSkew || d < -maxClockSkew {
+		return "", "", errRequestExpired
+	}
+	canonical := canonicalRequest(r, cred)
+	expected := hex.EncodeToString(signature(secret, cred, canonical))
+	if !hmac.Equal([]byte(expected), []byte(cred.signature)) {
+		return "", "", errSignatureMismatch
+	}
+	if cred.payloadHash == unsignedPayload {
+		return cred.accessKey, "", nil
+	}
+	return cred.accessKey, cred.payloadHash, nil
+}
+
+// parseAuthorization parse header like:
+// AWS4-HMAC-SHA256 Credential=AK/20130524/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=hex
+func parseAuthorization(auth string, header http.Header) (*credential, error) {
+	if !strings.HasPrefix(auth, signAlgorithm+" ") {
+		return nil, errAuthMalformed
+	}
+	cred := &credential{}
+	for _, field := range strings.Split(strings.TrimPrefix(auth, signAlgorithm+" "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			return nil, errAuthMalformed
+		}
+		switch kv[0] {
+		case "Credential":
+			if err := cred.parseCredential(kv[1]); err != nil {
+				return nil, err
+			}
+		case "SignedHeaders":
+			cred.signedHeaders = strings.Split(kv[1], ";")
+		case "Signature":
+			cred.signature = kv[1]
+		}
+	}
+	if cred.accessKey == "" || len(cred.signedHeaders) == 0 || cred.signature == "" {
+		return nil, errAuthMalformed
+	}
+	amzDate := header.Get("X-Amz-Date")
+	if amzDate == "" {
+		return nil, errAuthMalformed
+	}
+	if err := cred.parseDate(amzDate); err != nil {
+		return nil, err
+	}
+	cred.payloadHash = header.Get("X-Amz-Content-Sha256")
+	if cred.payloadHash == "" {
+		return nil, errAuthMalformed
+	}
+	if strings.HasPrefix(cred.payloadHash, "STREAMING-") {
+		return nil, errNotImplemented
+	}
+	return cred, nil
+}
+
+func parsePresigned(query url.Values) (*credential, error) {
+	if query.Get("X-Amz-Algorithm") != signAlgorithm {
+		return nil, errAuthMalformed
+	}
+	cred := &credential{presigned: true, payloadHash: unsignedPayload}
+	if err := cred.parseCredential(query.Get("X-Amz-Credential")); err != nil {
+		return nil, err
+	}
+	if err := cred.parseDate(query.Get("X-Amz-Date")); err != nil {
+		return nil, err
+	}
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil || expires < 0 || expires > maxPresignExpires {
+		return nil, errAuthMalformed
+	}
+	cred.signedHeaders = strings.Split(query.Get("X-Amz-SignedHeaders"), ";")
+	cred.signature = query.Get("X-Amz-Signature")
+	if cred.signature == "" || len(cred.signedHeaders) == 0 {
+		return nil, errAuthMalformed
+	}
+	return cred, nil
+}
+
+func (c *credential) parseCredential(s string) error {
+	parts := strings.Split(s, "/")
+	if len(parts) != 5 || parts[4] != "aws4_request" || parts[3] != "s3" {
+		return errAuthMalformed
+	}
+	c.accessKey, c.date, c.region, c.service = parts[0], parts[1], parts[2], parts[3]
+	return nil
+}
+
+func (c *credential) parseDate(s string) error {
+	t, err := time.Parse(amzDateFormat, s)
+	if err != nil {
+		return errAuthMalformed
+	}
+	if t.Format("20060102") != c.date {
+		return errAuthMalformed
+	}
+	c.amzDate = t
+	return nil
+}
+
+func canonicalRequest(r *http.Request, cred *credential) string {
+	headers := make([]string, 0, len(cred.signedHeaders))
+	for _, name := range cred.signedHeaders {
+		var value string
+		if name == "host" {
+			value = r.Host
+		} else {
+			value = strings.Join(r.Header[http.CanonicalHeaderKey(name)], ",")
+		}
+		headers = append(headers, name+":"+strings.Join(strings.Fields(value), " ")+"\n")
+	}
+	return strings.Join([]string{
+		r.Method,
+		uriEncode(r.URL.Path, false),
+		canonicalQuery(r.URL.Query(), cred.presigned),
+		strings.Join(headers, ""),
+		strings.Join(cred.signedHeaders, ";"),
+		cred.payloadHash,
+	}, "\n")
+}
+
+// canonicalQuery sort parameters by encoded name, then by encoded value
+func canonicalQuery(query url.Values, presigned bool) string {
+	pairs := make([][2]string, 0, len(query))
+	for k, vs := range query {
+		if presigned && k == "X-Amz-Signature" {
+			continue
+		}
+		for _, v := range vs {
+			pairs = append(pairs, [2]string{uriEncode(k, true), uriEncode(v, true)})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	res := make([]string, len(pairs))
+	for i, p := range pairs {
+		res[i] = p[0] + "=" + p[1]
+	}
+	return strings.Join(res, "&")
+}
+
+// uriEncode encode every byte except unreserved characters, slash is kept in path
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func signature(secret string, cred *credential, canonical string) []byte {
+	hash := sha256.Sum256([]byte(canonical))
+	stringToSign := strings.Join([]string{
+		signAlgorithm,
+		cred.amzDate.Format(amzDateFormat),
+		cred.scope(),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+	key := hmacSHA256([]byte("AWS4"+secret), cred.date)
+	key = hmacSHA256(key, cred.region)
+	key = hmacSHA256(key, cred.service)
+	key = hmacSHA256(key, "aws4_request")
+	return hmacSHA256(key, stringToSign)
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/samoslab/nebula/client/common"
+	"github.com/samoslab/nebula/client/config"
+	"github.com/samoslab/nebula/util/file"
+)
+
+// DeviceNameReq name of new device
+type DeviceNameReq struct {
+	Name string `json:"name"`
+}
+
+// DeviceRevokeReq revoke device
+type DeviceRevokeReq struct {
+	DeviceId string `json:"device_id"`
+}
+
+// deviceKeyFile file of key of this device waiting for enrolling
+func deviceKeyFile(s *HTTPServer) string {
+	return filepath.Join(s.cfg.ConfigDir, config.DeviceKeyFile)
+}
+
+// DeviceRequestHandler make key of this device for enrolling it to an account, the request returned
+// is enrolled on primary device
+func DeviceRequestHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := s.log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &DeviceNameReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+		if req.Name == "" {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument name must not empty"))
+			return
+		}
+		if file.Exists(s.cfg.ConfigFile) {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("config exists, device is already of an account"))
+			return
+		}
+
+		// key waiting for enrolling is kept, so request can be asked again
+		keyFile := deviceKeyFile(s)
+		dk, err := config.LoadDeviceKey(keyFile)
+		if err != nil {
+			dk, err = config.NewDeviceKey(req.Name)
+		} else {
+			dk.Name = req.Name
+		}
+		if err == nil {
+			err = config.SaveDeviceKey(keyFile, dk)
+		}
+		var result interface{}
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("Make device key error %v", err)
+			code, errmsg = common.StatusErrFromError(err)
+		} else {
+			result = dk.Request()
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// DeviceEnrollHandler delegate account to device of request, on primary device
+func DeviceEnrollHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &config.DeviceRequest{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+		if req.DeviceId == "" || req.PublicKey == "" || req.Name == "" {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument device_id, name and public_key must not empty"))
+			return
+		}
+
+		grant, err := s.cm.EnrollDevice(req)
+		var result interface{}
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("Enroll device %s error %v", req.DeviceId, err)
+			code, errmsg = common.StatusErrFromError(err)
+		} else {
+			result = grant
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// DeviceAcceptHandler make config of this device from grant of primary device
+func DeviceAcceptHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := s.log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &config.DeviceGrant{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+		if file.Exists(s.cfg.ConfigFile) {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("config exists, device is already of an account"))
+			return
+		}
+
+		err := acceptDeviceGrant(deviceKeyFile(s), s.cfg.ConfigFile, req)
+		result, code, errmsg := "ok", 0, ""
+		if err != nil {
+			log.Errorf("Accept device grant error %v", err)
+			result = ""
+			code, errmsg = common.StatusErrFromError(err)
+		} else if s.cm == nil {
+			cm, err := InitClientManager(log, s.cfg)
+			if err != nil {
+				code = 1
+				errmsg = err.Error()
+			} else {
+				s.cm = cm
+			}
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// acceptDeviceGrant write config of device from grant for key waiting in keyFile, which is removed then
+func acceptDeviceGrant(keyFile, configFile string, grant *config.DeviceGrant) error {
+	dk, err := config.LoadDeviceKey(keyFile)
+	if err != nil {
+		return fmt.Errorf("no device key waiting for enrolling: %v", err)
+	}
+	cc, err := config.AcceptDeviceGrant(dk, grant)
+	if err != nil {
+		return err
+	}
+	if old, err := config.LoadConfig(configFile); err == nil && old.NodeId != cc.NodeId {
+		return fmt.Errorf("config of node %s exists, export and remove it first", old.NodeId)
+	} else if err != nil && err != config.ErrNoConf {
+		return err
+	}
+	if err = config.SaveClientConfig(configFile, cc); err != nil {
+		return err
+	}
+	return os.Remove(keyFile)
+}
+
+// DeviceListHandler devices enrolled to account
+func DeviceListHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodGet}) {
+			return
+		}
+
+		devices, err := s.cm.ListDevices()
+		var result interface{}
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("List devices error %v", err)
+			code, errmsg = common.StatusErrFromError(err)
+		} else {
+			result = devices
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// DeviceRevokeHandler revoke delegation of device, on primary device
+func DeviceRevokeHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &DeviceRevokeReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+		if req.DeviceId == "" {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument device_id must not empty"))
+			return
+		}
+
+		err := s.cm.RevokeDevice(req.DeviceId)
+		result, code, errmsg := "ok", 0, ""
+		if err != nil {
+			log.Errorf("Revoke device %s error %v", req.DeviceId, err)
+			result = ""
+			code, errmsg = common.StatusErrFromError(err)
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -298,7 +302,11 @@ func (s *HTTPServer) setupMux() *http.ServeMux {
 	handleAPI("/api/v1/store/folder/add", MkfolderHandler(s))
 	handleAPI("/api/v1/store/upload", UploadHandler(s))
 	handleAPI("/api/v1/store/download", DownloadHandler(s))
+	handleAPI("/api/v1/store/chunks", ChunksHandler(s))
+	handleAPI("/api/v1/store/chunk", ChunkHandler(s))
 	handleAPI("/api/v1/store/list", ListHandler(s))
+	handleAPI("/api/v1/store/search", SearchHandler(s))
+	handleAPI("/api/v1/store/localsearch", LocalSearchHandler(s))
 	handleAPI("/api/v1/store/remove", RemoveHandler(s))
 	handleAPI("/api/v1/store/progress", ProgressHandler(s))
 	handleAPI("/api/v1/store/uploaddir", UploadDirHandler(s))
@@ -330,11 +338,24 @@ func (s *HTTPServer) setupMux() *http.ServeMux {
 	handleAPI("/api/v1/service/root", RootPathHandler(s))
 	handleAPI("/api/v1/config/import", ConfigImportHandler(s))
 	handleAPI("/api/v1/config/export", ConfigExportHandler(s))
+	handleAPI("/api/v1/config/recover", ConfigRecoverHandler(s))
+	handleAPI("/api/v1/device/request", DeviceRequestHandler(s))
+	handleAPI("/api/v1/device/enroll", DeviceEnrollHandler(s))
+	handleAPI("/api/v1/device/accept", DeviceAcceptHandler(s))
+	handleAPI("/api/v1/device/list", DeviceListHandler(s))
+	handleAPI("/api/v1/device/revoke", DeviceRevokeHandler(s))
 
 	handleAPI("/api/v1/space/verify", SpaceVerifyHandler(s))
 	handleAPI("/api/v1/space/password", PasswordHandler(s))
 	handleAPI("/api/v1/space/status", SpaceStatusHandler(s))
 
+	handleAPI("/api/v1/audit/status", AuditStatusHandler(s))
+	handleAPI("/api/v1/metadata/progress", MetadataProgressHandler(s))
+
+	handleAPI("/api/v1/backup/jobs", BackupJobsHandler(s))
+	handleAPI("/api/v1/backup/run", BackupRunHandler(s))
+	handleAPI("/api/v1/backup/restore", BackupRestoreHandler(s))
+
 	// Static files
 	mux.Handle("/", http.FileServer(http.Dir(s.cfg.StaticDir)))
 	return mux
@@ -342,8 +363,9 @@ func (s *HTTPServer) setupMux() *http.ServeMux {
 
 // RegisterReq request struct for register
 type RegisterReq struct {
-	Email  string `json:"email"`
-	Resend bool   `josn:"resend"`
+	Email          string `json:"email"`
+	Resend         bool   `josn:"resend"`
+	RecoveryPhrase bool   `json:"recovery_phrase"`
 }
 
 // VerifyEmailReq request struct for verify email
@@ -382,6 +404,52 @@ type ListReq struct {
 	Sno      uint32 `json:"space_no"`
 }
 
+// SearchReq request struct for search files
+type SearchReq struct {
+	Path          string `json:"path"`
+	Recursive     bool   `json:"recursive"`
+	Name          string `json:"name"`
+	Regex         bool   `json:"regex"`
+	FileType      string `json:"filetype"`
+	MinSize       uint64 `json:"minsize"`
+	MaxSize       uint64 `json:"maxsize"`
+	MinModTime    uint64 `json:"minmodtime"`
+	MaxModTime    uint64 `json:"maxmodtime"`
+	IncludeFolder bool   `json:"folder"`
+	PageSize      uint32 `json:"pagesize"`
+	PageNum       uint32 `json:"pagenum"`
+	SortType      string `json:"sorttype"`
+	AscOrder      bool   `json:"ascorder"`
+	Sno           uint32 `json:"space_no"`
+}
+
+// LocalSearchReq request struct for search files in metadata cache
+type LocalSearchReq struct {
+	Path     string `json:"path"`
+	Name     string `json:"name"`
+	FileType string `json:"filetype"`
+	MinSize  uint64 `json:"minsize"`
+	MaxSize  uint64 `json:"maxsize"`
+	Folder   bool   `json:"folder"`
+	Limit    int    `json:"limit"`
+	Sno      uint32 `json:"space_no"`
+}
+
+// ChunksReq request struct for chunks of file
+type ChunksReq struct {
+	FileHash string `json:"filehash"`
+	FileSize uint64 `json:"filesize"`
+	Sno      uint32 `json:"space_no"`
+}
+
+// ChunkReq request struct for download one chunk of file
+type ChunkReq struct {
+	FileHash string `json:"filehash"`
+	FileSize uint64 `json:"filesize"`
+	Sno      uint32 `json:"space_no"`
+	Index    int    `json:"index"`
+}
+
 // RemoveReq request struct for remove file
 type RemoveReq struct {
 	Target    string `json:"target"`
@@ -437,12 +505,20 @@ type SpaceStatusReq struct {
 
 // ConfigImportReq import config
 type ConfigImportReq struct {
-	FileName string `json:"filename"`
+	FileName   string `json:"filename"`
+	Passphrase string `json:"passphrase"`
 }
 
 // ConfigExportReq export config
 type ConfigExportReq struct {
-	Filename string `json:"filename"`
+	Filename   string `json:"filename"`
+	Passphrase string `json:"passphrase"`
+}
+
+// ConfigRecoverReq recover config from recovery phrase
+type ConfigRecoverReq struct {
+	Phrase string `json:"recovery_phrase"`
+	Email  string `json:"email"`
 }
 
 // ServiceStatusHandler returns service status
@@ -707,7 +783,7 @@ func ConfigImportHandler(s *HTTPServer) http.HandlerFunc {
 			return
 		}
 
-		err := config.ImportConfig(req.FileName, s.cfg.ConfigFile)
+		cc, err := config.ImportConfig(req.FileName, s.cfg.ConfigFile, req.Passphrase)
 		result, code, errmsg := "ok", 0, ""
 		if err != nil {
 			result = ""
@@ -722,6 +798,11 @@ func ConfigImportHandler(s *HTTPServer) http.HandlerFunc {
 					s.cm = cm
 				}
 			}
+			if s.cm != nil {
+				if err := s.cm.RestorePasswords(cc); err != nil {
+					log.Errorf("Restore space passwords failed %v", err)
+				}
+			}
 		}
 
 		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
@@ -735,7 +816,8 @@ func ConfigImportHandler(s *HTTPServer) http.HandlerFunc {
 	}
 }
 
-// ConfigExportHandler set user root path handler
+// ConfigExportHandler export config encrypted under passphrase, to file if filename is given or as
+// download otherwise
 func ConfigExportHandler(s *HTTPServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -743,20 +825,132 @@ func ConfigExportHandler(s *HTTPServer) http.HandlerFunc {
 			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
 			return
 		}
+		log := s.cm.Log
 		w.Header().Set("Accept", "application/json")
 
-		if !validMethod(ctx, w, r, []string{http.MethodGet}) {
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &ConfigExportReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+		if len(req.Passphrase) < config.MinPassphraseLen {
+			errorResponse(ctx, w, http.StatusBadRequest, config.ErrShortPassphrase)
+			return
+		}
+
+		if req.Filename == "" {
+			data, err := s.cm.SealConfig(req.Passphrase)
+			if err != nil {
+				errorResponse(ctx, w, http.StatusInternalServerError, err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Disposition", "attachment;filename=config.json")
+			w.Write(data)
+			return
+		}
+
+		err := s.cm.ExportConfig(req.Filename, req.Passphrase)
+		result, code, errmsg := "ok", 0, ""
+		if err != nil {
+			result = ""
+			code, errmsg = common.StatusErrFromError(err)
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// ConfigRecoverHandler regenerate config of node from its recovery phrase
+func ConfigRecoverHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := s.log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &ConfigRecoverReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
 			return
 		}
 
 		defer r.Body.Close()
+		if req.Phrase == "" || req.Email == "" {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument recovery_phrase and email must not empty"))
+			return
+		}
+
+		err := recoverConfig(s.cfg.ConfigFile, req.Phrase, req.Email)
+		result, code, errmsg := "ok", 0, ""
+		if err != nil {
+			result = ""
+			code, errmsg = common.StatusErrFromError(err)
+		} else if s.cm == nil {
+			cm, err := InitClientManager(log, s.cfg)
+			if err != nil {
+				code = 1
+				errmsg = err.Error()
+			} else {
+				s.cm = cm
+			}
+		}
 
-		confFile := s.cm.ExportFile()
-		w.Header().Set("Content-Disposition", "attachment;filename=config.json")
-		http.ServeFile(w, r, confFile)
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
 	}
 }
 
+// recoverConfig write config regenerated from recovery phrase, config of another node is not replaced
+func recoverConfig(configFile, phrase, email string) error {
+	cc, err := config.RecoverConfig(phrase, email)
+	if err != nil {
+		return err
+	}
+	if old, err := config.LoadConfig(configFile); err == nil && old.NodeId != cc.NodeId {
+		return fmt.Errorf("config of node %s exists, export and remove it first", old.NodeId)
+	} else if err != nil && err != config.ErrNoConf {
+		return err
+	}
+	return config.SaveClientConfig(configFile, cc)
+}
+
 // RegisterHandler client register handler, client must register first then can be using service
 func RegisterHandler(s *HTTPServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -788,13 +982,23 @@ func RegisterHandler(s *HTTPServer) http.HandlerFunc {
 		}
 
 		var err error
+		phrase := ""
 		if regReq.Resend {
 			err = regclient.ResendVerifyCode(s.cfg.ConfigFile, s.cfg.TrackerServer)
 		} else {
 			log.Infof("Register email %s dir %s", regReq.Email, s.cfg.ConfigFile)
-			err = regclient.RegisterClient(log, s.cfg.ConfigFile, s.cfg.TrackerServer, regReq.Email)
+			if regReq.RecoveryPhrase {
+				phrase, err = config.NewRecoveryPhrase()
+			}
+			if err == nil {
+				err = regclient.RegisterClient(log, s.cfg.ConfigFile, s.cfg.TrackerServer, regReq.Email, phrase)
+			}
 		}
 		result, code, errmsg := "ok", 0, ""
+		if phrase != "" {
+			// phrase is shown only once, node key can be regenerated from it
+			result = phrase
+		}
 		if err != nil {
 			log.Errorf("Send email %+v error %v", regReq, err)
 			result = ""
@@ -974,7 +1178,7 @@ func UploadHandler(s *HTTPServer) http.HandlerFunc {
 		}
 
 		log.Infof("Upload files %+v", req.Filename)
-		err := s.cm.UploadFile(req.Filename, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Sno)
+		err := s.cm.UploadFile(req.Filename, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Compress, req.Sno)
 		result, code, errmsg := "ok", 0, ""
 		if err != nil {
 			log.Errorf("Upload %+v error %v", req, err)
@@ -1353,7 +1557,7 @@ func UploadDirHandler(s *HTTPServer) http.HandlerFunc {
 		}
 
 		log.Infof("Upload parent %s", req.Parent)
-		err := s.cm.UploadDir(req.Parent, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Sno)
+		err := s.cm.UploadDir(req.Parent, req.Dest, req.Interactive, req.NewVersion, req.IsEncrypt, req.Compress, req.Sno)
 		result, code, errmsg := "ok", 0, ""
 		if err != nil {
 			log.Errorf("Upload %+v error %v", req, err)
@@ -1427,6 +1631,147 @@ func DownloadHandler(s *HTTPServer) http.HandlerFunc {
 	}
 }
 
+// ChunksHandler return chunks of file, chunk is the unit of ranged download
+func ChunksHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &ChunksReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+
+		if req.FileHash == "" || req.FileSize == 0 {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument filehash or filesize must not empty"))
+			return
+		}
+
+		var result []daemon.Chunk
+		code, errmsg := 0, ""
+		f, err := s.cm.OpenRemoteFile(req.FileHash, req.FileSize, req.Sno)
+		if err != nil {
+			log.Errorf("Chunks of %+v error %v", req, err)
+			code, errmsg = common.StatusErrFromError(err)
+		} else {
+			result = f.Chunks
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// ChunkHandler download one chunk of file, the chunk is returned as octet stream if succeed
+func ChunkHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &ChunkReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+
+		if req.FileHash == "" || req.FileSize == 0 {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument filehash or filesize must not empty"))
+			return
+		}
+
+		file, err := s.downloadChunk(req)
+		if err != nil {
+			log.Errorf("Download chunk %+v error %v", req, err)
+			code, errmsg := common.StatusErrFromError(err)
+			rsp, err := common.MakeUnifiedHTTPResponse(code, "", errmsg)
+			if err != nil {
+				errorResponse(ctx, w, http.StatusBadRequest, err)
+				return
+			}
+			if err := JSONResponse(w, rsp); err != nil {
+				log.Infof("Error %v\n", err)
+			}
+			return
+		}
+		defer func() {
+			file.Close()
+			os.Remove(file.Name())
+		}()
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if fi, err := file.Stat(); err == nil {
+			w.Header().Set("Content-Length", strconv.FormatInt(fi.Size(), 10))
+		}
+		if _, err := io.Copy(w, file); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// downloadChunk download chunk into temporary file
+func (s *HTTPServer) downloadChunk(req *ChunkReq) (*os.File, error) {
+	f, err := s.cm.OpenRemoteFile(req.FileHash, req.FileSize, req.Sno)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempFile(s.cm.TempDir, "chunk-")
+	if err != nil {
+		return nil, err
+	}
+	tmp.Close()
+	if err = s.cm.DownloadChunk(f, req.Index, tmp.Name()); err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	file, err := os.Open(tmp.Name())
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return file, nil
+}
+
 // DownloadDirHandler download directory from provider
 func DownloadDirHandler(s *HTTPServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1572,7 +1917,7 @@ func ListHandler(s *HTTPServer) http.HandlerFunc {
 		}
 
 		log.Infof("List %+v", req)
-		result, err := s.cm.ListFiles(req.Path, req.PageSize, req.PageNum, req.SortType, req.AscOrder, req.Sno)
+		result, err := s.cm.ListFilesCached(req.Path, req.PageSize, req.PageNum, req.SortType, req.AscOrder, req.Sno)
 		code, errmsg := 0, ""
 		if err != nil {
 			log.Errorf("List file %+v error %v", req, err)
@@ -1590,6 +1935,183 @@ func ListHandler(s *HTTPServer) http.HandlerFunc {
 	}
 }
 
+// SearchHandler search files under path
+func SearchHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &SearchReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+		if req.Path == "" {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument path must not empty"))
+			return
+		}
+
+		log.Infof("Search %+v", req)
+		param := daemon.SearchParam{
+			Path:          req.Path,
+			Recursive:     req.Recursive,
+			NamePattern:   req.Name,
+			Regex:         req.Regex,
+			FileType:      req.FileType,
+			MinSize:       req.MinSize,
+			MaxSize:       req.MaxSize,
+			MinModTime:    req.MinModTime,
+			MaxModTime:    req.MaxModTime,
+			IncludeFolder: req.IncludeFolder,
+			PageSize:      req.PageSize,
+			PageNum:       req.PageNum,
+			SortType:      req.SortType,
+			AscOrder:      req.AscOrder,
+		}
+		result, err := s.cm.SearchFiles(param, req.Sno)
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("Search %+v error %v", req, err)
+			code, errmsg = common.StatusErrFromError(err)
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// LocalSearchHandler search files in folders listed before, it works without tracker
+func LocalSearchHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &LocalSearchReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+		if req.Path == "" {
+			req.Path = "/"
+		}
+
+		log.Infof("Local search %+v", req)
+		flt := daemon.CacheFilter{Name: req.Name, FileType: req.FileType, MinSize: req.MinSize, MaxSize: req.MaxSize, Folder: req.Folder}
+		result, err := s.cm.SearchCachedFiles(req.Path, req.Sno, flt, req.Limit)
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("Local search %+v error %v", req, err)
+			code, errmsg = common.StatusErrFromError(err)
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// AuditStatusHandler reports of files audited by challenging their providers
+func AuditStatusHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		if !validMethod(ctx, w, r, []string{http.MethodGet}) {
+			return
+		}
+
+		result, err := s.cm.AuditReports()
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("Audit status error %v", err)
+			code, errmsg = common.StatusErrFromError(err)
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// MetadataProgressHandler progress of PoR metadata generation of uploading blocks
+func MetadataProgressHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		if !validMethod(ctx, w, r, []string{http.MethodGet}) {
+			return
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(0, s.cm.MetaJobProgress(), "")
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
 // RemoveHandler remove file handler
 func RemoveHandler(s *HTTPServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1925,3 +2447,135 @@ func validMethod(ctx context.Context, w http.ResponseWriter, r *http.Request, al
 
 	return false
 }
+
+// BackupJobsHandler backup jobs of config with their snapshots
+func BackupJobsHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		if !validMethod(ctx, w, r, []string{http.MethodGet}) {
+			return
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(0, s.cm.BackupJobs(), "")
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// BackupRunHandler run backup job at back-end
+func BackupRunHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &common.BackupReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+
+		if req.Job == "" {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument job must not empty"))
+			return
+		}
+
+		log.Infof("Backup %s", req.Job)
+		result, err := s.cm.AddTask(common.TaskBackupType, req)
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("Backup %+v error %v", req, err)
+			code, errmsg = common.StatusErrFromError(err)
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
+
+// BackupRestoreHandler restore snapshot of backup job at back-end
+func BackupRestoreHandler(s *HTTPServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !s.CanBeWork() {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("register first"))
+			return
+		}
+		log := s.cm.Log
+		w.Header().Set("Accept", "application/json")
+
+		if !validMethod(ctx, w, r, []string{http.MethodPost}) {
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			errorResponse(ctx, w, http.StatusUnsupportedMediaType, errors.New("Invalid content type"))
+			return
+		}
+
+		req := &common.RestoreReq{}
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&req); err != nil {
+			err = fmt.Errorf("Invalid json request body: %v", err)
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		defer r.Body.Close()
+
+		if req.Job == "" || req.Snapshot == "" || req.Dest == "" {
+			errorResponse(ctx, w, http.StatusBadRequest, errors.New("argument job, snapshot or dest_dir must not empty"))
+			return
+		}
+
+		log.Infof("Restore %s@%s into %s", req.Job, req.Snapshot, req.Dest)
+		result, err := s.cm.AddTask(common.TaskRestoreType, req)
+		code, errmsg := 0, ""
+		if err != nil {
+			log.Errorf("Restore %+v error %v", req, err)
+			code, errmsg = common.StatusErrFromError(err)
+		}
+
+		rsp, err := common.MakeUnifiedHTTPResponse(code, result, errmsg)
+		if err != nil {
+			errorResponse(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+		if err := JSONResponse(w, rsp); err != nil {
+			log.Infof("Error %v\n", err)
+		}
+	}
+}
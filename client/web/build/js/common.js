@@ -1434,6 +1434,31 @@ var packageMethod = {
                 }
             }
         });
+    },
+    //导出配置文件，用口令加密；
+    exportConfig:function(){
+        let passphrase = prompt($.i18n.prop('exportPassphrase'));
+        if(passphrase===null){
+            return;
+        }
+        $.ajax({
+            url:"/api/v1/config/export",
+            method:'POST',
+            contentType:'application/json',
+            data:JSON.stringify({"passphrase":passphrase}),
+            dataType:'text',
+            success:function(res){
+                let blob = new Blob([res],{type:'application/json'});
+                let a = document.createElement('a');
+                a.href = URL.createObjectURL(blob);
+                a.download = 'config.json';
+                a.click();
+                URL.revokeObjectURL(a.href);
+            },
+            error:function(xhr){
+                alert(xhr.responseText);
+            }
+        });
     }
 };
 
@@ -23,9 +23,12 @@ var NoConfErr = errors.New("not found config file")
 var ConfVerifyErr = errors.New("verify config file failed")
 
 type ExtraStorageInfo struct {
-	Path   string
-	Volume uint64
-	Index  byte // 1-based
+	Path     string
+	Volume   uint64
+	Index    byte   // 1-based
+	Tier     string `json:",omitempty"` // optional, small: prefer small blocks, eg: SSD, large: prefer large blocks
+	Removing bool   `json:",omitempty"` // blocks are migrating to other storages, no new block write to it
+	Removed  bool   `json:",omitempty"` // keep the index occupied, providerDb store index byte
 }
 
 type ProviderConfig struct {
@@ -39,10 +42,13 @@ type ProviderConfig struct {
 	Availability      float64
 	MainStoragePath   string
 	MainStorageVolume uint64
+	MainStorageTier   string `json:",omitempty"`
 	UpBandwidth       uint64
 	DownBandwidth     uint64
 	EncryptKey        map[string]string  // key: version, eg: 0, 1, 2
 	ExtraStorage      []ExtraStorageInfo `json:",omitempty"` //key:storage index, 1-based eg: 1, 2, 3
+	Rebalance         bool               `json:",omitempty"` // set by rebalance command, reset by daemon when finished
+	VolumeChanged     bool               `json:",omitempty"` // set when storage removed or rebalanced, reset after tracker is updated
 }
 
 var providerConfig *ProviderConfig
@@ -162,6 +168,7 @@ func checkAndReload() {
 			err = verifyConfig(pc)
 			if err == nil {
 				providerConfig = pc
+				checkStorageAvailableSpaceOfConf()
 			} else {
 				log.Warnln(err)
 			}
@@ -51,3 +51,55 @@ func getConfigFileSize() int64 {
 	}
 	return fileInfo.Size()
 }
+
+func TestStorageVolume(t *testing.T) {
+	providerConfig = &ProviderConfig{
+		MainStorageVolume: 200000000000,
+		ExtraStorage: []ExtraStorageInfo{
+			ExtraStorageInfo{Path: "/extra/storage/path1",
+				Volume:  200000000000,
+				Index:   1,
+				Removed: true},
+			ExtraStorageInfo{Path: "/extra/storage/path2",
+				Volume:   100000000000,
+				Index:    2,
+				Removing: true},
+			ExtraStorageInfo{Path: "/extra/storage/path3",
+				Volume: 300000000000,
+				Index:  3},
+		},
+	}
+	mainVolume, extraVolume := StorageVolume()
+	if mainVolume != 200000000000 {
+		t.Errorf("Failed. main volume: %d", mainVolume)
+	}
+	if len(extraVolume) != 1 || extraVolume[0] != 300000000000 {
+		t.Errorf("Failed. extra volume: %v", extraVolume)
+	}
+}
+
+func TestVolumeChanged(t *testing.T) {
+	configFilePath = "/tmp/config-volume-test.json"
+	defer removeConfigFile()
+	providerConfig = &ProviderConfig{NodeId: "test-node-id", Rebalance: true}
+	if VolumeChanged() {
+		t.Errorf("Failed. volume should not be changed")
+	}
+	if err := FinishRebalance(); err != nil {
+		t.Fatalf("Failed. %s", err)
+	}
+	// the change is saved, so it survives restart until tracker is updated
+	pc, err := readConfig()
+	if err != nil {
+		t.Fatalf("Failed. %s", err)
+	}
+	if pc.Rebalance || !pc.VolumeChanged || !VolumeChanged() {
+		t.Errorf("Failed. volume change should be saved")
+	}
+	if err := FinishVolumeUpdate(); err != nil {
+		t.Fatalf("Failed. %s", err)
+	}
+	if pc, err = readConfig(); err != nil || pc.VolumeChanged || VolumeChanged() {
+		t.Errorf("Failed. volume change should be reset")
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samoslab/nebula/provider/disk"
+	log "github.com/sirupsen/logrus"
+)
+
+const TierSmall = "small"
+const TierLarge = "large"
+
+// same as small_file_limit of provider impl, block less than it is stored in small file db
+const small_file_limit = 512 * 1024
+
+// seconds of cached free space keep valid
+const free_space_ttl = 10
+
+func ValidTier(tier string) bool {
+	return tier == "" || tier == TierSmall || tier == TierLarge
+}
+
+func (self *Storage) cacheFreeSpace(free uint64) {
+	atomic.StoreUint64(&self.free, free)
+	atomic.StoreInt64(&self.freeTs, time.Now().Unix())
+}
+
+func (self *Storage) freeSpace() uint64 {
+	if time.Now().Unix()-atomic.LoadInt64(&self.freeTs) > free_space_ttl {
+		_, free, err := disk.Space(self.Path)
+		if err != nil {
+			log.Warnf("get storage %s free space error:%s", self.Path, err)
+			return 0
+		}
+		self.cacheFreeSpace(free)
+	}
+	return atomic.LoadUint64(&self.free)
+}
+
+func (self *Storage) minAvailable() uint64 {
+	if self.Index == 0 {
+		return min_available_volume_of_main
+	}
+	return min_available_volume
+}
+
+// AvailableSpace return bytes can be written, bounded by disk free space excluding the reserved margin
+// and by the quota of declared volume, in-flight writes are excluded from both
+func (self *Storage) AvailableSpace() uint64 {
+	avail := self.freeSpace()
+	if min := self.minAvailable(); avail > min {
+		avail -= min
+	} else {
+		avail = 0
+	}
+	if left, ok := self.quotaLeft(); ok && left < avail {
+		avail = left
+	}
+	reserved := atomic.LoadUint64(&self.reserved)
+	if avail <= reserved {
+		return 0
+	}
+	return avail - reserved
+}
+
+// tierRank 0: preferred, 1: no preference, 2: only if nothing else available
+func (self *Storage) tierRank(size uint64) int {
+	switch self.Tier {
+	case TierSmall:
+		if size < small_file_limit {
+			return 0
+		}
+		return 2
+	case TierLarge:
+		if size >= small_file_limit {
+			return 0
+		}
+		return 2
+	}
+	return 1
+}
+
+func chooseStorage(size uint64) *Storage {
+	sl := storageSlice
+	candidates := make([]*Storage, 0, len(sl))
+	weights := make([]uint64, 0, len(sl))
+	for rank := 0; rank < 3; rank++ {
+		candidates, weights = candidates[:0], weights[:0]
+		var total uint64
+		for _, s := range sl {
+			if s.tierRank(size) != rank {
+				continue
+			}
+			w := s.AvailableSpace()
+			if w <= size {
+				continue
+			}
+			candidates = append(candidates, s)
+			weights = append(weights, w)
+			total += w
+		}
+		if total == 0 {
+			continue
+		}
+		r := uint64(rand.Int63n(int64(total)))
+		for i, w := range weights {
+			if r < w {
+				return candidates[i]
+			}
+			r -= w
+		}
+	}
+	return nil
+}
+
+// GetWriteStorage choose a storage weighted by available space, return nil if no storage can hold size bytes
+func GetWriteStorage(size uint64) *Storage {
+	return chooseStorage(size)
+}
+
+type Reservation struct {
+	*Storage
+	size     uint64
+	released int32
+}
+
+var reserveMutex sync.Mutex
+
+// ReserveWriteStorage choose a storage and reserve size bytes of it until Release,
+// so concurrent writes can not overcommit the same disk
+func ReserveWriteStorage(size uint64) *Reservation {
+	reserveMutex.Lock()
+	defer reserveMutex.Unlock()
+	s := chooseStorage(size)
+	if s == nil {
+		return nil
+	}
+	atomic.AddUint64(&s.reserved, size)
+	return &Reservation{Storage: s, size: size}
+}
+
+// Release the reservation, stored is true if the data was written to the storage and accounted as used
+func (self *Reservation) Release(stored bool) {
+	if !atomic.CompareAndSwapInt32(&self.released, 0, 1) {
+		return
+	}
+	if stored {
+		self.Storage.AddUsed(self.size)
+	}
+	atomic.AddUint64(&self.Storage.reserved, ^(self.size - 1))
+	if stored {
+		// cached free space is not aware of the written data until refresh
+		for {
+			free := atomic.LoadUint64(&self.Storage.free)
+			val := uint64(0)
+			if free > self.size {
+				val = free - self.size
+			}
+			if atomic.CompareAndSwapUint64(&self.Storage.free, free, val) {
+				break
+			}
+		}
+	}
+}
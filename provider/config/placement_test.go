@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestStorage(index byte, free uint64, tier string) *Storage {
+	s := &Storage{Path: "/not/exists", Index: index, Tier: tier}
+	s.free = free
+	s.freeTs = time.Now().Unix() + 3600
+	return s
+}
+
+func TestChooseStorageTier(t *testing.T) {
+	ssd := newTestStorage(1, 10*min_available_volume, TierSmall)
+	hdd := newTestStorage(2, 100*min_available_volume, "")
+	storageSlice = []*Storage{hdd, ssd}
+	for i := 0; i < 100; i++ {
+		if s := GetWriteStorage(1024); s != ssd {
+			t.Errorf("Failed. small block should write to small tier storage")
+		}
+		if s := GetWriteStorage(small_file_limit); s != hdd {
+			t.Errorf("Failed. large block should not write to small tier storage")
+		}
+	}
+}
+
+func TestReserveWriteStorage(t *testing.T) {
+	s := newTestStorage(1, 3*min_available_volume, "")
+	storageSlice = []*Storage{s}
+	r1 := ReserveWriteStorage(min_available_volume)
+	if r1 == nil {
+		t.Fatalf("Failed. first reservation should success")
+	}
+	if r2 := ReserveWriteStorage(min_available_volume); r2 != nil {
+		t.Errorf("Failed. reservation should not overcommit storage")
+	}
+	r1.Release(false)
+	r3 := ReserveWriteStorage(min_available_volume)
+	if r3 == nil {
+		t.Fatalf("Failed. reservation should success after release")
+	}
+	r3.Release(true)
+	if s.reserved != 0 || s.free != 2*min_available_volume {
+		t.Errorf("Failed. reserved: %d, free: %d", s.reserved, s.free)
+	}
+}
+
+func TestAvailableSpaceBoundedByQuota(t *testing.T) {
+	s := newTestStorage(1, 10*min_available_volume, "")
+	if s.AvailableSpace() != 9*min_available_volume {
+		t.Errorf("Failed. no quota, available: %d", s.AvailableSpace())
+	}
+	s.DeclaredVolume = 4 * min_available_volume
+	s.SetFileUsed(min_available_volume)
+	s.AddUsed(1024)
+	if s.Used() != min_available_volume+1024 {
+		t.Errorf("Failed. used: %d", s.Used())
+	}
+	if s.AvailableSpace() != 3*min_available_volume-1024 {
+		t.Errorf("Failed. quota bounded, available: %d", s.AvailableSpace())
+	}
+	s.AddUsed(3 * min_available_volume)
+	if !s.QuotaExceeded() || s.AvailableSpace() != 0 {
+		t.Errorf("Failed. quota should be exceeded, available: %d", s.AvailableSpace())
+	}
+	storageSlice = []*Storage{s}
+	if ReserveWriteStorage(small_file_limit) != nil {
+		t.Errorf("Failed. should not reserve storage which quota exceeded")
+	}
+	s.SubUsed(2 * min_available_volume)
+	if s.QuotaExceeded() {
+		t.Errorf("Failed. quota should not be exceeded after block removed")
+	}
+}
+
+func TestGetStorageRemoved(t *testing.T) {
+	s := newTestStorage(7, min_available_volume, "")
+	putStorage("7", s)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			GetStorage(7)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		putStorage("8", s)
+	}
+	<-done
+	if path, err := GetStoragePath(7, "/a/b"); err != nil || path != "/not/exists"+sep+"a"+sep+"b" {
+		t.Errorf("Failed. path: %s error: %v", path, err)
+	}
+	storageMapLock.Lock()
+	delete(storageMap, "7")
+	delete(storageMap, "8")
+	storageMapLock.Unlock()
+	if GetStorage(7) != nil {
+		t.Errorf("Failed. removed storage should be nil")
+	}
+	if _, err := GetStoragePath(7, "/a/b"); err == nil {
+		t.Errorf("Failed. path of removed storage should be error")
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"sync/atomic"
+
+	"github.com/samoslab/nebula/provider/disk"
+	util_file "github.com/samoslab/nebula/util/file"
+	log "github.com/sirupsen/logrus"
+)
+
+// Used return bytes stored in the storage, include block files, small file db and segments,
+// provider db is counted in main storage
+func (self *Storage) Used() uint64 {
+	return atomic.LoadUint64(&self.fileUsed) + atomic.LoadUint64(&self.dbUsed)
+}
+
+// SetFileUsed set bytes of block files, called after provider db scanned
+func (self *Storage) SetFileUsed(size uint64) {
+	atomic.StoreUint64(&self.fileUsed, size)
+}
+
+// AddUsed account block stored in the storage, small block is counted into segments
+func (self *Storage) AddUsed(size uint64) {
+	if size < small_file_limit {
+		atomic.AddUint64(&self.dbUsed, size)
+	} else {
+		atomic.AddUint64(&self.fileUsed, size)
+	}
+}
+
+// SubUsed account block file removed from the storage, space of removed small block is
+// reclaimed when segment compacted, so it is corrected by refreshDbUsed
+func (self *Storage) SubUsed(size uint64) {
+	if size < small_file_limit {
+		return
+	}
+	for {
+		used := atomic.LoadUint64(&self.fileUsed)
+		val := uint64(0)
+		if used > size {
+			val = used - size
+		}
+		if atomic.CompareAndSwapUint64(&self.fileUsed, used, val) {
+			return
+		}
+	}
+}
+
+func (self *Storage) refreshDbUsed() {
+	size, err := dirSize(self.Path + sep + sys_folder + sep + "small-file")
+	if err != nil {
+		log.Warnf("get small file db size of storage %s error: %s", self.Path, err)
+		return
+	}
+	segSize, err := dirSize(self.segmentPath())
+	if err != nil {
+		log.Warnf("get segment size of storage %s error: %s", self.Path, err)
+		return
+	}
+	size += segSize
+	if self.Index == 0 {
+		pdbSize, err := dirSize(self.providerDbPath())
+		if err != nil {
+			log.Warnf("get provider db size error: %s", err)
+			return
+		}
+		size += pdbSize
+	}
+	atomic.StoreUint64(&self.dbUsed, size)
+}
+
+func dirSize(path string) (uint64, error) {
+	if !util_file.Exists(path) {
+		return 0, nil
+	}
+	size, err := disk.DirSize(path)
+	return uint64(size), err
+}
+
+// quotaLeft return bytes can be stored before reach the declared volume, ok is false if no quota
+func (self *Storage) quotaLeft() (left uint64, ok bool) {
+	if self.DeclaredVolume == 0 {
+		return 0, false
+	}
+	used := self.Used()
+	if used >= self.DeclaredVolume {
+		return 0, true
+	}
+	return self.DeclaredVolume - used, true
+}
+
+// QuotaExceeded return true if bytes stored reach the declared volume
+func (self *Storage) QuotaExceeded() bool {
+	left, ok := self.quotaLeft()
+	return ok && left == 0
+}
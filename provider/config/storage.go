@@ -2,17 +2,19 @@ package config
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/samoslab/nebula/provider/disk"
+	"github.com/samoslab/nebula/provider/segment"
 	util_bytes "github.com/samoslab/nebula/util/bytes"
 	util_file "github.com/samoslab/nebula/util/file"
 	util_num "github.com/samoslab/nebula/util/num"
@@ -30,10 +32,19 @@ const ModFactor = 1 << ModFactorExp
 const slash = "/"
 
 type Storage struct {
-	Path        string
-	Index       byte // 0 as Main Storage
-	Volume      uint64
-	SmallFileDb *leveldb.DB
+	Path           string
+	Index          byte // 0 as Main Storage
+	Volume         uint64
+	DeclaredVolume uint64 // volume promised to tracker, MainStorageVolume or ExtraStorageInfo.Volume
+	Tier           string
+	Removing       bool
+	SmallFileDb    *leveldb.DB // legacy store of small blocks, migrated to Segments
+	Segments       *segment.Store
+	reserved       uint64 // bytes of in-flight writes, atomic
+	fileUsed       uint64 // bytes of block files stored, atomic
+	dbUsed         uint64 // bytes of small file db and segments on disk, atomic
+	free           uint64 // cached free bytes of disk, atomic
+	freeTs         int64  // unix timestamp of free cached, atomic
 }
 
 func (self *Storage) initStorage() error {
@@ -70,6 +81,11 @@ func (self *Storage) initStorage() error {
 	if err != nil {
 		return fmt.Errorf("open small file db failed: %s", err)
 	}
+	self.Segments, err = segment.Open(self.segmentPath(), segment.DefaultSegmentSize)
+	if err != nil {
+		self.SmallFileDb.Close()
+		return fmt.Errorf("open segment store failed: %s", err)
+	}
 	tempPath := self.TempPath()
 	if !util_file.Exists(tempPath) {
 		if err = os.MkdirAll(tempPath, 0700); err != nil {
@@ -79,6 +95,10 @@ func (self *Storage) initStorage() error {
 	return nil
 }
 
+func (self *Storage) segmentPath() string {
+	return self.Path + sep + sys_folder + sep + "segment"
+}
+
 func (self *Storage) TempPath() string {
 	return self.Path + sep + sys_folder + sep + tmp_folder
 }
@@ -145,6 +165,7 @@ func NewStorage(path string, index byte) (storage *Storage, err error) {
 	if err = s.initStorage(); err != nil {
 		return nil, err
 	}
+	s.refreshDbUsed()
 	return s, nil
 }
 
@@ -157,54 +178,49 @@ func cleanPath(path string) string {
 	return path
 }
 
-func GetWriteStorage(size uint64) *Storage {
-	sl := storageSlice
-	l := len(sl)
-	if l == 0 {
-		return nil
-	}
-	defer incrementStorageIdx()
-	//104857600 = 100M
-	if size < 104857600 {
-		return sl[currentStorageIdx%uint64(l)]
-	} else {
-		first := int(currentStorageIdx % uint64(l))
-		for i := first; i < first+l; i++ {
-			s := sl[i%l]
-			_, free, err := disk.Space(s.Path)
-			if err != nil {
-				log.Warnf("get storage %s free space error:%s", s.Path, err)
-				continue
-			} else if free > min_available_volume+size {
-				return s
-			} else {
-				continue
-			}
-		}
-		return nil
+// GetStoragePath full path of block file, error if the storage is removed or not opened
+func GetStoragePath(index byte, subPath string) (string, error) {
+	s := GetStorage(index)
+	if s == nil {
+		return "", fmt.Errorf("storage %d not exist", index)
 	}
+	return s.Path + strings.Replace(subPath, slash, sep, -1), nil
 }
 
-func GetStoragePath(index byte, subPath string) string {
-	return storageMap[strconv.FormatInt(int64(index), 10)].Path + strings.Replace(subPath, slash, sep, -1)
-}
-
+// GetStorage opened storage of index, nil if it is removed or not opened
 func GetStorage(index byte) *Storage {
 	idx := strconv.FormatInt(int64(index), 10)
+	storageMapLock.RLock()
+	defer storageMapLock.RUnlock()
 	return storageMap[idx]
 }
 
 func ProviderDbPath() string {
-	return GetStorage(0).Path + sep + sys_folder + sep + "provider-db"
+	return GetStorage(0).providerDbPath()
+}
+
+func TaskJournalPath() string {
+	return GetStorage(0).Path + sep + sys_folder + sep + "task-journal"
+}
+
+func (self *Storage) providerDbPath() string {
+	return self.Path + sep + sys_folder + sep + "provider-db"
 }
 
 var storageSlice []*Storage
-var storageMap map[string]*Storage
 
-var currentStorageIdx uint64 = 0
+// storageMap is changed while serving when storage is added or removed online, writers hold both
+// checkStorageOfConf and storageMapLock, readers hold either of them
+var storageMap map[string]*Storage
+var storageMapLock sync.RWMutex
 
-func incrementStorageIdx() {
-	atomic.AddUint64(&currentStorageIdx, 1)
+func putStorage(idx string, s *Storage) {
+	storageMapLock.Lock()
+	defer storageMapLock.Unlock()
+	if storageMap == nil {
+		storageMap = make(map[string]*Storage)
+	}
+	storageMap[idx] = s
 }
 
 func checkStorageAvailableSpace() {
@@ -218,10 +234,15 @@ func checkStorageAvailableSpace() {
 			log.Warnf("get storage %s free space error:%s", s.Path, err)
 			continue
 		}
+		s.cacheFreeSpace(free)
+		s.refreshDbUsed()
 		if (s.Index == 0 && free <= min_available_volume_of_main) || (s.Index != 0 && free <= min_available_volume) {
 			log.Warnf("storage path %s available space less than 1GB", s.Path)
 			continue
 		}
+		if s.QuotaExceeded() {
+			log.Warnf("storage path %s used %d bytes, reach the volume %d", s.Path, s.Used(), s.DeclaredVolume)
+		}
 		sl = append(sl, s)
 	}
 	storageSlice = sl
@@ -237,9 +258,6 @@ var checkStorageOfConfFirst = true
 func checkStorageAvailableSpaceOfConf() {
 	checkStorageOfConf.Lock()
 	defer checkStorageOfConf.Unlock()
-	if storageMap == nil {
-		storageMap = make(map[string]*Storage, 1+len(providerConfig.ExtraStorage))
-	}
 	sl := make([]*Storage, 0, 1+len(providerConfig.ExtraStorage))
 	var ok bool
 	var s *Storage
@@ -249,8 +267,9 @@ func checkStorageAvailableSpaceOfConf() {
 		if err != nil {
 			log.Fatalf("main storage error: %s", err)
 		}
-		storageMap["0"] = s
+		putStorage("0", s)
 	}
+	s.DeclaredVolume, s.Tier = providerConfig.MainStorageVolume, providerConfig.MainStorageTier
 	s.cleanTemp()
 	if s.Volume > min_available_volume_of_main {
 		sl = append(sl, s)
@@ -259,6 +278,9 @@ func checkStorageAvailableSpaceOfConf() {
 	}
 	if len(providerConfig.ExtraStorage) > 0 {
 		for _, v := range providerConfig.ExtraStorage {
+			if v.Removed {
+				continue
+			}
 			idx := strconv.FormatInt(int64(v.Index), 10)
 			if s, ok = storageMap[idx]; !ok {
 				s, err = NewStorage(v.Path, v.Index)
@@ -270,7 +292,11 @@ func checkStorageAvailableSpaceOfConf() {
 					}
 					continue
 				}
-				storageMap[idx] = s
+				putStorage(idx, s)
+			}
+			s.DeclaredVolume, s.Tier, s.Removing = v.Volume, v.Tier, v.Removing
+			if s.Removing {
+				continue
 			}
 			if s.Volume > min_available_volume {
 				sl = append(sl, s)
@@ -283,10 +309,107 @@ func checkStorageAvailableSpaceOfConf() {
 	checkStorageOfConfFirst = false
 }
 
+// StorageList return all opened storage order by index, include storage which is removing
+func StorageList() []*Storage {
+	checkStorageOfConf.Lock()
+	defer checkStorageOfConf.Unlock()
+	res := make([]*Storage, 0, len(storageMap))
+	for _, s := range storageMap {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Index < res[j].Index })
+	return res
+}
+
+func RemovingStorage() []*Storage {
+	res := make([]*Storage, 0, 1)
+	for _, s := range StorageList() {
+		if s.Removing {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// FinishRemoveStorage mark the extra storage as removed after all blocks of it were migrated
+func FinishRemoveStorage(index byte) error {
+	if index == 0 {
+		return errors.New("main storage can not be removed")
+	}
+	checkStorageOfConf.Lock()
+	defer checkStorageOfConf.Unlock()
+	found := false
+	for i, v := range providerConfig.ExtraStorage {
+		if v.Index == index {
+			providerConfig.ExtraStorage[i].Removing = false
+			providerConfig.ExtraStorage[i].Removed = true
+			providerConfig.VolumeChanged = true
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("extra storage %d not found", index)
+	}
+	idx := strconv.FormatInt(int64(index), 10)
+	storageMapLock.Lock()
+	s, ok := storageMap[idx]
+	delete(storageMap, idx)
+	storageMapLock.Unlock()
+	if ok {
+		s.SmallFileDb.Close()
+		s.Segments.Close()
+	}
+	return saveProviderConfig(configFilePath, providerConfig)
+}
+
+func RebalanceRequested() bool {
+	return providerConfig != nil && providerConfig.Rebalance
+}
+
+func FinishRebalance() error {
+	checkStorageOfConf.Lock()
+	defer checkStorageOfConf.Unlock()
+	providerConfig.Rebalance = false
+	providerConfig.VolumeChanged = true
+	return saveProviderConfig(configFilePath, providerConfig)
+}
+
+// VolumeChanged return true if storage volume changed and tracker is not updated yet
+func VolumeChanged() bool {
+	checkStorageOfConf.Lock()
+	defer checkStorageOfConf.Unlock()
+	return providerConfig != nil && providerConfig.VolumeChanged
+}
+
+// FinishVolumeUpdate reset VolumeChanged after tracker is updated
+func FinishVolumeUpdate() error {
+	checkStorageOfConf.Lock()
+	defer checkStorageOfConf.Unlock()
+	providerConfig.VolumeChanged = false
+	return saveProviderConfig(configFilePath, providerConfig)
+}
+
+// StorageVolume return declared volume of main storage and extra storage that not removed
+func StorageVolume() (mainVolume uint64, extraVolume []uint64) {
+	pc := providerConfig
+	mainVolume = pc.MainStorageVolume
+	extraVolume = make([]uint64, 0, len(pc.ExtraStorage))
+	for _, v := range pc.ExtraStorage {
+		if !v.Removed && !v.Removing {
+			extraVolume = append(extraVolume, v.Volume)
+		}
+	}
+	return
+}
+
 func stopStorage() {
+	storageMapLock.RLock()
+	defer storageMapLock.RUnlock()
 	if storageMap != nil {
 		for _, v := range storageMap {
 			v.SmallFileDb.Close()
+			v.Segments.Close()
 		}
 	}
 }
@@ -298,16 +421,7 @@ func AvailableVolume() (total uint64, max uint64) {
 		return
 	}
 	for _, s := range storageSlice {
-		_, free, err := disk.Space(s.Path)
-		if err != nil {
-			log.Errorf("get disk space of path %s error: %s", s.Path, err)
-			continue
-		}
-		if s.Index == 0 {
-			free -= min_available_volume_of_main
-		} else {
-			free -= min_available_volume
-		}
+		free := s.AvailableSpace()
 		if free > min_available_volume_plus {
 			total += free
 			if free > max {
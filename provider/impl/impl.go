@@ -5,7 +5,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
-	"math/big"
 	"os"
 	"sort"
 	"sync"
@@ -17,11 +16,14 @@ import (
 	"github.com/samoslab/nebula/provider/node"
 	pb "github.com/samoslab/nebula/provider/pb"
 	provider_client "github.com/samoslab/nebula/provider/provider_client"
+	"github.com/samoslab/nebula/provider/segment"
 	task_client "github.com/samoslab/nebula/provider/task_client"
 	tcppb "github.com/samoslab/nebula/tracker/collector/provider/pb"
 	ttpb "github.com/samoslab/nebula/tracker/task/pb"
 	util_file "github.com/samoslab/nebula/util/file"
 	util_hash "github.com/samoslab/nebula/util/hash"
+	"github.com/samoslab/nebula/util/por"
+	"github.com/samoslab/nebula/util/trackerpool"
 	log "github.com/sirupsen/logrus"
 	"github.com/syndtr/goleveldb/leveldb"
 	"golang.org/x/net/context"
@@ -39,15 +41,22 @@ type ProviderService struct {
 	node               *node.Node
 	nodeIdHash         []byte
 	providerDb         *leveldb.DB
+	taskDb             *leveldb.DB
 	taskGetting        gosync.Mutex
 	blocksVerifying    gosync.Mutex
+	storageMigrating   gosync.Mutex
+	segmentsCompacting gosync.Mutex
+	smallFileMigrated  bool
+	blockLocks         [256]sync.Mutex
 	replicateChan      chan *ttpb.Task
 	sendChan           chan *ttpb.Task
 	removeAndProveChan chan *ttpb.Task
 	closeSignal        []chan bool
+	pendingTasks       map[string]bool
+	pendingMutex       sync.Mutex
 	shutdownSignal     chan bool
 	waitClose          sync.WaitGroup
-	taskConnection     *grpc.ClientConn
+	taskServers        *trackerpool.Pool
 	ptsc               ttpb.ProviderTaskServiceClient
 }
 
@@ -63,12 +72,75 @@ func NewProviderService(taskServer string, private bool) *ProviderService {
 	if err != nil {
 		log.Fatalf("open Provider DB failed:%s", err)
 	}
+	ps.initStorageUsage()
+	ps.taskDb, err = leveldb.OpenFile(config.TaskJournalPath(), nil)
+	if err != nil {
+		log.Fatalf("open task journal failed:%s", err)
+	}
 	ps.initTaskProcessor(taskServer, private)
 	return ps
 }
 
+// initStorageUsage sum size of block files and live segment records in every storage, size of small file db
+// is counted by config, index of segment record lost in crash is dropped and reported by VerifyBlocks
+func (self *ProviderService) initStorageUsage() {
+	used := make(map[byte]uint64, 8)
+	live := make(map[byte]map[uint32]uint64, 8)
+	lost := make([][]byte, 0, 8)
+	iter := self.providerDb.NewIterator(nil, nil)
+	for iter.Next() {
+		val := iter.Value()
+		if len(val) <= 1 {
+			continue
+		}
+		storage := config.GetStorage(val[0])
+		if storage == nil {
+			continue
+		}
+		if isSegmentValue(val) {
+			loc, err := segment.ParseLocation(val[2:])
+			if err != nil || !storage.Segments.Valid(iter.Key(), loc) {
+				lost = append(lost, append([]byte(nil), iter.Key()...))
+				continue
+			}
+			if live[val[0]] == nil {
+				live[val[0]] = make(map[uint32]uint64, 16)
+			}
+			live[val[0]][loc.Segment] += uint64(segment.RecordSize(len(iter.Key()), loc.Length))
+			continue
+		}
+		path, err := config.GetStoragePath(val[0], string(val[1:]))
+		if err != nil {
+			continue
+		}
+		fileInfo, err := os.Stat(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Warnf("stat block %x failed: %s", iter.Key(), err)
+			}
+			continue
+		}
+		used[val[0]] += uint64(fileInfo.Size())
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		log.Errorf("iterate provider db failed: %s", err)
+	}
+	for _, key := range lost {
+		log.Warnf("segment record of block %x is lost, drop it from provider db", key)
+		if err := self.providerDb.Delete(key, nil); err != nil {
+			log.Errorf("delete %x from provider db failed: %s", key, err)
+		}
+	}
+	for _, s := range config.StorageList() {
+		s.SetFileUsed(used[s.Index])
+		s.Segments.SetLive(live[s.Index])
+	}
+}
+
 func (self *ProviderService) Close() {
 	self.providerDb.Close()
+	self.taskDb.Close()
 }
 
 func (self *ProviderService) Ping(ctx context.Context, req *pb.PingReq) (*pb.PingResp, error) {
@@ -132,20 +204,16 @@ func (self *ProviderService) StoreSmall(ctx context.Context, req *pb.StoreReq) (
 		logWarnAndSetActionLog(err, al)
 		return
 	}
-	storage := config.GetWriteStorage(req.BlockSize)
-	if storage == nil {
-		err = status.Errorf(codes.ResourceExhausted, "available disk space of this provider is not enlough, blockKey: %s blockSize: %d", req.BlockKey, req.BlockSize)
+	reservation := config.ReserveWriteStorage(req.BlockSize)
+	if reservation == nil {
+		err = status.Errorf(codes.ResourceExhausted, "available disk space or volume quota of this provider is not enlough, blockKey: %s blockSize: %d", req.BlockKey, req.BlockSize)
 		logWarnAndSetActionLog(err, al)
 		al.TransportSize += uint64(len(req.Data))
 		return
 	}
-	if err = storage.SmallFileDb.Put(req.BlockKey, req.Data, nil); err != nil {
-		err = status.Errorf(codes.Internal, "save to small file db failed, blockKey: %x error: %s", req.BlockKey, err)
-		logWarnAndSetActionLog(err, al)
-		return
-	}
-	if err = self.providerDb.Put(req.BlockKey, []byte{storage.Index}, nil); err != nil {
-		err = status.Errorf(codes.Internal, "save to provider db failed, blockKey: %x error: %s", req.BlockKey, err)
+	defer func() { reservation.Release(err == nil) }()
+	if err = self.putSmall(reservation.Storage, req.BlockKey, req.Data); err != nil {
+		err = status.Errorf(codes.Internal, "save small file failed, blockKey: %x error: %s", req.BlockKey, err)
 		logWarnAndSetActionLog(err, al)
 		return
 	}
@@ -191,9 +259,8 @@ func (self *ProviderService) Store(stream pb.ProviderService_StoreServer) (er er
 			}
 			if found, smallFile, storageIdx, subPath := self.querySubPath(blockKey); found {
 				if smallFile {
-					storage := config.GetStorage(storageIdx)
-					data, err := storage.SmallFileDb.Get(blockKey, nil)
-					if err != nil && err != leveldb.ErrNotFound {
+					data, err := self.readSmall(blockKey, storageIdx, subPath)
+					if err != nil && !isDataLost(err) {
 						er = status.Errorf(codes.Internal, "read small file error, blockKey: %x error: %s", blockKey, err)
 						logWarnAndSetActionLog(er, al)
 						al.TransportSize += uint64(len(req.Data))
@@ -206,7 +273,13 @@ func (self *ProviderService) Store(stream pb.ProviderService_StoreServer) (er er
 						return
 					}
 				} else {
-					path := config.GetStoragePath(storageIdx, subPath)
+					path, err := config.GetStoragePath(storageIdx, subPath)
+					if err != nil {
+						er = status.Errorf(codes.Internal, "get path failed, blockKey: %x error: %s", blockKey, err)
+						logWarnAndSetActionLog(er, al)
+						al.TransportSize += uint64(len(req.Data))
+						return
+					}
 					fileInfo, err := os.Stat(path)
 					if err != nil && !os.IsNotExist(err) {
 						er = status.Errorf(codes.Internal, "stat file failed, blockKey: %x error: %s", blockKey, err)
@@ -234,17 +307,22 @@ func (self *ProviderService) Store(stream pb.ProviderService_StoreServer) (er er
 								al.TransportSize += uint64(len(req.Data))
 								return
 							}
+							if storage := config.GetStorage(storageIdx); storage != nil {
+								storage.SubUsed(uint64(fileInfo.Size()))
+							}
 						}
 					}
 				}
 			}
-			storage = config.GetWriteStorage(blockSize)
-			if storage == nil {
-				er = status.Errorf(codes.ResourceExhausted, "available disk space of this provider is not enlough, blockKey: %s blockSize: %d", blockKey, blockSize)
+			reservation := config.ReserveWriteStorage(blockSize)
+			if reservation == nil {
+				er = status.Errorf(codes.ResourceExhausted, "available disk space or volume quota of this provider is not enlough, blockKey: %s blockSize: %d", blockKey, blockSize)
 				logWarnAndSetActionLog(er, al)
 				al.TransportSize += uint64(len(req.Data))
 				return
 			}
+			defer func() { reservation.Release(er == nil) }()
+			storage = reservation.Storage
 			tempFilePath = storage.TempFilePath(blockKey)
 			file, err = os.OpenFile(
 				tempFilePath,
@@ -331,7 +409,7 @@ func (self *ProviderService) RetrieveSmall(ctx context.Context, req *pb.Retrieve
 			return
 		}
 	}
-	found, smallFile, storageIdx, _ := self.querySubPath(req.BlockKey)
+	found, smallFile, storageIdx, subPath := self.querySubPath(req.BlockKey)
 	if !found {
 		err = status.Errorf(codes.NotFound, "file not exist, blockKey: %x", req.BlockKey)
 		logWarnAndSetActionLog(err, al)
@@ -342,8 +420,7 @@ func (self *ProviderService) RetrieveSmall(ctx context.Context, req *pb.Retrieve
 		logWarnAndSetActionLog(err, al)
 		return
 	}
-	storage := config.GetStorage(storageIdx)
-	data, err := storage.SmallFileDb.Get(req.BlockKey, nil)
+	data, err := self.readSmall(req.BlockKey, storageIdx, subPath)
 	if err != nil {
 		err = status.Errorf(codes.Internal, "read small file error, blockKey: %x error: %s", req.BlockKey, err)
 		logWarnAndSetActionLog(err, al)
@@ -389,7 +466,12 @@ func (self *ProviderService) Retrieve(req *pb.RetrieveReq, stream pb.ProviderSer
 		logWarnAndSetActionLog(err, al)
 		return
 	}
-	path := config.GetStoragePath(storageIdx, subPath)
+	path, err := config.GetStoragePath(storageIdx, subPath)
+	if err != nil {
+		err = status.Errorf(codes.Internal, "get path failed, blockKey: %x error: %s", req.BlockKey, err)
+		logWarnAndSetActionLog(err, al)
+		return
+	}
 	hash, err := util_hash.Sha1File(path)
 	if err != nil {
 		err = status.Errorf(codes.Internal, "sha1 sum file %s failed, blockKey: %x error: %s", path, req.BlockKey, err)
@@ -446,6 +528,8 @@ func (self *ProviderService) Remove(ctx context.Context, req *pb.RemoveReq) (res
 			return
 		}
 	}
+	unlock := self.lockBlock(req.Key)
+	defer unlock()
 	found, smallFile, storageIdx, subPath := self.querySubPath(req.Key)
 	if !found {
 		err = status.Errorf(codes.NotFound, "file not exist, key: %x", req.Key)
@@ -458,15 +542,13 @@ func (self *ProviderService) Remove(ctx context.Context, req *pb.RemoveReq) (res
 		return
 	}
 	if smallFile {
-		storage := config.GetStorage(storageIdx)
-		if err = storage.SmallFileDb.Delete(req.Key, nil); err != nil {
+		if err = self.deleteSmall(req.Key, storageIdx, subPath); err != nil {
 			err = status.Errorf(codes.Internal, "delete from small file db failed, key: %x error: %s", req.Key, err)
 			log.Warnln(err)
 			return
 		}
 	} else {
-		path := config.GetStoragePath(storageIdx, subPath)
-		if err = os.Remove(path); err != nil {
+		if err = removeFile(storageIdx, subPath); err != nil {
 			err = status.Errorf(codes.Internal, "remove file failed, key: %x error: %s", req.Key, err)
 			log.Warnln(err)
 			return
@@ -503,16 +585,20 @@ func (self *ProviderService) GetFragment(ctx context.Context, req *pb.GetFragmen
 	}
 	var res [][]byte
 	if smallFile {
-		storage := config.GetStorage(storageIdx)
-		data, er := storage.SmallFileDb.Get(req.Key, nil)
+		r, size, er := self.smallReader(req.Key, storageIdx, subPath)
 		if er != nil {
 			err = status.Errorf(codes.Internal, "read small file error, blockKey: %x error: %s", req.Key, er)
 			log.Warnln(err)
 			return
 		}
-		res, err = getFragmentFromByteSlice(req.Key, data, req.Positions, req.Size)
+		res, err = getFragmentFromReader(req.Key, r, size, req.Positions, req.Size)
 	} else {
-		path := config.GetStoragePath(storageIdx, subPath)
+		path, er := config.GetStoragePath(storageIdx, subPath)
+		if er != nil {
+			err = status.Errorf(codes.Internal, "get path failed, key: %x error: %s", req.Key, er)
+			log.Warnln(err)
+			return
+		}
 		res, err = getFragmentFromFile(req.Key, path, req.Positions, req.Size)
 	}
 	if err != nil {
@@ -521,17 +607,22 @@ func (self *ProviderService) GetFragment(ctx context.Context, req *pb.GetFragmen
 	return &pb.GetFragmentResp{Data: res}, nil
 }
 
-func getFragmentFromByteSlice(key []byte, data []byte, positions []byte, size uint32) (fragment [][]byte, err error) {
+func getFragmentFromReader(key []byte, r io.ReaderAt, fileSize int64, positions []byte, size uint32) (fragment [][]byte, err error) {
 	res := make([][]byte, 0, len(positions))
-	fileSize := uint32(len(data))
 	for _, posPercent := range positions {
-		pos := uint32(posPercent) * fileSize / 100
-		if pos+size > fileSize {
+		pos := int64(posPercent) * fileSize / 100
+		if pos+int64(size) > fileSize {
 			err = status.Errorf(codes.InvalidArgument, "file position %d%%+%d out of bounds, key: %x", posPercent, size, key)
 			log.Warnln(err)
 			return
 		}
-		res = append(res, data[pos:pos+size])
+		buf := make([]byte, size)
+		if _, er := r.ReadAt(buf, pos); er != nil && !(er == io.EOF && pos+int64(size) == fileSize) {
+			err = status.Errorf(codes.Internal, "read small file failed, key: %x error: %s", key, er)
+			log.Warnln(err)
+			return
+		}
+		res = append(res, buf)
 	}
 	return res, nil
 }
@@ -592,6 +683,27 @@ func (self *ProviderService) saveFile(key []byte, fileSize uint64, tmpFilePath s
 	return self.providerDb.Put(key, pathSlice, nil)
 }
 
+// removeFile remove the block file and release the used volume of the storage
+func removeFile(storageIdx byte, subPath string) error {
+	storage := config.GetStorage(storageIdx)
+	if storage == nil {
+		return fmt.Errorf("storage %d not exist", storageIdx)
+	}
+	path, err := config.GetStoragePath(storageIdx, subPath)
+	if err != nil {
+		return err
+	}
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil {
+		return err
+	}
+	storage.SubUsed(uint64(fileInfo.Size()))
+	return nil
+}
+
 func (self *ProviderService) queryByKey(key []byte) []byte {
 	val, err := self.providerDb.Get(key, nil)
 	if err == nil {
@@ -608,6 +720,8 @@ func (self *ProviderService) querySubPath(key []byte) (found bool, smallFile boo
 		return false, false, 0, ""
 	} else if len(bytes) == 1 {
 		return true, true, bytes[0], ""
+	} else if isSegmentValue(bytes) {
+		return true, true, bytes[0], string(bytes[2:])
 	} else {
 		return true, false, bytes[0], string(bytes[1:])
 	}
@@ -628,13 +742,44 @@ func (self *ProviderService) CheckAvailable(ctx context.Context, req *pb.CheckAv
 	return &pb.CheckAvailableResp{Total: total, MaxFileSize: max, Version: 1}, nil
 }
 
+// Prove prove possession of block to owner of the file, who challenges with the metadata kept by itself
+func (self *ProviderService) Prove(ctx context.Context, req *pb.ProveReq) (resp *pb.ProveResp, err error) {
+	if !skip_check_auth {
+		if err = req.CheckAuth(self.node.PubKeyBytes); err != nil {
+			err = status.Errorf(codes.Unauthenticated, "check auth failed, blockKey: %x error: %s", req.BlockKey, err)
+			log.Warnln(err)
+			return
+		}
+	}
+	if len(req.ChunkSeq) == 0 || req.ChunkSize == 0 {
+		err = status.Errorf(codes.InvalidArgument, "no chunk to prove, blockKey: %x", req.BlockKey)
+		log.Warnln(err)
+		return
+	}
+	if found, _, _, _ := self.querySubPath(req.BlockKey); !found {
+		err = status.Errorf(codes.NotFound, "file not exist, blockKey: %x", req.BlockKey)
+		log.Warnln(err)
+		return
+	}
+	result, err := self.taskProve(req.BlockKey, req.BlockSize, req.ChunkSize, req.ChunkSeq, req.SchemeVersion)
+	if err != nil {
+		err = status.Errorf(codes.Internal, "prove failed, blockKey: %x error: %s", req.BlockKey, err)
+		log.Warnln(err)
+		return
+	}
+	return &pb.ProveResp{Result: result}, nil
+}
+
 func (self *ProviderService) initTaskProcessor(taskServer string, private bool) {
 	self.taskGetting = gosync.NewMutex()
 	self.blocksVerifying = gosync.NewMutex()
+	self.storageMigrating = gosync.NewMutex()
+	self.segmentsCompacting = gosync.NewMutex()
 	self.shutdownSignal = make(chan bool, 1)
 	self.replicateChan = make(chan *ttpb.Task, 320)
 	self.sendChan = make(chan *ttpb.Task, 320)
 	self.removeAndProveChan = make(chan *ttpb.Task, 320)
+	self.pendingTasks = make(map[string]bool, 64)
 	replicateThread := 2
 	sendTread := 1
 	processRemoveAndProve := 1
@@ -659,17 +804,18 @@ func (self *ProviderService) initTaskProcessor(taskServer string, private bool)
 	}
 	self.waitClose.Add(replicateThread + sendTread + processRemoveAndProve)
 	var err error
-	self.taskConnection, err = grpc.Dial(taskServer, grpc.WithInsecure())
+	self.taskServers, err = trackerpool.New(log.StandardLogger(), trackerpool.Split(taskServer), nil, nil)
 	if err != nil {
 		fmt.Printf("RPC Dial taskServer %s failed: %s\n", taskServer, err.Error())
 		os.Exit(60)
 	}
-	self.ptsc = ttpb.NewProviderTaskServiceClient(self.taskConnection)
+	self.taskServers.Start()
+	self.ptsc = ttpb.NewProviderTaskServiceClient(self.taskServers.Conn())
 }
 
 func (self *ProviderService) CloseTaskProcessor() {
-	defer self.taskConnection.Close()
-	self.shutdownSignal <- true
+	defer self.taskServers.Close()
+	close(self.shutdownSignal)
 	for _, closeSig := range self.closeSignal {
 		closeSig <- true
 	}
@@ -683,33 +829,44 @@ func (self *ProviderService) processReplicate(closeSig chan bool) {
 			self.waitClose.Done()
 			return
 		case ta := <-self.replicateChan:
-			if len(ta.OppositeId) == 0 {
-				fmt.Printf("Task [%x] info error, REPLICATE task haven't opposite id\n", ta.Id)
-				continue
-			}
-			resp, err := task_client.GetOppositeInfo(self.ptsc, ta.Id)
-			if err != nil {
-				fmt.Printf("Get task [%x] opposite info failed: %s\n", ta.Id, err.Error())
-				continue
-			}
-			if len(resp.Info) == 0 {
-				fmt.Printf("Get task [%x] opposite info error, REPLICATE task haven't opposite info\n", ta.Id)
-				continue
-			}
-			var remark string
-			success := true
-			if err = self.taskReplicate(ta.FileHash, ta.FileSize, ta.BlockHash, ta.BlockSize, resp.Timestamp, resp.Info); err != nil {
-				remark = err.Error()
-				success = false
-				fmt.Printf("taskReplicate failed, blockKey: %x, error: %s\n", ta.BlockHash, remark)
-			}
-			if err = task_client.FinishTask(self.ptsc, ta.Id, uint64(time.Now().Unix()), success, remark); err != nil {
-				fmt.Printf("Finish replicate task [%x] failed: %s\n", ta.Id, err.Error())
-			}
+			self.runReplicate(ta)
 		}
 	}
 }
 
+func (self *ProviderService) runReplicate(ta *ttpb.Task) {
+	defer self.donePending(ta.Id)
+	if len(ta.OppositeId) == 0 {
+		fmt.Printf("Task [%x] info error, REPLICATE task haven't opposite id\n", ta.Id)
+		self.deleteTaskEntry(ta.Id)
+		return
+	}
+	resp, err := task_client.GetOppositeInfo(self.ptsc, ta.Id)
+	if err != nil {
+		fmt.Printf("Get task [%x] opposite info failed: %s\n", ta.Id, err.Error())
+		if !self.retryTask(ta.Id, err) {
+			self.finishTask(ta, false, err.Error())
+		}
+		return
+	}
+	if len(resp.Info) == 0 {
+		fmt.Printf("Get task [%x] opposite info error, REPLICATE task haven't opposite info\n", ta.Id)
+		self.deleteTaskEntry(ta.Id)
+		return
+	}
+	var remark string
+	success := true
+	if err = self.taskReplicate(ta.FileHash, ta.FileSize, ta.BlockHash, ta.BlockSize, resp.Timestamp, resp.Info); err != nil {
+		fmt.Printf("taskReplicate failed, blockKey: %x, error: %s\n", ta.BlockHash, err.Error())
+		if self.retryTask(ta.Id, err) {
+			return
+		}
+		remark = err.Error()
+		success = false
+	}
+	self.finishTask(ta, success, remark)
+}
+
 func (self *ProviderService) processSend(closeSig chan bool) {
 	for {
 		select {
@@ -717,31 +874,42 @@ func (self *ProviderService) processSend(closeSig chan bool) {
 			self.waitClose.Done()
 			return
 		case ta := <-self.sendChan:
-			if len(ta.OppositeId) != 1 {
-				fmt.Printf("Task [%x] info error, SEND task haven't single opposite id\n", ta.Id)
-				continue
-			}
-			resp, err := task_client.GetOppositeInfo(self.ptsc, ta.Id)
-			if err != nil {
-				fmt.Printf("Get task [%x] opposite info failed: %s\n", ta.Id, err.Error())
-				continue
-			}
-			if len(resp.Info) != 1 {
-				fmt.Printf("Get task [%x] opposite info error, SEND task haven't single opposite info\n", ta.Id)
-				continue
-			}
-			var remark string
-			success := true
-			if err = self.taskSend(ta.FileHash, ta.FileSize, ta.BlockHash, ta.BlockSize, resp.Timestamp, resp.Info[0]); err != nil {
-				remark = err.Error()
-				success = false
-				fmt.Printf("taskSend failed, blockKey: %x, error: %s\n", ta.BlockHash, remark)
-			}
-			if err = task_client.FinishTask(self.ptsc, ta.Id, uint64(time.Now().Unix()), success, remark); err != nil {
-				fmt.Printf("Finish send task [%x] failed: %s\n", ta.Id, err.Error())
-			}
+			self.runSend(ta)
+		}
+	}
+}
+
+func (self *ProviderService) runSend(ta *ttpb.Task) {
+	defer self.donePending(ta.Id)
+	if len(ta.OppositeId) != 1 {
+		fmt.Printf("Task [%x] info error, SEND task haven't single opposite id\n", ta.Id)
+		self.deleteTaskEntry(ta.Id)
+		return
+	}
+	resp, err := task_client.GetOppositeInfo(self.ptsc, ta.Id)
+	if err != nil {
+		fmt.Printf("Get task [%x] opposite info failed: %s\n", ta.Id, err.Error())
+		if !self.retryTask(ta.Id, err) {
+			self.finishTask(ta, false, err.Error())
+		}
+		return
+	}
+	if len(resp.Info) != 1 {
+		fmt.Printf("Get task [%x] opposite info error, SEND task haven't single opposite info\n", ta.Id)
+		self.deleteTaskEntry(ta.Id)
+		return
+	}
+	var remark string
+	success := true
+	if err = self.taskSend(ta.FileHash, ta.FileSize, ta.BlockHash, ta.BlockSize, resp.Timestamp, resp.Info[0]); err != nil {
+		fmt.Printf("taskSend failed, blockKey: %x, error: %s\n", ta.BlockHash, err.Error())
+		if self.retryTask(ta.Id, err) {
+			return
 		}
+		remark = err.Error()
+		success = false
 	}
+	self.finishTask(ta, success, remark)
 }
 
 func (self *ProviderService) processRemoveAndProve(closeSig chan bool) {
@@ -752,49 +920,65 @@ func (self *ProviderService) processRemoveAndProve(closeSig chan bool) {
 			return
 		case ta := <-self.removeAndProveChan:
 			if ta.Type == ttpb.TaskType_REMOVE {
-				var remark string
-				success := true
-				if err := self.taskRemove(ta.FileHash, ta.FileSize, ta.BlockHash, ta.BlockSize); err != nil {
-					remark = err.Error()
-					success = false
-					fmt.Printf("taskRemove failed, blockKey: %x, error: %s\n", ta.BlockHash, remark)
-				}
-				if err := task_client.FinishTask(self.ptsc, ta.Id, uint64(time.Now().Unix()), success, remark); err != nil {
-					fmt.Printf("Finish remove task [%x] failed: %s\n", ta.Id, err.Error())
-				}
+				self.runRemove(ta)
 			} else if ta.Type == ttpb.TaskType_PROVE {
-				proofId, chunkSize, chunkSeq, err := task_client.GetProveInfo(self.ptsc, ta.Id)
-				if err != nil {
-					fmt.Printf("Get task [%x] prove info failed: %s\n", ta.Id, err.Error())
-					continue
-				}
-				if len(proofId) == 0 {
-					fmt.Printf("none proof id, task id: %x\n", ta.Id)
-					continue
-				}
-				if len(ta.ProofId) > 0 && !bytes.Equal(ta.ProofId, proofId) {
-					fmt.Printf("task [%x] prove id not same\n", ta.Id)
-					continue
-				}
-				result, err := self.taskProve(ta.BlockHash, ta.BlockSize, chunkSize, chunkSeq)
-				var remark string
-				if err != nil {
-					remark = err.Error()
-				}
-				if err = task_client.FinishProve(self.ptsc, ta.Id, proofId, uint64(time.Now().Unix()), result, remark); err != nil {
-					fmt.Printf("Finish prove task [%x] failed: %s\n", ta.Id, err.Error())
-				}
+				self.runProve(ta)
 			}
 		}
 	}
 }
 
+func (self *ProviderService) runRemove(ta *ttpb.Task) {
+	defer self.donePending(ta.Id)
+	var remark string
+	success := true
+	if err := self.taskRemove(ta.FileHash, ta.FileSize, ta.BlockHash, ta.BlockSize); err != nil {
+		fmt.Printf("taskRemove failed, blockKey: %x, error: %s\n", ta.BlockHash, err.Error())
+		if self.retryTask(ta.Id, err) {
+			return
+		}
+		remark = err.Error()
+		success = false
+	}
+	self.finishTask(ta, success, remark)
+}
+
+func (self *ProviderService) runProve(ta *ttpb.Task) {
+	defer self.donePending(ta.Id)
+	proofId, chunkSize, chunkSeq, version, err := task_client.GetProveInfo(self.ptsc, ta.Id)
+	if err != nil {
+		fmt.Printf("Get task [%x] prove info failed: %s\n", ta.Id, err.Error())
+		if !self.retryTask(ta.Id, err) {
+			self.deleteTaskEntry(ta.Id)
+		}
+		return
+	}
+	if len(proofId) == 0 {
+		fmt.Printf("none proof id, task id: %x\n", ta.Id)
+		self.deleteTaskEntry(ta.Id)
+		return
+	}
+	if len(ta.ProofId) > 0 && !bytes.Equal(ta.ProofId, proofId) {
+		fmt.Printf("task [%x] prove id not same\n", ta.Id)
+		self.deleteTaskEntry(ta.Id)
+		return
+	}
+	result, err := self.taskProve(ta.BlockHash, ta.BlockSize, chunkSize, chunkSeq, version)
+	var remark string
+	if err != nil {
+		remark = err.Error()
+	}
+	self.finishProve(ta, proofId, result, remark)
+}
+
+// GetTask retry tasks in journal, then fetch new tasks from task server, tasks already in journal are ignored
 func (self *ProviderService) GetTask() {
 	if self.taskGetting.TryLock() {
 		defer self.taskGetting.UnLock()
 	} else {
 		return
 	}
+	self.retryTasks()
 	taskList, err := task_client.TaskList(self.ptsc, len(self.removeAndProveChan) == 0,
 		len(self.removeAndProveChan) == 0, len(self.sendChan) == 0, len(self.replicateChan) == 0)
 	if err != nil {
@@ -805,20 +989,15 @@ func (self *ProviderService) GetTask() {
 		return
 	}
 	for _, ta := range taskList {
-		switch ta.Type {
-		case ttpb.TaskType_REMOVE:
-			self.removeAndProveChan <- ta
-		case ttpb.TaskType_PROVE:
-			self.removeAndProveChan <- ta
-		case ttpb.TaskType_SEND:
-			self.sendChan <- ta
-		case ttpb.TaskType_REPLICATE:
-			self.replicateChan <- ta
+		if self.acceptTask(ta) {
+			self.dispatchTask(ta, true)
 		}
 	}
 }
 
 func (self *ProviderService) taskRemove(fileHash []byte, fileSize uint64, blockHash []byte, blockSize uint64) (err error) {
+	unlock := self.lockBlock(blockHash)
+	defer unlock()
 	found, smallFile, storageIdx, subPath := self.querySubPath(blockHash)
 	if !found {
 		return
@@ -827,89 +1006,47 @@ func (self *ProviderService) taskRemove(fileHash []byte, fileSize uint64, blockH
 		return fmt.Errorf("delete from provider db failed, error: %s", err)
 	}
 	if smallFile {
-		storage := config.GetStorage(storageIdx)
-		if err = storage.SmallFileDb.Delete(blockHash, nil); err != nil {
-			return fmt.Errorf("delete from small file db failed, error: %s", err)
+		if err = self.deleteSmall(blockHash, storageIdx, subPath); err != nil {
+			return fmt.Errorf("delete small file failed, error: %s", err)
 		}
 	} else {
-		path := config.GetStoragePath(storageIdx, subPath)
-		if err = os.Remove(path); err != nil {
+		if err = removeFile(storageIdx, subPath); err != nil {
 			return fmt.Errorf("remove file failed, error: %s", err)
 		}
 	}
 	return
 }
 
-func (self *ProviderService) taskProve(blockHash []byte, blockSize uint64, chunkSize uint32, chunkSeq map[uint32][]byte) (result []byte, err error) {
+func (self *ProviderService) taskProve(blockHash []byte, blockSize uint64, chunkSize uint32, chunkSeq map[uint32][]byte, version uint32) (result []byte, err error) {
+	scheme, err := por.Lookup(version)
+	if err != nil {
+		return nil, err
+	}
 	found, smallFile, storageIdx, subPath := self.querySubPath(blockHash)
 	if !found {
 		return nil, fmt.Errorf("file not exist")
 	}
-	keys := make([]uint32, 0, len(chunkSeq))
-	for k, _ := range chunkSeq {
-		keys = append(keys, k)
-	}
-	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
-	res := big.NewInt(0)
 	if smallFile {
-		storage := config.GetStorage(storageIdx)
-		data, er := storage.SmallFileDb.Get(blockHash, nil)
+		r, length, er := self.smallReader(blockHash, storageIdx, subPath)
 		if er != nil {
 			return nil, fmt.Errorf("read small file error, error: %s", er)
 		}
-		length := int64(len(data))
-		for _, k := range keys {
-			start := int64(k-1) * int64(chunkSize)
-			if k < 1 || start >= length {
-				return nil, fmt.Errorf("seq out of range: %d", k)
-			}
-			end := start + int64(chunkSize)
-			if end > length {
-				end = length
-			}
-			bm := new(big.Int)
-			bm.SetBytes(data[start:end])
-			bv := new(big.Int)
-			bv.SetBytes(chunkSeq[k])
-			bm.Mul(bm, bv)
-			res.Add(res, bm)
-		}
-	} else {
-		path := config.GetStoragePath(storageIdx, subPath)
-		fileInfo, er := os.Stat(path)
-		if er != nil {
-			return nil, fmt.Errorf("stat file failed, error: %s", er)
-		}
-		fileSize := int64(fileInfo.Size())
-		file, er := os.Open(path)
-		if er != nil {
-			return nil, fmt.Errorf("open file failed, error: %s", er)
-		}
-		defer file.Close()
-		buf := make([]byte, chunkSize)
-		for _, k := range keys {
-			start := int64(k-1) * int64(chunkSize)
-			if start >= fileSize {
-				return nil, fmt.Errorf("seq out of range")
-			}
-			file.Seek(start, 0)
-			bytesRead, er := file.Read(buf)
-			if er != nil && er != io.EOF {
-				return nil, fmt.Errorf("read file failed, error: %s", er)
-			}
-			if bytesRead > 0 {
-				bm := new(big.Int)
-				bm.SetBytes(buf[:bytesRead])
-				bv := new(big.Int)
-				bv.SetBytes(chunkSeq[k])
-				bm.Mul(bm, bv)
-				res.Add(res, bm)
-			} else {
-				return nil, fmt.Errorf("read file get nothing, start position: %d", start)
-			}
-		}
+		return scheme.Prove(r, length, chunkSize, chunkSeq)
+	}
+	path, er := config.GetStoragePath(storageIdx, subPath)
+	if er != nil {
+		return nil, er
+	}
+	fileInfo, er := os.Stat(path)
+	if er != nil {
+		return nil, fmt.Errorf("stat file failed, error: %s", er)
 	}
-	return res.Bytes(), nil
+	file, er := os.Open(path)
+	if er != nil {
+		return nil, fmt.Errorf("open file failed, error: %s", er)
+	}
+	defer file.Close()
+	return scheme.Prove(file, fileInfo.Size(), chunkSize, chunkSeq)
 }
 
 func (self *ProviderService) taskSend(fileHash []byte, fileSize uint64, blockHash []byte, blockSize uint64, timestamp uint64, oppositeInfo *ttpb.OppositeInfo) (err error) {
@@ -925,8 +1062,7 @@ func (self *ProviderService) taskSend(fileHash []byte, fileSize uint64, blockHas
 	defer conn.Close()
 	psc := pb.NewProviderServiceClient(conn)
 	if smallFile {
-		storage := config.GetStorage(storageIdx)
-		data, er := storage.SmallFileDb.Get(blockHash, nil)
+		data, er := self.readSmall(blockHash, storageIdx, subPath)
 		if er != nil {
 			return fmt.Errorf("read small file error, error: %s", er)
 		}
@@ -938,7 +1074,10 @@ func (self *ProviderService) taskSend(fileHash []byte, fileSize uint64, blockHas
 		}
 		return provider_client.StoreSmall(psc, data, oppositeInfo.Auth, timestamp, oppositeInfo.Ticket, fileHash, fileSize, blockHash, blockSize)
 	} else {
-		path := config.GetStoragePath(storageIdx, subPath)
+		path, er := config.GetStoragePath(storageIdx, subPath)
+		if er != nil {
+			return er
+		}
 		fileInfo, er := os.Stat(path)
 		if er != nil {
 			return fmt.Errorf("stat file failed, error: %s", er)
@@ -962,16 +1101,18 @@ func (self *ProviderService) taskReplicate(fileHash []byte, fileSize uint64, blo
 	var storage *config.Storage
 	if found {
 		if smallFile {
-			storage := config.GetStorage(storageIdx)
-			data, er := storage.SmallFileDb.Get(blockHash, nil)
-			if er != nil && er != leveldb.ErrNotFound {
+			data, er := self.readSmall(blockHash, storageIdx, subPath)
+			if er != nil && !isDataLost(er) {
 				return fmt.Errorf("read small file error, error: %s", er)
 			}
 			if len(data) == int(blockSize) && bytes.Equal(util_hash.Sha1(data), blockHash) {
 				return nil
 			}
 		} else {
-			path := config.GetStoragePath(storageIdx, subPath)
+			path, er := config.GetStoragePath(storageIdx, subPath)
+			if er != nil {
+				return er
+			}
 			fileInfo, er := os.Stat(path)
 			if er != nil && !os.IsNotExist(er) {
 				return fmt.Errorf("stat file failed, error: %s", er)
@@ -987,8 +1128,14 @@ func (self *ProviderService) taskReplicate(fileHash []byte, fileSize uint64, blo
 			}
 		}
 		storage = config.GetStorage(storageIdx)
-	} else {
-		storage = config.GetWriteStorage(blockSize)
+	}
+	if storage == nil || storage.Removing {
+		reservation := config.ReserveWriteStorage(blockSize)
+		if reservation == nil {
+			return fmt.Errorf("available disk space or volume quota of this provider is not enlough, blockSize: %d", blockSize)
+		}
+		defer func() { reservation.Release(err == nil) }()
+		storage = reservation.Storage
 	}
 	smallFile = (blockSize < small_file_limit)
 	providers := testPing(oppositeInfo)
@@ -1016,11 +1163,8 @@ func (self *ProviderService) taskReplicate(fileHash []byte, fileSize uint64, blo
 				errs = append(errs, fmt.Errorf("check data hash failed, provider id: %s, block key: %x", pro.NodeId, blockHash))
 				continue
 			}
-			if err = storage.SmallFileDb.Put(blockHash, data, nil); err != nil {
-				return fmt.Errorf("save to small file db failed, error: %s", err)
-			}
-			if err = self.providerDb.Put(blockHash, []byte{storage.Index}, nil); err != nil {
-				return fmt.Errorf("save to provider db failed, error: %s", err)
+			if err = self.putSmall(storage, blockHash, data); err != nil {
+				return fmt.Errorf("save small file failed, error: %s", err)
 			}
 		} else {
 			tempFilePath := storage.TempFilePath(blockHash)
@@ -1056,9 +1200,8 @@ func (self *ProviderService) taskReplicate(fileHash []byte, fileSize uint64, blo
 				return fmt.Errorf("close temp file failed, tempFilePath: %s error: %s", tempFilePath, err)
 			}
 			if found {
-				path := config.GetStoragePath(storageIdx, subPath)
-				if util_file.Exists(path) {
-					if err = os.Remove(path); err != nil {
+				if path, err := config.GetStoragePath(storageIdx, subPath); err == nil && util_file.Exists(path) {
+					if err = removeFile(storageIdx, subPath); err != nil {
 						return fmt.Errorf("remove old file failed, path: %s error: %s", path, err)
 					}
 				}
@@ -1174,14 +1317,13 @@ func (self *ProviderService) verifyBlock(hash []byte, size uint64) bool {
 		return false
 	}
 	if smallFile {
-		storage := config.GetStorage(storageIdx)
-		if storage == nil {
-			return false
-		}
-		data, err := storage.SmallFileDb.Get(hash, nil)
+		data, err := self.readSmall(hash, storageIdx, subPath)
 		return err == nil && len(data) > 0 && bytes.Equal(hash, util_hash.Sha1(data))
 	} else {
-		path := config.GetStoragePath(storageIdx, subPath)
+		path, err := config.GetStoragePath(storageIdx, subPath)
+		if err != nil {
+			return false
+		}
 		sum, err := util_hash.Sha1File(path)
 		return err == nil && len(sum) > 0 && bytes.Equal(hash, sum)
 	}
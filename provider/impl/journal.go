@@ -0,0 +1,252 @@
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	task_client "github.com/samoslab/nebula/provider/task_client"
+	ttpb "github.com/samoslab/nebula/tracker/task/pb"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	task_state_queued    = 1 // received from task server, wait to run
+	task_state_reporting = 2 // finished, wait task server acknowledge the result
+)
+
+// seconds of first retry interval, doubled after every failed attempt
+const task_retry_interval = 60
+const task_retry_max_interval = 3600
+
+// failed task is reported to task server as failed after so many attempts
+const task_max_attempts = 6
+
+// taskEntry is the journal record of a task, deleted after task server acknowledged the result
+type taskEntry struct {
+	Task         *ttpb.Task
+	State        byte
+	Attempts     uint32
+	NextTry      int64
+	LastError    string `json:",omitempty"`
+	FinishedTime uint64 `json:",omitempty"`
+	Success      bool   `json:",omitempty"`
+	Remark       string `json:",omitempty"`
+	ProofId      []byte `json:",omitempty"`
+	Result       []byte `json:",omitempty"`
+}
+
+func retryInterval(attempts uint32) int64 {
+	interval := int64(task_retry_interval)
+	for i := uint32(1); i < attempts && interval < task_retry_max_interval; i++ {
+		interval *= 2
+	}
+	if interval > task_retry_max_interval {
+		interval = task_retry_max_interval
+	}
+	return interval
+}
+
+func (self *ProviderService) getTaskEntry(taskId []byte) *taskEntry {
+	val, err := self.taskDb.Get(taskId, nil)
+	if err != nil {
+		if err != leveldb.ErrNotFound {
+			log.Errorf("get task [%x] from task journal failed: %s", taskId, err)
+		}
+		return nil
+	}
+	entry := &taskEntry{}
+	if err = json.Unmarshal(val, entry); err != nil {
+		log.Errorf("unmarshal task [%x] of task journal failed: %s", taskId, err)
+		return nil
+	}
+	return entry
+}
+
+func (self *ProviderService) putTaskEntry(entry *taskEntry) error {
+	val, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return self.taskDb.Put(entry.Task.Id, val, nil)
+}
+
+func (self *ProviderService) deleteTaskEntry(taskId []byte) {
+	if err := self.taskDb.Delete(taskId, nil); err != nil {
+		log.Errorf("delete task [%x] from task journal failed: %s", taskId, err)
+	}
+}
+
+// acceptTask record the task in journal, return false if the task is already in journal
+func (self *ProviderService) acceptTask(ta *ttpb.Task) bool {
+	if ok, err := self.taskDb.Has(ta.Id, nil); err != nil {
+		log.Errorf("query task [%x] from task journal failed: %s", ta.Id, err)
+		return false
+	} else if ok {
+		return false
+	}
+	if err := self.putTaskEntry(&taskEntry{Task: ta, State: task_state_queued, NextTry: time.Now().Unix()}); err != nil {
+		log.Errorf("save task [%x] to task journal failed: %s", ta.Id, err)
+		return false
+	}
+	return true
+}
+
+// dispatchTask send the task to the processor, return false if not wait and the processor is busy
+func (self *ProviderService) dispatchTask(ta *ttpb.Task, wait bool) bool {
+	var ch chan *ttpb.Task
+	switch ta.Type {
+	case ttpb.TaskType_REMOVE, ttpb.TaskType_PROVE:
+		ch = self.removeAndProveChan
+	case ttpb.TaskType_SEND:
+		ch = self.sendChan
+	case ttpb.TaskType_REPLICATE:
+		ch = self.replicateChan
+	default:
+		log.Warnf("unknown type %d of task [%x]", ta.Type, ta.Id)
+		self.deleteTaskEntry(ta.Id)
+		return false
+	}
+	id := string(ta.Id)
+	self.pendingMutex.Lock()
+	if self.pendingTasks[id] {
+		self.pendingMutex.Unlock()
+		return false
+	}
+	self.pendingTasks[id] = true
+	self.pendingMutex.Unlock()
+	if wait {
+		ch <- ta
+		return true
+	}
+	select {
+	case ch <- ta:
+		return true
+	default:
+		self.donePending(ta.Id)
+		return false
+	}
+}
+
+func (self *ProviderService) donePending(taskId []byte) {
+	self.pendingMutex.Lock()
+	delete(self.pendingTasks, string(taskId))
+	self.pendingMutex.Unlock()
+}
+
+func (self *ProviderService) isPending(taskId []byte) bool {
+	self.pendingMutex.Lock()
+	defer self.pendingMutex.Unlock()
+	return self.pendingTasks[string(taskId)]
+}
+
+// retryTask schedule the task to run again with backoff, return false if reach the maximum attempts
+func (self *ProviderService) retryTask(taskId []byte, cause error) bool {
+	entry := self.getTaskEntry(taskId)
+	if entry == nil {
+		return false
+	}
+	entry.Attempts++
+	if entry.Attempts >= task_max_attempts {
+		return false
+	}
+	entry.LastError = cause.Error()
+	entry.NextTry = time.Now().Unix() + retryInterval(entry.Attempts)
+	if err := self.putTaskEntry(entry); err != nil {
+		log.Errorf("save task [%x] to task journal failed: %s", taskId, err)
+	}
+	return true
+}
+
+// finishTask record result of REMOVE, SEND or REPLICATE task and report it to task server
+func (self *ProviderService) finishTask(ta *ttpb.Task, success bool, remark string) {
+	entry := &taskEntry{Task: ta, State: task_state_reporting, FinishedTime: uint64(time.Now().Unix()),
+		Success: success, Remark: remark}
+	if err := self.putTaskEntry(entry); err != nil {
+		log.Errorf("save task [%x] to task journal failed: %s", ta.Id, err)
+	}
+	self.reportTask(entry)
+}
+
+// finishProve record result of PROVE task and report it to task server
+func (self *ProviderService) finishProve(ta *ttpb.Task, proofId []byte, result []byte, remark string) {
+	entry := &taskEntry{Task: ta, State: task_state_reporting, FinishedTime: uint64(time.Now().Unix()),
+		ProofId: proofId, Result: result, Remark: remark}
+	if err := self.putTaskEntry(entry); err != nil {
+		log.Errorf("save task [%x] to task journal failed: %s", ta.Id, err)
+	}
+	self.reportTask(entry)
+}
+
+// reportAcknowledged return true if task server received the report, error of invalid report is not retried
+func reportAcknowledged(err error) bool {
+	if err == nil {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.FailedPrecondition:
+		return true
+	}
+	return false
+}
+
+func (self *ProviderService) reportTask(entry *taskEntry) {
+	var err error
+	if entry.Task.Type == ttpb.TaskType_PROVE {
+		err = task_client.FinishProve(self.ptsc, entry.Task.Id, entry.ProofId, entry.FinishedTime, entry.Result, entry.Remark)
+	} else {
+		err = task_client.FinishTask(self.ptsc, entry.Task.Id, entry.FinishedTime, entry.Success, entry.Remark)
+	}
+	if reportAcknowledged(err) {
+		if err != nil {
+			fmt.Printf("Finish %s task [%x] rejected: %s\n", entry.Task.Type, entry.Task.Id, err.Error())
+		}
+		self.deleteTaskEntry(entry.Task.Id)
+		return
+	}
+	fmt.Printf("Finish %s task [%x] failed: %s\n", entry.Task.Type, entry.Task.Id, err.Error())
+	entry.Attempts++
+	entry.LastError = err.Error()
+	entry.NextTry = time.Now().Unix() + retryInterval(entry.Attempts)
+	if err = self.putTaskEntry(entry); err != nil {
+		log.Errorf("save task [%x] to task journal failed: %s", entry.Task.Id, err)
+	}
+}
+
+// retryTasks run queued tasks of journal again and resend reports which task server not acknowledged
+func (self *ProviderService) retryTasks() {
+	now := time.Now().Unix()
+	entries := make([]*taskEntry, 0, 16)
+	iter := self.taskDb.NewIterator(nil, nil)
+	for iter.Next() {
+		entry := &taskEntry{}
+		if err := json.Unmarshal(iter.Value(), entry); err != nil || entry.Task == nil {
+			log.Errorf("unmarshal task [%x] of task journal failed: %v", iter.Key(), err)
+			continue
+		}
+		if entry.NextTry <= now {
+			entries = append(entries, entry)
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		log.Errorf("iterate task journal failed: %s", err)
+	}
+	for _, entry := range entries {
+		if self.shuttingDown() {
+			return
+		}
+		if self.isPending(entry.Task.Id) {
+			continue
+		}
+		switch entry.State {
+		case task_state_queued:
+			self.dispatchTask(entry.Task, false)
+		case task_state_reporting:
+			self.reportTask(entry)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package impl
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	ttpb "github.com/samoslab/nebula/tracker/task/pb"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryInterval(t *testing.T) {
+	require.Equal(t, int64(task_retry_interval), retryInterval(0))
+	require.Equal(t, int64(task_retry_interval), retryInterval(1))
+	require.Equal(t, int64(task_retry_interval*4), retryInterval(3))
+	require.Equal(t, int64(task_retry_max_interval), retryInterval(100))
+}
+
+func TestReportAcknowledged(t *testing.T) {
+	require.True(t, reportAcknowledged(nil))
+	require.True(t, reportAcknowledged(status.Error(codes.NotFound, "task not found")))
+	require.False(t, reportAcknowledged(status.Error(codes.Unavailable, "connection refused")))
+	require.False(t, reportAcknowledged(errors.New("timeout")))
+}
+
+func TestTaskJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "task-journal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := leveldb.OpenFile(dir, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	ps := &ProviderService{taskDb: db, pendingTasks: make(map[string]bool), removeAndProveChan: make(chan *ttpb.Task, 1)}
+	ta := &ttpb.Task{Id: []byte("task-1"), Type: ttpb.TaskType_REMOVE}
+	require.True(t, ps.acceptTask(ta))
+	require.False(t, ps.acceptTask(ta), "task should be deduplicated by id")
+	require.True(t, ps.dispatchTask(ta, false))
+	require.False(t, ps.dispatchTask(ta, false), "pending task should not be dispatched again")
+	<-ps.removeAndProveChan
+	ps.donePending(ta.Id)
+	for i := 1; i < task_max_attempts; i++ {
+		require.True(t, ps.retryTask(ta.Id, errors.New("failed")))
+	}
+	entry := ps.getTaskEntry(ta.Id)
+	require.Equal(t, uint32(task_max_attempts-1), entry.Attempts)
+	require.Equal(t, "failed", entry.LastError)
+	require.True(t, entry.NextTry > time.Now().Unix())
+	require.False(t, ps.retryTask(ta.Id, errors.New("failed")))
+	ps.deleteTaskEntry(ta.Id)
+	require.Nil(t, ps.getTaskEntry(ta.Id))
+}
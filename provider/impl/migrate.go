@@ -0,0 +1,354 @@
+package impl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/samoslab/nebula/provider/config"
+	"github.com/samoslab/nebula/provider/disk"
+	util_hash "github.com/samoslab/nebula/util/hash"
+	log "github.com/sirupsen/logrus"
+)
+
+// rebalance stop when free ratio of every storage is within this range of the average
+const rebalance_tolerance = 0.05
+
+func (self *ProviderService) lockBlock(key []byte) func() {
+	m := &self.blockLocks[key[len(key)-1]]
+	m.Lock()
+	return m.Unlock
+}
+
+func (self *ProviderService) shuttingDown() bool {
+	select {
+	case <-self.shutdownSignal:
+		return true
+	default:
+		return false
+	}
+}
+
+// MigrateStorage move small blocks of legacy small file db into segments, move blocks out of removing storages
+// and rebalance storages if requested, return true if storage volume changed. The change is also kept in config
+// by config.VolumeChanged until tracker is notified.
+func (self *ProviderService) MigrateStorage() (changed bool) {
+	if self.storageMigrating.TryLock() {
+		defer self.storageMigrating.UnLock()
+	} else {
+		return false
+	}
+	if !self.smallFileMigrated {
+		finished := true
+		for _, s := range config.StorageList() {
+			if !self.migrateSmallFileDb(s) {
+				finished = false
+			}
+		}
+		self.smallFileMigrated = finished
+	}
+	for _, s := range config.RemovingStorage() {
+		if self.shuttingDown() {
+			return
+		}
+		if self.migrateStorage(s) {
+			if err := config.FinishRemoveStorage(s.Index); err != nil {
+				log.Errorf("finish remove storage %s failed: %s", s.Path, err)
+				continue
+			}
+			log.Infof("storage %s removed, all blocks migrated", s.Path)
+			changed = true
+		}
+	}
+	if config.RebalanceRequested() {
+		if self.rebalance() {
+			if err := config.FinishRebalance(); err != nil {
+				log.Errorf("finish rebalance failed: %s", err)
+			}
+			changed = true
+		}
+	}
+	return
+}
+
+// migrateStorage return true when no block left in the storage
+func (self *ProviderService) migrateStorage(src *config.Storage) bool {
+	iter := self.providerDb.NewIterator(nil, nil)
+	defer iter.Release()
+	var moved, failed int
+	for iter.Next() {
+		if self.shuttingDown() {
+			return false
+		}
+		val := iter.Value()
+		if len(val) == 0 || val[0] != src.Index {
+			continue
+		}
+		key := append([]byte(nil), iter.Key()...)
+		if _, err := self.moveBlock(key, append([]byte(nil), val...), src, nil); err != nil {
+			log.Warnf("migrate block %x from storage %s failed: %s", key, src.Path, err)
+			failed++
+			continue
+		}
+		moved++
+	}
+	if err := iter.Error(); err != nil {
+		log.Errorf("iterate provider db failed: %s", err)
+		return false
+	}
+	log.Infof("migrate storage %s, moved: %d, failed: %d", src.Path, moved, failed)
+	return failed == 0 && self.countBlocks(src.Index) == 0
+}
+
+func (self *ProviderService) countBlocks(index byte) (count int) {
+	iter := self.providerDb.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		if val := iter.Value(); len(val) > 0 && val[0] == index {
+			count++
+		}
+	}
+	return
+}
+
+type balanceStorage struct {
+	*config.Storage
+	total uint64
+	free  uint64
+}
+
+func (self *balanceStorage) ratio() float64 {
+	return float64(self.free) / float64(self.total)
+}
+
+// rebalance move blocks from the fullest storages to the emptiest ones, return false if interrupted
+func (self *ProviderService) rebalance() bool {
+	list := make([]*balanceStorage, 0, 8)
+	var sumTotal, sumFree uint64
+	for _, s := range config.StorageList() {
+		if s.Removing {
+			continue
+		}
+		total, free, err := disk.Space(s.Path)
+		if err != nil || total == 0 {
+			log.Warnf("get storage %s space failed: %v", s.Path, err)
+			continue
+		}
+		list = append(list, &balanceStorage{Storage: s, total: total, free: free})
+		sumTotal += total
+		sumFree += free
+	}
+	if len(list) < 2 {
+		return true
+	}
+	avg := float64(sumFree) / float64(sumTotal)
+	overloaded := make(map[byte]*balanceStorage, len(list))
+	for _, bs := range list {
+		if bs.ratio() < avg-rebalance_tolerance {
+			overloaded[bs.Index] = bs
+		}
+	}
+	if len(overloaded) == 0 {
+		return true
+	}
+	iter := self.providerDb.NewIterator(nil, nil)
+	defer iter.Release()
+	var moved int
+	for iter.Next() && len(overloaded) > 0 {
+		if self.shuttingDown() {
+			return false
+		}
+		val := iter.Value()
+		if len(val) == 0 {
+			continue
+		}
+		src, ok := overloaded[val[0]]
+		if !ok {
+			continue
+		}
+		var dest *balanceStorage
+		for _, bs := range list {
+			if bs.Index != src.Index && (dest == nil || bs.ratio() > dest.ratio()) {
+				dest = bs
+			}
+		}
+		if dest.ratio() < avg {
+			break
+		}
+		key := append([]byte(nil), iter.Key()...)
+		size, err := self.moveBlock(key, append([]byte(nil), val...), src.Storage, dest.Storage)
+		if err != nil {
+			log.Warnf("rebalance block %x from storage %s to %s failed: %s", key, src.Path, dest.Path, err)
+			continue
+		}
+		moved++
+		src.free += size
+		if dest.free > size {
+			dest.free -= size
+		} else {
+			dest.free = 0
+		}
+		if src.ratio() >= avg-rebalance_tolerance/2 {
+			delete(overloaded, src.Index)
+		}
+	}
+	log.Infof("rebalance finished, moved %d blocks", moved)
+	return true
+}
+
+// moveBlock copy the block to dest storage, switch the providerDb entry and delete the source,
+// if dest is nil choose one by config.GetWriteStorage
+func (self *ProviderService) moveBlock(key []byte, val []byte, src *config.Storage, dest *config.Storage) (size uint64, err error) {
+	if isSmallValue(val) {
+		return self.moveSmallBlock(key, val, src, dest)
+	}
+	srcPath, err := config.GetStoragePath(src.Index, string(val[1:]))
+	if err != nil {
+		return 0, err
+	}
+	fileInfo, err := os.Stat(srcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, self.dropLostBlock(key, val)
+		}
+		return 0, fmt.Errorf("stat file failed, error: %s", err)
+	}
+	size = uint64(fileInfo.Size())
+	if dest == nil {
+		if dest = config.GetWriteStorage(size); dest == nil {
+			return 0, fmt.Errorf("available disk space of this provider is not enlough, blockSize: %d", size)
+		}
+	}
+	if dest.Index == src.Index {
+		return 0, fmt.Errorf("source and destination is the same storage")
+	}
+	tempFilePath := dest.TempFilePath(key)
+	if err = copyFile(srcPath, tempFilePath); err != nil {
+		os.Remove(tempFilePath)
+		return 0, err
+	}
+	hash, err := util_hash.Sha1File(tempFilePath)
+	if err != nil {
+		os.Remove(tempFilePath)
+		return 0, fmt.Errorf("sha1 sum file %s failed, error: %s", tempFilePath, err)
+	}
+	if !bytes.Equal(hash, key) {
+		os.Remove(tempFilePath)
+		return 0, self.dropLostBlock(key, val)
+	}
+	fullPath, subPath, err := dest.GetPathPair(key)
+	if err != nil {
+		os.Remove(tempFilePath)
+		return 0, err
+	}
+	if err = os.Rename(tempFilePath, fullPath); err != nil {
+		os.Remove(tempFilePath)
+		return 0, err
+	}
+	pathSlice := make([]byte, len(subPath)+1)
+	copy(pathSlice[1:], subPath)
+	pathSlice[0] = dest.Index
+	unlock := self.lockBlock(key)
+	cur := self.queryByKey(key)
+	if !bytes.Equal(cur, val) {
+		unlock()
+		// removed or rewritten while copying
+		if !bytes.Equal(cur, pathSlice) {
+			os.Remove(fullPath)
+		}
+		return 0, nil
+	}
+	err = self.providerDb.Put(key, pathSlice, nil)
+	unlock()
+	if err != nil {
+		os.Remove(fullPath)
+		return 0, fmt.Errorf("save to provider db failed, error: %s", err)
+	}
+	dest.AddUsed(size)
+	if err = os.Remove(srcPath); err != nil {
+		log.Warnf("remove migrated file %s failed: %s", srcPath, err)
+	} else {
+		src.SubUsed(size)
+	}
+	return size, nil
+}
+
+func (self *ProviderService) moveSmallBlock(key []byte, val []byte, src *config.Storage, dest *config.Storage) (size uint64, err error) {
+	var subPath string
+	if isSegmentValue(val) {
+		subPath = string(val[2:])
+	}
+	data, err := self.readSmall(key, src.Index, subPath)
+	if isDataLost(err) {
+		return 0, self.dropLostBlock(key, val)
+	} else if err != nil {
+		return 0, fmt.Errorf("read small file error, error: %s", err)
+	}
+	if !bytes.Equal(util_hash.Sha1(data), key) {
+		return 0, self.dropLostBlock(key, val)
+	}
+	size = uint64(len(data))
+	if dest == nil {
+		if dest = config.GetWriteStorage(size); dest == nil {
+			return 0, fmt.Errorf("available disk space of this provider is not enlough, blockSize: %d", size)
+		}
+	}
+	if dest.Index == src.Index {
+		return 0, fmt.Errorf("source and destination is the same storage")
+	}
+	loc, err := dest.Segments.Append(key, data)
+	if err != nil {
+		return 0, fmt.Errorf("append to segment failed, error: %s", err)
+	}
+	unlock := self.lockBlock(key)
+	if !bytes.Equal(self.queryByKey(key), val) {
+		unlock()
+		// removed or rewritten while copying
+		dest.Segments.MarkDead(key, loc)
+		return 0, nil
+	}
+	err = self.providerDb.Put(key, segmentValue(dest.Index, loc), nil)
+	unlock()
+	if err != nil {
+		dest.Segments.MarkDead(key, loc)
+		return 0, fmt.Errorf("save to provider db failed, error: %s", err)
+	}
+	dest.AddUsed(size)
+	if err = self.deleteSmall(key, src.Index, subPath); err != nil {
+		log.Warnf("delete migrated small file %x from storage %s failed: %s", key, src.Path, err)
+	}
+	return size, nil
+}
+
+// dropLostBlock delete the index of block which is missing or corrupted, VerifyBlocks will report it to tracker
+func (self *ProviderService) dropLostBlock(key []byte, val []byte) error {
+	unlock := self.lockBlock(key)
+	defer unlock()
+	if !bytes.Equal(self.queryByKey(key), val) {
+		return nil
+	}
+	log.Warnf("block %x is lost or corrupted, drop it from provider db", key)
+	return self.providerDb.Delete(key, nil)
+}
+
+func copyFile(srcPath string, destPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("open file %s failed, error: %s", srcPath, err)
+	}
+	defer src.Close()
+	dest, err := os.OpenFile(destPath, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("open temp write file failed, error: %s", err)
+	}
+	if _, err = io.Copy(dest, src); err != nil {
+		dest.Close()
+		return fmt.Errorf("copy file %s failed, error: %s", srcPath, err)
+	}
+	if err = dest.Sync(); err != nil {
+		dest.Close()
+		return fmt.Errorf("sync file %s failed, error: %s", destPath, err)
+	}
+	return dest.Close()
+}
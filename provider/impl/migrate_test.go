@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gosync "github.com/lrita/gosync"
+	"github.com/samoslab/nebula/provider/config"
+	"github.com/samoslab/nebula/provider/node"
+	"github.com/samoslab/nebula/provider/segment"
+	util_file "github.com/samoslab/nebula/util/file"
+	util_hash "github.com/samoslab/nebula/util/hash"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// newMigrateService provider service with main storage and one extra storage in temp dir
+func newMigrateService(t *testing.T, dir string) *ProviderService {
+	mainPath, extraPath := filepath.Join(dir, "main"), filepath.Join(dir, "extra")
+	require.NoError(t, os.MkdirAll(mainPath, 0700))
+	require.NoError(t, os.MkdirAll(extraPath, 0700))
+	no := node.NewNode(2)
+	config.CreateProviderConfig(dir, &config.ProviderConfig{
+		NodeId:          no.NodeIdStr(),
+		PublicKey:       no.PublicKeyStr(),
+		PrivateKey:      no.PrivateKeyStr(),
+		MainStoragePath: mainPath,
+		ExtraStorage:    []config.ExtraStorageInfo{config.ExtraStorageInfo{Path: extraPath, Index: 1}},
+	})
+	require.NoError(t, config.LoadConfig(dir))
+	config.StartAutoCheck()
+	db, err := leveldb.OpenFile(filepath.Join(dir, "provider-db"), nil)
+	require.NoError(t, err)
+	return &ProviderService{providerDb: db, segmentsCompacting: gosync.NewMutex()}
+}
+
+// only one test opens storages, they are kept in package state of config
+func TestMigrateBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "provider-migrate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ps := newMigrateService(t, dir)
+	defer config.StopAutoCheck()
+	defer ps.providerDb.Close()
+	src, dest := config.GetStorage(0), config.GetStorage(1)
+
+	// block file
+	data := []byte("content of block stored in file")
+	key := util_hash.Sha1(data)
+	fullPath, subPath, err := src.GetPathPair(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(fullPath, data, 0600))
+	val := append([]byte{src.Index}, subPath...)
+	require.NoError(t, ps.providerDb.Put(key, val, nil))
+	size, err := ps.moveBlock(key, val, src, dest)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(data)), size)
+	require.False(t, util_file.Exists(fullPath))
+	found, smallFile, idx, sp := ps.querySubPath(key)
+	require.True(t, found)
+	require.False(t, smallFile)
+	require.Equal(t, dest.Index, idx)
+	movedPath, err := config.GetStoragePath(idx, sp)
+	require.NoError(t, err)
+	moved, err := ioutil.ReadFile(movedPath)
+	require.NoError(t, err)
+	require.Equal(t, data, moved)
+
+	// small block in segment
+	small := []byte("small block")
+	smallKey := util_hash.Sha1(small)
+	require.NoError(t, ps.putSmall(src, smallKey, small))
+	val = ps.queryByKey(smallKey)
+	size, err = ps.moveBlock(smallKey, val, src, dest)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(small)), size)
+	found, smallFile, idx, sp = ps.querySubPath(smallKey)
+	require.True(t, found)
+	require.True(t, smallFile)
+	require.Equal(t, dest.Index, idx)
+	read, err := ps.readSmall(smallKey, idx, sp)
+	require.NoError(t, err)
+	require.Equal(t, small, read)
+	// reader got before the segment is compacted reads the moved record
+	compacted := segment.Location{Segment: 999, Length: uint32(len(small))}
+	r, _, err := ps.smallReader(smallKey, src.Index, string(compacted.Bytes()))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	n, err := r.ReadAt(buf, 6)
+	require.NoError(t, err)
+	require.Equal(t, "block", string(buf[:n]))
+
+	// block file missing
+	missingKey := util_hash.Sha1([]byte("missing"))
+	_, subPath, err = src.GetPathPair(missingKey)
+	require.NoError(t, err)
+	val = append([]byte{src.Index}, subPath...)
+	require.NoError(t, ps.providerDb.Put(missingKey, val, nil))
+	size, err = ps.moveBlock(missingKey, val, src, dest)
+	require.NoError(t, err)
+	require.Zero(t, size)
+	require.Nil(t, ps.queryByKey(missingKey))
+
+	// block file corrupted
+	corruptKey := util_hash.Sha1([]byte("original"))
+	fullPath, subPath, err = src.GetPathPair(corruptKey)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(fullPath, []byte("corrupted"), 0600))
+	val = append([]byte{src.Index}, subPath...)
+	require.NoError(t, ps.providerDb.Put(corruptKey, val, nil))
+	size, err = ps.moveBlock(corruptKey, val, src, dest)
+	require.NoError(t, err)
+	require.Zero(t, size)
+	require.Nil(t, ps.queryByKey(corruptKey))
+
+	// small block of segment lost
+	lostKey := util_hash.Sha1([]byte("lost small block"))
+	val = segmentValue(src.Index, segment.Location{Segment: 999, Length: 16})
+	require.NoError(t, ps.providerDb.Put(lostKey, val, nil))
+	size, err = ps.moveBlock(lostKey, val, src, dest)
+	require.NoError(t, err)
+	require.Zero(t, size)
+	require.Nil(t, ps.queryByKey(lostKey))
+
+	// index changed since it was read, not dropped
+	require.NoError(t, ps.providerDb.Put(missingKey, []byte{dest.Index}, nil))
+	require.NoError(t, ps.dropLostBlock(missingKey, val))
+	require.Equal(t, []byte{dest.Index}, ps.queryByKey(missingKey))
+}
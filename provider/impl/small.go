@@ -0,0 +1,267 @@
+package impl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/samoslab/nebula/provider/config"
+	"github.com/samoslab/nebula/provider/segment"
+	util_hash "github.com/samoslab/nebula/util/hash"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// provider db value of small block in legacy small file db is | storage index |, in segment store is
+// | storage index | segment_marker | segment location |, subPath of block file always begin with slash,
+// so it never conflict with segment_marker
+const segment_marker = 0
+
+func segmentValue(storageIdx byte, loc segment.Location) []byte {
+	val := make([]byte, 2, 2+len(loc.Bytes()))
+	val[0], val[1] = storageIdx, segment_marker
+	return append(val, loc.Bytes()...)
+}
+
+func isSegmentValue(val []byte) bool {
+	return len(val) > 2 && val[1] == segment_marker
+}
+
+func isSmallValue(val []byte) bool {
+	return len(val) == 1 || isSegmentValue(val)
+}
+
+// isDataLost return true if the small block can not be read and should be stored again
+func isDataLost(err error) bool {
+	return err == leveldb.ErrNotFound || err == segment.ErrNotExist || err == segment.ErrChecksum || err == segment.ErrCorrupted
+}
+
+// readSmall read small block and verify the record checksum, subPath is the segment location,
+// empty if the block is in legacy small file db
+func (self *ProviderService) readSmall(key []byte, storageIdx byte, subPath string) ([]byte, error) {
+	storage := config.GetStorage(storageIdx)
+	if storage == nil {
+		return nil, fmt.Errorf("storage %d not exist", storageIdx)
+	}
+	if subPath == "" {
+		return storage.SmallFileDb.Get(key, nil)
+	}
+	loc, err := segment.ParseLocation([]byte(subPath))
+	if err != nil {
+		return nil, err
+	}
+	data, err := storage.Segments.Read(key, loc)
+	if err == segment.ErrNotExist {
+		// the segment was compacted after location queried
+		if found, smallFile, idx, sp := self.querySubPath(key); found && smallFile && (idx != storageIdx || sp != subPath) {
+			return self.readSmall(key, idx, sp)
+		}
+	}
+	return data, err
+}
+
+// segmentReader read small block in segment on demand, the location is queried again if the segment is
+// removed by compaction between reads
+type segmentReader struct {
+	service    *ProviderService
+	key        []byte
+	mutex      sync.Mutex
+	store      *segment.Store
+	loc        segment.Location
+	storageIdx byte
+	subPath    string
+}
+
+func (self *segmentReader) ReadAt(p []byte, off int64) (int, error) {
+	for {
+		self.mutex.Lock()
+		store, loc, storageIdx, subPath := self.store, self.loc, self.storageIdx, self.subPath
+		self.mutex.Unlock()
+		n, err := store.ReadAt(self.key, loc, p, off)
+		if err != segment.ErrNotExist {
+			return n, err
+		}
+		// the segment was compacted after location queried
+		found, smallFile, idx, sp := self.service.querySubPath(self.key)
+		if !found || !smallFile || sp == "" || (idx == storageIdx && sp == subPath) {
+			return n, err
+		}
+		storage := config.GetStorage(idx)
+		if storage == nil {
+			return n, err
+		}
+		newLoc, er := segment.ParseLocation([]byte(sp))
+		if er != nil {
+			return n, er
+		}
+		self.mutex.Lock()
+		self.store, self.loc, self.storageIdx, self.subPath = storage.Segments, newLoc, idx, sp
+		self.mutex.Unlock()
+	}
+}
+
+// smallReader return reader of small block, data in segment is read on demand instead of load all
+func (self *ProviderService) smallReader(key []byte, storageIdx byte, subPath string) (r io.ReaderAt, size int64, err error) {
+	if subPath == "" {
+		data, err := self.readSmall(key, storageIdx, subPath)
+		if err != nil {
+			return nil, 0, err
+		}
+		return bytes.NewReader(data), int64(len(data)), nil
+	}
+	storage := config.GetStorage(storageIdx)
+	if storage == nil {
+		return nil, 0, fmt.Errorf("storage %d not exist", storageIdx)
+	}
+	loc, err := segment.ParseLocation([]byte(subPath))
+	if err != nil {
+		return nil, 0, err
+	}
+	return &segmentReader{service: self, key: key, store: storage.Segments, loc: loc, storageIdx: storageIdx, subPath: subPath}, int64(loc.Length), nil
+}
+
+// putSmall append small block to segment of the storage and point the index to it, old copy is released
+func (self *ProviderService) putSmall(storage *config.Storage, key []byte, data []byte) error {
+	loc, err := storage.Segments.Append(key, data)
+	if err != nil {
+		return fmt.Errorf("append to segment failed, error: %s", err)
+	}
+	unlock := self.lockBlock(key)
+	old := self.queryByKey(key)
+	err = self.providerDb.Put(key, segmentValue(storage.Index, loc), nil)
+	unlock()
+	if err != nil {
+		storage.Segments.MarkDead(key, loc)
+		return fmt.Errorf("save to provider db failed, error: %s", err)
+	}
+	if len(old) == 1 {
+		self.deleteSmall(key, old[0], "")
+	} else if isSegmentValue(old) {
+		self.deleteSmall(key, old[0], string(old[2:]))
+	}
+	return nil
+}
+
+// deleteSmall release small block after the index deleted or changed, segment compaction is triggered if needed
+func (self *ProviderService) deleteSmall(key []byte, storageIdx byte, subPath string) error {
+	storage := config.GetStorage(storageIdx)
+	if storage == nil {
+		return nil
+	}
+	if subPath == "" {
+		return storage.SmallFileDb.Delete(key, nil)
+	}
+	loc, err := segment.ParseLocation([]byte(subPath))
+	if err != nil {
+		return err
+	}
+	storage.Segments.MarkDead(key, loc)
+	if len(storage.Segments.Compactable()) > 0 {
+		go self.CompactSegments()
+	}
+	return nil
+}
+
+// CompactSegments rewrite live records of segments which most records were removed, then delete the segments
+func (self *ProviderService) CompactSegments() {
+	if self.segmentsCompacting.TryLock() {
+		defer self.segmentsCompacting.UnLock()
+	} else {
+		return
+	}
+	for _, s := range config.StorageList() {
+		for _, id := range s.Segments.Compactable() {
+			if self.shuttingDown() {
+				return
+			}
+			if err := self.compactSegment(s, id); err != nil {
+				log.Errorf("compact segment %d of storage %s failed: %s", id, s.Path, err)
+			}
+		}
+	}
+}
+
+func (self *ProviderService) compactSegment(s *config.Storage, id uint32) error {
+	var moved int
+	err := s.Segments.Scan(id, func(key []byte, loc segment.Location, data []byte) error {
+		unlock := self.lockBlock(key)
+		defer unlock()
+		if !bytes.Equal(self.queryByKey(key), segmentValue(s.Index, loc)) {
+			return nil
+		}
+		newLoc, err := s.Segments.Append(key, data)
+		if err != nil {
+			return err
+		}
+		if err = self.providerDb.Put(key, segmentValue(s.Index, newLoc), nil); err != nil {
+			s.Segments.MarkDead(key, newLoc)
+			return err
+		}
+		moved++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("compact segment %d of storage %s, moved %d records", id, s.Path, moved)
+	return s.Segments.Remove(id)
+}
+
+// migrateSmallFileDb move small blocks in legacy small file db of the storage into segments, return true if finished
+func (self *ProviderService) migrateSmallFileDb(s *config.Storage) bool {
+	iter := s.SmallFileDb.NewIterator(nil, nil)
+	defer iter.Release()
+	var moved, failed int
+	for iter.Next() {
+		if self.shuttingDown() {
+			return false
+		}
+		key := append([]byte(nil), iter.Key()...)
+		if err := self.migrateSmallBlock(s, key, iter.Value()); err != nil {
+			log.Warnf("migrate small block %x of storage %s failed: %s", key, s.Path, err)
+			failed++
+			continue
+		}
+		moved++
+	}
+	if err := iter.Error(); err != nil {
+		log.Errorf("iterate small file db of storage %s failed: %s", s.Path, err)
+		return false
+	}
+	if moved > 0 {
+		log.Infof("migrate small file db of storage %s to segments, moved: %d, failed: %d", s.Path, moved, failed)
+		if err := s.SmallFileDb.CompactRange(util.Range{}); err != nil {
+			log.Warnf("compact small file db of storage %s failed: %s", s.Path, err)
+		}
+	}
+	return failed == 0
+}
+
+func (self *ProviderService) migrateSmallBlock(s *config.Storage, key []byte, data []byte) error {
+	unlock := self.lockBlock(key)
+	cur := self.queryByKey(key)
+	if len(cur) == 1 && cur[0] == s.Index {
+		if !bytes.Equal(util_hash.Sha1(data), key) {
+			log.Warnf("block %x is corrupted, drop it from provider db", key)
+			if err := self.providerDb.Delete(key, nil); err != nil {
+				unlock()
+				return err
+			}
+		} else {
+			loc, err := s.Segments.Append(key, data)
+			if err != nil {
+				unlock()
+				return err
+			}
+			if err = self.providerDb.Put(key, segmentValue(s.Index, loc), nil); err != nil {
+				unlock()
+				s.Segments.MarkDead(key, loc)
+				return err
+			}
+		}
+	}
+	unlock()
+	return s.SmallFileDb.Delete(key, nil)
+}
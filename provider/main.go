@@ -31,7 +31,9 @@ import (
 	trp_pb "github.com/samoslab/nebula/tracker/register/provider/pb"
 	util_hash "github.com/samoslab/nebula/util/hash"
 	util_rsa "github.com/samoslab/nebula/util/rsa"
+	"github.com/samoslab/nebula/util/trackerpool"
 	upnp "github.com/samoslab/nebula/util/upnp"
+	"github.com/sirupsen/logrus"
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/yanzay/log"
 	"golang.org/x/net/context"
@@ -57,16 +59,16 @@ func main() {
 
 	daemonCommand := flag.NewFlagSet("daemon", flag.ExitOnError)
 	daemonConfigDirFlag := daemonCommand.String("configDir", defaultConfigDirFlag, "config directory")
-	daemonTrackerServerFlag := daemonCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, eg: tracker.store.samos.io:6677")
+	daemonTrackerServerFlag := daemonCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, multiple addresses are separated by comma, eg: tracker.store.samos.io:6677")
 	daemonCollectorServerFlag := daemonCommand.String("collectorServer", "collector.store.samos.io:6688", "collector server address, eg: collector.store.samos.io:6688")
-	daemonTaskServerFlag := daemonCommand.String("taskServer", "task.store.samos.io:6622", "task server address, eg: task.store.samos.io:6622")
+	daemonTaskServerFlag := daemonCommand.String("taskServer", "task.store.samos.io:6622", "task server address, multiple addresses are separated by comma, eg: task.store.samos.io:6622")
 	listenFlag := daemonCommand.String("listen", ":6666", "listen address and port, eg: 111.111.111.111:6666 or :6666")
 	disableAutoRefreshIpFlag := daemonCommand.Bool("disableAutoRefreshIp", false, "disable auto refresh provider ip or enable auto refresh provider ip")
 	quietFlag := daemonCommand.Bool("quiet", false, "not print dot when running")
 
 	registerCommand := flag.NewFlagSet("register", flag.ExitOnError)
 	registerConfigDirFlag := registerCommand.String("configDir", defaultConfigDirFlag, "config directory")
-	registerTrackerServerFlag := registerCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, eg: tracker.store.samos.io:6677")
+	registerTrackerServerFlag := registerCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, multiple addresses are separated by comma, eg: tracker.store.samos.io:6677")
 	registerListenFlag := registerCommand.String("listen", ":6666", "listen address and port, eg: 111.111.111.111:6666 or :6666")
 	walletAddressFlag := registerCommand.String("walletAddress", "", "Samos wallet address to accept earnings")
 	billEmailFlag := registerCommand.String("billEmail", "", "email where send bill to")
@@ -75,33 +77,41 @@ func main() {
 	downBandwidthFlag := registerCommand.Uint("downBandwidth", 0, "download bandwidth, unit: Mbps, eg: 100, 20")
 	mainStoragePathFlag := registerCommand.String("mainStoragePath", "", "main storage path")
 	mainStorageVolumeFlag := registerCommand.String("mainStorageVolume", "", "main storage volume size, unit TB or GB, eg: 2TB or 500GB")
-	extraStorageFlag := registerCommand.String("extraStorage", "", "extra storage, format:path1:volume1,path2:volume2, path can not contain comma, eg: /mnt/sde1:1TB,/mnt/sdf1:800GB,/mnt/sdg1:500GB")
+	extraStorageFlag := registerCommand.String("extraStorage", "", "extra storage, format:path1:volume1[:tier1],path2:volume2[:tier2], path can not contain comma, tier is small or large, eg: /mnt/sde1:1TB,/mnt/sdf1:800GB,/mnt/ssd1:500GB:small")
 	portFlag := registerCommand.Uint("port", 6666, "outer network port for client to connect, eg:6666")
 	hostFlag := registerCommand.String("host", "", "outer ip or domain for client to connect, eg: 123.123.123.123")
 	dynamicDomainFlag := registerCommand.String("dynamicDomain", "", "dynamic domain for client to connect, eg: mydomain.xicp.net")
 
 	verifyEmailCommand := flag.NewFlagSet("verifyEmail", flag.ExitOnError)
 	verifyEmailConfigDirFlag := verifyEmailCommand.String("configDir", defaultConfigDirFlag, "config directory")
-	verifyEmailTrackerServerFlag := verifyEmailCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, eg: tracker.store.samos.io:6677")
+	verifyEmailTrackerServerFlag := verifyEmailCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, multiple addresses are separated by comma, eg: tracker.store.samos.io:6677")
 	verifyCodeFlag := verifyEmailCommand.String("verifyCode", "", "verify code from verify email")
 
 	resendVerifyCodeCommand := flag.NewFlagSet("resendVerifyCode", flag.ExitOnError)
 	resendVerifyCodeConfigDirFlag := resendVerifyCodeCommand.String("configDir", defaultConfigDirFlag, "config directory")
-	resendVerifyCodeTrackerServerFlag := resendVerifyCodeCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, eg: tracker.store.samos.io:6677")
+	resendVerifyCodeTrackerServerFlag := resendVerifyCodeCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, multiple addresses are separated by comma, eg: tracker.store.samos.io:6677")
 
 	addStorageCommand := flag.NewFlagSet("addStorage", flag.ExitOnError)
 	addStorageConfigDirFlag := addStorageCommand.String("configDir", defaultConfigDirFlag, "config directory")
-	addStorageTrackerServerFlag := addStorageCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, eg: tracker.store.samos.io:6677")
+	addStorageTrackerServerFlag := addStorageCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, multiple addresses are separated by comma, eg: tracker.store.samos.io:6677")
 	pathFlag := addStorageCommand.String("path", "", "add storage path")
 	volumeFlag := addStorageCommand.String("volume", "", "add storage volume size, unit TB or GB, eg: 2TB or 500GB")
+	tierFlag := addStorageCommand.String("tier", "", "optional storage tier, small: prefer small blocks (eg: SSD), large: prefer large blocks")
+
+	removeStorageCommand := flag.NewFlagSet("removeStorage", flag.ExitOnError)
+	removeStorageConfigDirFlag := removeStorageCommand.String("configDir", defaultConfigDirFlag, "config directory")
+	removeStoragePathFlag := removeStorageCommand.String("path", "", "path of extra storage to remove")
+
+	rebalanceCommand := flag.NewFlagSet("rebalance", flag.ExitOnError)
+	rebalanceConfigDirFlag := rebalanceCommand.String("configDir", defaultConfigDirFlag, "config directory")
 
 	switchPrivateCommand := flag.NewFlagSet("switchPrivate", flag.ExitOnError)
 	switchPrivateConfigDirFlag := switchPrivateCommand.String("configDir", defaultConfigDirFlag, "config directory")
-	switchPrivateTrackerServerFlag := switchPrivateCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, eg: tracker.store.samos.io:6677")
+	switchPrivateTrackerServerFlag := switchPrivateCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, multiple addresses are separated by comma, eg: tracker.store.samos.io:6677")
 
 	switchPublicCommand := flag.NewFlagSet("switchPublic", flag.ExitOnError)
 	switchPublicConfigDirFlag := switchPublicCommand.String("configDir", defaultConfigDirFlag, "config directory")
-	switchPublicTrackerServerFlag := switchPublicCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, eg: tracker.store.samos.io:6677")
+	switchPublicTrackerServerFlag := switchPublicCommand.String("trackerServer", "tracker.store.samos.io:6677", "tracker server address, multiple addresses are separated by comma, eg: tracker.store.samos.io:6677")
 	switchPublicListenFlag := switchPublicCommand.String("listen", ":6666", "listen address and port, eg: 111.111.111.111:6666 or :6666")
 	switchPublicPortFlag := switchPublicCommand.Uint("port", 6666, "outer network port for client to connect, eg:6666")
 	switchPublicHostFlag := switchPublicCommand.String("host", "", "outer ip or domain for client to connect, eg: 123.123.123.123")
@@ -117,8 +127,12 @@ func main() {
 		resendVerifyCodeCommand.PrintDefaults()
 		fmt.Println(" daemon [-configDir config-dir] [-trackerServer tracker-server-and-port] [-listen listen-address-and-port] [-disableAutoRefreshIp] [-quiet]")
 		daemonCommand.PrintDefaults()
-		fmt.Println(" addStorage [-configDir config-dir] [-trackerServer tracker-server-and-port] -path storage-path -volume storage-volume")
+		fmt.Println(" addStorage [-configDir config-dir] [-trackerServer tracker-server-and-port] [-tier storage-tier] -path storage-path -volume storage-volume")
 		addStorageCommand.PrintDefaults()
+		fmt.Println(" removeStorage [-configDir config-dir] -path storage-path")
+		removeStorageCommand.PrintDefaults()
+		fmt.Println(" rebalance [-configDir config-dir]")
+		rebalanceCommand.PrintDefaults()
 		fmt.Println(" switchPrivate [-configDir config-dir] [-trackerServer tracker-server-and-port] ")
 		switchPrivateCommand.PrintDefaults()
 		fmt.Println(" switchPublic [-configDir config-dir] [-trackerServer tracker-server-and-port] [-listen listen-address-and-port] [-host outer-host] [-dynamicDomain dynamic-domain] [-port outer-port]")
@@ -136,7 +150,13 @@ func main() {
 			*upBandwidthFlag, *downBandwidthFlag, *portFlag, *hostFlag, *dynamicDomainFlag, *mainStoragePathFlag, *mainStorageVolumeFlag, *extraStorageFlag)
 	case "addStorage":
 		addStorageCommand.Parse(os.Args[2:])
-		addStorage(*addStorageConfigDirFlag, *addStorageTrackerServerFlag, *pathFlag, *volumeFlag)
+		addStorage(*addStorageConfigDirFlag, *addStorageTrackerServerFlag, *pathFlag, *volumeFlag, *tierFlag)
+	case "removeStorage":
+		removeStorageCommand.Parse(os.Args[2:])
+		removeStorage(*removeStorageConfigDirFlag, *removeStoragePathFlag)
+	case "rebalance":
+		rebalanceCommand.Parse(os.Args[2:])
+		rebalance(*rebalanceConfigDirFlag)
 	case "verifyEmail":
 		verifyEmailCommand.Parse(os.Args[2:])
 		verifyEmail(*verifyEmailConfigDirFlag, *verifyEmailTrackerServerFlag, *verifyCodeFlag)
@@ -171,13 +191,13 @@ func verifyEmail(configDir string, trackerServer string, verifyCode string) {
 		fmt.Printf("verifyCode is required.\n")
 		os.Exit(7)
 	}
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("RPC Dial failed: %s\n", err.Error())
 		os.Exit(8)
 	}
-	defer conn.Close()
-	prsc := trp_pb.NewProviderRegisterServiceClient(conn)
+	defer trackers.Close()
+	prsc := trp_pb.NewProviderRegisterServiceClient(trackers.Conn())
 	code, errMsg, err := client.VerifyBillEmail(prsc, verifyCode)
 	if err != nil {
 		fmt.Printf("verifyEmail failed: %s\n", err.Error())
@@ -203,13 +223,13 @@ func resendVerifyCode(configDir string, trackerServer string) {
 		fmt.Println("failed to load config, can not resend verify code email: " + err.Error())
 		os.Exit(202)
 	}
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("RPC Dial failed: %s\n", err.Error())
 		os.Exit(8)
 	}
-	defer conn.Close()
-	prsc := trp_pb.NewProviderRegisterServiceClient(conn)
+	defer trackers.Close()
+	prsc := trp_pb.NewProviderRegisterServiceClient(trackers.Conn())
 	success, err := client.ResendVerifyCode(prsc)
 	if err != nil {
 		fmt.Printf("resendVerifyCode failed: %s\n", err.Error())
@@ -262,15 +282,23 @@ func daemon(configDir string, trackerServer string, collectorServer string, task
 		go startServer(listen, grpcServer, providerServer)
 		defer grpcServer.GracefulStop()
 	}
+	trackers, err := trackerpool.New(logrus.StandardLogger(), trackerpool.Split(trackerServer), trackerpool.PingTracker, client.TrackerServers)
+	if err != nil {
+		fmt.Printf("RPC Dial failed: %s\n", err.Error())
+		os.Exit(61)
+	}
+	trackers.Start()
+	defer trackers.Close()
+	prsc := trp_pb.NewProviderRegisterServiceClient(trackers.Conn())
 	cronRunner := cron.New()
 	if private {
 		fmt.Println("Starting samos private network node.")
-		cronRunner.AddFunc("@every 5m", func() { client.PrivateAlive(trackerServer) })
+		cronRunner.AddFunc("@every 5m", func() { client.PrivateAlive(prsc) })
 	}
 	if !disableAutoRefreshIpFlag && !config.GetProviderConfig().Ddns && !private {
-		refreshIp(trackerServer, port, true)
+		refreshIp(prsc, port, true)
 		cronRunner.AddFunc("@every 2m", func() {
-			refreshIp(trackerServer, port, false)
+			refreshIp(prsc, port, false)
 		})
 	}
 	fmt.Println("Node is running.")
@@ -278,6 +306,20 @@ func daemon(configDir string, trackerServer string, collectorServer string, task
 		cronRunner.AddFunc("0 * * * * *", func() { fmt.Print(".") })
 	}
 	cronRunner.AddFunc("@every 1m", func() { providerServer.GetTask() })
+	cronRunner.AddFunc("@every 1m", func() {
+		providerServer.MigrateStorage()
+		// volume change is kept in config, so it is retried until tracker is updated
+		if !config.VolumeChanged() {
+			return
+		}
+		if err := client.UpdateStorageVolume(prsc); err != nil {
+			log.Warningf("update storage volume failed, retry later: %s", err)
+			return
+		}
+		if err := config.FinishVolumeUpdate(); err != nil {
+			log.Warningf("save storage volume updated failed: %s", err)
+		}
+	})
 	rand.Seed(time.Now().UnixNano())
 	random := rand.Intn(300)
 	cronRunner.AddFunc(fmt.Sprintf("%d %d 0 %d/3 * *", random%60, 30+random/60, time.Now().Day()%3+1), func() { providerServer.VerifyBlocks() })
@@ -392,10 +434,18 @@ func register(configDir string, trackerServer string, listen string, walletAddre
 		var index byte = 1
 		for _, str := range arr {
 			unit := strings.Split(str, ":")
-			if len(unit) != 2 {
+			if len(unit) != 2 && len(unit) != 3 {
 				fmt.Printf("extraStorage format error: %s, wrong unit: %s\n", extraStorageFlag, str)
 				os.Exit(21)
 			}
+			var tier string
+			if len(unit) == 3 {
+				tier = unit[2]
+				if !config.ValidTier(tier) {
+					fmt.Printf("extraStorage path %s tier %s is not valid, must be small or large\n", unit[0], tier)
+					os.Exit(28)
+				}
+			}
 			volume, err := parseStorageVolume(unit[1])
 			if err != nil {
 				fmt.Printf("extraStorage path %s parse error: %s\n", unit[0], err.Error())
@@ -424,7 +474,7 @@ func register(configDir string, trackerServer string, listen string, walletAddre
 					os.Exit(27)
 				}
 			}
-			extraStorage = append(extraStorage, config.ExtraStorageInfo{Path: unit[0], Volume: volume, Index: index})
+			extraStorage = append(extraStorage, config.ExtraStorageInfo{Path: unit[0], Volume: volume, Index: index, Tier: tier})
 			index++
 		}
 	}
@@ -505,13 +555,13 @@ func doRegister(configDir string, trackerServer string, listen string, walletAdd
 	go startPingServer(listen, grpcServer, util_hash.Sha1(no.NodeId))
 	defer grpcServer.GracefulStop()
 	time.Sleep(time.Duration(5) * time.Second) //for loadbalance health check
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("RPC Dial failed: %s\n", err.Error())
 		os.Exit(52)
 	}
-	defer conn.Close()
-	prsc := trp_pb.NewProviderRegisterServiceClient(conn)
+	defer trackers.Close()
+	prsc := trp_pb.NewProviderRegisterServiceClient(trackers.Conn())
 	pubKeyBytes, publicKeyHash, clientIp, err := client.GetPublicKey(prsc)
 	if err != nil {
 		fmt.Printf("GetPublicKey failed: %s\n", err.Error())
@@ -680,7 +730,14 @@ func (self *pingProviderService) GetFragment(ctx context.Context, req *pb.GetFra
 func (self *pingProviderService) CheckAvailable(ctx context.Context, req *pb.CheckAvailableReq) (resp *pb.CheckAvailableResp, err error) {
 	return nil, nil
 }
-func addStorage(configDir string, trackerServer string, path string, volumeStr string) {
+func (self *pingProviderService) Prove(ctx context.Context, req *pb.ProveReq) (resp *pb.ProveResp, err error) {
+	return nil, nil
+}
+func addStorage(configDir string, trackerServer string, path string, volumeStr string, tier string) {
+	if !config.ValidTier(tier) {
+		fmt.Printf("storage tier %s is not valid, must be small or large\n", tier)
+		os.Exit(12)
+	}
 	volume, err := parseStorageVolume(volumeStr)
 	if err != nil {
 		fmt.Printf("storage path %s parse error: %s\n", path, err.Error())
@@ -723,18 +780,18 @@ func addStorage(configDir string, trackerServer string, path string, volumeStr s
 		os.Exit(7)
 	}
 	for _, v := range pc.ExtraStorage {
-		if strings.Index(path, v.Path) == 0 {
+		if !v.Removed && strings.Index(path, v.Path) == 0 {
 			fmt.Printf("can not use %s as storage path, %s is already as storage path\n", path, v.Path)
 			os.Exit(8)
 		}
 	}
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("RPC Dial failed: %s\n", err.Error())
 		os.Exit(9)
 	}
-	defer conn.Close()
-	prsc := trp_pb.NewProviderRegisterServiceClient(conn)
+	defer trackers.Close()
+	prsc := trp_pb.NewProviderRegisterServiceClient(trackers.Conn())
 	success, err := client.AddExtraStorage(prsc, volume)
 	if err != nil {
 		fmt.Printf("resendVerifyCode failed: %s\n", err.Error())
@@ -747,11 +804,72 @@ func addStorage(configDir string, trackerServer string, path string, volumeStr s
 	idx := byte(len(pc.ExtraStorage) + 1)
 	pc.ExtraStorage = append(pc.ExtraStorage, config.ExtraStorageInfo{Path: path,
 		Volume: volume,
-		Index:  idx})
+		Index:  idx,
+		Tier:   tier})
 	config.SaveProviderConfig()
 	fmt.Println("Add storage success, please backup your config file: " + config.GetConfigFullPath(configDir))
 }
 
+func removeStorage(configDir string, path string) {
+	if len(path) == 0 {
+		fmt.Println("path is required.")
+		os.Exit(2)
+	}
+	err := config.LoadConfig(configDir)
+	if err != nil {
+		if err == config.NoConfErr {
+			fmt.Printf("Config file is not ready, please run \"%s register\" to register first\n", os.Args[0])
+			os.Exit(200)
+		} else if err == config.ConfVerifyErr {
+			fmt.Println("Config file wrong, can not remove storage.")
+			os.Exit(201)
+		}
+		fmt.Println("failed to load config, can not remove storage: " + err.Error())
+		os.Exit(202)
+	}
+	pc := config.GetProviderConfig()
+	path = strings.TrimSuffix(path, string(os.PathSeparator))
+	if path == strings.TrimSuffix(pc.MainStoragePath, string(os.PathSeparator)) {
+		fmt.Println("main storage can not be removed")
+		os.Exit(3)
+	}
+	for i, v := range pc.ExtraStorage {
+		if v.Removed || strings.TrimSuffix(v.Path, string(os.PathSeparator)) != path {
+			continue
+		}
+		if v.Removing {
+			fmt.Printf("storage %s is already removing\n", path)
+			os.Exit(4)
+		}
+		pc.ExtraStorage[i].Removing = true
+		config.SaveProviderConfig()
+		fmt.Println("Storage is marked as removing, daemon will migrate its blocks to other storages and notify tracker when finished.")
+		fmt.Println("Do not detach the disk until the storage is marked as removed in config file: " + config.GetConfigFullPath(configDir))
+		return
+	}
+	fmt.Printf("storage %s not found\n", path)
+	os.Exit(5)
+}
+
+func rebalance(configDir string) {
+	err := config.LoadConfig(configDir)
+	if err != nil {
+		if err == config.NoConfErr {
+			fmt.Printf("Config file is not ready, please run \"%s register\" to register first\n", os.Args[0])
+			os.Exit(200)
+		} else if err == config.ConfVerifyErr {
+			fmt.Println("Config file wrong, can not rebalance.")
+			os.Exit(201)
+		}
+		fmt.Println("failed to load config, can not rebalance: " + err.Error())
+		os.Exit(202)
+	}
+	pc := config.GetProviderConfig()
+	pc.Rebalance = true
+	config.SaveProviderConfig()
+	fmt.Println("Rebalance requested, daemon will move blocks between storages in background.")
+}
+
 func switchPrivate(configDir string, trackerServer string) {
 	err := config.LoadConfig(configDir)
 	if err != nil {
@@ -778,13 +896,13 @@ func switchPrivate(configDir string, trackerServer string) {
 		str := string(line)
 		if "yes" == strings.ToLower(strings.TrimSpace(str)) {
 			fmt.Printf("You entered \"%s\", will switch to private network node.\n", str)
-			conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+			trackers, err := trackerpool.Dial(trackerServer)
 			if err != nil {
 				fmt.Printf("RPC Dial failed: %s\n", err.Error())
 				os.Exit(9)
 			}
-			defer conn.Close()
-			prsc := trp_pb.NewProviderRegisterServiceClient(conn)
+			defer trackers.Close()
+			prsc := trp_pb.NewProviderRegisterServiceClient(trackers.Conn())
 			success, err := client.SwitchPrivate(prsc)
 			if err != nil {
 				fmt.Printf("resendVerifyCode failed: %s\n", err.Error())
@@ -848,13 +966,13 @@ func switchPublic(configDir string, trackerServer string, listen string, port ui
 	go startPingServer(listen, grpcServer, util_hash.Sha1(nodeId))
 	defer grpcServer.GracefulStop()
 	time.Sleep(time.Duration(5) * time.Second) //for loadbalance health check
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
+	trackers, err := trackerpool.Dial(trackerServer)
 	if err != nil {
 		fmt.Printf("RPC Dial failed: %s\n", err.Error())
 		os.Exit(3)
 	}
-	defer conn.Close()
-	prsc := trp_pb.NewProviderRegisterServiceClient(conn)
+	defer trackers.Close()
+	prsc := trp_pb.NewProviderRegisterServiceClient(trackers.Conn())
 	pubKeyBytes, publicKeyHash, clientIp, err := client.GetPublicKey(prsc)
 	if err != nil {
 		fmt.Printf("GetPublicKey failed: %s\n", err.Error())
@@ -940,20 +1058,8 @@ func newProviderConfig(no *node.Node, walletAddress string, billEmail string,
 	return pc
 }
 
-func refreshIp(trackerServer string, providerPort int, exitOnError bool) (ip string) {
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
-	if err != nil {
-		if exitOnError {
-			fmt.Printf("RPC Dial failed: %s\n", err.Error())
-			os.Exit(61)
-		} else {
-			log.Warningf("RPC Dial failed when refresh ip, info: %s", err)
-			return
-		}
-	}
-	defer conn.Close()
-	prsc := trp_pb.NewProviderRegisterServiceClient(conn)
-	ip, err = client.RefreshIp(prsc, uint32(providerPort))
+func refreshIp(prsc trp_pb.ProviderRegisterServiceClient, providerPort int, exitOnError bool) (ip string) {
+	ip, err := client.RefreshIp(prsc, uint32(providerPort))
 	if err != nil {
 		if exitOnError {
 			fmt.Printf("refresh ip failed: %s\n", err.Error())
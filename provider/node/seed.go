@@ -0,0 +1,126 @@
+package node
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	util_hash "github.com/samoslab/nebula/util/hash"
+)
+
+// seedStream deterministic stream of bytes expanded from seed by sha256 in counter mode
+type seedStream struct {
+	seed    []byte
+	counter uint64
+	buf     []byte
+}
+
+func (s *seedStream) Read(p []byte) (int, error) {
+	for n := 0; n < len(p); {
+		if len(s.buf) == 0 {
+			block := make([]byte, len(s.seed)+8)
+			copy(block, s.seed)
+			binary.BigEndian.PutUint64(block[len(s.seed):], s.counter)
+			s.counter++
+			sum := sha256.Sum256(block)
+			s.buf = sum[:]
+		}
+		c := copy(p[n:], s.buf)
+		s.buf = s.buf[c:]
+		n += c
+	}
+	return len(p), nil
+}
+
+// seedPrime prime of bits bits searched upward from a number read from rand, top two bits are set
+// so product of two such primes has exactly twice the bits
+func seedPrime(rand io.Reader, bits int) (*big.Int, error) {
+	b := make([]byte, (bits+7)/8)
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return nil, err
+	}
+	if extra := uint(len(b)*8 - bits); extra > 0 {
+		b[0] &= byte(0xff >> extra)
+	}
+	p := new(big.Int).SetBytes(b)
+	p.SetBit(p, bits-1, 1)
+	p.SetBit(p, bits-2, 1)
+	p.SetBit(p, 0, 1)
+	two := big.NewInt(2)
+	for ; p.BitLen() == bits; p.Add(p, two) {
+		if p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+	return nil, errors.New("no prime found from seed")
+}
+
+// seedKey rsa key of bits bits generated only from rand, unlike rsa.GenerateKey the same stream
+// always gives the same key
+func seedKey(rand io.Reader, bits int) (*rsa.PrivateKey, error) {
+	e := big.NewInt(65537)
+	one := big.NewInt(1)
+	for {
+		p, err := seedPrime(rand, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := seedPrime(rand, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		pminus1 := new(big.Int).Sub(p, one)
+		qminus1 := new(big.Int).Sub(q, one)
+		totient := new(big.Int).Mul(pminus1, qminus1)
+		d := new(big.Int).ModInverse(e, totient)
+		if d == nil {
+			continue
+		}
+		if p.Cmp(q) < 0 {
+			p, q = q, p
+		}
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: int(e.Int64())},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		key.Precompute()
+		if err = key.Validate(); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+}
+
+// NewNodeFromSeed node whose keys are derived only from seed, same seed always gives same node
+func NewNodeFromSeed(seed []byte, difficulty int) (*Node, error) {
+	if len(seed) < 16 {
+		return nil, errors.New("seed is too short")
+	}
+	rand := &seedStream{seed: seed}
+	n := &Node{}
+	for {
+		pk, err := seedKey(rand, RSA_KEY_BYTES*8)
+		if err != nil {
+			return nil, err
+		}
+		n.PriKey = pk
+		n.PubKey = &pk.PublicKey
+		n.PubKeyBytes = x509.MarshalPKCS1PublicKey(n.PubKey)
+		n.NodeId = util_hash.Sha1(n.PubKeyBytes)
+		if count_preceding_zero_bits(util_hash.Sha1(n.NodeId)) < difficulty {
+			break
+		}
+	}
+	aesKey := make([]byte, 256)
+	rand.Read(aesKey)
+	n.EncryptKey = map[string][]byte{"0": aesKey}
+	return n, nil
+}
@@ -20,6 +20,8 @@ It has these top-level messages:
 	GetFragmentResp
 	CheckAvailableReq
 	CheckAvailableResp
+	ProveReq
+	ProveResp
 */
 package provider_pb
 
@@ -467,6 +469,118 @@ func (m *CheckAvailableResp) GetVersion() uint32 {
 	return 0
 }
 
+type ProveReq struct {
+	Version       uint32            `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	Auth          []byte            `protobuf:"bytes,2,opt,name=auth,proto3" json:"auth,omitempty"`
+	Timestamp     uint64            `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Ticket        string            `protobuf:"bytes,4,opt,name=ticket" json:"ticket,omitempty"`
+	FileKey       []byte            `protobuf:"bytes,5,opt,name=fileKey,proto3" json:"fileKey,omitempty"`
+	FileSize      uint64            `protobuf:"varint,6,opt,name=fileSize" json:"fileSize,omitempty"`
+	BlockKey      []byte            `protobuf:"bytes,7,opt,name=blockKey,proto3" json:"blockKey,omitempty"`
+	BlockSize     uint64            `protobuf:"varint,8,opt,name=blockSize" json:"blockSize,omitempty"`
+	ChunkSize     uint32            `protobuf:"varint,9,opt,name=chunkSize" json:"chunkSize,omitempty"`
+	ChunkSeq      map[uint32][]byte `protobuf:"bytes,10,rep,name=chunkSeq" json:"chunkSeq,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SchemeVersion uint32            `protobuf:"varint,11,opt,name=schemeVersion" json:"schemeVersion,omitempty"`
+}
+
+func (m *ProveReq) Reset()                    { *m = ProveReq{} }
+func (m *ProveReq) String() string            { return proto.CompactTextString(m) }
+func (*ProveReq) ProtoMessage()               {}
+func (*ProveReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ProveReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ProveReq) GetAuth() []byte {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *ProveReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ProveReq) GetTicket() string {
+	if m != nil {
+		return m.Ticket
+	}
+	return ""
+}
+
+func (m *ProveReq) GetFileKey() []byte {
+	if m != nil {
+		return m.FileKey
+	}
+	return nil
+}
+
+func (m *ProveReq) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *ProveReq) GetBlockKey() []byte {
+	if m != nil {
+		return m.BlockKey
+	}
+	return nil
+}
+
+func (m *ProveReq) GetBlockSize() uint64 {
+	if m != nil {
+		return m.BlockSize
+	}
+	return 0
+}
+
+func (m *ProveReq) GetChunkSize() uint32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *ProveReq) GetChunkSeq() map[uint32][]byte {
+	if m != nil {
+		return m.ChunkSeq
+	}
+	return nil
+}
+
+func (m *ProveReq) GetSchemeVersion() uint32 {
+	if m != nil {
+		return m.SchemeVersion
+	}
+	return 0
+}
+
+type ProveResp struct {
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *ProveResp) Reset()                    { *m = ProveResp{} }
+func (m *ProveResp) String() string            { return proto.CompactTextString(m) }
+func (*ProveResp) ProtoMessage()               {}
+func (*ProveResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *ProveResp) GetResult() []byte {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PingReq)(nil), "provider.pb.PingReq")
 	proto.RegisterType((*PingResp)(nil), "provider.pb.PingResp")
@@ -480,6 +594,8 @@ func init() {
 	proto.RegisterType((*GetFragmentResp)(nil), "provider.pb.GetFragmentResp")
 	proto.RegisterType((*CheckAvailableReq)(nil), "provider.pb.CheckAvailableReq")
 	proto.RegisterType((*CheckAvailableResp)(nil), "provider.pb.CheckAvailableResp")
+	proto.RegisterType((*ProveReq)(nil), "provider.pb.ProveReq")
+	proto.RegisterType((*ProveResp)(nil), "provider.pb.ProveResp")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -540,6 +656,12 @@ type ProviderServiceClient interface {
 	// deprecated
 	GetFragment(ctx context.Context, in *GetFragmentReq, opts ...grpc.CallOption) (*GetFragmentResp, error)
 	CheckAvailable(ctx context.Context, in *CheckAvailableReq, opts ...grpc.CallOption) (*CheckAvailableResp, error)
+	// auth is the retrieve auth of block from tracker
+	// codes.Unauthenticated, "check auth failed, blockKey: %x error: %s"
+	// codes.InvalidArgument, "no chunk to prove, blockKey: %x"
+	// codes.NotFound, "file not exist, blockKey: %x"
+	// codes.Internal, "prove failed, blockKey: %x error: %s"
+	Prove(ctx context.Context, in *ProveReq, opts ...grpc.CallOption) (*ProveResp, error)
 }
 
 type providerServiceClient struct {
@@ -670,6 +792,15 @@ func (c *providerServiceClient) CheckAvailable(ctx context.Context, in *CheckAva
 	return out, nil
 }
 
+func (c *providerServiceClient) Prove(ctx context.Context, in *ProveReq, opts ...grpc.CallOption) (*ProveResp, error) {
+	out := new(ProveResp)
+	err := grpc.Invoke(ctx, "/provider.pb.ProviderService/Prove", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ProviderService service
 
 type ProviderServiceServer interface {
@@ -720,6 +851,12 @@ type ProviderServiceServer interface {
 	// deprecated
 	GetFragment(context.Context, *GetFragmentReq) (*GetFragmentResp, error)
 	CheckAvailable(context.Context, *CheckAvailableReq) (*CheckAvailableResp, error)
+	// auth is the retrieve auth of block from tracker
+	// codes.Unauthenticated, "check auth failed, blockKey: %x error: %s"
+	// codes.InvalidArgument, "no chunk to prove, blockKey: %x"
+	// codes.NotFound, "file not exist, blockKey: %x"
+	// codes.Internal, "prove failed, blockKey: %x error: %s"
+	Prove(context.Context, *ProveReq) (*ProveResp, error)
 }
 
 func RegisterProviderServiceServer(s *grpc.Server, srv ProviderServiceServer) {
@@ -881,6 +1018,24 @@ func _ProviderService_CheckAvailable_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProviderService_Prove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).Prove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/provider.pb.ProviderService/Prove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).Prove(ctx, req.(*ProveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ProviderService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "provider.pb.ProviderService",
 	HandlerType: (*ProviderServiceServer)(nil),
@@ -909,6 +1064,10 @@ var _ProviderService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckAvailable",
 			Handler:    _ProviderService_CheckAvailable_Handler,
 		},
+		{
+			MethodName: "Prove",
+			Handler:    _ProviderService_Prove_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -928,43 +1087,50 @@ var _ProviderService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("provider.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 599 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdd, 0x6e, 0xd3, 0x4c,
-	0x10, 0xfd, 0xdc, 0x38, 0x89, 0x33, 0xf9, 0xe9, 0xc7, 0xa8, 0x04, 0x63, 0xaa, 0x12, 0x19, 0x15,
-	0x45, 0x5c, 0x44, 0x08, 0xc4, 0x0d, 0x88, 0x0b, 0x54, 0x29, 0xfc, 0xdd, 0x54, 0xce, 0x13, 0x6c,
-	0x9c, 0x6d, 0xb3, 0x8a, 0x9d, 0x35, 0xde, 0x6d, 0x44, 0x91, 0x10, 0xaf, 0xc2, 0x13, 0xf0, 0x4e,
-	0xbc, 0x02, 0x4f, 0x80, 0x76, 0x6d, 0x27, 0xde, 0x34, 0x8e, 0x04, 0xaa, 0xb8, 0x9b, 0x99, 0x9d,
-	0x39, 0x73, 0x7c, 0x32, 0x33, 0x81, 0x5e, 0x92, 0xf2, 0x15, 0x9b, 0xd1, 0x74, 0x94, 0xa4, 0x5c,
-	0x72, 0x6c, 0x6f, 0xfc, 0xa9, 0xff, 0x08, 0x9a, 0xe7, 0x6c, 0x79, 0x19, 0xd0, 0x4f, 0xe8, 0x42,
-	0x73, 0x45, 0x53, 0xc1, 0xf8, 0xd2, 0xb5, 0x06, 0xd6, 0xb0, 0x1b, 0x14, 0xae, 0xff, 0x04, 0x9c,
-	0x2c, 0x49, 0x24, 0x78, 0x02, 0xb0, 0xe4, 0x33, 0xfa, 0x7e, 0xf6, 0x8e, 0x88, 0xb9, 0x4e, 0xec,
-	0x04, 0xa5, 0x88, 0xff, 0xcb, 0x02, 0x67, 0x22, 0x79, 0x4a, 0x15, 0x24, 0x82, 0x3d, 0x23, 0x92,
-	0xe4, 0x69, 0xda, 0x2e, 0xb7, 0x39, 0x30, 0xda, 0xa8, 0x6c, 0x72, 0x25, 0xe7, 0x6e, 0x2d, 0xcb,
-	0x56, 0x36, 0x1e, 0x43, 0x4b, 0xb2, 0x98, 0x0a, 0x49, 0xe2, 0xc4, 0xb5, 0x07, 0xd6, 0xd0, 0x0e,
-	0x36, 0x01, 0xec, 0x43, 0x43, 0xb2, 0x70, 0x41, 0xa5, 0x5b, 0x1f, 0x58, 0xc3, 0x56, 0x90, 0x7b,
-	0xaa, 0xc7, 0x05, 0x8b, 0xe8, 0x47, 0x7a, 0xed, 0x36, 0x34, 0x58, 0xe1, 0xa2, 0x07, 0x8e, 0x32,
-	0x27, 0xec, 0x0b, 0x75, 0x9b, 0x1a, 0x6e, 0xed, 0xab, 0xb7, 0x69, 0xc4, 0xc3, 0x85, 0x2a, 0x73,
-	0x74, 0xd9, 0xda, 0x57, 0x3c, 0xb4, 0xad, 0x0b, 0x5b, 0x19, 0x8f, 0x75, 0xc0, 0x3f, 0x85, 0x56,
-	0xfe, 0xcd, 0x22, 0x51, 0xcd, 0xc5, 0x55, 0x18, 0x52, 0x21, 0xf4, 0x77, 0x3b, 0x41, 0xe1, 0xfa,
-	0x3f, 0x2d, 0x68, 0x07, 0x54, 0xa6, 0x8c, 0xae, 0xe8, 0x5e, 0xc5, 0xd7, 0x52, 0x1c, 0x54, 0x49,
-	0x51, 0xab, 0x96, 0xc2, 0xae, 0x92, 0xa2, 0x5e, 0x2d, 0x45, 0x63, 0x8f, 0x14, 0xcd, 0x7d, 0x52,
-	0x38, 0xdb, 0x52, 0xf8, 0xd0, 0xd9, 0x7c, 0xa2, 0x48, 0x76, 0x8d, 0x80, 0xff, 0x15, 0x5a, 0x01,
-	0x8d, 0xf9, 0xed, 0x8b, 0xf0, 0x3f, 0xd4, 0x16, 0xf4, 0x5a, 0x2b, 0xd0, 0x09, 0x94, 0xa9, 0x30,
-	0x84, 0xe2, 0x59, 0xd7, 0xa9, 0xda, 0xf6, 0x1f, 0x03, 0x14, 0xed, 0xf7, 0xfe, 0x5c, 0xdf, 0x2d,
-	0xe8, 0xbd, 0xa5, 0x72, 0x9c, 0x92, 0xcb, 0x98, 0x2e, 0xe5, 0xbf, 0x25, 0xdb, 0xcd, 0xc8, 0x2a,
-	0x8c, 0x84, 0x0b, 0x26, 0x19, 0x5f, 0x8a, 0x7c, 0x98, 0x37, 0x01, 0xff, 0x14, 0x0e, 0x0d, 0x86,
-	0x86, 0xe0, 0xb5, 0xb5, 0xe0, 0xdf, 0xe0, 0xce, 0xd9, 0x9c, 0x86, 0x8b, 0x37, 0x2b, 0xc2, 0x22,
-	0x32, 0x8d, 0x6e, 0x5d, 0x78, 0xf3, 0x2a, 0xd8, 0x37, 0xae, 0xc2, 0x05, 0xe0, 0x36, 0x01, 0x91,
-	0xe0, 0x11, 0xd4, 0x25, 0x97, 0x24, 0xd2, 0xfd, 0xed, 0x20, 0x73, 0x70, 0x00, 0xed, 0x98, 0x7c,
-	0x1e, 0x17, 0xa3, 0x79, 0xa0, 0xdf, 0xca, 0xa1, 0x32, 0xf3, 0x9a, 0xc1, 0xfc, 0xd9, 0x0f, 0x1b,
-	0x0e, 0xcf, 0xf3, 0xf3, 0x36, 0xa1, 0xe9, 0x8a, 0x85, 0x14, 0x5f, 0x80, 0xad, 0xae, 0x17, 0x1e,
-	0x8d, 0x4a, 0x87, 0x6f, 0x94, 0x5f, 0x3d, 0xef, 0xee, 0x8e, 0xa8, 0x48, 0xfc, 0xff, 0xf0, 0x25,
-	0xd4, 0xf5, 0x4e, 0xa3, 0x99, 0x51, 0xdc, 0x36, 0xaf, 0xbf, 0x2b, 0xac, 0x2a, 0x87, 0x16, 0xbe,
-	0x06, 0xd0, 0x81, 0x49, 0x4c, 0xa2, 0xe8, 0x8f, 0x01, 0xf0, 0x0c, 0x9c, 0x62, 0x87, 0xd0, 0x35,
-	0xb2, 0x4a, 0xd7, 0xc3, 0xbb, 0x5f, 0xf1, 0xa2, 0x20, 0x9e, 0x5a, 0x38, 0x86, 0x6e, 0x11, 0xcb,
-	0x68, 0xfc, 0x1d, 0x12, 0xbe, 0x82, 0x46, 0xb6, 0x2d, 0xd8, 0xdf, 0x4a, 0xcb, 0x37, 0xd8, 0xbb,
-	0xb7, 0x33, 0xae, 0x8b, 0x3f, 0x40, 0xbb, 0x34, 0x9f, 0xf8, 0xc0, 0xc8, 0x34, 0x77, 0xcb, 0x3b,
-	0xae, 0x7e, 0xd4, 0x58, 0x13, 0xe8, 0x99, 0x33, 0x84, 0x27, 0x46, 0xc5, 0x8d, 0x09, 0xf7, 0x1e,
-	0xee, 0x7d, 0x57, 0xa0, 0xd3, 0x86, 0xfe, 0x4f, 0x7c, 0xfe, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xcf,
-	0xd5, 0x7f, 0xb2, 0x25, 0x07, 0x00, 0x00,
+	// 713 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xb1, 0x93, 0x38, 0x93, 0xa6, 0x85, 0x55, 0x09, 0xc6, 0x54, 0x25, 0x72, 0x29, 0x8a,
+	0x38, 0x44, 0xa8, 0x08, 0xa9, 0x6a, 0x85, 0x10, 0xaa, 0x28, 0x7f, 0x97, 0xca, 0x91, 0xb8, 0x3b,
+	0xce, 0xb4, 0xb1, 0xe2, 0xbf, 0x7a, 0x37, 0x11, 0x45, 0x42, 0xbc, 0x4a, 0x5f, 0x8d, 0x1b, 0x67,
+	0x9e, 0x00, 0xed, 0x7a, 0x9d, 0x78, 0xd3, 0x24, 0x12, 0xa8, 0xe2, 0xc0, 0x6d, 0xe6, 0xdb, 0xf9,
+	0xdb, 0xcf, 0x93, 0x6f, 0x03, 0x9b, 0x69, 0x96, 0x4c, 0x83, 0x21, 0x66, 0xbd, 0x34, 0x4b, 0x58,
+	0x42, 0x9a, 0x73, 0x7f, 0xe0, 0xec, 0x41, 0xfd, 0x2c, 0x88, 0x2f, 0x5c, 0xbc, 0x24, 0x16, 0xd4,
+	0xa7, 0x98, 0xd1, 0x20, 0x89, 0x2d, 0xad, 0xa3, 0x75, 0x5b, 0x6e, 0xe1, 0x3a, 0xcf, 0xc0, 0xcc,
+	0x83, 0x68, 0x4a, 0x76, 0x01, 0xe2, 0x64, 0x88, 0x1f, 0x86, 0xef, 0x3d, 0x3a, 0x12, 0x81, 0x1b,
+	0x6e, 0x09, 0x71, 0x7e, 0x69, 0x60, 0xf6, 0x59, 0x92, 0x21, 0x2f, 0x49, 0xc0, 0x18, 0x7a, 0xcc,
+	0x93, 0x61, 0xc2, 0x2e, 0xb7, 0xa9, 0x28, 0x6d, 0x78, 0xb4, 0x37, 0x61, 0x23, 0x4b, 0xcf, 0xa3,
+	0xb9, 0x4d, 0x76, 0xa0, 0xc1, 0x82, 0x08, 0x29, 0xf3, 0xa2, 0xd4, 0x32, 0x3a, 0x5a, 0xd7, 0x70,
+	0xe7, 0x00, 0x69, 0x43, 0x8d, 0x05, 0xfe, 0x18, 0x99, 0x55, 0xed, 0x68, 0xdd, 0x86, 0x2b, 0x3d,
+	0xde, 0xe3, 0x3c, 0x08, 0xf1, 0x13, 0x5e, 0x59, 0x35, 0x51, 0xac, 0x70, 0x89, 0x0d, 0x26, 0x37,
+	0xfb, 0xc1, 0x57, 0xb4, 0xea, 0xa2, 0xdc, 0xcc, 0xe7, 0x67, 0x83, 0x30, 0xf1, 0xc7, 0x3c, 0xcd,
+	0x14, 0x69, 0x33, 0x9f, 0xcf, 0x21, 0x6c, 0x91, 0xd8, 0xc8, 0xe7, 0x98, 0x01, 0xce, 0x3e, 0x34,
+	0xe4, 0x9d, 0x69, 0xca, 0x9b, 0xd3, 0x89, 0xef, 0x23, 0xa5, 0xe2, 0xde, 0xa6, 0x5b, 0xb8, 0xce,
+	0x0f, 0x0d, 0x9a, 0x2e, 0xb2, 0x2c, 0xc0, 0x29, 0xae, 0x65, 0x7c, 0x46, 0x45, 0x65, 0x15, 0x15,
+	0xfa, 0x6a, 0x2a, 0x8c, 0x55, 0x54, 0x54, 0x57, 0x53, 0x51, 0x5b, 0x43, 0x45, 0x7d, 0x1d, 0x15,
+	0xe6, 0x22, 0x15, 0x0e, 0x6c, 0xcc, 0xaf, 0x48, 0xd3, 0x65, 0x2b, 0xe0, 0x7c, 0x83, 0x86, 0x8b,
+	0x51, 0x72, 0xfb, 0x24, 0xdc, 0x05, 0x7d, 0x8c, 0x57, 0x82, 0x81, 0x0d, 0x97, 0x9b, 0xbc, 0x06,
+	0xe5, 0x73, 0x56, 0x45, 0xa8, 0xb0, 0x9d, 0xa7, 0x00, 0x45, 0xfb, 0xb5, 0x9f, 0xeb, 0x5a, 0x83,
+	0xcd, 0x77, 0xc8, 0x4e, 0x33, 0xef, 0x22, 0xc2, 0x98, 0xfd, 0xdb, 0x61, 0x5b, 0xf9, 0xb0, 0xbc,
+	0x46, 0x9a, 0xd0, 0x80, 0x05, 0x49, 0x4c, 0xe5, 0x32, 0xcf, 0x01, 0x67, 0x1f, 0xb6, 0x94, 0x09,
+	0x15, 0xc2, 0xf5, 0x19, 0xe1, 0xdf, 0xe1, 0xde, 0xc9, 0x08, 0xfd, 0xf1, 0x9b, 0xa9, 0x17, 0x84,
+	0xde, 0x20, 0xbc, 0x75, 0xe2, 0x55, 0x55, 0x30, 0x6e, 0xa8, 0xc2, 0x39, 0x90, 0xc5, 0x01, 0x68,
+	0x4a, 0xb6, 0xa1, 0xca, 0x12, 0xe6, 0x85, 0xa2, 0xbf, 0xe1, 0xe6, 0x0e, 0xe9, 0x40, 0x33, 0xf2,
+	0xbe, 0x9c, 0x16, 0xab, 0x59, 0x11, 0x67, 0x65, 0xa8, 0x3c, 0xb9, 0xae, 0x2a, 0xd5, 0xb5, 0x0e,
+	0xe6, 0x59, 0x96, 0xfc, 0xb7, 0x3f, 0x2f, 0x7e, 0xea, 0x8f, 0x26, 0xf1, 0x5c, 0x87, 0x5a, 0xee,
+	0x1c, 0x20, 0xaf, 0xc1, 0xcc, 0x1d, 0xbc, 0xb4, 0xa0, 0xa3, 0x77, 0x9b, 0x07, 0x7b, 0xbd, 0x92,
+	0xda, 0xf7, 0x0a, 0x6a, 0x7a, 0x27, 0x32, 0xea, 0x6d, 0xcc, 0xb2, 0x2b, 0x77, 0x96, 0x44, 0x9e,
+	0x40, 0x8b, 0xfa, 0x23, 0x8c, 0xf0, 0xb3, 0x24, 0xae, 0x29, 0x5a, 0xa8, 0xa0, 0x7d, 0x0c, 0x2d,
+	0xa5, 0x40, 0xb1, 0xca, 0x39, 0xcb, 0xdc, 0xe4, 0x9f, 0x76, 0xea, 0x85, 0x13, 0x94, 0x14, 0xe7,
+	0xce, 0x51, 0xe5, 0x50, 0x73, 0xf6, 0xa0, 0x21, 0xc7, 0xa0, 0x82, 0xd6, 0x0c, 0xe9, 0x24, 0x64,
+	0x52, 0x1f, 0xa4, 0x77, 0xf0, 0xd3, 0x80, 0xad, 0x33, 0x39, 0x78, 0x1f, 0xb3, 0x69, 0xe0, 0x23,
+	0x79, 0x09, 0x06, 0x7f, 0x85, 0xc8, 0xb6, 0x7a, 0xa5, 0xfc, 0xf5, 0xb2, 0xef, 0x2f, 0x41, 0x69,
+	0xea, 0xdc, 0x21, 0x47, 0x50, 0x15, 0xda, 0x4c, 0xd4, 0x88, 0xe2, 0x8d, 0xb2, 0xdb, 0xcb, 0x60,
+	0x9e, 0xd9, 0xd5, 0xc8, 0x2b, 0x00, 0x01, 0xf4, 0x23, 0x2f, 0x0c, 0xff, 0xb8, 0x00, 0x39, 0x01,
+	0xb3, 0xd0, 0x42, 0x62, 0x29, 0x51, 0xa5, 0x57, 0xc0, 0x7e, 0xb8, 0xe2, 0x84, 0x97, 0x78, 0xae,
+	0x91, 0x53, 0x68, 0x15, 0x58, 0x3e, 0xc6, 0xdf, 0x55, 0x22, 0xc7, 0x50, 0xcb, 0x55, 0x8f, 0xb4,
+	0x17, 0xc2, 0xa4, 0x12, 0xdb, 0x0f, 0x96, 0xe2, 0x22, 0xf9, 0x23, 0x34, 0x4b, 0x3a, 0x43, 0x1e,
+	0x29, 0x91, 0xaa, 0x46, 0xda, 0x3b, 0xab, 0x0f, 0x45, 0xad, 0x3e, 0x6c, 0xaa, 0x5a, 0x40, 0x76,
+	0x95, 0x8c, 0x1b, 0x4a, 0x65, 0x3f, 0x5e, 0x7b, 0x2e, 0x8a, 0x1e, 0x42, 0x55, 0x6c, 0xd5, 0xc2,
+	0x47, 0x2a, 0x16, 0xde, 0x6e, 0x2f, 0x83, 0x79, 0xe6, 0xa0, 0x26, 0xfe, 0x15, 0xbd, 0xf8, 0x3d,
+	0x00, 0x9e, 0xe0, 0xa2, 0x34, 0x27, 0x09, 0x00, 0x00,
 }
@@ -160,14 +160,7 @@ func SwitchPublic(client pb.ProviderRegisterServiceClient, publicKeyHash []byte,
 	return resp.Code, resp.ErrMsg, nil
 }
 
-func PrivateAlive(trackerServer string) error {
-	conn, err := grpc.Dial(trackerServer, grpc.WithInsecure())
-	if err != nil {
-		fmt.Printf("RPC Dial tracker %s failed: %s\n", trackerServer, err.Error())
-		return err
-	}
-	defer conn.Close()
-	prsc := pb.NewProviderRegisterServiceClient(conn)
+func PrivateAlive(prsc pb.ProviderRegisterServiceClient) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	node := node.LoadFormConfig()
@@ -178,7 +171,7 @@ func PrivateAlive(trackerServer string) error {
 		Total:       total,
 		MaxFileSize: max}
 	req.SignReq(node.PriKey)
-	_, err = prsc.PrivateAlive(ctx, req)
+	_, err := prsc.PrivateAlive(ctx, req)
 	if err != nil {
 		fmt.Printf("PrivateAlive failed: %s\n", err.Error())
 	}
@@ -188,6 +181,23 @@ func PrivateAlive(trackerServer string) error {
 func GetTrackerServer(client pb.ProviderRegisterServiceClient) (server map[string]uint32, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return getTrackerServer(ctx, client)
+}
+
+// TrackerServers addresses of all trackers, used to refresh tracker pool
+func TrackerServers(ctx context.Context, conn *grpc.ClientConn) ([]string, error) {
+	server, err := getTrackerServer(ctx, pb.NewProviderRegisterServiceClient(conn))
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(server))
+	for s, port := range server {
+		addrs = append(addrs, fmt.Sprintf("%s:%d", s, port))
+	}
+	return addrs, nil
+}
+
+func getTrackerServer(ctx context.Context, client pb.ProviderRegisterServiceClient) (server map[string]uint32, err error) {
 	node := node.LoadFormConfig()
 	req := &pb.GetTrackerServerReq{NodeId: node.NodeId,
 		Timestamp: uint64(time.Now().Unix())}
@@ -236,3 +246,28 @@ func RefreshIp(client pb.ProviderRegisterServiceClient, port uint32) (ip string,
 	return resp.Ip, nil
 
 }
+
+func UpdateStorageVolume(prsc pb.ProviderRegisterServiceClient) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	node := node.LoadFormConfig()
+	mainVolume, extraVolume := config.StorageVolume()
+	total, max := config.AvailableVolume()
+	req := &pb.UpdateStorageVolumeReq{Version: 1,
+		NodeId:             node.NodeId,
+		Timestamp:          uint64(time.Now().Unix()),
+		MainStorageVolume:  mainVolume,
+		ExtraStorageVolume: extraVolume,
+		Total:              total,
+		MaxFileSize:        max}
+	req.SignReq(node.PriKey)
+	resp, err := prsc.UpdateStorageVolume(ctx, req)
+	if err != nil {
+		fmt.Printf("UpdateStorageVolume failed: %s\n", err.Error())
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("tracker refused storage volume update")
+	}
+	return nil
+}
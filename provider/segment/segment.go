@@ -0,0 +1,411 @@
+// Package segment store small blocks in append-only segment files.
+// Record format: | crc32 (4) | key length (1) | data length (4) | key | data |,
+// crc32 (Castagnoli) is computed over everything after the crc field.
+package segment
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const header_size = 9
+const location_size = 12
+const filename_suffix = ".seg"
+
+// DefaultSegmentSize new record is written to next segment when current one exceed it
+const DefaultSegmentSize = 256 * 1024 * 1024
+
+// segment can be compacted when dead bytes reach this ratio of its size
+const compact_ratio = 0.5
+
+var ErrNotExist = errors.New("segment not exist")
+var ErrChecksum = errors.New("record checksum mismatch")
+var ErrCorrupted = errors.New("record corrupted")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Location of record, Length is the data length
+type Location struct {
+	Segment uint32
+	Offset  uint32
+	Length  uint32
+}
+
+func (self Location) Bytes() []byte {
+	b := make([]byte, location_size)
+	binary.BigEndian.PutUint32(b, self.Segment)
+	binary.BigEndian.PutUint32(b[4:], self.Offset)
+	binary.BigEndian.PutUint32(b[8:], self.Length)
+	return b
+}
+
+func ParseLocation(b []byte) (loc Location, err error) {
+	if len(b) != location_size {
+		return loc, fmt.Errorf("wrong location length: %d", len(b))
+	}
+	loc.Segment = binary.BigEndian.Uint32(b)
+	loc.Offset = binary.BigEndian.Uint32(b[4:])
+	loc.Length = binary.BigEndian.Uint32(b[8:])
+	return
+}
+
+// RecordSize return bytes of the record on disk
+func RecordSize(keyLen int, dataLen uint32) uint32 {
+	return header_size + uint32(keyLen) + dataLen
+}
+
+type segmentFile struct {
+	file *os.File
+	size uint32
+	dead uint32
+}
+
+type Store struct {
+	dir         string
+	segmentSize uint32
+	mutex       sync.RWMutex
+	segments    map[uint32]*segmentFile
+	active      uint32
+}
+
+// Open the store in dir, torn record at the tail of the last segment is truncated
+func Open(dir string, segmentSize uint32) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint32, 0, len(files))
+	for _, f := range files {
+		if !f.Mode().IsRegular() || !strings.HasSuffix(f.Name(), filename_suffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(f.Name(), filename_suffix), 10, 32)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint32(id))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	s := &Store{dir: dir, segmentSize: segmentSize, segments: make(map[uint32]*segmentFile, len(ids)+1)}
+	for i, id := range ids {
+		last := i == len(ids)-1
+		flag := os.O_RDONLY
+		if last {
+			flag = os.O_RDWR
+		}
+		f, err := os.OpenFile(s.path(id), flag, 0600)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		seg := &segmentFile{file: f}
+		s.segments[id] = seg
+		if last {
+			if err = seg.recover(); err != nil {
+				s.Close()
+				return nil, err
+			}
+			s.active = id
+		} else {
+			fi, err := f.Stat()
+			if err != nil {
+				s.Close()
+				return nil, err
+			}
+			seg.size = uint32(fi.Size())
+		}
+	}
+	if len(ids) == 0 {
+		if err = s.create(1); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (self *Store) path(id uint32) string {
+	return filepath.Join(self.dir, fmt.Sprintf("%08d%s", id, filename_suffix))
+}
+
+func (self *Store) create(id uint32) error {
+	f, err := os.OpenFile(self.path(id), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	self.segments[id] = &segmentFile{file: f}
+	self.active = id
+	return nil
+}
+
+// recover scan all records, corrupted records followed by a valid one are skipped and accounted as dead,
+// the segment is truncated only when no valid record follows, which is a torn write at the tail
+func (self *segmentFile) recover() error {
+	fi, err := self.file.Stat()
+	if err != nil {
+		return err
+	}
+	fileSize := fi.Size()
+	var offset, skipped int64
+	for offset < fileSize {
+		_, header, err := readRecord(self.file, offset, fileSize)
+		if err == nil {
+			offset += int64(RecordSize(int(header.keyLen), header.length))
+			continue
+		}
+		next := nextRecord(self.file, offset, fileSize)
+		if next == fileSize {
+			break
+		}
+		log.Warnf("segment %s skip %d bytes of corrupted record at %d: %s", self.file.Name(), next-offset, offset, err)
+		skipped += next - offset
+		offset = next
+	}
+	if offset < fileSize {
+		log.Warnf("segment %s truncate torn record at %d, file size: %d", self.file.Name(), offset, fileSize)
+		if err = self.file.Truncate(offset); err != nil {
+			return err
+		}
+		if err = self.file.Sync(); err != nil {
+			return err
+		}
+	}
+	self.size = uint32(offset)
+	self.dead = uint32(skipped)
+	return nil
+}
+
+// nextRecord return offset of the first valid record after the invalid one at offset, size if there is none
+func nextRecord(r io.ReaderAt, offset int64, size int64) int64 {
+	for offset++; offset+header_size < size; offset++ {
+		if _, _, err := readRecord(r, offset, size); err == nil {
+			return offset
+		}
+	}
+	return size
+}
+
+type recordHeader struct {
+	keyLen byte
+	length uint32
+}
+
+// readRecord read and verify the whole record at offset, the record must end within size
+func readRecord(r io.ReaderAt, offset int64, size int64) (key []byte, header recordHeader, err error) {
+	var buf [header_size]byte
+	if _, err = r.ReadAt(buf[:], offset); err != nil {
+		return
+	}
+	header.keyLen = buf[4]
+	header.length = binary.BigEndian.Uint32(buf[5:])
+	if header.keyLen == 0 || offset+int64(RecordSize(int(header.keyLen), header.length)) > size {
+		return nil, header, ErrCorrupted
+	}
+	body := make([]byte, int(header.keyLen)+int(header.length))
+	if _, err = r.ReadAt(body, offset+header_size); err != nil {
+		if err == io.EOF {
+			err = ErrCorrupted
+		}
+		return
+	}
+	crc := crc32.Update(crc32.Checksum(buf[4:], crcTable), crcTable, body)
+	if crc != binary.BigEndian.Uint32(buf[:4]) {
+		return nil, header, ErrChecksum
+	}
+	return body, header, nil
+}
+
+// Append write the record to the active segment and sync it to disk
+func (self *Store) Append(key []byte, data []byte) (loc Location, err error) {
+	if len(key) == 0 || len(key) > 255 {
+		return loc, fmt.Errorf("wrong key length: %d", len(key))
+	}
+	size := RecordSize(len(key), uint32(len(data)))
+	buf := make([]byte, size)
+	buf[4] = byte(len(key))
+	binary.BigEndian.PutUint32(buf[5:], uint32(len(data)))
+	copy(buf[header_size:], key)
+	copy(buf[header_size+len(key):], data)
+	binary.BigEndian.PutUint32(buf, crc32.Checksum(buf[4:], crcTable))
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	seg := self.segments[self.active]
+	if seg.size > 0 && uint64(seg.size)+uint64(size) > uint64(self.segmentSize) {
+		if err = self.create(self.active + 1); err != nil {
+			return
+		}
+		seg = self.segments[self.active]
+	}
+	if _, err = seg.file.WriteAt(buf, int64(seg.size)); err != nil {
+		seg.file.Truncate(int64(seg.size))
+		return
+	}
+	if err = seg.file.Sync(); err != nil {
+		seg.file.Truncate(int64(seg.size))
+		return
+	}
+	loc = Location{Segment: self.active, Offset: seg.size, Length: uint32(len(data))}
+	seg.size += size
+	return loc, nil
+}
+
+// Read the data of record and verify checksum
+func (self *Store) Read(key []byte, loc Location) ([]byte, error) {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+	seg, ok := self.segments[loc.Segment]
+	if !ok {
+		return nil, ErrNotExist
+	}
+	if uint64(loc.Offset)+uint64(RecordSize(len(key), loc.Length)) > uint64(seg.size) {
+		return nil, ErrCorrupted
+	}
+	body, header, err := readRecord(seg.file, int64(loc.Offset), int64(seg.size))
+	if err != nil {
+		return nil, err
+	}
+	if int(header.keyLen) != len(key) || header.length != loc.Length || string(body[:len(key)]) != string(key) {
+		return nil, ErrCorrupted
+	}
+	return body[len(key):], nil
+}
+
+// ReadAt read part of the data without verify checksum
+func (self *Store) ReadAt(key []byte, loc Location, p []byte, off int64) (n int, err error) {
+	if off < 0 || off >= int64(loc.Length) {
+		return 0, io.EOF
+	}
+	if remain := int64(loc.Length) - off; int64(len(p)) > remain {
+		p = p[:remain]
+		defer func() {
+			if err == nil {
+				err = io.EOF
+			}
+		}()
+	}
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+	seg, ok := self.segments[loc.Segment]
+	if !ok {
+		return 0, ErrNotExist
+	}
+	if uint64(loc.Offset)+uint64(RecordSize(len(key), loc.Length)) > uint64(seg.size) {
+		return 0, ErrCorrupted
+	}
+	return seg.file.ReadAt(p, int64(loc.Offset)+header_size+int64(len(key))+off)
+}
+
+// Valid return true if the location is inside an existing segment
+func (self *Store) Valid(key []byte, loc Location) bool {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+	seg, ok := self.segments[loc.Segment]
+	return ok && uint64(loc.Offset)+uint64(RecordSize(len(key), loc.Length)) <= uint64(seg.size)
+}
+
+// MarkDead account the record as garbage, it is reclaimed when the segment compacted
+func (self *Store) MarkDead(key []byte, loc Location) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if seg, ok := self.segments[loc.Segment]; ok {
+		seg.dead += RecordSize(len(key), loc.Length)
+		if seg.dead > seg.size {
+			seg.dead = seg.size
+		}
+	}
+}
+
+// SetLive set live bytes of every segment, the rest is dead, segment not in live has no live record
+func (self *Store) SetLive(live map[uint32]uint64) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	for id, seg := range self.segments {
+		l := live[id]
+		if l > uint64(seg.size) {
+			l = uint64(seg.size)
+		}
+		seg.dead = seg.size - uint32(l)
+	}
+}
+
+// Compactable return sealed segments which dead bytes reach the compact ratio
+func (self *Store) Compactable() []uint32 {
+	self.mutex.RLock()
+	defer self.mutex.RUnlock()
+	res := make([]uint32, 0, 4)
+	for id, seg := range self.segments {
+		if id != self.active && float64(seg.dead) >= float64(seg.size)*compact_ratio {
+			res = append(res, id)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// Scan call fn with every valid record of the segment in order, invalid records are skipped
+func (self *Store) Scan(id uint32, fn func(key []byte, loc Location, data []byte) error) error {
+	self.mutex.RLock()
+	seg, ok := self.segments[id]
+	var size uint32
+	if ok {
+		size = seg.size
+	}
+	self.mutex.RUnlock()
+	if !ok {
+		return ErrNotExist
+	}
+	var offset uint32
+	for offset < size {
+		body, header, err := readRecord(seg.file, int64(offset), int64(size))
+		if err != nil {
+			next := nextRecord(seg.file, int64(offset), int64(size))
+			log.Warnf("segment %d skip %d bytes of invalid record at %d: %s", id, next-int64(offset), offset, err)
+			offset = uint32(next)
+			continue
+		}
+		loc := Location{Segment: id, Offset: offset, Length: header.length}
+		if err = fn(body[:header.keyLen], loc, body[header.keyLen:]); err != nil {
+			return err
+		}
+		offset += RecordSize(int(header.keyLen), header.length)
+	}
+	return nil
+}
+
+// Remove delete the sealed segment
+func (self *Store) Remove(id uint32) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if id == self.active {
+		return errors.New("can not remove active segment")
+	}
+	seg, ok := self.segments[id]
+	if !ok {
+		return ErrNotExist
+	}
+	delete(self.segments, id)
+	seg.file.Close()
+	return os.Remove(self.path(id))
+}
+
+func (self *Store) Close() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	for _, seg := range self.segments {
+		seg.file.Close()
+	}
+}
@@ -0,0 +1,173 @@
+package segment
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	util_hash "github.com/samoslab/nebula/util/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func tempStore(t *testing.T, segmentSize uint32) (string, *Store) {
+	dir, err := ioutil.TempDir("", "segment")
+	require.NoError(t, err)
+	s, err := Open(dir, segmentSize)
+	require.NoError(t, err)
+	return dir, s
+}
+
+func TestAppendAndRead(t *testing.T) {
+	dir, s := tempStore(t, 1024)
+	defer os.RemoveAll(dir)
+	locs := make([]Location, 0, 10)
+	for i := 0; i < 10; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 200)
+		loc, err := s.Append(util_hash.Sha1(data), data)
+		require.NoError(t, err)
+		locs = append(locs, loc)
+	}
+	require.True(t, locs[9].Segment > 1)
+	for i, loc := range locs {
+		data := bytes.Repeat([]byte{byte(i)}, 200)
+		key := util_hash.Sha1(data)
+		parsed, err := ParseLocation(loc.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, loc, parsed)
+		res, err := s.Read(key, loc)
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+		buf := make([]byte, 50)
+		n, err := s.ReadAt(key, loc, buf, 180)
+		require.Equal(t, io.EOF, err)
+		require.Equal(t, 20, n)
+		_, err = s.Read(util_hash.Sha1([]byte("other")), loc)
+		require.Equal(t, ErrCorrupted, err)
+	}
+	s.Close()
+	s, err := Open(dir, 1024)
+	require.NoError(t, err)
+	defer s.Close()
+	data := bytes.Repeat([]byte{9}, 200)
+	res, err := s.Read(util_hash.Sha1(data), locs[9])
+	require.NoError(t, err)
+	require.Equal(t, data, res)
+}
+
+func TestRecoverTornRecord(t *testing.T) {
+	dir, s := tempStore(t, DefaultSegmentSize)
+	defer os.RemoveAll(dir)
+	data := []byte("first record")
+	loc1, err := s.Append(util_hash.Sha1(data), data)
+	require.NoError(t, err)
+	loc2, err := s.Append(util_hash.Sha1([]byte("second")), []byte("second"))
+	require.NoError(t, err)
+	s.Close()
+	path := s.path(loc1.Segment)
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, fi.Size()-3))
+	s, err = Open(dir, DefaultSegmentSize)
+	require.NoError(t, err)
+	defer s.Close()
+	res, err := s.Read(util_hash.Sha1(data), loc1)
+	require.NoError(t, err)
+	require.Equal(t, data, res)
+	require.False(t, s.Valid(util_hash.Sha1([]byte("second")), loc2))
+	loc3, err := s.Append(util_hash.Sha1([]byte("third")), []byte("third"))
+	require.NoError(t, err)
+	require.Equal(t, loc2.Offset, loc3.Offset)
+}
+
+func TestRecoverCorruptedMiddle(t *testing.T) {
+	dir, s := tempStore(t, DefaultSegmentSize)
+	defer os.RemoveAll(dir)
+	keys := make([][]byte, 0, 3)
+	locs := make([]Location, 0, 3)
+	for _, d := range []string{"first record", "second record", "third record"} {
+		loc, err := s.Append(util_hash.Sha1([]byte(d)), []byte(d))
+		require.NoError(t, err)
+		keys, locs = append(keys, util_hash.Sha1([]byte(d))), append(locs, loc)
+	}
+	s.Close()
+	// length of second record is garbage, it can not be used to find the third one
+	f, err := os.OpenFile(s.path(locs[1].Segment), os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xff, 0xff, 0xff}, int64(locs[1].Offset)+5)
+	require.NoError(t, err)
+	f.Close()
+	s, err = Open(dir, DefaultSegmentSize)
+	require.NoError(t, err)
+	defer s.Close()
+	res, err := s.Read(keys[0], locs[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("first record"), res)
+	_, err = s.Read(keys[1], locs[1])
+	require.Equal(t, ErrCorrupted, err)
+	res, err = s.Read(keys[2], locs[2])
+	require.NoError(t, err)
+	require.Equal(t, []byte("third record"), res)
+	scanned := make([][]byte, 0, 2)
+	require.NoError(t, s.Scan(locs[0].Segment, func(key []byte, loc Location, data []byte) error {
+		scanned = append(scanned, key)
+		return nil
+	}))
+	require.Equal(t, [][]byte{keys[0], keys[2]}, scanned)
+	loc, err := s.Append(util_hash.Sha1([]byte("fourth")), []byte("fourth"))
+	require.NoError(t, err)
+	require.Equal(t, locs[2].Offset+RecordSize(len(keys[2]), locs[2].Length), loc.Offset)
+}
+
+func TestChecksum(t *testing.T) {
+	dir, s := tempStore(t, DefaultSegmentSize)
+	defer os.RemoveAll(dir)
+	defer s.Close()
+	data := []byte("some data")
+	key := util_hash.Sha1(data)
+	loc, err := s.Append(key, data)
+	require.NoError(t, err)
+	f, err := os.OpenFile(s.path(loc.Segment), os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{'x'}, int64(loc.Offset)+header_size+int64(len(key)))
+	require.NoError(t, err)
+	f.Close()
+	_, err = s.Read(key, loc)
+	require.Equal(t, ErrChecksum, err)
+}
+
+func TestCompact(t *testing.T) {
+	dir, s := tempStore(t, 512)
+	defer os.RemoveAll(dir)
+	defer s.Close()
+	keys := make([][]byte, 0, 4)
+	locs := make([]Location, 0, 4)
+	for i := 0; i < 4; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 200)
+		loc, err := s.Append(util_hash.Sha1(data), data)
+		require.NoError(t, err)
+		keys, locs = append(keys, util_hash.Sha1(data)), append(locs, loc)
+	}
+	require.Equal(t, locs[0].Segment, locs[1].Segment)
+	require.Empty(t, s.Compactable())
+	s.MarkDead(keys[0], locs[0])
+	require.Equal(t, []uint32{locs[0].Segment}, s.Compactable())
+	live := make([][]byte, 0, 2)
+	require.NoError(t, s.Scan(locs[0].Segment, func(key []byte, loc Location, data []byte) error {
+		if loc != locs[0] {
+			live = append(live, key)
+		}
+		return nil
+	}))
+	require.Equal(t, [][]byte{keys[1]}, live)
+	require.NoError(t, s.Remove(locs[0].Segment))
+	_, err := s.Read(keys[1], locs[1])
+	require.Equal(t, ErrNotExist, err)
+	s.SetLive(map[uint32]uint64{})
+	require.Empty(t, s.Compactable(), "active segment should not be compacted")
+	loc, err := s.Append(keys[0], bytes.Repeat([]byte{0}, 200))
+	require.NoError(t, err)
+	require.Equal(t, locs[2].Segment+1, loc.Segment)
+	require.Equal(t, []uint32{locs[2].Segment}, s.Compactable())
+}
@@ -0,0 +1,29 @@
+package metadata_pb
+
+import (
+	"context"
+	"encoding/hex"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// DeviceIdHeader grpc metadata key of hex device id of enrolled device signing the request, requests
+// without it are signed by primary key of account
+const DeviceIdHeader = "nebula-device-id"
+
+// DeviceIdFromContext device id of incoming request, nil if it is sent by primary device
+func DeviceIdFromContext(ctx context.Context) []byte {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	values := md.Get(DeviceIdHeader)
+	if len(values) == 0 {
+		return nil
+	}
+	id, err := hex.DecodeString(values[0])
+	if err != nil {
+		return nil
+	}
+	return id
+}
@@ -17,6 +17,7 @@ It has these top-level messages:
 	MkFolderResp
 	CheckFileExistReq
 	CheckFileExistResp
+	Durability
 	UploadFilePrepareReq
 	SplitPartition
 	PieceHashAndSize
@@ -32,6 +33,9 @@ It has these top-level messages:
 	ListFilesReq
 	ListFilesResp
 	FileOrFolder
+	SearchFilesReq
+	SearchFilesResp
+	SearchedFile
 	RetrieveFileReq
 	RetrieveFileResp
 	RetrievePartition
@@ -43,6 +47,34 @@ It has these top-level messages:
 	MoveResp
 	SpaceSysFileReq
 	SpaceSysFileResp
+	ReportBlocksReq
+	BadBlock
+	ReportBlocksResp
+	RepairBlocksDoneReq
+	RepairBlocksDoneResp
+	MkFoldersReq
+	MkFoldersResp
+	CheckFilesExistReq
+	CheckFileItem
+	CheckFilesExistResp
+	UploadFilesDoneReq
+	UploadFileDoneItem
+	UploadFilesDoneResp
+	PackRange
+	PackFilesDoneReq
+	PackMember
+	PackFilesDoneResp
+	Delegation
+	WrappedSpaceKey
+	EnrollDeviceReq
+	EnrollDeviceResp
+	ListDevicesReq
+	Device
+	ListDevicesResp
+	RevokeDeviceReq
+	RevokeDeviceResp
+	DeviceSpaceKeysReq
+	DeviceSpaceKeysResp
 */
 package metadata_pb
 
@@ -385,21 +417,25 @@ func (m *MkFolderResp) GetErrMsg() string {
 }
 
 type CheckFileExistReq struct {
-	Version       uint32    `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
-	NodeId        []byte    `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
-	Timestamp     uint64    `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
-	Parent        *FilePath `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
-	FileHash      []byte    `protobuf:"bytes,5,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
-	FileSize      uint64    `protobuf:"varint,6,opt,name=fileSize" json:"fileSize,omitempty"`
-	FileType      string    `protobuf:"bytes,7,opt,name=fileType" json:"fileType,omitempty"`
-	EncryptKey    []byte    `protobuf:"bytes,8,opt,name=encryptKey,proto3" json:"encryptKey,omitempty"`
-	PublicKeyHash []byte    `protobuf:"bytes,9,opt,name=publicKeyHash,proto3" json:"publicKeyHash,omitempty"`
-	FileName      string    `protobuf:"bytes,10,opt,name=fileName" json:"fileName,omitempty"`
-	FileModTime   uint64    `protobuf:"varint,11,opt,name=fileModTime" json:"fileModTime,omitempty"`
-	FileData      []byte    `protobuf:"bytes,12,opt,name=fileData,proto3" json:"fileData,omitempty"`
-	Interactive   bool      `protobuf:"varint,13,opt,name=interactive" json:"interactive,omitempty"`
-	NewVersion    bool      `protobuf:"varint,14,opt,name=newVersion" json:"newVersion,omitempty"`
-	Sign          []byte    `protobuf:"bytes,15,opt,name=sign,proto3" json:"sign,omitempty"`
+	Version       uint32      `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId        []byte      `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp     uint64      `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Parent        *FilePath   `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
+	FileHash      []byte      `protobuf:"bytes,5,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
+	FileSize      uint64      `protobuf:"varint,6,opt,name=fileSize" json:"fileSize,omitempty"`
+	FileType      string      `protobuf:"bytes,7,opt,name=fileType" json:"fileType,omitempty"`
+	EncryptKey    []byte      `protobuf:"bytes,8,opt,name=encryptKey,proto3" json:"encryptKey,omitempty"`
+	PublicKeyHash []byte      `protobuf:"bytes,9,opt,name=publicKeyHash,proto3" json:"publicKeyHash,omitempty"`
+	FileName      string      `protobuf:"bytes,10,opt,name=fileName" json:"fileName,omitempty"`
+	FileModTime   uint64      `protobuf:"varint,11,opt,name=fileModTime" json:"fileModTime,omitempty"`
+	FileData      []byte      `protobuf:"bytes,12,opt,name=fileData,proto3" json:"fileData,omitempty"`
+	Interactive   bool        `protobuf:"varint,13,opt,name=interactive" json:"interactive,omitempty"`
+	NewVersion    bool        `protobuf:"varint,14,opt,name=newVersion" json:"newVersion,omitempty"`
+	Sign          []byte      `protobuf:"bytes,15,opt,name=sign,proto3" json:"sign,omitempty"`
+	Durability    *Durability `protobuf:"bytes,16,opt,name=durability" json:"durability,omitempty"`
+	Pack          bool        `protobuf:"varint,17,opt,name=pack" json:"pack,omitempty"`
+	Encoding      string      `protobuf:"bytes,18,opt,name=encoding" json:"encoding,omitempty"`
+	DecodedSize   uint64      `protobuf:"varint,19,opt,name=decodedSize" json:"decodedSize,omitempty"`
 }
 
 func (m *CheckFileExistReq) Reset()                    { *m = CheckFileExistReq{} }
@@ -512,14 +548,43 @@ func (m *CheckFileExistReq) GetSign() []byte {
 	return nil
 }
 
+func (m *CheckFileExistReq) GetDurability() *Durability {
+	if m != nil {
+		return m.Durability
+	}
+	return nil
+}
+
+func (m *CheckFileExistReq) GetPack() bool {
+	if m != nil {
+		return m.Pack
+	}
+	return false
+}
+
+func (m *CheckFileExistReq) GetEncoding() string {
+	if m != nil {
+		return m.Encoding
+	}
+	return ""
+}
+
+func (m *CheckFileExistReq) GetDecodedSize() uint64 {
+	if m != nil {
+		return m.DecodedSize
+	}
+	return 0
+}
+
 type CheckFileExistResp struct {
-	Code             uint32        `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
-	ErrMsg           string        `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
-	StoreType        FileStoreType `protobuf:"varint,3,opt,name=storeType,enum=metadata.pb.FileStoreType" json:"storeType,omitempty"`
-	DataPieceCount   uint32        `protobuf:"varint,4,opt,name=dataPieceCount" json:"dataPieceCount,omitempty"`
-	VerifyPieceCount uint32        `protobuf:"varint,5,opt,name=verifyPieceCount" json:"verifyPieceCount,omitempty"`
-	ReplicaCount     uint32        `protobuf:"varint,6,opt,name=replicaCount" json:"replicaCount,omitempty"`
-	ChunkSize        uint32        `protobuf:"varint,7,opt,name=chunkSize" json:"chunkSize,omitempty"`
+	Code              uint32        `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg            string        `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	StoreType         FileStoreType `protobuf:"varint,3,opt,name=storeType,enum=metadata.pb.FileStoreType" json:"storeType,omitempty"`
+	DataPieceCount    uint32        `protobuf:"varint,4,opt,name=dataPieceCount" json:"dataPieceCount,omitempty"`
+	VerifyPieceCount  uint32        `protobuf:"varint,5,opt,name=verifyPieceCount" json:"verifyPieceCount,omitempty"`
+	ReplicaCount      uint32        `protobuf:"varint,6,opt,name=replicaCount" json:"replicaCount,omitempty"`
+	ChunkSize         uint32        `protobuf:"varint,7,opt,name=chunkSize" json:"chunkSize,omitempty"`
+	DurabilityClamped bool          `protobuf:"varint,8,opt,name=durabilityClamped" json:"durabilityClamped,omitempty"`
 }
 
 func (m *CheckFileExistResp) Reset()                    { *m = CheckFileExistResp{} }
@@ -576,6 +641,13 @@ func (m *CheckFileExistResp) GetChunkSize() uint32 {
 	return 0
 }
 
+func (m *CheckFileExistResp) GetDurabilityClamped() bool {
+	if m != nil {
+		return m.DurabilityClamped
+	}
+	return false
+}
+
 type UploadFilePrepareReq struct {
 	Version   uint32            `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
 	NodeId    []byte            `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
@@ -589,7 +661,7 @@ type UploadFilePrepareReq struct {
 func (m *UploadFilePrepareReq) Reset()                    { *m = UploadFilePrepareReq{} }
 func (m *UploadFilePrepareReq) String() string            { return proto.CompactTextString(m) }
 func (*UploadFilePrepareReq) ProtoMessage()               {}
-func (*UploadFilePrepareReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*UploadFilePrepareReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *UploadFilePrepareReq) GetVersion() uint32 {
 	if m != nil {
@@ -647,7 +719,7 @@ type SplitPartition struct {
 func (m *SplitPartition) Reset()                    { *m = SplitPartition{} }
 func (m *SplitPartition) String() string            { return proto.CompactTextString(m) }
 func (*SplitPartition) ProtoMessage()               {}
-func (*SplitPartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*SplitPartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *SplitPartition) GetPiece() []*PieceHashAndSize {
 	if m != nil {
@@ -664,7 +736,7 @@ type PieceHashAndSize struct {
 func (m *PieceHashAndSize) Reset()                    { *m = PieceHashAndSize{} }
 func (m *PieceHashAndSize) String() string            { return proto.CompactTextString(m) }
 func (*PieceHashAndSize) ProtoMessage()               {}
-func (*PieceHashAndSize) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*PieceHashAndSize) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *PieceHashAndSize) GetHash() []byte {
 	if m != nil {
@@ -689,7 +761,7 @@ type UploadFilePrepareResp struct {
 func (m *UploadFilePrepareResp) Reset()                    { *m = UploadFilePrepareResp{} }
 func (m *UploadFilePrepareResp) String() string            { return proto.CompactTextString(m) }
 func (*UploadFilePrepareResp) ProtoMessage()               {}
-func (*UploadFilePrepareResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*UploadFilePrepareResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *UploadFilePrepareResp) GetPartition() []*ErasureCodePartition {
 	if m != nil {
@@ -724,7 +796,7 @@ type ReplicaProvider struct {
 func (m *ReplicaProvider) Reset()                    { *m = ReplicaProvider{} }
 func (m *ReplicaProvider) String() string            { return proto.CompactTextString(m) }
 func (*ReplicaProvider) ProtoMessage()               {}
-func (*ReplicaProvider) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*ReplicaProvider) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *ReplicaProvider) GetNodeId() []byte {
 	if m != nil {
@@ -776,7 +848,7 @@ type ErasureCodePartition struct {
 func (m *ErasureCodePartition) Reset()                    { *m = ErasureCodePartition{} }
 func (m *ErasureCodePartition) String() string            { return proto.CompactTextString(m) }
 func (*ErasureCodePartition) ProtoMessage()               {}
-func (*ErasureCodePartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*ErasureCodePartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *ErasureCodePartition) GetProviderAuth() []*BlockProviderAuth {
 	if m != nil {
@@ -803,7 +875,7 @@ type BlockProviderAuth struct {
 func (m *BlockProviderAuth) Reset()                    { *m = BlockProviderAuth{} }
 func (m *BlockProviderAuth) String() string            { return proto.CompactTextString(m) }
 func (*BlockProviderAuth) ProtoMessage()               {}
-func (*BlockProviderAuth) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*BlockProviderAuth) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *BlockProviderAuth) GetNodeId() []byte {
 	if m != nil {
@@ -850,7 +922,7 @@ type PieceHashAuth struct {
 func (m *PieceHashAuth) Reset()                    { *m = PieceHashAuth{} }
 func (m *PieceHashAuth) String() string            { return proto.CompactTextString(m) }
 func (*PieceHashAuth) ProtoMessage()               {}
-func (*PieceHashAuth) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*PieceHashAuth) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *PieceHashAuth) GetHash() []byte {
 	if m != nil {
@@ -896,12 +968,14 @@ type UploadFileDoneReq struct {
 	Interactive   bool              `protobuf:"varint,13,opt,name=interactive" json:"interactive,omitempty"`
 	NewVersion    bool              `protobuf:"varint,14,opt,name=newVersion" json:"newVersion,omitempty"`
 	Sign          []byte            `protobuf:"bytes,15,opt,name=sign,proto3" json:"sign,omitempty"`
+	Encoding      string            `protobuf:"bytes,16,opt,name=encoding" json:"encoding,omitempty"`
+	DecodedSize   uint64            `protobuf:"varint,17,opt,name=decodedSize" json:"decodedSize,omitempty"`
 }
 
 func (m *UploadFileDoneReq) Reset()                    { *m = UploadFileDoneReq{} }
 func (m *UploadFileDoneReq) String() string            { return proto.CompactTextString(m) }
 func (*UploadFileDoneReq) ProtoMessage()               {}
-func (*UploadFileDoneReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*UploadFileDoneReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *UploadFileDoneReq) GetVersion() uint32 {
 	if m != nil {
@@ -1008,6 +1082,20 @@ func (m *UploadFileDoneReq) GetSign() []byte {
 	return nil
 }
 
+func (m *UploadFileDoneReq) GetEncoding() string {
+	if m != nil {
+		return m.Encoding
+	}
+	return ""
+}
+
+func (m *UploadFileDoneReq) GetDecodedSize() uint64 {
+	if m != nil {
+		return m.DecodedSize
+	}
+	return 0
+}
+
 type StorePartition struct {
 	Block []*StoreBlock `protobuf:"bytes,1,rep,name=block" json:"block,omitempty"`
 }
@@ -1015,7 +1103,7 @@ type StorePartition struct {
 func (m *StorePartition) Reset()                    { *m = StorePartition{} }
 func (m *StorePartition) String() string            { return proto.CompactTextString(m) }
 func (*StorePartition) ProtoMessage()               {}
-func (*StorePartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*StorePartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *StorePartition) GetBlock() []*StoreBlock {
 	if m != nil {
@@ -1041,7 +1129,7 @@ type StoreBlock struct {
 func (m *StoreBlock) Reset()                    { *m = StoreBlock{} }
 func (m *StoreBlock) String() string            { return proto.CompactTextString(m) }
 func (*StoreBlock) ProtoMessage()               {}
-func (*StoreBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*StoreBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *StoreBlock) GetHash() []byte {
 	if m != nil {
@@ -1128,7 +1216,7 @@ type UploadFileDoneResp struct {
 func (m *UploadFileDoneResp) Reset()                    { *m = UploadFileDoneResp{} }
 func (m *UploadFileDoneResp) String() string            { return proto.CompactTextString(m) }
 func (*UploadFileDoneResp) ProtoMessage()               {}
-func (*UploadFileDoneResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*UploadFileDoneResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *UploadFileDoneResp) GetCode() uint32 {
 	if m != nil {
@@ -1159,7 +1247,7 @@ type ListFilesReq struct {
 func (m *ListFilesReq) Reset()                    { *m = ListFilesReq{} }
 func (m *ListFilesReq) String() string            { return proto.CompactTextString(m) }
 func (*ListFilesReq) ProtoMessage()               {}
-func (*ListFilesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*ListFilesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *ListFilesReq) GetVersion() uint32 {
 	if m != nil {
@@ -1234,7 +1322,7 @@ type ListFilesResp struct {
 func (m *ListFilesResp) Reset()                    { *m = ListFilesResp{} }
 func (m *ListFilesResp) String() string            { return proto.CompactTextString(m) }
 func (*ListFilesResp) ProtoMessage()               {}
-func (*ListFilesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*ListFilesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *ListFilesResp) GetCode() uint32 {
 	if m != nil {
@@ -1265,19 +1353,23 @@ func (m *ListFilesResp) GetFof() []*FileOrFolder {
 }
 
 type FileOrFolder struct {
-	Id       []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Folder   bool   `protobuf:"varint,2,opt,name=folder" json:"folder,omitempty"`
-	Name     string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
-	ModTime  uint64 `protobuf:"varint,4,opt,name=modTime" json:"modTime,omitempty"`
-	FileHash []byte `protobuf:"bytes,5,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
-	FileSize uint64 `protobuf:"varint,6,opt,name=fileSize" json:"fileSize,omitempty"`
-	FileType string `protobuf:"bytes,7,opt,name=fileType" json:"fileType,omitempty"`
+	Id          []byte      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Folder      bool        `protobuf:"varint,2,opt,name=folder" json:"folder,omitempty"`
+	Name        string      `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	ModTime     uint64      `protobuf:"varint,4,opt,name=modTime" json:"modTime,omitempty"`
+	FileHash    []byte      `protobuf:"bytes,5,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
+	FileSize    uint64      `protobuf:"varint,6,opt,name=fileSize" json:"fileSize,omitempty"`
+	FileType    string      `protobuf:"bytes,7,opt,name=fileType" json:"fileType,omitempty"`
+	Durability  *Durability `protobuf:"bytes,8,opt,name=durability" json:"durability,omitempty"`
+	Pack        *PackRange  `protobuf:"bytes,9,opt,name=pack" json:"pack,omitempty"`
+	Encoding    string      `protobuf:"bytes,10,opt,name=encoding" json:"encoding,omitempty"`
+	DecodedSize uint64      `protobuf:"varint,11,opt,name=decodedSize" json:"decodedSize,omitempty"`
 }
 
 func (m *FileOrFolder) Reset()                    { *m = FileOrFolder{} }
 func (m *FileOrFolder) String() string            { return proto.CompactTextString(m) }
 func (*FileOrFolder) ProtoMessage()               {}
-func (*FileOrFolder) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*FileOrFolder) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *FileOrFolder) GetId() []byte {
 	if m != nil {
@@ -1328,6 +1420,250 @@ func (m *FileOrFolder) GetFileType() string {
 	return ""
 }
 
+func (m *FileOrFolder) GetDurability() *Durability {
+	if m != nil {
+		return m.Durability
+	}
+	return nil
+}
+
+func (m *FileOrFolder) GetPack() *PackRange {
+	if m != nil {
+		return m.Pack
+	}
+	return nil
+}
+
+func (m *FileOrFolder) GetEncoding() string {
+	if m != nil {
+		return m.Encoding
+	}
+	return ""
+}
+
+func (m *FileOrFolder) GetDecodedSize() uint64 {
+	if m != nil {
+		return m.DecodedSize
+	}
+	return 0
+}
+
+type SearchFilesReq struct {
+	Version       uint32    `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId        []byte    `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp     uint64    `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Parent        *FilePath `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
+	Recursive     bool      `protobuf:"varint,5,opt,name=recursive" json:"recursive,omitempty"`
+	NamePattern   string    `protobuf:"bytes,6,opt,name=namePattern" json:"namePattern,omitempty"`
+	Regex         bool      `protobuf:"varint,7,opt,name=regex" json:"regex,omitempty"`
+	FileType      string    `protobuf:"bytes,8,opt,name=fileType" json:"fileType,omitempty"`
+	MinSize       uint64    `protobuf:"varint,9,opt,name=minSize" json:"minSize,omitempty"`
+	MaxSize       uint64    `protobuf:"varint,10,opt,name=maxSize" json:"maxSize,omitempty"`
+	MinModTime    uint64    `protobuf:"varint,11,opt,name=minModTime" json:"minModTime,omitempty"`
+	MaxModTime    uint64    `protobuf:"varint,12,opt,name=maxModTime" json:"maxModTime,omitempty"`
+	IncludeFolder bool      `protobuf:"varint,13,opt,name=includeFolder" json:"includeFolder,omitempty"`
+	PageSize      uint32    `protobuf:"varint,14,opt,name=pageSize" json:"pageSize,omitempty"`
+	PageNum       uint32    `protobuf:"varint,15,opt,name=pageNum" json:"pageNum,omitempty"`
+	SortType      SortType  `protobuf:"varint,16,opt,name=sortType,enum=metadata.pb.SortType" json:"sortType,omitempty"`
+	AscOrder      bool      `protobuf:"varint,17,opt,name=ascOrder" json:"ascOrder,omitempty"`
+	Sign          []byte    `protobuf:"bytes,18,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *SearchFilesReq) Reset()                    { *m = SearchFilesReq{} }
+func (m *SearchFilesReq) String() string            { return proto.CompactTextString(m) }
+func (*SearchFilesReq) ProtoMessage()               {}
+func (*SearchFilesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *SearchFilesReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *SearchFilesReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetParent() *FilePath {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *SearchFilesReq) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+func (m *SearchFilesReq) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
+	}
+	return ""
+}
+
+func (m *SearchFilesReq) GetRegex() bool {
+	if m != nil {
+		return m.Regex
+	}
+	return false
+}
+
+func (m *SearchFilesReq) GetFileType() string {
+	if m != nil {
+		return m.FileType
+	}
+	return ""
+}
+
+func (m *SearchFilesReq) GetMinSize() uint64 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetMaxSize() uint64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetMinModTime() uint64 {
+	if m != nil {
+		return m.MinModTime
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetMaxModTime() uint64 {
+	if m != nil {
+		return m.MaxModTime
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetIncludeFolder() bool {
+	if m != nil {
+		return m.IncludeFolder
+	}
+	return false
+}
+
+func (m *SearchFilesReq) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetPageNum() uint32 {
+	if m != nil {
+		return m.PageNum
+	}
+	return 0
+}
+
+func (m *SearchFilesReq) GetSortType() SortType {
+	if m != nil {
+		return m.SortType
+	}
+	return SortType_Name
+}
+
+func (m *SearchFilesReq) GetAscOrder() bool {
+	if m != nil {
+		return m.AscOrder
+	}
+	return false
+}
+
+func (m *SearchFilesReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type SearchFilesResp struct {
+	Code        uint32          `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg      string          `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	TotalRecord uint32          `protobuf:"varint,3,opt,name=totalRecord" json:"totalRecord,omitempty"`
+	File        []*SearchedFile `protobuf:"bytes,4,rep,name=file" json:"file,omitempty"`
+}
+
+func (m *SearchFilesResp) Reset()                    { *m = SearchFilesResp{} }
+func (m *SearchFilesResp) String() string            { return proto.CompactTextString(m) }
+func (*SearchFilesResp) ProtoMessage()               {}
+func (*SearchFilesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *SearchFilesResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *SearchFilesResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *SearchFilesResp) GetTotalRecord() uint32 {
+	if m != nil {
+		return m.TotalRecord
+	}
+	return 0
+}
+
+func (m *SearchFilesResp) GetFile() []*SearchedFile {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+type SearchedFile struct {
+	Parent string        `protobuf:"bytes,1,opt,name=parent" json:"parent,omitempty"`
+	Fof    *FileOrFolder `protobuf:"bytes,2,opt,name=fof" json:"fof,omitempty"`
+}
+
+func (m *SearchedFile) Reset()                    { *m = SearchedFile{} }
+func (m *SearchedFile) String() string            { return proto.CompactTextString(m) }
+func (*SearchedFile) ProtoMessage()               {}
+func (*SearchedFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *SearchedFile) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *SearchedFile) GetFof() *FileOrFolder {
+	if m != nil {
+		return m.Fof
+	}
+	return nil
+}
+
 type RetrieveFileReq struct {
 	Version   uint32 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
 	NodeId    []byte `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
@@ -1341,7 +1677,7 @@ type RetrieveFileReq struct {
 func (m *RetrieveFileReq) Reset()                    { *m = RetrieveFileReq{} }
 func (m *RetrieveFileReq) String() string            { return proto.CompactTextString(m) }
 func (*RetrieveFileReq) ProtoMessage()               {}
-func (*RetrieveFileReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*RetrieveFileReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *RetrieveFileReq) GetVersion() uint32 {
 	if m != nil {
@@ -1393,19 +1729,22 @@ func (m *RetrieveFileReq) GetSign() []byte {
 }
 
 type RetrieveFileResp struct {
-	Code       uint32               `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
-	ErrMsg     string               `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
-	FileData   []byte               `protobuf:"bytes,3,opt,name=fileData,proto3" json:"fileData,omitempty"`
-	FileType   string               `protobuf:"bytes,4,opt,name=fileType" json:"fileType,omitempty"`
-	EncryptKey []byte               `protobuf:"bytes,5,opt,name=encryptKey,proto3" json:"encryptKey,omitempty"`
-	Partition  []*RetrievePartition `protobuf:"bytes,6,rep,name=partition" json:"partition,omitempty"`
-	Timestamp  uint64               `protobuf:"varint,7,opt,name=timestamp" json:"timestamp,omitempty"`
+	Code        uint32               `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg      string               `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	FileData    []byte               `protobuf:"bytes,3,opt,name=fileData,proto3" json:"fileData,omitempty"`
+	FileType    string               `protobuf:"bytes,4,opt,name=fileType" json:"fileType,omitempty"`
+	EncryptKey  []byte               `protobuf:"bytes,5,opt,name=encryptKey,proto3" json:"encryptKey,omitempty"`
+	Partition   []*RetrievePartition `protobuf:"bytes,6,rep,name=partition" json:"partition,omitempty"`
+	Timestamp   uint64               `protobuf:"varint,7,opt,name=timestamp" json:"timestamp,omitempty"`
+	Pack        *PackRange           `protobuf:"bytes,8,opt,name=pack" json:"pack,omitempty"`
+	Encoding    string               `protobuf:"bytes,9,opt,name=encoding" json:"encoding,omitempty"`
+	DecodedSize uint64               `protobuf:"varint,10,opt,name=decodedSize" json:"decodedSize,omitempty"`
 }
 
 func (m *RetrieveFileResp) Reset()                    { *m = RetrieveFileResp{} }
 func (m *RetrieveFileResp) String() string            { return proto.CompactTextString(m) }
 func (*RetrieveFileResp) ProtoMessage()               {}
-func (*RetrieveFileResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*RetrieveFileResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *RetrieveFileResp) GetCode() uint32 {
 	if m != nil {
@@ -1456,6 +1795,27 @@ func (m *RetrieveFileResp) GetTimestamp() uint64 {
 	return 0
 }
 
+func (m *RetrieveFileResp) GetPack() *PackRange {
+	if m != nil {
+		return m.Pack
+	}
+	return nil
+}
+
+func (m *RetrieveFileResp) GetEncoding() string {
+	if m != nil {
+		return m.Encoding
+	}
+	return ""
+}
+
+func (m *RetrieveFileResp) GetDecodedSize() uint64 {
+	if m != nil {
+		return m.DecodedSize
+	}
+	return 0
+}
+
 type RetrievePartition struct {
 	Block []*RetrieveBlock `protobuf:"bytes,1,rep,name=block" json:"block,omitempty"`
 }
@@ -1463,7 +1823,7 @@ type RetrievePartition struct {
 func (m *RetrievePartition) Reset()                    { *m = RetrievePartition{} }
 func (m *RetrievePartition) String() string            { return proto.CompactTextString(m) }
 func (*RetrievePartition) ProtoMessage()               {}
-func (*RetrievePartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*RetrievePartition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *RetrievePartition) GetBlock() []*RetrieveBlock {
 	if m != nil {
@@ -1483,7 +1843,7 @@ type RetrieveBlock struct {
 func (m *RetrieveBlock) Reset()                    { *m = RetrieveBlock{} }
 func (m *RetrieveBlock) String() string            { return proto.CompactTextString(m) }
 func (*RetrieveBlock) ProtoMessage()               {}
-func (*RetrieveBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*RetrieveBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *RetrieveBlock) GetHash() []byte {
 	if m != nil {
@@ -1531,7 +1891,7 @@ type RetrieveNode struct {
 func (m *RetrieveNode) Reset()                    { *m = RetrieveNode{} }
 func (m *RetrieveNode) String() string            { return proto.CompactTextString(m) }
 func (*RetrieveNode) ProtoMessage()               {}
-func (*RetrieveNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*RetrieveNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *RetrieveNode) GetNodeId() []byte {
 	if m != nil {
@@ -1580,7 +1940,7 @@ type RemoveReq struct {
 func (m *RemoveReq) Reset()                    { *m = RemoveReq{} }
 func (m *RemoveReq) String() string            { return proto.CompactTextString(m) }
 func (*RemoveReq) ProtoMessage()               {}
-func (*RemoveReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*RemoveReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *RemoveReq) GetVersion() uint32 {
 	if m != nil {
@@ -1632,7 +1992,7 @@ type RemoveResp struct {
 func (m *RemoveResp) Reset()                    { *m = RemoveResp{} }
 func (m *RemoveResp) String() string            { return proto.CompactTextString(m) }
 func (*RemoveResp) ProtoMessage()               {}
-func (*RemoveResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*RemoveResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *RemoveResp) GetCode() uint32 {
 	if m != nil {
@@ -1660,7 +2020,7 @@ type MoveReq struct {
 func (m *MoveReq) Reset()                    { *m = MoveReq{} }
 func (m *MoveReq) String() string            { return proto.CompactTextString(m) }
 func (*MoveReq) ProtoMessage()               {}
-func (*MoveReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*MoveReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *MoveReq) GetVersion() uint32 {
 	if m != nil {
@@ -1712,7 +2072,7 @@ type MoveResp struct {
 func (m *MoveResp) Reset()                    { *m = MoveResp{} }
 func (m *MoveResp) String() string            { return proto.CompactTextString(m) }
 func (*MoveResp) ProtoMessage()               {}
-func (*MoveResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*MoveResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 func (m *MoveResp) GetCode() uint32 {
 	if m != nil {
@@ -1739,7 +2099,7 @@ type SpaceSysFileReq struct {
 func (m *SpaceSysFileReq) Reset()                    { *m = SpaceSysFileReq{} }
 func (m *SpaceSysFileReq) String() string            { return proto.CompactTextString(m) }
 func (*SpaceSysFileReq) ProtoMessage()               {}
-func (*SpaceSysFileReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*SpaceSysFileReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *SpaceSysFileReq) GetVersion() uint32 {
 	if m != nil {
@@ -1783,7 +2143,7 @@ type SpaceSysFileResp struct {
 func (m *SpaceSysFileResp) Reset()                    { *m = SpaceSysFileResp{} }
 func (m *SpaceSysFileResp) String() string            { return proto.CompactTextString(m) }
 func (*SpaceSysFileResp) ProtoMessage()               {}
-func (*SpaceSysFileResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*SpaceSysFileResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *SpaceSysFileResp) GetData() []byte {
 	if m != nil {
@@ -1792,42 +2152,1490 @@ func (m *SpaceSysFileResp) GetData() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*PingReq)(nil), "metadata.pb.PingReq")
-	proto.RegisterType((*PingResp)(nil), "metadata.pb.PingResp")
-	proto.RegisterType((*GetPublicKeyReq)(nil), "metadata.pb.GetPublicKeyReq")
-	proto.RegisterType((*GetPublicKeyResp)(nil), "metadata.pb.GetPublicKeyResp")
-	proto.RegisterType((*MkFolderReq)(nil), "metadata.pb.MkFolderReq")
-	proto.RegisterType((*FilePath)(nil), "metadata.pb.FilePath")
-	proto.RegisterType((*MkFolderResp)(nil), "metadata.pb.MkFolderResp")
-	proto.RegisterType((*CheckFileExistReq)(nil), "metadata.pb.CheckFileExistReq")
-	proto.RegisterType((*CheckFileExistResp)(nil), "metadata.pb.CheckFileExistResp")
-	proto.RegisterType((*UploadFilePrepareReq)(nil), "metadata.pb.UploadFilePrepareReq")
-	proto.RegisterType((*SplitPartition)(nil), "metadata.pb.SplitPartition")
-	proto.RegisterType((*PieceHashAndSize)(nil), "metadata.pb.PieceHashAndSize")
-	proto.RegisterType((*UploadFilePrepareResp)(nil), "metadata.pb.UploadFilePrepareResp")
-	proto.RegisterType((*ReplicaProvider)(nil), "metadata.pb.ReplicaProvider")
-	proto.RegisterType((*ErasureCodePartition)(nil), "metadata.pb.ErasureCodePartition")
-	proto.RegisterType((*BlockProviderAuth)(nil), "metadata.pb.BlockProviderAuth")
-	proto.RegisterType((*PieceHashAuth)(nil), "metadata.pb.PieceHashAuth")
-	proto.RegisterType((*UploadFileDoneReq)(nil), "metadata.pb.UploadFileDoneReq")
-	proto.RegisterType((*StorePartition)(nil), "metadata.pb.StorePartition")
-	proto.RegisterType((*StoreBlock)(nil), "metadata.pb.StoreBlock")
-	proto.RegisterType((*UploadFileDoneResp)(nil), "metadata.pb.UploadFileDoneResp")
-	proto.RegisterType((*ListFilesReq)(nil), "metadata.pb.ListFilesReq")
-	proto.RegisterType((*ListFilesResp)(nil), "metadata.pb.ListFilesResp")
-	proto.RegisterType((*FileOrFolder)(nil), "metadata.pb.FileOrFolder")
-	proto.RegisterType((*RetrieveFileReq)(nil), "metadata.pb.RetrieveFileReq")
-	proto.RegisterType((*RetrieveFileResp)(nil), "metadata.pb.RetrieveFileResp")
-	proto.RegisterType((*RetrievePartition)(nil), "metadata.pb.RetrievePartition")
-	proto.RegisterType((*RetrieveBlock)(nil), "metadata.pb.RetrieveBlock")
-	proto.RegisterType((*RetrieveNode)(nil), "metadata.pb.RetrieveNode")
-	proto.RegisterType((*RemoveReq)(nil), "metadata.pb.RemoveReq")
-	proto.RegisterType((*RemoveResp)(nil), "metadata.pb.RemoveResp")
-	proto.RegisterType((*MoveReq)(nil), "metadata.pb.MoveReq")
+type Durability struct {
+	StoreType        FileStoreType `protobuf:"varint,1,opt,name=storeType,enum=metadata.pb.FileStoreType" json:"storeType,omitempty"`
+	DataPieceCount   uint32        `protobuf:"varint,2,opt,name=dataPieceCount" json:"dataPieceCount,omitempty"`
+	VerifyPieceCount uint32        `protobuf:"varint,3,opt,name=verifyPieceCount" json:"verifyPieceCount,omitempty"`
+	ReplicaCount     uint32        `protobuf:"varint,4,opt,name=replicaCount" json:"replicaCount,omitempty"`
+}
+
+func (m *Durability) Reset()                    { *m = Durability{} }
+func (m *Durability) String() string            { return proto.CompactTextString(m) }
+func (*Durability) ProtoMessage()               {}
+func (*Durability) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *Durability) GetStoreType() FileStoreType {
+	if m != nil {
+		return m.StoreType
+	}
+	return FileStoreType_ErasureCode
+}
+
+func (m *Durability) GetDataPieceCount() uint32 {
+	if m != nil {
+		return m.DataPieceCount
+	}
+	return 0
+}
+
+func (m *Durability) GetVerifyPieceCount() uint32 {
+	if m != nil {
+		return m.VerifyPieceCount
+	}
+	return 0
+}
+
+func (m *Durability) GetReplicaCount() uint32 {
+	if m != nil {
+		return m.ReplicaCount
+	}
+	return 0
+}
+
+type ReportBlocksReq struct {
+	Version   uint32              `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId    []byte              `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp uint64              `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	SpaceNo   uint32              `protobuf:"varint,4,opt,name=spaceNo" json:"spaceNo,omitempty"`
+	FileHash  []byte              `protobuf:"bytes,5,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
+	FileSize  uint64              `protobuf:"varint,6,opt,name=fileSize" json:"fileSize,omitempty"`
+	Block     []*BadBlock         `protobuf:"bytes,7,rep,name=block" json:"block,omitempty"`
+	Repaired  []*PieceHashAndSize `protobuf:"bytes,8,rep,name=repaired" json:"repaired,omitempty"`
+	Sign      []byte              `protobuf:"bytes,9,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *ReportBlocksReq) Reset()                    { *m = ReportBlocksReq{} }
+func (m *ReportBlocksReq) String() string            { return proto.CompactTextString(m) }
+func (*ReportBlocksReq) ProtoMessage()               {}
+func (*ReportBlocksReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *ReportBlocksReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ReportBlocksReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *ReportBlocksReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ReportBlocksReq) GetSpaceNo() uint32 {
+	if m != nil {
+		return m.SpaceNo
+	}
+	return 0
+}
+
+func (m *ReportBlocksReq) GetFileHash() []byte {
+	if m != nil {
+		return m.FileHash
+	}
+	return nil
+}
+
+func (m *ReportBlocksReq) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *ReportBlocksReq) GetBlock() []*BadBlock {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *ReportBlocksReq) GetRepaired() []*PieceHashAndSize {
+	if m != nil {
+		return m.Repaired
+	}
+	return nil
+}
+
+func (m *ReportBlocksReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type BadBlock struct {
+	Hash        []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockSeq    uint32 `protobuf:"varint,2,opt,name=blockSeq" json:"blockSeq,omitempty"`
+	Checksum    bool   `protobuf:"varint,3,opt,name=checksum" json:"checksum,omitempty"`
+	Corrupt     bool   `protobuf:"varint,4,opt,name=corrupt" json:"corrupt,omitempty"`
+	StoreNodeId []byte `protobuf:"bytes,5,opt,name=storeNodeId,proto3" json:"storeNodeId,omitempty"`
+}
+
+func (m *BadBlock) Reset()                    { *m = BadBlock{} }
+func (m *BadBlock) String() string            { return proto.CompactTextString(m) }
+func (*BadBlock) ProtoMessage()               {}
+func (*BadBlock) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *BadBlock) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *BadBlock) GetBlockSeq() uint32 {
+	if m != nil {
+		return m.BlockSeq
+	}
+	return 0
+}
+
+func (m *BadBlock) GetChecksum() bool {
+	if m != nil {
+		return m.Checksum
+	}
+	return false
+}
+
+func (m *BadBlock) GetCorrupt() bool {
+	if m != nil {
+		return m.Corrupt
+	}
+	return false
+}
+
+func (m *BadBlock) GetStoreNodeId() []byte {
+	if m != nil {
+		return m.StoreNodeId
+	}
+	return nil
+}
+
+type ReportBlocksResp struct {
+	Code      uint32                `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg    string                `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	Partition *ErasureCodePartition `protobuf:"bytes,3,opt,name=partition" json:"partition,omitempty"`
+	ChunkSize uint32                `protobuf:"varint,4,opt,name=chunkSize" json:"chunkSize,omitempty"`
+}
+
+func (m *ReportBlocksResp) Reset()                    { *m = ReportBlocksResp{} }
+func (m *ReportBlocksResp) String() string            { return proto.CompactTextString(m) }
+func (*ReportBlocksResp) ProtoMessage()               {}
+func (*ReportBlocksResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ReportBlocksResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ReportBlocksResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *ReportBlocksResp) GetPartition() *ErasureCodePartition {
+	if m != nil {
+		return m.Partition
+	}
+	return nil
+}
+
+func (m *ReportBlocksResp) GetChunkSize() uint32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+type RepairBlocksDoneReq struct {
+	Version   uint32        `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId    []byte        `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp uint64        `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	SpaceNo   uint32        `protobuf:"varint,4,opt,name=spaceNo" json:"spaceNo,omitempty"`
+	FileHash  []byte        `protobuf:"bytes,5,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
+	FileSize  uint64        `protobuf:"varint,6,opt,name=fileSize" json:"fileSize,omitempty"`
+	BadHash   [][]byte      `protobuf:"bytes,7,rep,name=badHash,proto3" json:"badHash,omitempty"`
+	Block     []*StoreBlock `protobuf:"bytes,8,rep,name=block" json:"block,omitempty"`
+	Sign      []byte        `protobuf:"bytes,9,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *RepairBlocksDoneReq) Reset()                    { *m = RepairBlocksDoneReq{} }
+func (m *RepairBlocksDoneReq) String() string            { return proto.CompactTextString(m) }
+func (*RepairBlocksDoneReq) ProtoMessage()               {}
+func (*RepairBlocksDoneReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *RepairBlocksDoneReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *RepairBlocksDoneReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *RepairBlocksDoneReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RepairBlocksDoneReq) GetSpaceNo() uint32 {
+	if m != nil {
+		return m.SpaceNo
+	}
+	return 0
+}
+
+func (m *RepairBlocksDoneReq) GetFileHash() []byte {
+	if m != nil {
+		return m.FileHash
+	}
+	return nil
+}
+
+func (m *RepairBlocksDoneReq) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *RepairBlocksDoneReq) GetBadHash() [][]byte {
+	if m != nil {
+		return m.BadHash
+	}
+	return nil
+}
+
+func (m *RepairBlocksDoneReq) GetBlock() []*StoreBlock {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *RepairBlocksDoneReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type RepairBlocksDoneResp struct {
+	Code   uint32 `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+}
+
+func (m *RepairBlocksDoneResp) Reset()                    { *m = RepairBlocksDoneResp{} }
+func (m *RepairBlocksDoneResp) String() string            { return proto.CompactTextString(m) }
+func (*RepairBlocksDoneResp) ProtoMessage()               {}
+func (*RepairBlocksDoneResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *RepairBlocksDoneResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *RepairBlocksDoneResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+type MkFoldersReq struct {
+	Version     uint32    `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId      []byte    `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp   uint64    `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Parent      *FilePath `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
+	Path        []string  `protobuf:"bytes,5,rep,name=path" json:"path,omitempty"`
+	Interactive bool      `protobuf:"varint,6,opt,name=interactive" json:"interactive,omitempty"`
+	Sign        []byte    `protobuf:"bytes,7,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *MkFoldersReq) Reset()                    { *m = MkFoldersReq{} }
+func (m *MkFoldersReq) String() string            { return proto.CompactTextString(m) }
+func (*MkFoldersReq) ProtoMessage()               {}
+func (*MkFoldersReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *MkFoldersReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *MkFoldersReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *MkFoldersReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *MkFoldersReq) GetParent() *FilePath {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *MkFoldersReq) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *MkFoldersReq) GetInteractive() bool {
+	if m != nil {
+		return m.Interactive
+	}
+	return false
+}
+
+func (m *MkFoldersReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type MkFoldersResp struct {
+	Code   uint32 `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+}
+
+func (m *MkFoldersResp) Reset()                    { *m = MkFoldersResp{} }
+func (m *MkFoldersResp) String() string            { return proto.CompactTextString(m) }
+func (*MkFoldersResp) ProtoMessage()               {}
+func (*MkFoldersResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *MkFoldersResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *MkFoldersResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+type CheckFilesExistReq struct {
+	Version       uint32           `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId        []byte           `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp     uint64           `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	PublicKeyHash []byte           `protobuf:"bytes,4,opt,name=publicKeyHash,proto3" json:"publicKeyHash,omitempty"`
+	Interactive   bool             `protobuf:"varint,5,opt,name=interactive" json:"interactive,omitempty"`
+	NewVersion    bool             `protobuf:"varint,6,opt,name=newVersion" json:"newVersion,omitempty"`
+	File          []*CheckFileItem `protobuf:"bytes,7,rep,name=file" json:"file,omitempty"`
+	Sign          []byte           `protobuf:"bytes,8,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *CheckFilesExistReq) Reset()                    { *m = CheckFilesExistReq{} }
+func (m *CheckFilesExistReq) String() string            { return proto.CompactTextString(m) }
+func (*CheckFilesExistReq) ProtoMessage()               {}
+func (*CheckFilesExistReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *CheckFilesExistReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *CheckFilesExistReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *CheckFilesExistReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *CheckFilesExistReq) GetPublicKeyHash() []byte {
+	if m != nil {
+		return m.PublicKeyHash
+	}
+	return nil
+}
+
+func (m *CheckFilesExistReq) GetInteractive() bool {
+	if m != nil {
+		return m.Interactive
+	}
+	return false
+}
+
+func (m *CheckFilesExistReq) GetNewVersion() bool {
+	if m != nil {
+		return m.NewVersion
+	}
+	return false
+}
+
+func (m *CheckFilesExistReq) GetFile() []*CheckFileItem {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *CheckFilesExistReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type CheckFileItem struct {
+	Parent      *FilePath   `protobuf:"bytes,1,opt,name=parent" json:"parent,omitempty"`
+	FileHash    []byte      `protobuf:"bytes,2,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
+	FileSize    uint64      `protobuf:"varint,3,opt,name=fileSize" json:"fileSize,omitempty"`
+	FileType    string      `protobuf:"bytes,4,opt,name=fileType" json:"fileType,omitempty"`
+	EncryptKey  []byte      `protobuf:"bytes,5,opt,name=encryptKey,proto3" json:"encryptKey,omitempty"`
+	FileName    string      `protobuf:"bytes,6,opt,name=fileName" json:"fileName,omitempty"`
+	FileModTime uint64      `protobuf:"varint,7,opt,name=fileModTime" json:"fileModTime,omitempty"`
+	FileData    []byte      `protobuf:"bytes,8,opt,name=fileData,proto3" json:"fileData,omitempty"`
+	Durability  *Durability `protobuf:"bytes,9,opt,name=durability" json:"durability,omitempty"`
+}
+
+func (m *CheckFileItem) Reset()                    { *m = CheckFileItem{} }
+func (m *CheckFileItem) String() string            { return proto.CompactTextString(m) }
+func (*CheckFileItem) ProtoMessage()               {}
+func (*CheckFileItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *CheckFileItem) GetParent() *FilePath {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *CheckFileItem) GetFileHash() []byte {
+	if m != nil {
+		return m.FileHash
+	}
+	return nil
+}
+
+func (m *CheckFileItem) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *CheckFileItem) GetFileType() string {
+	if m != nil {
+		return m.FileType
+	}
+	return ""
+}
+
+func (m *CheckFileItem) GetEncryptKey() []byte {
+	if m != nil {
+		return m.EncryptKey
+	}
+	return nil
+}
+
+func (m *CheckFileItem) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *CheckFileItem) GetFileModTime() uint64 {
+	if m != nil {
+		return m.FileModTime
+	}
+	return 0
+}
+
+func (m *CheckFileItem) GetFileData() []byte {
+	if m != nil {
+		return m.FileData
+	}
+	return nil
+}
+
+func (m *CheckFileItem) GetDurability() *Durability {
+	if m != nil {
+		return m.Durability
+	}
+	return nil
+}
+
+type CheckFilesExistResp struct {
+	Code   uint32                `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string                `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	Result []*CheckFileExistResp `protobuf:"bytes,3,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *CheckFilesExistResp) Reset()                    { *m = CheckFilesExistResp{} }
+func (m *CheckFilesExistResp) String() string            { return proto.CompactTextString(m) }
+func (*CheckFilesExistResp) ProtoMessage()               {}
+func (*CheckFilesExistResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *CheckFilesExistResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *CheckFilesExistResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *CheckFilesExistResp) GetResult() []*CheckFileExistResp {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type UploadFilesDoneReq struct {
+	Version       uint32                `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId        []byte                `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp     uint64                `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	PublicKeyHash []byte                `protobuf:"bytes,4,opt,name=publicKeyHash,proto3" json:"publicKeyHash,omitempty"`
+	Interactive   bool                  `protobuf:"varint,5,opt,name=interactive" json:"interactive,omitempty"`
+	NewVersion    bool                  `protobuf:"varint,6,opt,name=newVersion" json:"newVersion,omitempty"`
+	File          []*UploadFileDoneItem `protobuf:"bytes,7,rep,name=file" json:"file,omitempty"`
+	Sign          []byte                `protobuf:"bytes,8,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *UploadFilesDoneReq) Reset()                    { *m = UploadFilesDoneReq{} }
+func (m *UploadFilesDoneReq) String() string            { return proto.CompactTextString(m) }
+func (*UploadFilesDoneReq) ProtoMessage()               {}
+func (*UploadFilesDoneReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *UploadFilesDoneReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *UploadFilesDoneReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *UploadFilesDoneReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *UploadFilesDoneReq) GetPublicKeyHash() []byte {
+	if m != nil {
+		return m.PublicKeyHash
+	}
+	return nil
+}
+
+func (m *UploadFilesDoneReq) GetInteractive() bool {
+	if m != nil {
+		return m.Interactive
+	}
+	return false
+}
+
+func (m *UploadFilesDoneReq) GetNewVersion() bool {
+	if m != nil {
+		return m.NewVersion
+	}
+	return false
+}
+
+func (m *UploadFilesDoneReq) GetFile() []*UploadFileDoneItem {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *UploadFilesDoneReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type UploadFileDoneItem struct {
+	Parent      *FilePath         `protobuf:"bytes,1,opt,name=parent" json:"parent,omitempty"`
+	FileHash    []byte            `protobuf:"bytes,2,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
+	FileSize    uint64            `protobuf:"varint,3,opt,name=fileSize" json:"fileSize,omitempty"`
+	FileType    string            `protobuf:"bytes,4,opt,name=fileType" json:"fileType,omitempty"`
+	EncryptKey  []byte            `protobuf:"bytes,5,opt,name=encryptKey,proto3" json:"encryptKey,omitempty"`
+	FileName    string            `protobuf:"bytes,6,opt,name=fileName" json:"fileName,omitempty"`
+	FileModTime uint64            `protobuf:"varint,7,opt,name=fileModTime" json:"fileModTime,omitempty"`
+	Partition   []*StorePartition `protobuf:"bytes,8,rep,name=partition" json:"partition,omitempty"`
+}
+
+func (m *UploadFileDoneItem) Reset()                    { *m = UploadFileDoneItem{} }
+func (m *UploadFileDoneItem) String() string            { return proto.CompactTextString(m) }
+func (*UploadFileDoneItem) ProtoMessage()               {}
+func (*UploadFileDoneItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *UploadFileDoneItem) GetParent() *FilePath {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *UploadFileDoneItem) GetFileHash() []byte {
+	if m != nil {
+		return m.FileHash
+	}
+	return nil
+}
+
+func (m *UploadFileDoneItem) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *UploadFileDoneItem) GetFileType() string {
+	if m != nil {
+		return m.FileType
+	}
+	return ""
+}
+
+func (m *UploadFileDoneItem) GetEncryptKey() []byte {
+	if m != nil {
+		return m.EncryptKey
+	}
+	return nil
+}
+
+func (m *UploadFileDoneItem) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *UploadFileDoneItem) GetFileModTime() uint64 {
+	if m != nil {
+		return m.FileModTime
+	}
+	return 0
+}
+
+func (m *UploadFileDoneItem) GetPartition() []*StorePartition {
+	if m != nil {
+		return m.Partition
+	}
+	return nil
+}
+
+type UploadFilesDoneResp struct {
+	Code   uint32                `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string                `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	Result []*UploadFileDoneResp `protobuf:"bytes,3,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *UploadFilesDoneResp) Reset()                    { *m = UploadFilesDoneResp{} }
+func (m *UploadFilesDoneResp) String() string            { return proto.CompactTextString(m) }
+func (*UploadFilesDoneResp) ProtoMessage()               {}
+func (*UploadFilesDoneResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *UploadFilesDoneResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *UploadFilesDoneResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *UploadFilesDoneResp) GetResult() []*UploadFileDoneResp {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type PackRange struct {
+	PackHash []byte `protobuf:"bytes,1,opt,name=packHash,proto3" json:"packHash,omitempty"`
+	PackSize uint64 `protobuf:"varint,2,opt,name=packSize" json:"packSize,omitempty"`
+	Offset   uint64 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *PackRange) Reset()                    { *m = PackRange{} }
+func (m *PackRange) String() string            { return proto.CompactTextString(m) }
+func (*PackRange) ProtoMessage()               {}
+func (*PackRange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *PackRange) GetPackHash() []byte {
+	if m != nil {
+		return m.PackHash
+	}
+	return nil
+}
+
+func (m *PackRange) GetPackSize() uint64 {
+	if m != nil {
+		return m.PackSize
+	}
+	return 0
+}
+
+func (m *PackRange) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type PackFilesDoneReq struct {
+	Version       uint32            `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId        []byte            `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp     uint64            `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	PublicKeyHash []byte            `protobuf:"bytes,4,opt,name=publicKeyHash,proto3" json:"publicKeyHash,omitempty"`
+	SpaceNo       uint32            `protobuf:"varint,5,opt,name=spaceNo" json:"spaceNo,omitempty"`
+	PackHash      []byte            `protobuf:"bytes,6,opt,name=packHash,proto3" json:"packHash,omitempty"`
+	PackSize      uint64            `protobuf:"varint,7,opt,name=packSize" json:"packSize,omitempty"`
+	EncryptKey    []byte            `protobuf:"bytes,8,opt,name=encryptKey,proto3" json:"encryptKey,omitempty"`
+	Partition     []*StorePartition `protobuf:"bytes,9,rep,name=partition" json:"partition,omitempty"`
+	Member        []*PackMember     `protobuf:"bytes,10,rep,name=member" json:"member,omitempty"`
+	Interactive   bool              `protobuf:"varint,11,opt,name=interactive" json:"interactive,omitempty"`
+	NewVersion    bool              `protobuf:"varint,12,opt,name=newVersion" json:"newVersion,omitempty"`
+	Sign          []byte            `protobuf:"bytes,13,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *PackFilesDoneReq) Reset()                    { *m = PackFilesDoneReq{} }
+func (m *PackFilesDoneReq) String() string            { return proto.CompactTextString(m) }
+func (*PackFilesDoneReq) ProtoMessage()               {}
+func (*PackFilesDoneReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *PackFilesDoneReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *PackFilesDoneReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *PackFilesDoneReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *PackFilesDoneReq) GetPublicKeyHash() []byte {
+	if m != nil {
+		return m.PublicKeyHash
+	}
+	return nil
+}
+
+func (m *PackFilesDoneReq) GetSpaceNo() uint32 {
+	if m != nil {
+		return m.SpaceNo
+	}
+	return 0
+}
+
+func (m *PackFilesDoneReq) GetPackHash() []byte {
+	if m != nil {
+		return m.PackHash
+	}
+	return nil
+}
+
+func (m *PackFilesDoneReq) GetPackSize() uint64 {
+	if m != nil {
+		return m.PackSize
+	}
+	return 0
+}
+
+func (m *PackFilesDoneReq) GetEncryptKey() []byte {
+	if m != nil {
+		return m.EncryptKey
+	}
+	return nil
+}
+
+func (m *PackFilesDoneReq) GetPartition() []*StorePartition {
+	if m != nil {
+		return m.Partition
+	}
+	return nil
+}
+
+func (m *PackFilesDoneReq) GetMember() []*PackMember {
+	if m != nil {
+		return m.Member
+	}
+	return nil
+}
+
+func (m *PackFilesDoneReq) GetInteractive() bool {
+	if m != nil {
+		return m.Interactive
+	}
+	return false
+}
+
+func (m *PackFilesDoneReq) GetNewVersion() bool {
+	if m != nil {
+		return m.NewVersion
+	}
+	return false
+}
+
+func (m *PackFilesDoneReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type PackMember struct {
+	Parent      *FilePath `protobuf:"bytes,1,opt,name=parent" json:"parent,omitempty"`
+	FileName    string    `protobuf:"bytes,2,opt,name=fileName" json:"fileName,omitempty"`
+	FileHash    []byte    `protobuf:"bytes,3,opt,name=fileHash,proto3" json:"fileHash,omitempty"`
+	FileSize    uint64    `protobuf:"varint,4,opt,name=fileSize" json:"fileSize,omitempty"`
+	FileType    string    `protobuf:"bytes,5,opt,name=fileType" json:"fileType,omitempty"`
+	FileModTime uint64    `protobuf:"varint,6,opt,name=fileModTime" json:"fileModTime,omitempty"`
+	Offset      uint64    `protobuf:"varint,7,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *PackMember) Reset()                    { *m = PackMember{} }
+func (m *PackMember) String() string            { return proto.CompactTextString(m) }
+func (*PackMember) ProtoMessage()               {}
+func (*PackMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *PackMember) GetParent() *FilePath {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *PackMember) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *PackMember) GetFileHash() []byte {
+	if m != nil {
+		return m.FileHash
+	}
+	return nil
+}
+
+func (m *PackMember) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *PackMember) GetFileType() string {
+	if m != nil {
+		return m.FileType
+	}
+	return ""
+}
+
+func (m *PackMember) GetFileModTime() uint64 {
+	if m != nil {
+		return m.FileModTime
+	}
+	return 0
+}
+
+func (m *PackMember) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type PackFilesDoneResp struct {
+	Code   uint32                `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string                `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	Result []*UploadFileDoneResp `protobuf:"bytes,3,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *PackFilesDoneResp) Reset()                    { *m = PackFilesDoneResp{} }
+func (m *PackFilesDoneResp) String() string            { return proto.CompactTextString(m) }
+func (*PackFilesDoneResp) ProtoMessage()               {}
+func (*PackFilesDoneResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *PackFilesDoneResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *PackFilesDoneResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *PackFilesDoneResp) GetResult() []*UploadFileDoneResp {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type Delegation struct {
+	NodeId       []byte `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	DeviceId     []byte `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	DevicePubKey []byte `protobuf:"bytes,3,opt,name=devicePubKey,proto3" json:"devicePubKey,omitempty"`
+	Name         string `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	Created      uint64 `protobuf:"varint,5,opt,name=created" json:"created,omitempty"`
+	Sign         []byte `protobuf:"bytes,6,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *Delegation) Reset()                    { *m = Delegation{} }
+func (m *Delegation) String() string            { return proto.CompactTextString(m) }
+func (*Delegation) ProtoMessage()               {}
+func (*Delegation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *Delegation) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *Delegation) GetDeviceId() []byte {
+	if m != nil {
+		return m.DeviceId
+	}
+	return nil
+}
+
+func (m *Delegation) GetDevicePubKey() []byte {
+	if m != nil {
+		return m.DevicePubKey
+	}
+	return nil
+}
+
+func (m *Delegation) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Delegation) GetCreated() uint64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Delegation) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type WrappedSpaceKey struct {
+	SpaceNo uint32 `protobuf:"varint,1,opt,name=spaceNo" json:"spaceNo,omitempty"`
+	Key     []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *WrappedSpaceKey) Reset()                    { *m = WrappedSpaceKey{} }
+func (m *WrappedSpaceKey) String() string            { return proto.CompactTextString(m) }
+func (*WrappedSpaceKey) ProtoMessage()               {}
+func (*WrappedSpaceKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *WrappedSpaceKey) GetSpaceNo() uint32 {
+	if m != nil {
+		return m.SpaceNo
+	}
+	return 0
+}
+
+func (m *WrappedSpaceKey) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type EnrollDeviceReq struct {
+	Version    uint32             `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId     []byte             `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp  uint64             `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Delegation *Delegation        `protobuf:"bytes,4,opt,name=delegation" json:"delegation,omitempty"`
+	SpaceKey   []*WrappedSpaceKey `protobuf:"bytes,5,rep,name=spaceKey" json:"spaceKey,omitempty"`
+	Sign       []byte             `protobuf:"bytes,6,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *EnrollDeviceReq) Reset()                    { *m = EnrollDeviceReq{} }
+func (m *EnrollDeviceReq) String() string            { return proto.CompactTextString(m) }
+func (*EnrollDeviceReq) ProtoMessage()               {}
+func (*EnrollDeviceReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *EnrollDeviceReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *EnrollDeviceReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *EnrollDeviceReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *EnrollDeviceReq) GetDelegation() *Delegation {
+	if m != nil {
+		return m.Delegation
+	}
+	return nil
+}
+
+func (m *EnrollDeviceReq) GetSpaceKey() []*WrappedSpaceKey {
+	if m != nil {
+		return m.SpaceKey
+	}
+	return nil
+}
+
+func (m *EnrollDeviceReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type EnrollDeviceResp struct {
+	Code   uint32 `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+}
+
+func (m *EnrollDeviceResp) Reset()                    { *m = EnrollDeviceResp{} }
+func (m *EnrollDeviceResp) String() string            { return proto.CompactTextString(m) }
+func (*EnrollDeviceResp) ProtoMessage()               {}
+func (*EnrollDeviceResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *EnrollDeviceResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *EnrollDeviceResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+type ListDevicesReq struct {
+	Version   uint32 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId    []byte `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp uint64 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Sign      []byte `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *ListDevicesReq) Reset()                    { *m = ListDevicesReq{} }
+func (m *ListDevicesReq) String() string            { return proto.CompactTextString(m) }
+func (*ListDevicesReq) ProtoMessage()               {}
+func (*ListDevicesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *ListDevicesReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ListDevicesReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *ListDevicesReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ListDevicesReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type Device struct {
+	Delegation  *Delegation `protobuf:"bytes,1,opt,name=delegation" json:"delegation,omitempty"`
+	Revoked     bool        `protobuf:"varint,2,opt,name=revoked" json:"revoked,omitempty"`
+	RevokedTime uint64      `protobuf:"varint,3,opt,name=revokedTime" json:"revokedTime,omitempty"`
+	LastSeen    uint64      `protobuf:"varint,4,opt,name=lastSeen" json:"lastSeen,omitempty"`
+}
+
+func (m *Device) Reset()                    { *m = Device{} }
+func (m *Device) String() string            { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()               {}
+func (*Device) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *Device) GetDelegation() *Delegation {
+	if m != nil {
+		return m.Delegation
+	}
+	return nil
+}
+
+func (m *Device) GetRevoked() bool {
+	if m != nil {
+		return m.Revoked
+	}
+	return false
+}
+
+func (m *Device) GetRevokedTime() uint64 {
+	if m != nil {
+		return m.RevokedTime
+	}
+	return 0
+}
+
+func (m *Device) GetLastSeen() uint64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+type ListDevicesResp struct {
+	Code   uint32    `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string    `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	Device []*Device `protobuf:"bytes,3,rep,name=device" json:"device,omitempty"`
+}
+
+func (m *ListDevicesResp) Reset()                    { *m = ListDevicesResp{} }
+func (m *ListDevicesResp) String() string            { return proto.CompactTextString(m) }
+func (*ListDevicesResp) ProtoMessage()               {}
+func (*ListDevicesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *ListDevicesResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ListDevicesResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *ListDevicesResp) GetDevice() []*Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+type RevokeDeviceReq struct {
+	Version   uint32 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId    []byte `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp uint64 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	DeviceId  []byte `protobuf:"bytes,4,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Sign      []byte `protobuf:"bytes,5,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *RevokeDeviceReq) Reset()                    { *m = RevokeDeviceReq{} }
+func (m *RevokeDeviceReq) String() string            { return proto.CompactTextString(m) }
+func (*RevokeDeviceReq) ProtoMessage()               {}
+func (*RevokeDeviceReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *RevokeDeviceReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *RevokeDeviceReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *RevokeDeviceReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RevokeDeviceReq) GetDeviceId() []byte {
+	if m != nil {
+		return m.DeviceId
+	}
+	return nil
+}
+
+func (m *RevokeDeviceReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type RevokeDeviceResp struct {
+	Code   uint32 `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg string `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+}
+
+func (m *RevokeDeviceResp) Reset()                    { *m = RevokeDeviceResp{} }
+func (m *RevokeDeviceResp) String() string            { return proto.CompactTextString(m) }
+func (*RevokeDeviceResp) ProtoMessage()               {}
+func (*RevokeDeviceResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *RevokeDeviceResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *RevokeDeviceResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+type DeviceSpaceKeysReq struct {
+	Version   uint32 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId    []byte `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp uint64 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Sign      []byte `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *DeviceSpaceKeysReq) Reset()                    { *m = DeviceSpaceKeysReq{} }
+func (m *DeviceSpaceKeysReq) String() string            { return proto.CompactTextString(m) }
+func (*DeviceSpaceKeysReq) ProtoMessage()               {}
+func (*DeviceSpaceKeysReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *DeviceSpaceKeysReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DeviceSpaceKeysReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *DeviceSpaceKeysReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *DeviceSpaceKeysReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type DeviceSpaceKeysResp struct {
+	Code     uint32             `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	ErrMsg   string             `protobuf:"bytes,2,opt,name=errMsg" json:"errMsg,omitempty"`
+	SpaceKey []*WrappedSpaceKey `protobuf:"bytes,3,rep,name=spaceKey" json:"spaceKey,omitempty"`
+}
+
+func (m *DeviceSpaceKeysResp) Reset()                    { *m = DeviceSpaceKeysResp{} }
+func (m *DeviceSpaceKeysResp) String() string            { return proto.CompactTextString(m) }
+func (*DeviceSpaceKeysResp) ProtoMessage()               {}
+func (*DeviceSpaceKeysResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *DeviceSpaceKeysResp) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *DeviceSpaceKeysResp) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
+func (m *DeviceSpaceKeysResp) GetSpaceKey() []*WrappedSpaceKey {
+	if m != nil {
+		return m.SpaceKey
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PingReq)(nil), "metadata.pb.PingReq")
+	proto.RegisterType((*PingResp)(nil), "metadata.pb.PingResp")
+	proto.RegisterType((*GetPublicKeyReq)(nil), "metadata.pb.GetPublicKeyReq")
+	proto.RegisterType((*GetPublicKeyResp)(nil), "metadata.pb.GetPublicKeyResp")
+	proto.RegisterType((*MkFolderReq)(nil), "metadata.pb.MkFolderReq")
+	proto.RegisterType((*FilePath)(nil), "metadata.pb.FilePath")
+	proto.RegisterType((*MkFolderResp)(nil), "metadata.pb.MkFolderResp")
+	proto.RegisterType((*CheckFileExistReq)(nil), "metadata.pb.CheckFileExistReq")
+	proto.RegisterType((*CheckFileExistResp)(nil), "metadata.pb.CheckFileExistResp")
+	proto.RegisterType((*UploadFilePrepareReq)(nil), "metadata.pb.UploadFilePrepareReq")
+	proto.RegisterType((*SplitPartition)(nil), "metadata.pb.SplitPartition")
+	proto.RegisterType((*PieceHashAndSize)(nil), "metadata.pb.PieceHashAndSize")
+	proto.RegisterType((*UploadFilePrepareResp)(nil), "metadata.pb.UploadFilePrepareResp")
+	proto.RegisterType((*ReplicaProvider)(nil), "metadata.pb.ReplicaProvider")
+	proto.RegisterType((*ErasureCodePartition)(nil), "metadata.pb.ErasureCodePartition")
+	proto.RegisterType((*BlockProviderAuth)(nil), "metadata.pb.BlockProviderAuth")
+	proto.RegisterType((*PieceHashAuth)(nil), "metadata.pb.PieceHashAuth")
+	proto.RegisterType((*UploadFileDoneReq)(nil), "metadata.pb.UploadFileDoneReq")
+	proto.RegisterType((*StorePartition)(nil), "metadata.pb.StorePartition")
+	proto.RegisterType((*StoreBlock)(nil), "metadata.pb.StoreBlock")
+	proto.RegisterType((*UploadFileDoneResp)(nil), "metadata.pb.UploadFileDoneResp")
+	proto.RegisterType((*ListFilesReq)(nil), "metadata.pb.ListFilesReq")
+	proto.RegisterType((*ListFilesResp)(nil), "metadata.pb.ListFilesResp")
+	proto.RegisterType((*FileOrFolder)(nil), "metadata.pb.FileOrFolder")
+	proto.RegisterType((*SearchFilesReq)(nil), "metadata.pb.SearchFilesReq")
+	proto.RegisterType((*SearchFilesResp)(nil), "metadata.pb.SearchFilesResp")
+	proto.RegisterType((*SearchedFile)(nil), "metadata.pb.SearchedFile")
+	proto.RegisterType((*RetrieveFileReq)(nil), "metadata.pb.RetrieveFileReq")
+	proto.RegisterType((*RetrieveFileResp)(nil), "metadata.pb.RetrieveFileResp")
+	proto.RegisterType((*RetrievePartition)(nil), "metadata.pb.RetrievePartition")
+	proto.RegisterType((*RetrieveBlock)(nil), "metadata.pb.RetrieveBlock")
+	proto.RegisterType((*RetrieveNode)(nil), "metadata.pb.RetrieveNode")
+	proto.RegisterType((*RemoveReq)(nil), "metadata.pb.RemoveReq")
+	proto.RegisterType((*RemoveResp)(nil), "metadata.pb.RemoveResp")
+	proto.RegisterType((*MoveReq)(nil), "metadata.pb.MoveReq")
 	proto.RegisterType((*MoveResp)(nil), "metadata.pb.MoveResp")
 	proto.RegisterType((*SpaceSysFileReq)(nil), "metadata.pb.SpaceSysFileReq")
 	proto.RegisterType((*SpaceSysFileResp)(nil), "metadata.pb.SpaceSysFileResp")
+	proto.RegisterType((*Durability)(nil), "metadata.pb.Durability")
+	proto.RegisterType((*ReportBlocksReq)(nil), "metadata.pb.ReportBlocksReq")
+	proto.RegisterType((*BadBlock)(nil), "metadata.pb.BadBlock")
+	proto.RegisterType((*ReportBlocksResp)(nil), "metadata.pb.ReportBlocksResp")
+	proto.RegisterType((*RepairBlocksDoneReq)(nil), "metadata.pb.RepairBlocksDoneReq")
+	proto.RegisterType((*RepairBlocksDoneResp)(nil), "metadata.pb.RepairBlocksDoneResp")
+	proto.RegisterType((*MkFoldersReq)(nil), "metadata.pb.MkFoldersReq")
+	proto.RegisterType((*MkFoldersResp)(nil), "metadata.pb.MkFoldersResp")
+	proto.RegisterType((*CheckFilesExistReq)(nil), "metadata.pb.CheckFilesExistReq")
+	proto.RegisterType((*CheckFileItem)(nil), "metadata.pb.CheckFileItem")
+	proto.RegisterType((*CheckFilesExistResp)(nil), "metadata.pb.CheckFilesExistResp")
+	proto.RegisterType((*UploadFilesDoneReq)(nil), "metadata.pb.UploadFilesDoneReq")
+	proto.RegisterType((*UploadFileDoneItem)(nil), "metadata.pb.UploadFileDoneItem")
+	proto.RegisterType((*UploadFilesDoneResp)(nil), "metadata.pb.UploadFilesDoneResp")
+	proto.RegisterType((*PackRange)(nil), "metadata.pb.PackRange")
+	proto.RegisterType((*PackFilesDoneReq)(nil), "metadata.pb.PackFilesDoneReq")
+	proto.RegisterType((*PackMember)(nil), "metadata.pb.PackMember")
+	proto.RegisterType((*PackFilesDoneResp)(nil), "metadata.pb.PackFilesDoneResp")
+	proto.RegisterType((*Delegation)(nil), "metadata.pb.Delegation")
+	proto.RegisterType((*WrappedSpaceKey)(nil), "metadata.pb.WrappedSpaceKey")
+	proto.RegisterType((*EnrollDeviceReq)(nil), "metadata.pb.EnrollDeviceReq")
+	proto.RegisterType((*EnrollDeviceResp)(nil), "metadata.pb.EnrollDeviceResp")
+	proto.RegisterType((*ListDevicesReq)(nil), "metadata.pb.ListDevicesReq")
+	proto.RegisterType((*Device)(nil), "metadata.pb.Device")
+	proto.RegisterType((*ListDevicesResp)(nil), "metadata.pb.ListDevicesResp")
+	proto.RegisterType((*RevokeDeviceReq)(nil), "metadata.pb.RevokeDeviceReq")
+	proto.RegisterType((*RevokeDeviceResp)(nil), "metadata.pb.RevokeDeviceResp")
+	proto.RegisterType((*DeviceSpaceKeysReq)(nil), "metadata.pb.DeviceSpaceKeysReq")
+	proto.RegisterType((*DeviceSpaceKeysResp)(nil), "metadata.pb.DeviceSpaceKeysResp")
 	proto.RegisterEnum("metadata.pb.FileStoreType", FileStoreType_name, FileStoreType_value)
 	proto.RegisterEnum("metadata.pb.SortType", SortType_name, SortType_value)
 }
@@ -1850,10 +3658,21 @@ type MatadataServiceClient interface {
 	UploadFilePrepare(ctx context.Context, in *UploadFilePrepareReq, opts ...grpc.CallOption) (*UploadFilePrepareResp, error)
 	UploadFileDone(ctx context.Context, in *UploadFileDoneReq, opts ...grpc.CallOption) (*UploadFileDoneResp, error)
 	ListFiles(ctx context.Context, in *ListFilesReq, opts ...grpc.CallOption) (*ListFilesResp, error)
+	SearchFiles(ctx context.Context, in *SearchFilesReq, opts ...grpc.CallOption) (*SearchFilesResp, error)
 	RetrieveFile(ctx context.Context, in *RetrieveFileReq, opts ...grpc.CallOption) (*RetrieveFileResp, error)
 	Remove(ctx context.Context, in *RemoveReq, opts ...grpc.CallOption) (*RemoveResp, error)
 	Move(ctx context.Context, in *MoveReq, opts ...grpc.CallOption) (*MoveResp, error)
 	SpaceSysFile(ctx context.Context, in *SpaceSysFileReq, opts ...grpc.CallOption) (*SpaceSysFileResp, error)
+	ReportBlocks(ctx context.Context, in *ReportBlocksReq, opts ...grpc.CallOption) (*ReportBlocksResp, error)
+	RepairBlocksDone(ctx context.Context, in *RepairBlocksDoneReq, opts ...grpc.CallOption) (*RepairBlocksDoneResp, error)
+	MkFolders(ctx context.Context, in *MkFoldersReq, opts ...grpc.CallOption) (*MkFoldersResp, error)
+	CheckFilesExist(ctx context.Context, in *CheckFilesExistReq, opts ...grpc.CallOption) (*CheckFilesExistResp, error)
+	UploadFilesDone(ctx context.Context, in *UploadFilesDoneReq, opts ...grpc.CallOption) (*UploadFilesDoneResp, error)
+	PackFilesDone(ctx context.Context, in *PackFilesDoneReq, opts ...grpc.CallOption) (*PackFilesDoneResp, error)
+	EnrollDevice(ctx context.Context, in *EnrollDeviceReq, opts ...grpc.CallOption) (*EnrollDeviceResp, error)
+	ListDevices(ctx context.Context, in *ListDevicesReq, opts ...grpc.CallOption) (*ListDevicesResp, error)
+	RevokeDevice(ctx context.Context, in *RevokeDeviceReq, opts ...grpc.CallOption) (*RevokeDeviceResp, error)
+	DeviceSpaceKeys(ctx context.Context, in *DeviceSpaceKeysReq, opts ...grpc.CallOption) (*DeviceSpaceKeysResp, error)
 }
 
 type matadataServiceClient struct {
@@ -1927,6 +3746,15 @@ func (c *matadataServiceClient) ListFiles(ctx context.Context, in *ListFilesReq,
 	return out, nil
 }
 
+func (c *matadataServiceClient) SearchFiles(ctx context.Context, in *SearchFilesReq, opts ...grpc.CallOption) (*SearchFilesResp, error) {
+	out := new(SearchFilesResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/SearchFiles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *matadataServiceClient) RetrieveFile(ctx context.Context, in *RetrieveFileReq, opts ...grpc.CallOption) (*RetrieveFileResp, error) {
 	out := new(RetrieveFileResp)
 	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/RetrieveFile", in, out, c.cc, opts...)
@@ -1963,6 +3791,96 @@ func (c *matadataServiceClient) SpaceSysFile(ctx context.Context, in *SpaceSysFi
 	return out, nil
 }
 
+func (c *matadataServiceClient) ReportBlocks(ctx context.Context, in *ReportBlocksReq, opts ...grpc.CallOption) (*ReportBlocksResp, error) {
+	out := new(ReportBlocksResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/ReportBlocks", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) RepairBlocksDone(ctx context.Context, in *RepairBlocksDoneReq, opts ...grpc.CallOption) (*RepairBlocksDoneResp, error) {
+	out := new(RepairBlocksDoneResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/RepairBlocksDone", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) MkFolders(ctx context.Context, in *MkFoldersReq, opts ...grpc.CallOption) (*MkFoldersResp, error) {
+	out := new(MkFoldersResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/MkFolders", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) CheckFilesExist(ctx context.Context, in *CheckFilesExistReq, opts ...grpc.CallOption) (*CheckFilesExistResp, error) {
+	out := new(CheckFilesExistResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/CheckFilesExist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) UploadFilesDone(ctx context.Context, in *UploadFilesDoneReq, opts ...grpc.CallOption) (*UploadFilesDoneResp, error) {
+	out := new(UploadFilesDoneResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/UploadFilesDone", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) PackFilesDone(ctx context.Context, in *PackFilesDoneReq, opts ...grpc.CallOption) (*PackFilesDoneResp, error) {
+	out := new(PackFilesDoneResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/PackFilesDone", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) EnrollDevice(ctx context.Context, in *EnrollDeviceReq, opts ...grpc.CallOption) (*EnrollDeviceResp, error) {
+	out := new(EnrollDeviceResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/EnrollDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) ListDevices(ctx context.Context, in *ListDevicesReq, opts ...grpc.CallOption) (*ListDevicesResp, error) {
+	out := new(ListDevicesResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/ListDevices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) RevokeDevice(ctx context.Context, in *RevokeDeviceReq, opts ...grpc.CallOption) (*RevokeDeviceResp, error) {
+	out := new(RevokeDeviceResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/RevokeDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matadataServiceClient) DeviceSpaceKeys(ctx context.Context, in *DeviceSpaceKeysReq, opts ...grpc.CallOption) (*DeviceSpaceKeysResp, error) {
+	out := new(DeviceSpaceKeysResp)
+	err := grpc.Invoke(ctx, "/metadata.pb.MatadataService/DeviceSpaceKeys", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for MatadataService service
 
 type MatadataServiceServer interface {
@@ -1973,10 +3891,21 @@ type MatadataServiceServer interface {
 	UploadFilePrepare(context.Context, *UploadFilePrepareReq) (*UploadFilePrepareResp, error)
 	UploadFileDone(context.Context, *UploadFileDoneReq) (*UploadFileDoneResp, error)
 	ListFiles(context.Context, *ListFilesReq) (*ListFilesResp, error)
+	SearchFiles(context.Context, *SearchFilesReq) (*SearchFilesResp, error)
 	RetrieveFile(context.Context, *RetrieveFileReq) (*RetrieveFileResp, error)
 	Remove(context.Context, *RemoveReq) (*RemoveResp, error)
 	Move(context.Context, *MoveReq) (*MoveResp, error)
 	SpaceSysFile(context.Context, *SpaceSysFileReq) (*SpaceSysFileResp, error)
+	ReportBlocks(context.Context, *ReportBlocksReq) (*ReportBlocksResp, error)
+	RepairBlocksDone(context.Context, *RepairBlocksDoneReq) (*RepairBlocksDoneResp, error)
+	MkFolders(context.Context, *MkFoldersReq) (*MkFoldersResp, error)
+	CheckFilesExist(context.Context, *CheckFilesExistReq) (*CheckFilesExistResp, error)
+	UploadFilesDone(context.Context, *UploadFilesDoneReq) (*UploadFilesDoneResp, error)
+	PackFilesDone(context.Context, *PackFilesDoneReq) (*PackFilesDoneResp, error)
+	EnrollDevice(context.Context, *EnrollDeviceReq) (*EnrollDeviceResp, error)
+	ListDevices(context.Context, *ListDevicesReq) (*ListDevicesResp, error)
+	RevokeDevice(context.Context, *RevokeDeviceReq) (*RevokeDeviceResp, error)
+	DeviceSpaceKeys(context.Context, *DeviceSpaceKeysReq) (*DeviceSpaceKeysResp, error)
 }
 
 func RegisterMatadataServiceServer(s *grpc.Server, srv MatadataServiceServer) {
@@ -2109,6 +4038,24 @@ func _MatadataService_ListFiles_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatadataService_SearchFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFilesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).SearchFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/SearchFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).SearchFiles(ctx, req.(*SearchFilesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MatadataService_RetrieveFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RetrieveFileReq)
 	if err := dec(in); err != nil {
@@ -2181,6 +4128,186 @@ func _MatadataService_SpaceSysFile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatadataService_ReportBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportBlocksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).ReportBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/ReportBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).ReportBlocks(ctx, req.(*ReportBlocksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_RepairBlocksDone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairBlocksDoneReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).RepairBlocksDone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/RepairBlocksDone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).RepairBlocksDone(ctx, req.(*RepairBlocksDoneReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_MkFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MkFoldersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).MkFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/MkFolders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).MkFolders(ctx, req.(*MkFoldersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_CheckFilesExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckFilesExistReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).CheckFilesExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/CheckFilesExist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).CheckFilesExist(ctx, req.(*CheckFilesExistReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_UploadFilesDone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadFilesDoneReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).UploadFilesDone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/UploadFilesDone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).UploadFilesDone(ctx, req.(*UploadFilesDoneReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_PackFilesDone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackFilesDoneReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).PackFilesDone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/PackFilesDone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).PackFilesDone(ctx, req.(*PackFilesDoneReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_EnrollDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollDeviceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).EnrollDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/EnrollDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).EnrollDevice(ctx, req.(*EnrollDeviceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).ListDevices(ctx, req.(*ListDevicesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_RevokeDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDeviceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).RevokeDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/RevokeDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).RevokeDevice(ctx, req.(*RevokeDeviceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatadataService_DeviceSpaceKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceSpaceKeysReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatadataServiceServer).DeviceSpaceKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metadata.pb.MatadataService/DeviceSpaceKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatadataServiceServer).DeviceSpaceKeys(ctx, req.(*DeviceSpaceKeysReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _MatadataService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "metadata.pb.MatadataService",
 	HandlerType: (*MatadataServiceServer)(nil),
@@ -2213,6 +4340,10 @@ var _MatadataService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListFiles",
 			Handler:    _MatadataService_ListFiles_Handler,
 		},
+		{
+			MethodName: "SearchFiles",
+			Handler:    _MatadataService_SearchFiles_Handler,
+		},
 		{
 			MethodName: "RetrieveFile",
 			Handler:    _MatadataService_RetrieveFile_Handler,
@@ -2229,6 +4360,46 @@ var _MatadataService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SpaceSysFile",
 			Handler:    _MatadataService_SpaceSysFile_Handler,
 		},
+		{
+			MethodName: "ReportBlocks",
+			Handler:    _MatadataService_ReportBlocks_Handler,
+		},
+		{
+			MethodName: "RepairBlocksDone",
+			Handler:    _MatadataService_RepairBlocksDone_Handler,
+		},
+		{
+			MethodName: "MkFolders",
+			Handler:    _MatadataService_MkFolders_Handler,
+		},
+		{
+			MethodName: "CheckFilesExist",
+			Handler:    _MatadataService_CheckFilesExist_Handler,
+		},
+		{
+			MethodName: "UploadFilesDone",
+			Handler:    _MatadataService_UploadFilesDone_Handler,
+		},
+		{
+			MethodName: "PackFilesDone",
+			Handler:    _MatadataService_PackFilesDone_Handler,
+		},
+		{
+			MethodName: "EnrollDevice",
+			Handler:    _MatadataService_EnrollDevice_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _MatadataService_ListDevices_Handler,
+		},
+		{
+			MethodName: "RevokeDevice",
+			Handler:    _MatadataService_RevokeDevice_Handler,
+		},
+		{
+			MethodName: "DeviceSpaceKeys",
+			Handler:    _MatadataService_DeviceSpaceKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "metadata.proto",
@@ -2237,113 +4408,190 @@ var _MatadataService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("metadata.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1713 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x4f, 0x6f, 0xdb, 0xc6,
-	0x12, 0x17, 0x25, 0x4a, 0x96, 0x46, 0x7f, 0x2c, 0x2f, 0x1c, 0x47, 0xd1, 0x4b, 0xfc, 0xf4, 0xf8,
-	0x1e, 0x02, 0x23, 0x41, 0x82, 0x57, 0x07, 0x49, 0xd3, 0xb4, 0x40, 0x9a, 0x3f, 0x4e, 0x53, 0xb4,
-	0x72, 0x84, 0x55, 0x1a, 0xa0, 0x40, 0x2f, 0x34, 0xb5, 0xb6, 0x08, 0x4b, 0x24, 0xb3, 0xa4, 0xd4,
-	0x38, 0x87, 0x7e, 0x80, 0x5e, 0xda, 0x6f, 0xd0, 0x53, 0x8b, 0x1e, 0x7b, 0x2a, 0x8a, 0xa2, 0xf7,
-	0x7e, 0x87, 0xa2, 0x5f, 0xa1, 0x97, 0x5e, 0x7b, 0x29, 0x66, 0x49, 0x8a, 0xbb, 0x24, 0x63, 0xd7,
-	0x41, 0x54, 0xe4, 0xd0, 0xdb, 0xce, 0xec, 0x70, 0x77, 0x66, 0xf6, 0x37, 0x7f, 0x76, 0x09, 0xad,
-	0x29, 0x0b, 0xcc, 0x91, 0x19, 0x98, 0x57, 0x3d, 0xee, 0x06, 0x2e, 0xa9, 0x27, 0xf4, 0x9e, 0xf1,
-	0x5f, 0x58, 0x19, 0xd8, 0xce, 0x01, 0x65, 0x4f, 0x49, 0x07, 0x56, 0xe6, 0x8c, 0xfb, 0xb6, 0xeb,
-	0x74, 0xb4, 0x9e, 0xb6, 0xd5, 0xa4, 0x31, 0x69, 0x00, 0x54, 0x43, 0x21, 0xdf, 0x33, 0x2e, 0xc3,
-	0xea, 0x7b, 0x2c, 0x18, 0xcc, 0xf6, 0x26, 0xb6, 0xf5, 0x01, 0x3b, 0x3a, 0xfe, 0xc3, 0x27, 0xd0,
-	0x56, 0x85, 0x7d, 0x8f, 0x9c, 0x87, 0x9a, 0x17, 0x33, 0x84, 0x7c, 0x83, 0x26, 0x0c, 0xf2, 0x3f,
-	0x68, 0x2e, 0x88, 0x87, 0xa6, 0x3f, 0xee, 0x14, 0x85, 0x84, 0xca, 0x34, 0x7e, 0xd1, 0xa0, 0xde,
-	0x3f, 0x7c, 0xe0, 0x4e, 0x46, 0x8c, 0x1f, 0xab, 0x01, 0xd9, 0x80, 0x8a, 0xe3, 0x8e, 0xd8, 0xfb,
-	0xa3, 0x68, 0xa1, 0x88, 0x42, 0x2d, 0x02, 0x7b, 0xca, 0xfc, 0xc0, 0x9c, 0x7a, 0x9d, 0x52, 0x4f,
-	0xdb, 0xd2, 0x69, 0xc2, 0x20, 0x57, 0xa0, 0xe2, 0x99, 0x9c, 0x39, 0x41, 0x47, 0xef, 0x69, 0x5b,
-	0xf5, 0xed, 0x33, 0x57, 0x25, 0x9f, 0x5d, 0x7d, 0x60, 0x4f, 0xd8, 0xc0, 0x0c, 0xc6, 0x34, 0x12,
-	0xc2, 0x4d, 0xf6, 0x85, 0x2e, 0x9d, 0x72, 0xaf, 0xb4, 0x55, 0xa3, 0x11, 0x45, 0x7a, 0x50, 0xb7,
-	0x9d, 0x80, 0x71, 0xd3, 0x0a, 0xec, 0x39, 0xeb, 0x54, 0x7a, 0xda, 0x56, 0x95, 0xca, 0x2c, 0x42,
-	0x40, 0xf7, 0xed, 0x03, 0xa7, 0xb3, 0x22, 0x94, 0x13, 0x63, 0xe3, 0x63, 0xa8, 0xc6, 0x3b, 0x90,
-	0x75, 0xd0, 0x3d, 0x33, 0x18, 0x0b, 0xab, 0x6a, 0x0f, 0x0b, 0x54, 0x50, 0xa4, 0x0d, 0x45, 0x3b,
-	0x32, 0xe8, 0x61, 0x81, 0x16, 0xed, 0x11, 0x3a, 0xc0, 0xf7, 0x4c, 0x8b, 0xed, 0xba, 0xc2, 0x98,
-	0x26, 0x8d, 0xc9, 0xbb, 0x75, 0xa8, 0xb9, 0x0e, 0x7b, 0xb4, 0x8f, 0xcb, 0x19, 0xb7, 0xa0, 0x91,
-	0xb8, 0xcd, 0xf7, 0x70, 0x7b, 0xcb, 0x1d, 0xb1, 0xc8, 0x69, 0x62, 0x8c, 0xc6, 0x30, 0xce, 0xfb,
-	0xfe, 0x81, 0xd8, 0xa0, 0x46, 0x23, 0xca, 0xf8, 0xb5, 0x04, 0x6b, 0xf7, 0xc6, 0xcc, 0x3a, 0x44,
-	0xe5, 0x76, 0x9e, 0xd9, 0x7e, 0xf0, 0x1a, 0x78, 0xbe, 0x0b, 0xd5, 0x7d, 0x7b, 0xc2, 0x04, 0x52,
-	0xca, 0x62, 0x9b, 0x05, 0x1d, 0xcf, 0x0d, 0xed, 0xe7, 0xa1, 0xeb, 0x75, 0xba, 0xa0, 0xe3, 0xb9,
-	0xc7, 0x47, 0x1e, 0x13, 0xbe, 0xaf, 0xd1, 0x05, 0x4d, 0x36, 0x01, 0x98, 0x63, 0xf1, 0x23, 0x2f,
-	0x40, 0x84, 0x56, 0xc5, 0xaa, 0x12, 0x27, 0x0b, 0xd1, 0x5a, 0x0e, 0x44, 0xe3, 0x1d, 0x76, 0xcd,
-	0x29, 0xeb, 0x40, 0xb2, 0x03, 0xd2, 0x88, 0x0b, 0x1c, 0xf7, 0xdd, 0xd1, 0x63, 0x7b, 0xca, 0x3a,
-	0x75, 0xa1, 0x9c, 0xcc, 0x8a, 0xbf, 0xbe, 0x6f, 0x06, 0x66, 0xa7, 0x91, 0xd8, 0x85, 0x74, 0x1a,
-	0x55, 0xcd, 0x2c, 0xaa, 0x36, 0x01, 0x1c, 0xf6, 0xe9, 0x93, 0xe8, 0x5c, 0x5a, 0x42, 0x40, 0xe2,
-	0x2c, 0x50, 0xb7, 0x2a, 0xa1, 0xee, 0xcb, 0x22, 0x90, 0xf4, 0xf1, 0x9e, 0x0e, 0x21, 0xe4, 0x26,
-	0xd4, 0xfc, 0xc0, 0xe5, 0xa1, 0x57, 0xf1, 0x64, 0x5b, 0xdb, 0xdd, 0xcc, 0xf1, 0x0d, 0x63, 0x09,
-	0x9a, 0x08, 0x93, 0x8b, 0xd0, 0x42, 0x99, 0x81, 0xcd, 0x2c, 0x76, 0xcf, 0x9d, 0x45, 0xa7, 0xdf,
-	0xa4, 0x29, 0x2e, 0xb9, 0x04, 0xed, 0x39, 0xe3, 0xf6, 0xfe, 0x91, 0x24, 0x59, 0x16, 0x92, 0x19,
-	0x3e, 0x31, 0xa0, 0xc1, 0x99, 0x37, 0xb1, 0x2d, 0x33, 0x94, 0xab, 0x08, 0x39, 0x85, 0x87, 0x58,
-	0xb4, 0xc6, 0x33, 0xe7, 0x50, 0x60, 0x64, 0x45, 0x08, 0x24, 0x0c, 0xe3, 0x37, 0x0d, 0xd6, 0x3f,
-	0xf2, 0x26, 0xae, 0x39, 0x12, 0xb8, 0xe3, 0x0c, 0x41, 0xb7, 0x0c, 0xd0, 0xcb, 0x28, 0xd6, 0x8f,
-	0x41, 0x71, 0x39, 0x85, 0xe2, 0xb7, 0xa0, 0xe6, 0x99, 0x3c, 0xb0, 0x03, 0xd4, 0xa4, 0xd2, 0x2b,
-	0x6d, 0xd5, 0xb7, 0xff, 0xa5, 0x38, 0x7c, 0xe8, 0x4d, 0xec, 0x60, 0x10, 0x8b, 0xd0, 0x44, 0x3a,
-	0x37, 0xf1, 0xec, 0x40, 0x4b, 0xfd, 0x80, 0x5c, 0x83, 0xb2, 0x87, 0x1e, 0xed, 0x68, 0x62, 0xf1,
-	0x0b, 0xca, 0xe2, 0xc2, 0xd7, 0xa8, 0xe3, 0x1d, 0x67, 0x84, 0xea, 0xd0, 0x50, 0xd6, 0xb8, 0x05,
-	0xed, 0xf4, 0x14, 0x6e, 0x37, 0x46, 0xeb, 0xc2, 0x7c, 0x2f, 0xc6, 0xa1, 0x0a, 0xcf, 0x99, 0xf0,
-	0x54, 0x93, 0x8a, 0xb1, 0xf1, 0xbd, 0x06, 0x67, 0x72, 0x5c, 0xee, 0x7b, 0xe4, 0xb6, 0x6c, 0x6b,
-	0xa8, 0xce, 0x7f, 0x14, 0x75, 0x76, 0xb8, 0xe9, 0xcf, 0x38, 0xbb, 0xe7, 0x8e, 0x58, 0xae, 0xc5,
-	0x37, 0xa1, 0xea, 0x71, 0x77, 0x6e, 0x63, 0x9a, 0x2e, 0x8a, 0xef, 0xcf, 0x2b, 0xdf, 0xd3, 0x10,
-	0x18, 0x83, 0x48, 0x86, 0x2e, 0xa4, 0x33, 0x48, 0x2a, 0x65, 0x91, 0x64, 0x7c, 0xa5, 0xc1, 0x6a,
-	0x6a, 0x05, 0x09, 0x0c, 0x9a, 0x02, 0x86, 0x0d, 0xa8, 0xf8, 0x8c, 0xcf, 0x85, 0x1e, 0x22, 0x7e,
-	0x42, 0x0a, 0x1d, 0xe2, 0xb9, 0x3c, 0x5e, 0x5f, 0x8c, 0x55, 0xe0, 0xe8, 0x69, 0xe0, 0x6c, 0x40,
-	0x25, 0xb0, 0xad, 0x43, 0x16, 0x46, 0x41, 0x8d, 0x46, 0x14, 0xae, 0x64, 0xce, 0x82, 0xb1, 0xc0,
-	0x7c, 0x83, 0x8a, 0xb1, 0xf1, 0x0c, 0xd6, 0xf3, 0x5c, 0x44, 0xee, 0x42, 0x23, 0xb6, 0xf4, 0xce,
-	0x4c, 0x94, 0x1a, 0xf4, 0xcd, 0xa6, 0xe2, 0x9b, 0xbb, 0x13, 0xd7, 0x3a, 0x1c, 0x48, 0x52, 0x54,
-	0xf9, 0x46, 0xd5, 0xb2, 0x98, 0xd2, 0xd2, 0xf8, 0x5a, 0x83, 0xb5, 0xcc, 0x0a, 0xaf, 0xc4, 0x3b,
-	0xeb, 0x50, 0xf6, 0x11, 0x21, 0xc2, 0x33, 0x55, 0x1a, 0x12, 0xe4, 0x06, 0x54, 0x11, 0x60, 0xc2,
-	0x9a, 0xb2, 0xb0, 0xa6, 0xfb, 0x02, 0xe0, 0xa2, 0x25, 0x0b, 0x59, 0xc3, 0x82, 0xa6, 0x32, 0xf5,
-	0x57, 0x51, 0x2b, 0x1d, 0x43, 0x29, 0xf7, 0x18, 0x74, 0xe9, 0x18, 0xfe, 0x28, 0xc1, 0x5a, 0x82,
-	0xf0, 0xfb, 0xae, 0xc3, 0xfe, 0x29, 0xa3, 0x4b, 0x2b, 0xa3, 0x4a, 0x82, 0x6c, 0xe4, 0x25, 0x48,
-	0x2c, 0x41, 0xb9, 0xe9, 0x62, 0x39, 0x55, 0xf6, 0x36, 0xb4, 0xd4, 0x2d, 0xc9, 0x15, 0x28, 0xef,
-	0x61, 0x6c, 0x44, 0x71, 0x77, 0x36, 0xab, 0x9e, 0x08, 0x1d, 0x1a, 0x4a, 0x19, 0xdf, 0x16, 0x01,
-	0x12, 0xee, 0x89, 0x08, 0xd5, 0x23, 0x84, 0x76, 0xa1, 0x2a, 0xbe, 0x1f, 0xb2, 0xa7, 0x51, 0x00,
-	0x2d, 0x68, 0x9c, 0xb3, 0xb0, 0xf0, 0xfb, 0xb3, 0x69, 0x14, 0x47, 0x0b, 0x1a, 0xbd, 0x20, 0xaa,
-	0xf4, 0x6e, 0x08, 0x41, 0x8c, 0xa6, 0x06, 0x95, 0x59, 0x6a, 0x09, 0xad, 0xa4, 0x4a, 0x28, 0xae,
-	0xed, 0x99, 0xdc, 0x9c, 0x0e, 0x03, 0x1e, 0x03, 0x24, 0xa6, 0xf1, 0xcb, 0x03, 0xe6, 0x30, 0x6e,
-	0x06, 0x2e, 0x8f, 0xf0, 0x91, 0x30, 0x10, 0xf7, 0xde, 0x6c, 0x0f, 0xa1, 0x13, 0xe2, 0x22, 0xa2,
-	0x90, 0xcf, 0x4d, 0x67, 0xe4, 0x4e, 0x05, 0x1c, 0x1a, 0x34, 0xa2, 0x48, 0x1b, 0x4a, 0xde, 0xd8,
-	0xee, 0xd4, 0x85, 0x86, 0x38, 0x34, 0xde, 0x05, 0x92, 0x0e, 0xb4, 0x53, 0xb6, 0xbc, 0xdf, 0x14,
-	0xa1, 0xf1, 0xa1, 0xed, 0x07, 0xb8, 0x80, 0xff, 0x7a, 0x84, 0xa9, 0x67, 0x1e, 0x24, 0xbd, 0x40,
-	0x93, 0x2e, 0x68, 0x54, 0x0d, 0xc7, 0xbb, 0xb3, 0x69, 0x74, 0x0a, 0x31, 0x49, 0xde, 0x80, 0xaa,
-	0xef, 0xf2, 0x60, 0x11, 0xa4, 0xad, 0xd4, 0x36, 0xc3, 0x68, 0x92, 0x2e, 0xc4, 0x70, 0x23, 0xd3,
-	0xb7, 0x1e, 0x71, 0xac, 0x95, 0xd5, 0x10, 0x12, 0x31, 0xbd, 0x80, 0x75, 0x4d, 0x82, 0xf5, 0xe7,
-	0x1a, 0x34, 0x25, 0x47, 0x9d, 0xb2, 0x6f, 0xec, 0x41, 0x3d, 0x70, 0x03, 0x73, 0x42, 0x99, 0xe5,
-	0xf2, 0x51, 0x84, 0x4f, 0x99, 0x45, 0x2e, 0x43, 0x69, 0xdf, 0xdd, 0xef, 0xe8, 0x22, 0x44, 0xce,
-	0x65, 0x9c, 0xf4, 0x88, 0x47, 0x77, 0x1a, 0x94, 0x32, 0x7e, 0xd0, 0xa0, 0x21, 0x73, 0x49, 0x4b,
-	0x5c, 0x97, 0xc2, 0x10, 0xc1, 0xcb, 0x52, 0x72, 0x5d, 0x2b, 0x0a, 0xdb, 0xe2, 0xeb, 0x1a, 0x01,
-	0xdd, 0xc1, 0x3c, 0x13, 0x26, 0x71, 0x31, 0x46, 0xb7, 0x4e, 0xa3, 0xfc, 0x12, 0x56, 0xdf, 0x98,
-	0x5c, 0x46, 0xce, 0x34, 0x7e, 0x16, 0x5d, 0x44, 0xc0, 0x6d, 0x36, 0x67, 0x68, 0xc2, 0x32, 0x30,
-	0x27, 0x5d, 0x15, 0x75, 0xe5, 0xaa, 0xf8, 0xd2, 0x16, 0xe5, 0xf5, 0x92, 0xbf, 0x6b, 0xd0, 0x56,
-	0x2d, 0x39, 0x25, 0x28, 0xe4, 0x1b, 0x50, 0x29, 0x75, 0x03, 0x92, 0x5d, 0xa8, 0x1f, 0x5b, 0x76,
-	0xca, 0x99, 0xb2, 0xf3, 0x4e, 0xb6, 0x67, 0xde, 0x4c, 0xf5, 0x81, 0xa1, 0xd6, 0xb9, 0x55, 0x41,
-	0x71, 0xed, 0x4a, 0xba, 0xd1, 0xd9, 0x81, 0xb5, 0xcc, 0xd7, 0xe4, 0xff, 0x6a, 0x82, 0xef, 0xe6,
-	0x6e, 0xa6, 0xe6, 0x78, 0x0d, 0x9a, 0xca, 0xc4, 0xd2, 0xd3, 0xfc, 0x9b, 0xd1, 0xcd, 0x0d, 0x73,
-	0x7a, 0xd4, 0x32, 0x9d, 0xcb, 0xd5, 0x13, 0x05, 0x68, 0x22, 0x6b, 0x7c, 0x06, 0x0d, 0x79, 0xea,
-	0x95, 0x34, 0x75, 0x49, 0x37, 0xa5, 0xe7, 0x76, 0x53, 0x65, 0xa9, 0x9b, 0xfa, 0x49, 0x83, 0x1a,
-	0x65, 0x53, 0x77, 0xbe, 0xac, 0x2e, 0x2a, 0x30, 0xf9, 0x01, 0x3b, 0x29, 0x3d, 0x87, 0x42, 0xb8,
-	0x18, 0x67, 0xd6, 0x8c, 0xfb, 0xd8, 0x30, 0x94, 0x85, 0x8b, 0x13, 0xc6, 0x22, 0x4a, 0x2a, 0x52,
-	0x94, 0xdc, 0x04, 0x88, 0xb5, 0x3f, 0x65, 0x69, 0xfa, 0x4e, 0x83, 0x95, 0xfe, 0xf2, 0xcc, 0xf6,
-	0xdd, 0x19, 0xb7, 0xd8, 0x09, 0x66, 0x87, 0x42, 0xa8, 0xf6, 0x88, 0xf9, 0xf1, 0x15, 0x44, 0x8c,
-	0x73, 0x8b, 0xc4, 0x0d, 0xa8, 0xf6, 0x5f, 0xc6, 0xd4, 0x2f, 0x34, 0x58, 0x1d, 0x62, 0x8a, 0x1a,
-	0x1e, 0xf9, 0x7f, 0x7f, 0x52, 0xcc, 0x3b, 0xb6, 0x8b, 0xd0, 0x56, 0x15, 0x0a, 0x2d, 0x42, 0x0f,
-	0xc5, 0x21, 0x8a, 0xe3, 0x4b, 0xdb, 0xd0, 0x54, 0x9e, 0x3c, 0xc8, 0x2a, 0xd4, 0xa5, 0x3b, 0x58,
-	0xbb, 0x40, 0xda, 0xd0, 0xe8, 0xcf, 0x26, 0x81, 0x1d, 0x5d, 0x1d, 0xdb, 0xda, 0xa5, 0xcb, 0x50,
-	0x8d, 0x0b, 0x32, 0xa9, 0x82, 0x8e, 0x8d, 0x6e, 0xbb, 0x40, 0xea, 0x78, 0xda, 0xa2, 0xee, 0xb4,
-	0x35, 0x64, 0x63, 0xde, 0x6d, 0x17, 0xb7, 0x7f, 0xac, 0xc0, 0x6a, 0xdf, 0x0c, 0xcf, 0x66, 0xc8,
-	0xf8, 0xdc, 0xb6, 0x18, 0xb9, 0x0e, 0xfa, 0xc0, 0x76, 0x0e, 0xc8, 0x7a, 0xea, 0xce, 0x23, 0x1e,
-	0x79, 0xbb, 0x67, 0x72, 0xb8, 0xbe, 0x67, 0x14, 0x48, 0x1f, 0x1a, 0xf2, 0x53, 0x2d, 0x51, 0x2f,
-	0xc7, 0xa9, 0x27, 0xdf, 0xee, 0x85, 0x63, 0x66, 0xc5, 0x72, 0x77, 0xa0, 0x1a, 0xbf, 0x34, 0x92,
-	0x8e, 0x22, 0x2c, 0xbd, 0xdb, 0x76, 0xcf, 0xbd, 0x60, 0x46, 0x2c, 0x31, 0x84, 0x96, 0xfa, 0x20,
-	0x45, 0xd4, 0x44, 0x9d, 0x79, 0x8c, 0xec, 0xfe, 0xfb, 0xd8, 0x79, 0xb1, 0xe8, 0x27, 0xf2, 0xed,
-	0x2b, 0x7a, 0x5f, 0x20, 0xea, 0x43, 0x42, 0xde, 0x93, 0x4f, 0xd7, 0x38, 0x49, 0x24, 0x56, 0x59,
-	0x6d, 0x39, 0x53, 0x2a, 0x67, 0x2e, 0x7e, 0x29, 0x95, 0xb3, 0xfd, 0xaa, 0x51, 0x20, 0xf7, 0xa1,
-	0xb6, 0xe8, 0xad, 0x88, 0xea, 0x31, 0xb9, 0x39, 0xed, 0x76, 0x5f, 0x34, 0x15, 0x9f, 0xaf, 0x5c,
-	0x8f, 0xc9, 0xf9, 0xdc, 0xfc, 0x1e, 0xc5, 0x57, 0xea, 0x7c, 0xd3, 0x85, 0xdc, 0x28, 0x90, 0xb7,
-	0xa1, 0x12, 0x66, 0x2e, 0xb2, 0x91, 0x12, 0x8d, 0x92, 0x71, 0xf7, 0x6c, 0x2e, 0x5f, 0x7c, 0x7c,
-	0x1d, 0x74, 0xcc, 0x04, 0x29, 0x88, 0x46, 0xe9, 0x2c, 0x05, 0xd1, 0x7e, 0xf2, 0x59, 0x1f, 0x1a,
-	0x72, 0xd8, 0xa5, 0x4c, 0x48, 0xa5, 0x88, 0x94, 0x09, 0xe9, 0x78, 0x35, 0x0a, 0x7b, 0x15, 0xf1,
-	0x3b, 0xe4, 0xda, 0x9f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xcc, 0x45, 0x4b, 0x39, 0x20, 0x19, 0x00,
-	0x00,
+	// 2951 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0xcd, 0x8f, 0xe4, 0x46,
+	0x15, 0x1f, 0x77, 0xbb, 0xbb, 0xdd, 0xaf, 0xbb, 0x67, 0x7a, 0x6a, 0x3f, 0xd2, 0x71, 0x76, 0x96,
+	0x8e, 0x41, 0xd1, 0x68, 0x97, 0x2c, 0xb0, 0x51, 0xbe, 0x81, 0x90, 0xfd, 0x08, 0x1b, 0x60, 0x36,
+	0x23, 0x77, 0x08, 0x8a, 0xe0, 0xe2, 0xb1, 0x6b, 0x66, 0xcc, 0x74, 0xdb, 0x4e, 0xd9, 0x3d, 0xcc,
+	0x04, 0x89, 0x3f, 0x80, 0x03, 0x5f, 0x82, 0x03, 0x07, 0xc4, 0x89, 0x88, 0x1b, 0x39, 0x20, 0x24,
+	0x50, 0x94, 0x1b, 0xe2, 0x4f, 0x40, 0x02, 0xae, 0x08, 0x2e, 0xfc, 0x0f, 0xa8, 0xca, 0x65, 0xbb,
+	0xaa, 0xec, 0x71, 0x4f, 0x2f, 0xdb, 0xab, 0x05, 0x71, 0xf3, 0xab, 0x7a, 0x5d, 0x7e, 0xef, 0xd5,
+	0xef, 0xbd, 0x7a, 0xef, 0x95, 0x1b, 0xd6, 0x67, 0x38, 0x71, 0x3c, 0x27, 0x71, 0x6e, 0x44, 0x24,
+	0x4c, 0x42, 0xd4, 0x2b, 0xe8, 0x3d, 0xeb, 0x93, 0xd0, 0xd9, 0xf5, 0x83, 0x03, 0x1b, 0xbf, 0x87,
+	0x46, 0xd0, 0x39, 0xc6, 0x24, 0xf6, 0xc3, 0x60, 0xa4, 0x8d, 0xb5, 0xed, 0x81, 0x9d, 0x91, 0x16,
+	0x80, 0x91, 0x32, 0xc5, 0x91, 0x75, 0x1d, 0x36, 0xbe, 0x8c, 0x93, 0xdd, 0xf9, 0xde, 0xd4, 0x77,
+	0xbf, 0x8a, 0x4f, 0xeb, 0x7f, 0xf8, 0x0e, 0x0c, 0x65, 0xe6, 0x38, 0x42, 0x57, 0xa0, 0x1b, 0x65,
+	0x03, 0x8c, 0xbf, 0x6f, 0x17, 0x03, 0xe8, 0x53, 0x30, 0xc8, 0x89, 0x7b, 0x4e, 0x7c, 0x38, 0x6a,
+	0x30, 0x0e, 0x79, 0xd0, 0xfa, 0x8b, 0x06, 0xbd, 0x9d, 0xa3, 0x37, 0xc2, 0xa9, 0x87, 0x49, 0xad,
+	0x04, 0xe8, 0x32, 0xb4, 0x83, 0xd0, 0xc3, 0x6f, 0x7a, 0x7c, 0x21, 0x4e, 0x51, 0x29, 0x12, 0x7f,
+	0x86, 0xe3, 0xc4, 0x99, 0x45, 0xa3, 0xe6, 0x58, 0xdb, 0xd6, 0xed, 0x62, 0x00, 0x3d, 0x0b, 0xed,
+	0xc8, 0x21, 0x38, 0x48, 0x46, 0xfa, 0x58, 0xdb, 0xee, 0xdd, 0xbc, 0x74, 0x43, 0xb0, 0xd9, 0x8d,
+	0x37, 0xfc, 0x29, 0xde, 0x75, 0x92, 0x43, 0x9b, 0x33, 0xd1, 0x97, 0xec, 0x33, 0x59, 0x46, 0xad,
+	0x71, 0x73, 0xbb, 0x6b, 0x73, 0x0a, 0x8d, 0xa1, 0xe7, 0x07, 0x09, 0x26, 0x8e, 0x9b, 0xf8, 0xc7,
+	0x78, 0xd4, 0x1e, 0x6b, 0xdb, 0x86, 0x2d, 0x0e, 0x21, 0x04, 0x7a, 0xec, 0x1f, 0x04, 0xa3, 0x0e,
+	0x13, 0x8e, 0x3d, 0x5b, 0xef, 0x82, 0x91, 0xbd, 0x01, 0x5d, 0x04, 0x3d, 0x72, 0x92, 0x43, 0xa6,
+	0x55, 0xf7, 0xde, 0x9a, 0xcd, 0x28, 0x34, 0x84, 0x86, 0xcf, 0x15, 0xba, 0xb7, 0x66, 0x37, 0x7c,
+	0x8f, 0x1a, 0x20, 0x8e, 0x1c, 0x17, 0xdf, 0x0f, 0x99, 0x32, 0x03, 0x3b, 0x23, 0x6f, 0xf5, 0xa0,
+	0x1b, 0x06, 0xf8, 0xad, 0x7d, 0xba, 0x9c, 0xf5, 0x0a, 0xf4, 0x0b, 0xb3, 0xc5, 0x11, 0x7d, 0xbd,
+	0x1b, 0x7a, 0x98, 0x1b, 0x8d, 0x3d, 0x53, 0x65, 0x30, 0x21, 0x3b, 0xf1, 0x01, 0x7b, 0x41, 0xd7,
+	0xe6, 0x94, 0xf5, 0x0f, 0x1d, 0x36, 0x6f, 0x1f, 0x62, 0xf7, 0x88, 0x0a, 0x77, 0xf7, 0xc4, 0x8f,
+	0x93, 0xc7, 0xc0, 0xf2, 0x26, 0x18, 0xfb, 0xfe, 0x14, 0x33, 0xa4, 0xb4, 0xd8, 0x6b, 0x72, 0x3a,
+	0x9b, 0x9b, 0xf8, 0xef, 0xa7, 0xa6, 0xd7, 0xed, 0x9c, 0xce, 0xe6, 0xde, 0x3e, 0x8d, 0x30, 0xb3,
+	0x7d, 0xd7, 0xce, 0x69, 0x74, 0x15, 0x00, 0x07, 0x2e, 0x39, 0x8d, 0x12, 0x8a, 0x50, 0x83, 0xad,
+	0x2a, 0x8c, 0x94, 0x21, 0xda, 0xad, 0x80, 0x68, 0xf6, 0x86, 0xfb, 0xce, 0x0c, 0x8f, 0xa0, 0x78,
+	0x03, 0xa5, 0x29, 0x2e, 0xe8, 0xf3, 0x4e, 0xe8, 0xbd, 0xed, 0xcf, 0xf0, 0xa8, 0xc7, 0x84, 0x13,
+	0x87, 0xb2, 0x5f, 0xdf, 0x71, 0x12, 0x67, 0xd4, 0x2f, 0xf4, 0xa2, 0xb4, 0x8a, 0xaa, 0x41, 0x19,
+	0x55, 0x57, 0x01, 0x02, 0xfc, 0x9d, 0x77, 0xf8, 0xbe, 0xac, 0x33, 0x06, 0x61, 0x24, 0x47, 0xdd,
+	0x46, 0x81, 0x3a, 0xf4, 0x22, 0x80, 0x37, 0x27, 0xce, 0x9e, 0x3f, 0xf5, 0x93, 0xd3, 0xd1, 0x90,
+	0x19, 0xff, 0x09, 0xc9, 0xf8, 0x77, 0xf2, 0x69, 0x5b, 0x60, 0xa5, 0x8b, 0x45, 0x8e, 0x7b, 0x34,
+	0xda, 0x64, 0xaf, 0x61, 0xcf, 0x54, 0x7c, 0x1c, 0xb8, 0xa1, 0xe7, 0x07, 0x07, 0x23, 0x94, 0x2a,
+	0x9f, 0xd1, 0x54, 0x7c, 0x0f, 0x53, 0xa4, 0x79, 0x6c, 0x67, 0x2e, 0xa4, 0xca, 0x0b, 0x43, 0xd6,
+	0xc7, 0x0d, 0x40, 0x2a, 0xd2, 0x96, 0x03, 0x2b, 0x7a, 0x09, 0xba, 0x71, 0x12, 0x92, 0x74, 0x83,
+	0x29, 0xc8, 0xd6, 0x6f, 0x9a, 0x25, 0x24, 0x4d, 0x32, 0x0e, 0xbb, 0x60, 0x46, 0xcf, 0xc0, 0x3a,
+	0xe5, 0xd9, 0xf5, 0xb1, 0x8b, 0x6f, 0x87, 0x73, 0x0e, 0xc4, 0x81, 0xad, 0x8c, 0xa2, 0x6b, 0x30,
+	0x3c, 0xc6, 0xc4, 0xdf, 0x3f, 0x15, 0x38, 0x5b, 0x8c, 0xb3, 0x34, 0x8e, 0x2c, 0xe8, 0x13, 0x1c,
+	0x4d, 0x7d, 0xd7, 0x49, 0xf9, 0xda, 0x8c, 0x4f, 0x1a, 0xa3, 0x6e, 0xe1, 0x1e, 0xce, 0x83, 0x23,
+	0x66, 0x94, 0x0e, 0x63, 0x28, 0x06, 0xd0, 0xa7, 0x61, 0xb3, 0x30, 0xf9, 0xed, 0xa9, 0x33, 0x8b,
+	0xb0, 0xc7, 0xa0, 0x69, 0xd8, 0xe5, 0x09, 0xeb, 0x63, 0x0d, 0xa0, 0xd8, 0x2d, 0xd9, 0x18, 0xda,
+	0x7f, 0x66, 0x8c, 0xc6, 0xb9, 0x8d, 0xd1, 0x3c, 0xa7, 0x31, 0xf4, 0xb2, 0x31, 0xac, 0x7f, 0x69,
+	0x70, 0xf1, 0xeb, 0xd1, 0x34, 0x74, 0x3c, 0xe6, 0xf1, 0x04, 0x53, 0x77, 0x5f, 0x45, 0xb8, 0x11,
+	0xe3, 0x87, 0x5e, 0x13, 0x3f, 0x5a, 0x4a, 0xfc, 0x78, 0x19, 0xba, 0x91, 0x43, 0x12, 0x3f, 0xa1,
+	0x92, 0xb4, 0xc7, 0xcd, 0xed, 0xde, 0xcd, 0xa7, 0x24, 0x93, 0x4e, 0xa2, 0xa9, 0x9f, 0xec, 0x66,
+	0x2c, 0x76, 0xc1, 0x5d, 0x19, 0xf2, 0xef, 0xc2, 0xba, 0xfc, 0x03, 0xf4, 0x1c, 0xb4, 0x22, 0x6a,
+	0xb3, 0x91, 0xc6, 0x16, 0xdf, 0x92, 0x16, 0x67, 0xd6, 0xa4, 0x32, 0xbe, 0x1e, 0x30, 0x8f, 0xb1,
+	0x53, 0x5e, 0xeb, 0x15, 0x18, 0xaa, 0x53, 0xf4, 0x75, 0x87, 0x54, 0xbb, 0xf4, 0xa4, 0x65, 0xcf,
+	0xa9, 0x08, 0xef, 0x63, 0xbe, 0x99, 0xec, 0xd9, 0xfa, 0x9d, 0x06, 0x97, 0x2a, 0x4c, 0x1e, 0x47,
+	0xe8, 0x35, 0x51, 0xd7, 0x54, 0x9c, 0xa7, 0x25, 0x71, 0xee, 0x12, 0x27, 0x9e, 0x13, 0x7c, 0x3b,
+	0xf4, 0x70, 0xa5, 0xc6, 0x2f, 0x81, 0x11, 0x91, 0xf0, 0xd8, 0xa7, 0x07, 0x64, 0x83, 0xfd, 0xfe,
+	0x8a, 0xf4, 0x7b, 0x3b, 0xdd, 0xfa, 0x5d, 0xce, 0x63, 0xe7, 0xdc, 0x25, 0xac, 0x34, 0x2b, 0xb0,
+	0xf2, 0x4b, 0x0d, 0x36, 0x94, 0x15, 0x04, 0x30, 0x68, 0x12, 0x18, 0x2e, 0x43, 0x3b, 0xc6, 0xe4,
+	0x18, 0x93, 0x2c, 0x5c, 0xa4, 0x14, 0x8b, 0x61, 0x21, 0xc9, 0xd6, 0x67, 0xcf, 0x32, 0x70, 0x74,
+	0x15, 0x38, 0x97, 0xa1, 0x9d, 0xf8, 0xee, 0x11, 0x4e, 0x9d, 0xbe, 0x6b, 0x73, 0x8a, 0xae, 0xe4,
+	0xcc, 0x93, 0x43, 0xe6, 0xe2, 0x7d, 0x9b, 0x3d, 0x5b, 0x27, 0x70, 0xb1, 0xca, 0x44, 0xe8, 0x16,
+	0xf4, 0x33, 0x4d, 0x5f, 0x9f, 0xb3, 0x43, 0x9e, 0xda, 0xe6, 0xaa, 0x64, 0x9b, 0x5b, 0xd3, 0xd0,
+	0x3d, 0xda, 0x15, 0xb8, 0x6c, 0xe9, 0x37, 0xb2, 0x94, 0x0d, 0x45, 0x4a, 0xeb, 0x57, 0x1a, 0x6c,
+	0x96, 0x56, 0x78, 0x28, 0xd6, 0xb9, 0x08, 0xad, 0x98, 0x22, 0x84, 0x59, 0xc6, 0xb0, 0x53, 0x02,
+	0xbd, 0x00, 0x06, 0x05, 0x18, 0xd3, 0xa6, 0xc5, 0xb4, 0x31, 0xcf, 0x00, 0x2e, 0xd5, 0x24, 0xe7,
+	0xb5, 0x5c, 0x18, 0x48, 0x53, 0xe7, 0x45, 0xad, 0xb0, 0x0d, 0xcd, 0xca, 0x6d, 0xd0, 0x85, 0x6d,
+	0xf8, 0xbd, 0x0e, 0x9b, 0x05, 0xc2, 0xef, 0x84, 0x01, 0xfe, 0x7f, 0x02, 0xb3, 0xb2, 0x04, 0x46,
+	0x0a, 0x90, 0xfd, 0xaa, 0x00, 0x49, 0x0f, 0x99, 0xca, 0x70, 0xb1, 0x9a, 0xfc, 0x46, 0x4c, 0x49,
+	0x86, 0xf5, 0x29, 0xc9, 0x66, 0x39, 0x25, 0x79, 0x0d, 0xd6, 0x65, 0x81, 0xd1, 0xb3, 0xd0, 0xda,
+	0xa3, 0x9e, 0xc5, 0xbd, 0xf6, 0x89, 0xb2, 0x72, 0xcc, 0xf1, 0xec, 0x94, 0xcb, 0xfa, 0x75, 0x03,
+	0xa0, 0x18, 0x5d, 0x88, 0x6f, 0x9d, 0xe3, 0xdb, 0x04, 0x83, 0xfd, 0x7e, 0x82, 0xdf, 0xe3, 0xee,
+	0x97, 0xd3, 0x74, 0xce, 0xa5, 0x59, 0x52, 0x3c, 0x9f, 0x71, 0x2f, 0xcc, 0x69, 0xaa, 0x11, 0x3b,
+	0xc5, 0xef, 0xa7, 0x00, 0xa6, 0xbe, 0xd8, 0xb7, 0xc5, 0x21, 0x39, 0xdf, 0x68, 0xab, 0xf9, 0x86,
+	0x09, 0x46, 0xe4, 0x10, 0x67, 0x36, 0x49, 0x48, 0x06, 0xaf, 0x8c, 0xa6, 0xbf, 0x3c, 0xc0, 0x01,
+	0x26, 0x4e, 0x12, 0x12, 0x8e, 0xae, 0x62, 0x80, 0x7a, 0x4d, 0x34, 0xdf, 0xa3, 0xc0, 0x4b, 0x51,
+	0xc5, 0x29, 0x3a, 0x4e, 0x9c, 0xc0, 0x0b, 0x67, 0x0c, 0x4c, 0x7d, 0x9b, 0x53, 0x68, 0x08, 0xcd,
+	0xe8, 0xd0, 0x1f, 0xf5, 0x98, 0x84, 0xf4, 0xd1, 0xfa, 0x12, 0x20, 0xd5, 0x4d, 0x97, 0x2c, 0x55,
+	0x3e, 0x68, 0x40, 0xff, 0x6b, 0x7e, 0x9c, 0xd0, 0x05, 0xe2, 0xc7, 0xc3, 0xc9, 0x23, 0xe7, 0xa0,
+	0xc8, 0x24, 0x06, 0x76, 0x4e, 0x53, 0xd1, 0xe8, 0xf3, 0xfd, 0xf9, 0x8c, 0xef, 0x42, 0x46, 0xa2,
+	0xcf, 0x81, 0x11, 0x87, 0x24, 0xc9, 0x5d, 0x7c, 0x5d, 0x79, 0xcd, 0x84, 0x4f, 0xda, 0x39, 0x1b,
+	0x7d, 0x91, 0x13, 0xbb, 0x6f, 0x11, 0x0f, 0xa7, 0x3b, 0x63, 0xd8, 0x39, 0x9d, 0x3b, 0x45, 0x57,
+	0xc8, 0x3b, 0xbe, 0xaf, 0xc1, 0x40, 0x30, 0xd4, 0x92, 0x49, 0xf6, 0x18, 0x7a, 0x49, 0x98, 0x38,
+	0x53, 0x1b, 0xbb, 0x21, 0xf1, 0x38, 0x3e, 0xc5, 0x21, 0x74, 0x1d, 0x9a, 0xfb, 0xe1, 0xfe, 0x48,
+	0x67, 0x2e, 0xf2, 0x64, 0xc9, 0x48, 0x6f, 0x11, 0x5e, 0x8b, 0x52, 0x2e, 0xeb, 0xaf, 0x0d, 0xe8,
+	0x8b, 0xa3, 0x68, 0x9d, 0x95, 0xb9, 0xa9, 0x8b, 0xd0, 0x22, 0xb7, 0x28, 0xb3, 0x1b, 0x4c, 0x37,
+	0x4e, 0x51, 0x99, 0x03, 0x1a, 0xa5, 0xd2, 0x23, 0x80, 0x3d, 0x53, 0xb3, 0xce, 0x78, 0x74, 0x4a,
+	0xcf, 0xee, 0x8c, 0x5c, 0x49, 0xc4, 0x95, 0x8b, 0x27, 0xe3, 0xfc, 0xc5, 0xd3, 0x35, 0x5e, 0x3c,
+	0x75, 0xd9, 0x4f, 0x2e, 0xcb, 0x87, 0xa5, 0xe3, 0x1e, 0xd9, 0x4e, 0x70, 0x80, 0x2b, 0x8a, 0x2a,
+	0xa8, 0x8f, 0x60, 0xbd, 0x72, 0x04, 0xfb, 0xad, 0x0e, 0xeb, 0x13, 0xec, 0x10, 0xf7, 0xf0, 0x71,
+	0xf1, 0x8a, 0x2b, 0xd0, 0x25, 0xd8, 0x9d, 0x93, 0x98, 0x46, 0xf9, 0x16, 0xdb, 0xd1, 0x62, 0x80,
+	0x6a, 0x44, 0x37, 0x72, 0xd7, 0x49, 0x12, 0x4c, 0x02, 0xb6, 0x1b, 0x5d, 0x5b, 0x1c, 0xa2, 0x29,
+	0x08, 0xc1, 0x07, 0xf8, 0x84, 0xed, 0x86, 0x61, 0xa7, 0x84, 0xb4, 0x4d, 0x86, 0xb2, 0x4d, 0x14,
+	0x14, 0x7e, 0xc0, 0x2c, 0xd4, 0xe5, 0xa0, 0x48, 0x49, 0x36, 0xe3, 0x9c, 0xb0, 0x19, 0xe0, 0x33,
+	0x29, 0x49, 0xcf, 0x9a, 0x99, 0x1f, 0xc8, 0x27, 0x9d, 0x30, 0xc2, 0xe6, 0x9d, 0x93, 0x6c, 0xbe,
+	0xcf, 0xe7, 0xf3, 0x11, 0x7a, 0xd8, 0xfa, 0x81, 0x3b, 0x9d, 0x7b, 0x38, 0x45, 0x35, 0x3f, 0xcf,
+	0xe4, 0x41, 0x29, 0x42, 0xac, 0x9f, 0x1d, 0x21, 0x36, 0xce, 0x8e, 0x10, 0xc3, 0xe5, 0x23, 0xc4,
+	0xe6, 0x19, 0x11, 0x02, 0x09, 0x11, 0xe2, 0x07, 0x1a, 0x6c, 0x48, 0xb0, 0x79, 0xe8, 0x31, 0xe2,
+	0x59, 0xd0, 0xe9, 0x06, 0x55, 0x06, 0x89, 0xf4, 0xcd, 0x98, 0x9d, 0x04, 0x36, 0x63, 0xb3, 0x26,
+	0xd0, 0x17, 0x47, 0xd9, 0x79, 0x93, 0x82, 0x4e, 0x4b, 0x5f, 0x9c, 0x52, 0x59, 0xe8, 0x69, 0x8c,
+	0xb5, 0xd2, 0xaa, 0xe5, 0xd0, 0xf3, 0x27, 0x56, 0x43, 0x24, 0xc4, 0xc7, 0xc7, 0x98, 0xbd, 0x6b,
+	0x05, 0xde, 0x21, 0xb4, 0xe8, 0x74, 0xa9, 0x45, 0xf7, 0xc0, 0x11, 0xa9, 0xaa, 0x92, 0xfc, 0x5b,
+	0x03, 0x86, 0xb2, 0x26, 0x4b, 0x6e, 0x98, 0xd8, 0x79, 0x6a, 0x2a, 0x9d, 0x27, 0xd1, 0xb7, 0xf4,
+	0xda, 0xa4, 0xb3, 0x55, 0x4a, 0x3a, 0x3f, 0x5f, 0xae, 0x98, 0xaf, 0x2a, 0x55, 0x60, 0x2a, 0x75,
+	0x65, 0x4e, 0x28, 0x99, 0xb6, 0xa3, 0x9a, 0x36, 0x8b, 0xa2, 0xc6, 0x92, 0x51, 0xb4, 0x5b, 0x1f,
+	0x45, 0xa1, 0x1c, 0x45, 0xef, 0xc2, 0x66, 0x49, 0x4e, 0xf4, 0x59, 0x39, 0x15, 0x34, 0x2b, 0xd5,
+	0x92, 0xb3, 0x41, 0x0d, 0x06, 0xd2, 0xc4, 0xca, 0x13, 0xc2, 0x17, 0xa1, 0x9b, 0x67, 0x7f, 0xbc,
+	0x34, 0x7b, 0xb2, 0x52, 0x4e, 0xca, 0x60, 0x17, 0xbc, 0xd6, 0xf7, 0xa0, 0x2f, 0x4e, 0x3d, 0x94,
+	0xe2, 0xb1, 0xa8, 0xda, 0xf4, 0xca, 0xaa, 0xad, 0x25, 0x54, 0x6d, 0x1f, 0x69, 0xd0, 0xb5, 0xf1,
+	0x2c, 0x3c, 0x5e, 0x55, 0xb5, 0x96, 0x38, 0xe4, 0x00, 0x2f, 0x3a, 0xb2, 0x52, 0xa6, 0x05, 0x47,
+	0x56, 0xe6, 0x8f, 0x6d, 0xc1, 0x1f, 0x5f, 0x02, 0xc8, 0xa4, 0x5f, 0x32, 0x89, 0xfd, 0x50, 0x83,
+	0xce, 0xce, 0xea, 0xd4, 0x8e, 0xc3, 0x39, 0x71, 0xf1, 0x02, 0xb5, 0x53, 0x26, 0x2a, 0xb6, 0x87,
+	0xe3, 0xac, 0xd5, 0xc1, 0x9e, 0x2b, 0xd3, 0xc9, 0x17, 0xc0, 0xd8, 0x79, 0x10, 0x55, 0x7f, 0x48,
+	0x0f, 0x19, 0x1a, 0x0c, 0x27, 0xa7, 0xf1, 0xa3, 0x0f, 0xbf, 0x55, 0xdb, 0xf6, 0x0c, 0x0c, 0x65,
+	0x81, 0x52, 0x8d, 0xa8, 0x85, 0x32, 0x17, 0xa5, 0xcf, 0xd6, 0x6f, 0x1a, 0xac, 0xf9, 0x14, 0x92,
+	0x84, 0xb9, 0x71, 0xfc, 0xdf, 0x71, 0x70, 0x5c, 0xcf, 0x02, 0x56, 0x67, 0xdc, 0x2c, 0xed, 0xfe,
+	0x2d, 0xc7, 0x13, 0x63, 0x15, 0x7a, 0x19, 0x0c, 0x82, 0x23, 0xc7, 0x27, 0xac, 0xe3, 0x7c, 0x8e,
+	0x66, 0x64, 0xce, 0x5e, 0x89, 0x91, 0x9f, 0x68, 0x60, 0x64, 0xaf, 0xa8, 0x8c, 0x7a, 0x62, 0x84,
+	0x6b, 0xd4, 0x44, 0xb8, 0xa6, 0x12, 0xe1, 0x46, 0xd0, 0x71, 0x43, 0x42, 0xe6, 0x51, 0xc2, 0x83,
+	0x5f, 0x46, 0x96, 0x8b, 0x61, 0x4d, 0x29, 0x86, 0xad, 0x5f, 0x68, 0x30, 0x94, 0xb7, 0x71, 0xc9,
+	0x53, 0x53, 0xea, 0x91, 0x36, 0xc7, 0xda, 0xd2, 0x3d, 0x52, 0xa9, 0x1c, 0xd7, 0x95, 0x72, 0xdc,
+	0xfa, 0x69, 0x03, 0x2e, 0xd8, 0xcc, 0xaa, 0xa9, 0x7c, 0xab, 0x6a, 0x5e, 0x3d, 0x7c, 0xa8, 0x8d,
+	0xa0, 0xb3, 0xe7, 0x78, 0xec, 0x67, 0x1d, 0x56, 0xe2, 0x67, 0x64, 0xd1, 0x40, 0x31, 0xce, 0xd3,
+	0x40, 0xa9, 0xc4, 0xd2, 0x2d, 0xb8, 0x58, 0xb6, 0xca, 0x92, 0xb1, 0xe7, 0xcf, 0x5a, 0x71, 0x27,
+	0xfa, 0x38, 0x54, 0x45, 0x88, 0xdf, 0xf8, 0xa6, 0x37, 0xc9, 0xec, 0xf9, 0x01, 0xef, 0x91, 0x5f,
+	0x85, 0x81, 0xa0, 0xd8, 0x92, 0x66, 0xf9, 0x91, 0x78, 0x07, 0x17, 0xaf, 0xec, 0xba, 0xb7, 0xd4,
+	0x8a, 0xd4, 0xab, 0x5a, 0x91, 0x8a, 0xfe, 0xad, 0x45, 0x1d, 0xc1, 0x76, 0xa9, 0x23, 0x78, 0x83,
+	0x17, 0x19, 0x9d, 0x8a, 0x0c, 0x2d, 0x57, 0xf3, 0xcd, 0x04, 0xcf, 0xd2, 0x2a, 0x23, 0xb7, 0xa7,
+	0x21, 0xd8, 0xf3, 0x8f, 0x0d, 0x18, 0x48, 0xbc, 0xc2, 0xd6, 0x6a, 0xcb, 0xf6, 0x7a, 0x1b, 0x35,
+	0x3e, 0xd4, 0xac, 0xe9, 0x3c, 0x2c, 0x9b, 0x76, 0x8b, 0x5d, 0xdc, 0x76, 0x7d, 0x17, 0xb7, 0x53,
+	0x7f, 0x0d, 0x6d, 0x28, 0xc5, 0x80, 0xdc, 0xf3, 0xe8, 0x9e, 0xbb, 0xe7, 0x61, 0xbd, 0x0f, 0x17,
+	0x4a, 0xc8, 0x5a, 0x32, 0xdc, 0xbe, 0x08, 0x6d, 0x82, 0xe3, 0xf9, 0x94, 0xa6, 0x95, 0x74, 0x43,
+	0x3f, 0x51, 0xbd, 0xa1, 0xf9, 0xe2, 0x36, 0x67, 0xb7, 0x7e, 0xd6, 0x10, 0x9b, 0x8b, 0x2b, 0x8b,
+	0xa3, 0x8f, 0x0a, 0xd6, 0xcf, 0x49, 0xb0, 0x96, 0xad, 0x20, 0xf7, 0x50, 0x17, 0x60, 0xfb, 0xc3,
+	0x06, 0xa0, 0xf2, 0x0f, 0xfe, 0xf7, 0x01, 0x2e, 0x5d, 0x53, 0x18, 0xcb, 0x5c, 0x53, 0x50, 0x18,
+	0x97, 0x90, 0xf4, 0x50, 0x61, 0x5c, 0x6e, 0x82, 0xe7, 0x30, 0xfe, 0x26, 0x74, 0xf3, 0xba, 0x36,
+	0xed, 0x1d, 0xb9, 0x47, 0xf7, 0x8a, 0x44, 0x2a, 0xa7, 0xb3, 0xb9, 0x49, 0x51, 0x46, 0xe6, 0x34,
+	0x95, 0x2a, 0xdc, 0xdf, 0x8f, 0xf9, 0xdd, 0x99, 0x6e, 0x73, 0xca, 0xfa, 0xa8, 0x09, 0x43, 0xba,
+	0xfa, 0x63, 0xe0, 0x21, 0x42, 0x3e, 0xd2, 0x2a, 0xe5, 0x23, 0xb9, 0xd2, 0xed, 0x1a, 0xa5, 0x3b,
+	0x8a, 0xd2, 0x8b, 0xee, 0xc6, 0x24, 0x40, 0x74, 0x97, 0xba, 0xb7, 0xfa, 0x0c, 0xb4, 0x67, 0x78,
+	0xb6, 0x87, 0xc9, 0x08, 0x2a, 0x32, 0x1a, 0x6a, 0xd1, 0x1d, 0x36, 0x6d, 0x73, 0x36, 0xd5, 0xff,
+	0x7b, 0x8b, 0xfc, 0xbf, 0x7f, 0xe6, 0x45, 0xd7, 0x40, 0x70, 0xe5, 0xbf, 0x6b, 0x00, 0xc5, 0xcb,
+	0x1e, 0xd0, 0x85, 0x99, 0x3b, 0x35, 0x14, 0x77, 0x12, 0xdd, 0xbb, 0x59, 0xe3, 0xde, 0x7a, 0x8d,
+	0x7b, 0xb7, 0x14, 0xf7, 0x56, 0x5c, 0xb4, 0x5d, 0x76, 0xd1, 0x02, 0xa6, 0x1d, 0x09, 0xa6, 0x27,
+	0xb0, 0xa9, 0xa0, 0xf4, 0x51, 0x79, 0xdf, 0x07, 0xf4, 0xf3, 0x1a, 0x3c, 0xc5, 0x07, 0x4e, 0x22,
+	0x3b, 0x80, 0xdc, 0x11, 0x31, 0xc1, 0xf0, 0xf0, 0xb1, 0xef, 0x16, 0xae, 0x91, 0xd3, 0xf4, 0xc3,
+	0x86, 0xf4, 0x79, 0x37, 0xbd, 0x2b, 0x4b, 0xcd, 0x29, 0x8d, 0xe5, 0xd7, 0x1a, 0xba, 0x7c, 0xad,
+	0xe1, 0x12, 0xec, 0x24, 0xd8, 0xe3, 0x9f, 0xa4, 0x64, 0x64, 0x65, 0x15, 0xfb, 0x05, 0xd8, 0xf8,
+	0x06, 0x71, 0xa2, 0x08, 0x7b, 0xac, 0x98, 0xa5, 0x8b, 0x0a, 0x1e, 0xa5, 0xc9, 0x1e, 0x35, 0x84,
+	0xe6, 0x11, 0x3e, 0xe5, 0x92, 0xd2, 0x47, 0xeb, 0x9f, 0x1a, 0x6c, 0xdc, 0x0d, 0x48, 0x38, 0x9d,
+	0xde, 0x61, 0x72, 0xad, 0x22, 0x0e, 0xd0, 0x2c, 0x22, 0x37, 0x25, 0xcf, 0x90, 0x95, 0x2c, 0x22,
+	0x9f, 0xb6, 0x05, 0x56, 0xfa, 0x51, 0x49, 0xcc, 0x95, 0x1a, 0xb5, 0x2a, 0x3e, 0x2a, 0x51, 0x14,
+	0xb7, 0x73, 0xee, 0x4a, 0x4b, 0x7d, 0x11, 0x86, 0xb2, 0xa6, 0x4b, 0xa6, 0xcb, 0x09, 0xac, 0xd3,
+	0x7b, 0xb4, 0xf4, 0xd7, 0x2b, 0x29, 0x23, 0x32, 0xa9, 0x75, 0x41, 0xea, 0x9f, 0x6b, 0xd0, 0x4e,
+	0x5f, 0xa9, 0xd8, 0x51, 0x3b, 0xbf, 0x1d, 0x47, 0xd0, 0x21, 0xf8, 0x38, 0x3c, 0xc2, 0x1e, 0xbf,
+	0x55, 0xcb, 0x48, 0xea, 0x9a, 0xfc, 0x91, 0xb9, 0x66, 0x2a, 0x91, 0x38, 0x44, 0x11, 0x3e, 0x75,
+	0xe2, 0x64, 0x82, 0x71, 0x90, 0x39, 0x7d, 0x46, 0x5b, 0xdf, 0x86, 0x0d, 0xc9, 0x22, 0x4b, 0x3a,
+	0xe7, 0x75, 0x68, 0xa7, 0xce, 0xc0, 0x9d, 0xf3, 0x82, 0xa2, 0x0b, 0xdb, 0x25, 0xce, 0x62, 0xfd,
+	0x98, 0xb5, 0xef, 0xa9, 0x5c, 0xab, 0x03, 0xaa, 0xe8, 0xcd, 0xba, 0xe2, 0xcd, 0xd9, 0xde, 0xb4,
+	0x64, 0x44, 0xc9, 0x22, 0x2d, 0x89, 0xa8, 0x13, 0x40, 0xe9, 0x2f, 0x33, 0x04, 0x3f, 0x32, 0x54,
+	0x7d, 0x17, 0x2e, 0x94, 0xde, 0xbc, 0xf4, 0xe7, 0x97, 0x85, 0x73, 0x36, 0x97, 0x71, 0xce, 0x6b,
+	0x37, 0x61, 0x20, 0x7d, 0x8d, 0x88, 0x36, 0xa0, 0x27, 0xf4, 0x4e, 0x86, 0x6b, 0x68, 0x08, 0xfd,
+	0x9d, 0xf9, 0x34, 0xf1, 0xf9, 0x37, 0x5f, 0x43, 0xed, 0xda, 0x75, 0x30, 0xb2, 0x9b, 0x2e, 0x64,
+	0x80, 0x4e, 0xcf, 0xaa, 0xe1, 0x1a, 0xea, 0xd1, 0xf6, 0x29, 0xc3, 0xe9, 0x50, 0xa3, 0xc3, 0xf4,
+	0x28, 0x1a, 0x36, 0x6e, 0xfe, 0x61, 0x00, 0x1b, 0x3b, 0x4e, 0x2a, 0xca, 0x04, 0x13, 0xe6, 0x3c,
+	0xcf, 0x83, 0x4e, 0xbf, 0x6f, 0x47, 0x17, 0x95, 0xc6, 0x16, 0xfb, 0x2e, 0xde, 0xbc, 0x54, 0x31,
+	0x1a, 0x47, 0xd6, 0x1a, 0xda, 0x81, 0xbe, 0xf8, 0x75, 0x3b, 0x92, 0x75, 0x54, 0xbe, 0x92, 0x37,
+	0xb7, 0x6a, 0x66, 0xd9, 0x72, 0xaf, 0x83, 0x91, 0xd5, 0xeb, 0x68, 0x24, 0x31, 0x0b, 0x9f, 0xba,
+	0x9b, 0x4f, 0x9e, 0x31, 0xc3, 0x96, 0x98, 0xc0, 0xba, 0x5c, 0xfc, 0xa0, 0xab, 0xb5, 0x95, 0xd1,
+	0x7b, 0xe6, 0xa2, 0xca, 0xc9, 0x5a, 0x43, 0xdf, 0x82, 0xcd, 0xd2, 0x87, 0x81, 0xe8, 0xe9, 0x33,
+	0x0e, 0xcb, 0xe2, 0x5b, 0x4d, 0xd3, 0x5a, 0xc4, 0x92, 0x89, 0x2c, 0x1f, 0xb5, 0x8a, 0xc8, 0xa5,
+	0x2f, 0xb6, 0xcc, 0x45, 0xe7, 0xb4, 0xb5, 0x86, 0xee, 0x40, 0x37, 0xff, 0xac, 0x01, 0xc9, 0x16,
+	0x13, 0xbf, 0x0b, 0x31, 0xcd, 0xb3, 0xa6, 0xd8, 0x2a, 0x5f, 0x81, 0x9e, 0x70, 0xf5, 0x89, 0x9e,
+	0xaa, 0xb8, 0x9a, 0xcc, 0x57, 0xba, 0x72, 0xf6, 0x64, 0x86, 0x15, 0xf1, 0x5a, 0x4e, 0xc1, 0x8a,
+	0x72, 0xf7, 0x68, 0x6e, 0xd5, 0xcc, 0xb2, 0xe5, 0x5e, 0x85, 0x76, 0x7a, 0xad, 0x80, 0x2e, 0x2b,
+	0xac, 0xfc, 0xa6, 0xc4, 0x7c, 0xa2, 0x72, 0x9c, 0xfd, 0xf8, 0x79, 0xd0, 0x69, 0x9b, 0x5e, 0x81,
+	0x3b, 0xbf, 0x6b, 0x30, 0x2f, 0x55, 0x8c, 0x66, 0x2a, 0x88, 0x3d, 0x71, 0x45, 0x05, 0xa5, 0x7f,
+	0x6f, 0x6e, 0xd5, 0xcc, 0x16, 0x16, 0x29, 0x5a, 0xae, 0x25, 0x8b, 0x48, 0x4d, 0x75, 0x73, 0xab,
+	0x66, 0x96, 0x2d, 0xf7, 0x2e, 0x0c, 0xd5, 0x5e, 0x20, 0x1a, 0xab, 0x3f, 0x52, 0x1b, 0xa8, 0xe6,
+	0xd3, 0x0b, 0x38, 0x32, 0x34, 0xe5, 0x8d, 0x34, 0x54, 0xed, 0x7f, 0x15, 0x68, 0x92, 0x7a, 0x6f,
+	0xd6, 0x1a, 0x7a, 0x07, 0x36, 0x94, 0xb6, 0x07, 0x3a, 0xc3, 0xf9, 0xf2, 0x76, 0x9b, 0x39, 0xae,
+	0x67, 0xc8, 0xd6, 0x55, 0xea, 0x50, 0x74, 0x96, 0x87, 0xe4, 0x6a, 0x8f, 0xeb, 0x19, 0xd8, 0xba,
+	0xbb, 0x30, 0x90, 0xf2, 0x6b, 0xb4, 0x55, 0xaa, 0x67, 0xa4, 0x35, 0xaf, 0xd6, 0x4d, 0x67, 0x3b,
+	0x2e, 0x26, 0x59, 0xca, 0x8e, 0x2b, 0x99, 0xa6, 0xb9, 0x55, 0x33, 0x9b, 0xb9, 0xa7, 0x90, 0x61,
+	0x28, 0xee, 0x29, 0x67, 0x63, 0xe6, 0x95, 0xb3, 0x27, 0x0b, 0x30, 0x16, 0xa7, 0x75, 0x09, 0x8c,
+	0x52, 0x6e, 0x61, 0x6e, 0xd5, 0xcc, 0x66, 0x7b, 0xa2, 0x1c, 0xa1, 0xca, 0x9e, 0x94, 0x8f, 0x76,
+	0x73, 0x5c, 0xcf, 0x40, 0xd7, 0xdd, 0x6b, 0xb3, 0x7f, 0x70, 0x3d, 0xf7, 0xef, 0x01, 0x00, 0xda,
+	0xc2, 0x57, 0xf5, 0xd3, 0x35, 0x00, 0x00,
 }
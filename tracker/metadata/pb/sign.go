@@ -1,12 +1,15 @@
 package metadata_pb
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"errors"
 
 	util_bytes "github.com/samoslab/nebula/util/bytes"
+	util_hash "github.com/samoslab/nebula/util/hash"
 )
 
 var byte_slice_true = []byte{1}
@@ -74,6 +77,19 @@ func (self *CheckFileExistReq) hash() []byte {
 	} else {
 		hasher.Write(byte_slice_false)
 	}
+	if d := self.Durability; d != nil {
+		hasher.Write(util_bytes.FromUint32(uint32(d.StoreType)))
+		hasher.Write(util_bytes.FromUint32(d.DataPieceCount))
+		hasher.Write(util_bytes.FromUint32(d.VerifyPieceCount))
+		hasher.Write(util_bytes.FromUint32(d.ReplicaCount))
+	}
+	if self.Pack {
+		hasher.Write(byte_slice_true)
+	}
+	if len(self.Encoding) > 0 {
+		hasher.Write([]byte(self.Encoding))
+		hasher.Write(util_bytes.FromUint64(self.DecodedSize))
+	}
 	return hasher.Sum(nil)
 }
 
@@ -161,6 +177,10 @@ func (self *UploadFileDoneReq) hash() []byte {
 	} else {
 		hasher.Write(byte_slice_false)
 	}
+	if len(self.Encoding) > 0 {
+		hasher.Write([]byte(self.Encoding))
+		hasher.Write(util_bytes.FromUint64(self.DecodedSize))
+	}
 	return hasher.Sum(nil)
 }
 
@@ -204,6 +224,58 @@ func (self *ListFilesReq) VerifySign(pubKey *rsa.PublicKey) error {
 	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
 }
 
+func (self *SearchFilesReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	switch v := self.Parent.OneOfPath.(type) {
+	case *FilePath_Path:
+		hasher.Write([]byte(v.Path))
+	case *FilePath_Id:
+		hasher.Write(v.Id)
+	}
+	hasher.Write(util_bytes.FromUint32(self.Parent.SpaceNo))
+	if self.Recursive {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	hasher.Write([]byte(self.NamePattern))
+	if self.Regex {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	hasher.Write([]byte(self.FileType))
+	hasher.Write(util_bytes.FromUint64(self.MinSize))
+	hasher.Write(util_bytes.FromUint64(self.MaxSize))
+	hasher.Write(util_bytes.FromUint64(self.MinModTime))
+	hasher.Write(util_bytes.FromUint64(self.MaxModTime))
+	if self.IncludeFolder {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	hasher.Write(util_bytes.FromUint32(self.PageSize))
+	hasher.Write(util_bytes.FromUint32(self.PageNum))
+	hasher.Write([]byte(self.SortType.String()))
+	if self.AscOrder {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *SearchFilesReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *SearchFilesReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
 func (self *RetrieveFileReq) hash() []byte {
 	hasher := sha256.New()
 	hasher.Write(self.NodeId)
@@ -291,3 +363,397 @@ func (self *SpaceSysFileReq) SignReq(priKey *rsa.PrivateKey) (err error) {
 func (self *SpaceSysFileReq) VerifySign(pubKey *rsa.PublicKey) error {
 	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
 }
+
+func (self *ReportBlocksReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	hasher.Write(util_bytes.FromUint32(self.SpaceNo))
+	hasher.Write(self.FileHash)
+	hasher.Write(util_bytes.FromUint64(self.FileSize))
+	for _, b := range self.Block {
+		hasher.Write(b.Hash)
+		hasher.Write(util_bytes.FromUint32(b.BlockSeq))
+		if b.Checksum {
+			hasher.Write(byte_slice_true)
+		} else {
+			hasher.Write(byte_slice_false)
+		}
+		if b.Corrupt {
+			hasher.Write(byte_slice_true)
+		} else {
+			hasher.Write(byte_slice_false)
+		}
+		hasher.Write(b.StoreNodeId)
+	}
+	for _, pi := range self.Repaired {
+		hasher.Write(pi.Hash)
+		hasher.Write(util_bytes.FromUint32(pi.Size))
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *ReportBlocksReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *ReportBlocksReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *RepairBlocksDoneReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	hasher.Write(util_bytes.FromUint32(self.SpaceNo))
+	hasher.Write(self.FileHash)
+	hasher.Write(util_bytes.FromUint64(self.FileSize))
+	for _, h := range self.BadHash {
+		hasher.Write(h)
+	}
+	for _, b := range self.Block {
+		hasher.Write(b.Hash)
+		hasher.Write(util_bytes.FromUint64(b.Size))
+		hasher.Write(util_bytes.FromUint32(b.BlockSeq))
+		if b.Checksum {
+			hasher.Write(byte_slice_true)
+		} else {
+			hasher.Write(byte_slice_false)
+		}
+		for _, by := range b.StoreNodeId {
+			hasher.Write(by)
+		}
+		hasher.Write(util_bytes.FromUint32(b.ChunkSize))
+		hasher.Write([]byte(b.ParamStr))
+		hasher.Write(b.Generator)
+		hasher.Write(b.PubKey)
+		hasher.Write(b.Random)
+		for _, phi := range b.Phi {
+			hasher.Write(phi)
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *RepairBlocksDoneReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *RepairBlocksDoneReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *MkFoldersReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	switch v := self.Parent.OneOfPath.(type) {
+	case *FilePath_Path:
+		hasher.Write([]byte(v.Path))
+	case *FilePath_Id:
+		hasher.Write(v.Id)
+	}
+	hasher.Write(util_bytes.FromUint32(self.Parent.SpaceNo))
+	for _, p := range self.Path {
+		hasher.Write([]byte(p))
+	}
+	if self.Interactive {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *MkFoldersReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *MkFoldersReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *CheckFilesExistReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	hasher.Write(self.PublicKeyHash)
+	if self.Interactive {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	if self.NewVersion {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	for _, f := range self.File {
+		switch v := f.Parent.OneOfPath.(type) {
+		case *FilePath_Path:
+			hasher.Write([]byte(v.Path))
+		case *FilePath_Id:
+			hasher.Write(v.Id)
+		}
+		hasher.Write(util_bytes.FromUint32(f.Parent.SpaceNo))
+		hasher.Write(f.FileHash)
+		hasher.Write(util_bytes.FromUint64(f.FileSize))
+		hasher.Write([]byte(f.FileType))
+		hasher.Write(f.EncryptKey)
+		hasher.Write([]byte(f.FileName))
+		hasher.Write(util_bytes.FromUint64(f.FileModTime))
+		if len(f.FileData) > 0 {
+			hasher.Write(f.FileData)
+		}
+		if d := f.Durability; d != nil {
+			hasher.Write(util_bytes.FromUint32(uint32(d.StoreType)))
+			hasher.Write(util_bytes.FromUint32(d.DataPieceCount))
+			hasher.Write(util_bytes.FromUint32(d.VerifyPieceCount))
+			hasher.Write(util_bytes.FromUint32(d.ReplicaCount))
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *CheckFilesExistReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *CheckFilesExistReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *UploadFilesDoneReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	hasher.Write(self.PublicKeyHash)
+	if self.Interactive {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	if self.NewVersion {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	for _, f := range self.File {
+		switch v := f.Parent.OneOfPath.(type) {
+		case *FilePath_Path:
+			hasher.Write([]byte(v.Path))
+		case *FilePath_Id:
+			hasher.Write(v.Id)
+		}
+		hasher.Write(util_bytes.FromUint32(f.Parent.SpaceNo))
+		hasher.Write(f.FileHash)
+		hasher.Write(util_bytes.FromUint64(f.FileSize))
+		hasher.Write([]byte(f.FileType))
+		hasher.Write(f.EncryptKey)
+		hasher.Write([]byte(f.FileName))
+		hasher.Write(util_bytes.FromUint64(f.FileModTime))
+		for _, p := range f.Partition {
+			for _, b := range p.Block {
+				hasher.Write(b.Hash)
+				hasher.Write(util_bytes.FromUint64(b.Size))
+				hasher.Write(util_bytes.FromUint32(b.BlockSeq))
+				if b.Checksum {
+					hasher.Write(byte_slice_true)
+				} else {
+					hasher.Write(byte_slice_false)
+				}
+				for _, by := range b.StoreNodeId {
+					hasher.Write(by)
+				}
+				hasher.Write(util_bytes.FromUint32(b.ChunkSize))
+				hasher.Write([]byte(b.ParamStr))
+				hasher.Write(b.Generator)
+				hasher.Write(b.PubKey)
+				hasher.Write(b.Random)
+				for _, phi := range b.Phi {
+					hasher.Write(phi)
+				}
+			}
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *UploadFilesDoneReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *UploadFilesDoneReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *PackFilesDoneReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	hasher.Write(self.PublicKeyHash)
+	hasher.Write(util_bytes.FromUint32(self.SpaceNo))
+	hasher.Write(self.PackHash)
+	hasher.Write(util_bytes.FromUint64(self.PackSize))
+	hasher.Write(self.EncryptKey)
+	for _, p := range self.Partition {
+		for _, b := range p.Block {
+			hasher.Write(b.Hash)
+			hasher.Write(util_bytes.FromUint64(b.Size))
+			hasher.Write(util_bytes.FromUint32(b.BlockSeq))
+			if b.Checksum {
+				hasher.Write(byte_slice_true)
+			} else {
+				hasher.Write(byte_slice_false)
+			}
+			for _, by := range b.StoreNodeId {
+				hasher.Write(by)
+			}
+			hasher.Write(util_bytes.FromUint32(b.ChunkSize))
+			hasher.Write([]byte(b.ParamStr))
+			hasher.Write(b.Generator)
+			hasher.Write(b.PubKey)
+			hasher.Write(b.Random)
+			for _, phi := range b.Phi {
+				hasher.Write(phi)
+			}
+		}
+	}
+	for _, m := range self.Member {
+		switch v := m.Parent.OneOfPath.(type) {
+		case *FilePath_Path:
+			hasher.Write([]byte(v.Path))
+		case *FilePath_Id:
+			hasher.Write(v.Id)
+		}
+		hasher.Write(util_bytes.FromUint32(m.Parent.SpaceNo))
+		hasher.Write([]byte(m.FileName))
+		hasher.Write(m.FileHash)
+		hasher.Write(util_bytes.FromUint64(m.FileSize))
+		hasher.Write([]byte(m.FileType))
+		hasher.Write(util_bytes.FromUint64(m.FileModTime))
+		hasher.Write(util_bytes.FromUint64(m.Offset))
+	}
+	if self.Interactive {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	if self.NewVersion {
+		hasher.Write(byte_slice_true)
+	} else {
+		hasher.Write(byte_slice_false)
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *PackFilesDoneReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *PackFilesDoneReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *Delegation) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(self.DeviceId)
+	hasher.Write(self.DevicePubKey)
+	hasher.Write([]byte(self.Name))
+	hasher.Write(util_bytes.FromUint64(self.Created))
+	return hasher.Sum(nil)
+}
+
+// SignDelegation sign delegation by primary key of account
+func (self *Delegation) SignDelegation(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+// VerifyDelegation verify delegation is signed by primary key of account and device id is of device key
+func (self *Delegation) VerifyDelegation(pubKey *rsa.PublicKey) error {
+	if !bytes.Equal(self.DeviceId, util_hash.Sha1(self.DevicePubKey)) {
+		return errors.New("device id is not match device public key")
+	}
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *EnrollDeviceReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	if d := self.Delegation; d != nil {
+		hasher.Write(d.hash())
+		hasher.Write(d.Sign)
+	}
+	for _, k := range self.SpaceKey {
+		hasher.Write(util_bytes.FromUint32(k.SpaceNo))
+		hasher.Write(k.Key)
+	}
+	return hasher.Sum(nil)
+}
+
+func (self *EnrollDeviceReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *EnrollDeviceReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *ListDevicesReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	return hasher.Sum(nil)
+}
+
+func (self *ListDevicesReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *ListDevicesReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *RevokeDeviceReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	hasher.Write(self.DeviceId)
+	return hasher.Sum(nil)
+}
+
+func (self *RevokeDeviceReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *RevokeDeviceReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
+func (self *DeviceSpaceKeysReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	return hasher.Sum(nil)
+}
+
+func (self *DeviceSpaceKeysReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *DeviceSpaceKeysReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
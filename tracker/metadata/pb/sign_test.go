@@ -8,6 +8,7 @@ import (
 	"time"
 
 	util_hash "github.com/samoslab/nebula/util/hash"
+	util_rsa "github.com/samoslab/nebula/util/rsa"
 )
 
 func TestMkFolderReq(t *testing.T) {
@@ -91,4 +92,301 @@ func TestCheckFileExistReq(t *testing.T) {
 	if req.VerifySign(pubKey) != nil {
 		t.Errorf("failed")
 	}
+	req.Durability = &Durability{StoreType: FileStoreType_ErasureCode, DataPieceCount: 10, VerifyPieceCount: 6}
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("failed")
+	}
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.Pack = true
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("failed")
+	}
+	req.Pack = false
+	req.Encoding, req.DecodedSize = "zstd", 200000
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.DecodedSize = 100000
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestSearchFilesReq(t *testing.T) {
+	req := SearchFilesReq{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		Timestamp:   uint64(time.Now().Unix()),
+		Parent:      &FilePath{SpaceNo: 0, OneOfPath: &FilePath_Path{"/folder1"}},
+		Recursive:   true,
+		NamePattern: "*.pdf",
+		FileType:    "application/pdf",
+		MaxSize:     1024 * 1024,
+		PageSize:    100,
+		PageNum:     1}
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	pubKey := &priKey.PublicKey
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.Regex = true
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestReportBlocksReq(t *testing.T) {
+	req := ReportBlocksReq{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		Timestamp: uint64(time.Now().Unix()),
+		FileHash:  util_hash.Sha1([]byte("test-file")),
+		FileSize:  98234,
+		Block:     []*BadBlock{{Hash: util_hash.Sha1([]byte("block")), BlockSeq: 3, StoreNodeId: []byte("provider")}},
+		Repaired:  []*PieceHashAndSize{{Hash: util_hash.Sha1([]byte("block")), Size: 1024}}}
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	pubKey := &priKey.PublicKey
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.Block[0].Corrupt = true
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestRepairBlocksDoneReq(t *testing.T) {
+	req := RepairBlocksDoneReq{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		Timestamp: uint64(time.Now().Unix()),
+		FileHash:  util_hash.Sha1([]byte("test-file")),
+		FileSize:  98234,
+		BadHash:   [][]byte{util_hash.Sha1([]byte("block"))},
+		Block:     []*StoreBlock{{Hash: util_hash.Sha1([]byte("block")), Size: 1024, BlockSeq: 3, StoreNodeId: [][]byte{[]byte("provider")}}}}
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	pubKey := &priKey.PublicKey
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.Block[0].StoreNodeId = [][]byte{[]byte("other")}
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestMkFoldersReq(t *testing.T) {
+	req := MkFoldersReq{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		Timestamp: uint64(time.Now().Unix()),
+		Parent:    &FilePath{SpaceNo: 0, OneOfPath: &FilePath_Path{"/folder1"}},
+		Path:      []string{"f1", "f1/f2", "f3"}}
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	pubKey := &priKey.PublicKey
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.Path[1] = "f1/f4"
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestCheckFilesExistReq(t *testing.T) {
+	path := &FilePath{SpaceNo: 0, OneOfPath: &FilePath_Path{"/folder1"}}
+	req := CheckFilesExistReq{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		Timestamp: uint64(time.Now().Unix()),
+		File: []*CheckFileItem{
+			{Parent: path, FileHash: util_hash.Sha1([]byte("tiny")), FileSize: 4, FileName: "tiny.txt", FileData: []byte("tiny")},
+			{Parent: path, FileHash: util_hash.Sha1([]byte("big")), FileSize: 98234, FileName: "big.bin"}}}
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	pubKey := &priKey.PublicKey
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.File[0].FileData = []byte("tine")
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestUploadFilesDoneReq(t *testing.T) {
+	req := UploadFilesDoneReq{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		Timestamp: uint64(time.Now().Unix()),
+		File: []*UploadFileDoneItem{{Parent: &FilePath{SpaceNo: 1, OneOfPath: &FilePath_Path{"/folder1"}},
+			FileHash:  util_hash.Sha1([]byte("big")),
+			FileSize:  98234,
+			FileName:  "big.bin",
+			Partition: []*StorePartition{{Block: []*StoreBlock{{Hash: util_hash.Sha1([]byte("block")), Size: 98234, StoreNodeId: [][]byte{[]byte("provider")}}}}}}}}
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	pubKey := &priKey.PublicKey
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.File[0].Parent.SpaceNo = 2
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestPackFilesDoneReq(t *testing.T) {
+	path := &FilePath{SpaceNo: 0, OneOfPath: &FilePath_Path{"/folder1"}}
+	req := PackFilesDoneReq{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		Timestamp: uint64(time.Now().Unix()),
+		PackHash:  util_hash.Sha1([]byte("archive")),
+		PackSize:  20000,
+		Partition: []*StorePartition{{Block: []*StoreBlock{{Hash: util_hash.Sha1([]byte("block")), Size: 5000, StoreNodeId: [][]byte{[]byte("provider")}}}}},
+		Member: []*PackMember{
+			{Parent: path, FileName: "a.txt", FileHash: util_hash.Sha1([]byte("a")), FileSize: 10000},
+			{Parent: path, FileName: "b.txt", FileHash: util_hash.Sha1([]byte("b")), FileSize: 10000, Offset: 10000}}}
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	pubKey := &priKey.PublicKey
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(pubKey) != nil {
+		t.Errorf("failed")
+	}
+	req.Member[1].Offset = 9000
+	if req.VerifySign(pubKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestEnrollDeviceReq(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	deviceKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	devicePubKey := x509.MarshalPKCS1PublicKey(&deviceKey.PublicKey)
+	d := &Delegation{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		DeviceId:     util_hash.Sha1(devicePubKey),
+		DevicePubKey: devicePubKey,
+		Name:         "laptop",
+		Created:      uint64(time.Now().Unix())}
+	if d.SignDelegation(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if d.VerifyDelegation(&priKey.PublicKey) != nil {
+		t.Errorf("failed")
+	}
+	if d.VerifyDelegation(&deviceKey.PublicKey) == nil {
+		t.Errorf("delegation signed by device key should not pass verify")
+	}
+	req := EnrollDeviceReq{NodeId: d.NodeId,
+		Timestamp:  uint64(time.Now().Unix()),
+		Delegation: d,
+		SpaceKey:   []*WrappedSpaceKey{{SpaceNo: 1, Key: []byte("wrapped")}}}
+	if req.SignReq(priKey) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(&priKey.PublicKey) != nil {
+		t.Errorf("failed")
+	}
+	d.DeviceId = util_hash.Sha1([]byte("other device"))
+	if d.VerifyDelegation(&priKey.PublicKey) == nil {
+		t.Errorf("device id not of device key should not pass verify")
+	}
+	if req.VerifySign(&priKey.PublicKey) == nil {
+		t.Errorf("changed request should not pass verify")
+	}
+}
+
+func TestDeviceRetrieveFileReq(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	deviceKey, err := rsa.GenerateKey(rand.Reader, 256*8)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	d := &Delegation{NodeId: util_hash.Sha1([]byte("test-node-id")),
+		DevicePubKey: x509.MarshalPKCS1PublicKey(&deviceKey.PublicKey)}
+	d.DeviceId = util_hash.Sha1(d.DevicePubKey)
+	if d.SignDelegation(priKey) != nil {
+		t.Errorf("failed")
+	}
+	// request of device is signed by device key and verified by the delegated key
+	req := RetrieveFileReq{NodeId: d.NodeId,
+		Timestamp: uint64(time.Now().Unix()),
+		FileHash:  util_hash.Sha1([]byte("file")),
+		FileSize:  4}
+	if req.SignReq(deviceKey) != nil {
+		t.Errorf("failed")
+	}
+	delegated, err := x509.ParsePKCS1PublicKey(d.DevicePubKey)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(delegated) != nil {
+		t.Errorf("failed")
+	}
+	if req.VerifySign(&priKey.PublicKey) == nil {
+		t.Errorf("request signed by device key should not pass verify by account key")
+	}
+	// encryptKey stored by account key is encrypted again by the delegated key for the device
+	password := []byte("password of file")
+	stored, err := util_rsa.EncryptLong(&priKey.PublicKey, password, 256)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	key, err := util_rsa.DecryptLong(priKey, stored, 256)
+	if err != nil {
+		t.Errorf("failed")
+	}
+	rsp := RetrieveFileResp{}
+	if rsp.EncryptKey, err = util_rsa.EncryptLong(delegated, key, 256); err != nil {
+		t.Errorf("failed")
+	}
+	if got, err := util_rsa.DecryptLong(deviceKey, rsp.EncryptKey, 256); err != nil || string(got) != string(password) {
+		t.Errorf("device should decrypt encryptKey by its key")
+	}
+	if _, err := util_rsa.DecryptLong(deviceKey, stored, 256); err == nil {
+		t.Errorf("key encrypted by account key should not be decrypted by device key")
+	}
 }
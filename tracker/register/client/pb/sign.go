@@ -42,6 +42,22 @@ func (self *ResendVerifyCodeReq) VerifySign(pubKey *rsa.PublicKey) error {
 	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
 }
 
+func (self *GetTrackerServerReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	return hasher.Sum(nil)
+}
+
+func (self *GetTrackerServerReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *GetTrackerServerReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
+
 func (self *BuyPackageReq) hash() []byte {
 	hasher := sha256.New()
 	hasher.Write(self.NodeId)
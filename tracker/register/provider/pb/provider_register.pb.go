@@ -32,6 +32,8 @@ It has these top-level messages:
 	SwitchPublicResp
 	PrivateAliveReq
 	PrivateAliveResp
+	UpdateStorageVolumeReq
+	UpdateStorageVolumeResp
 */
 package register_provider_pb
 
@@ -927,6 +929,94 @@ func (m *PrivateAliveResp) String() string            { return proto.CompactText
 func (*PrivateAliveResp) ProtoMessage()               {}
 func (*PrivateAliveResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
+type UpdateStorageVolumeReq struct {
+	Version            uint32   `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	NodeId             []byte   `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Timestamp          uint64   `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Sign               []byte   `protobuf:"bytes,4,opt,name=sign,proto3" json:"sign,omitempty"`
+	MainStorageVolume  uint64   `protobuf:"varint,5,opt,name=mainStorageVolume" json:"mainStorageVolume,omitempty"`
+	ExtraStorageVolume []uint64 `protobuf:"varint,6,rep,packed,name=extraStorageVolume" json:"extraStorageVolume,omitempty"`
+	Total              uint64   `protobuf:"varint,7,opt,name=total" json:"total,omitempty"`
+	MaxFileSize        uint64   `protobuf:"varint,8,opt,name=maxFileSize" json:"maxFileSize,omitempty"`
+}
+
+func (m *UpdateStorageVolumeReq) Reset()                    { *m = UpdateStorageVolumeReq{} }
+func (m *UpdateStorageVolumeReq) String() string            { return proto.CompactTextString(m) }
+func (*UpdateStorageVolumeReq) ProtoMessage()               {}
+func (*UpdateStorageVolumeReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *UpdateStorageVolumeReq) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *UpdateStorageVolumeReq) GetNodeId() []byte {
+	if m != nil {
+		return m.NodeId
+	}
+	return nil
+}
+
+func (m *UpdateStorageVolumeReq) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *UpdateStorageVolumeReq) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+func (m *UpdateStorageVolumeReq) GetMainStorageVolume() uint64 {
+	if m != nil {
+		return m.MainStorageVolume
+	}
+	return 0
+}
+
+func (m *UpdateStorageVolumeReq) GetExtraStorageVolume() []uint64 {
+	if m != nil {
+		return m.ExtraStorageVolume
+	}
+	return nil
+}
+
+func (m *UpdateStorageVolumeReq) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *UpdateStorageVolumeReq) GetMaxFileSize() uint64 {
+	if m != nil {
+		return m.MaxFileSize
+	}
+	return 0
+}
+
+type UpdateStorageVolumeResp struct {
+	Success bool `protobuf:"varint,1,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *UpdateStorageVolumeResp) Reset()                    { *m = UpdateStorageVolumeResp{} }
+func (m *UpdateStorageVolumeResp) String() string            { return proto.CompactTextString(m) }
+func (*UpdateStorageVolumeResp) ProtoMessage()               {}
+func (*UpdateStorageVolumeResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *UpdateStorageVolumeResp) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*GetPublicKeyReq)(nil), "register_provider_pb.GetPublicKeyReq")
 	proto.RegisterType((*GetPublicKeyResp)(nil), "register_provider_pb.GetPublicKeyResp")
@@ -952,6 +1042,8 @@ func init() {
 	proto.RegisterType((*SwitchPublicResp)(nil), "register_provider_pb.SwitchPublicResp")
 	proto.RegisterType((*PrivateAliveReq)(nil), "register_provider_pb.PrivateAliveReq")
 	proto.RegisterType((*PrivateAliveResp)(nil), "register_provider_pb.PrivateAliveResp")
+	proto.RegisterType((*UpdateStorageVolumeReq)(nil), "register_provider_pb.UpdateStorageVolumeReq")
+	proto.RegisterType((*UpdateStorageVolumeResp)(nil), "register_provider_pb.UpdateStorageVolumeResp")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -976,6 +1068,7 @@ type ProviderRegisterServiceClient interface {
 	SwitchPrivate(ctx context.Context, in *SwitchPrivateReq, opts ...grpc.CallOption) (*SwitchPrivateResp, error)
 	SwitchPublic(ctx context.Context, in *SwitchPublicReq, opts ...grpc.CallOption) (*SwitchPublicResp, error)
 	PrivateAlive(ctx context.Context, in *PrivateAliveReq, opts ...grpc.CallOption) (*PrivateAliveResp, error)
+	UpdateStorageVolume(ctx context.Context, in *UpdateStorageVolumeReq, opts ...grpc.CallOption) (*UpdateStorageVolumeResp, error)
 }
 
 type providerRegisterServiceClient struct {
@@ -1085,6 +1178,15 @@ func (c *providerRegisterServiceClient) PrivateAlive(ctx context.Context, in *Pr
 	return out, nil
 }
 
+func (c *providerRegisterServiceClient) UpdateStorageVolume(ctx context.Context, in *UpdateStorageVolumeReq, opts ...grpc.CallOption) (*UpdateStorageVolumeResp, error) {
+	out := new(UpdateStorageVolumeResp)
+	err := grpc.Invoke(ctx, "/register_provider_pb.ProviderRegisterService/UpdateStorageVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ProviderRegisterService service
 
 type ProviderRegisterServiceServer interface {
@@ -1099,6 +1201,7 @@ type ProviderRegisterServiceServer interface {
 	SwitchPrivate(context.Context, *SwitchPrivateReq) (*SwitchPrivateResp, error)
 	SwitchPublic(context.Context, *SwitchPublicReq) (*SwitchPublicResp, error)
 	PrivateAlive(context.Context, *PrivateAliveReq) (*PrivateAliveResp, error)
+	UpdateStorageVolume(context.Context, *UpdateStorageVolumeReq) (*UpdateStorageVolumeResp, error)
 }
 
 func RegisterProviderRegisterServiceServer(s *grpc.Server, srv ProviderRegisterServiceServer) {
@@ -1303,6 +1406,24 @@ func _ProviderRegisterService_PrivateAlive_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProviderRegisterService_UpdateStorageVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStorageVolumeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderRegisterServiceServer).UpdateStorageVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/register_provider_pb.ProviderRegisterService/UpdateStorageVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderRegisterServiceServer).UpdateStorageVolume(ctx, req.(*UpdateStorageVolumeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ProviderRegisterService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "register_provider_pb.ProviderRegisterService",
 	HandlerType: (*ProviderRegisterServiceServer)(nil),
@@ -1351,6 +1472,10 @@ var _ProviderRegisterService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PrivateAlive",
 			Handler:    _ProviderRegisterService_PrivateAlive_Handler,
 		},
+		{
+			MethodName: "UpdateStorageVolume",
+			Handler:    _ProviderRegisterService_UpdateStorageVolume_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "provider_register.proto",
@@ -1359,72 +1484,76 @@ var _ProviderRegisterService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("provider_register.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1065 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4b, 0x6f, 0x23, 0x45,
-	0x10, 0xde, 0x49, 0xc6, 0x8e, 0x5d, 0xb1, 0xd7, 0x4e, 0xdb, 0x64, 0x47, 0x16, 0x02, 0x33, 0x0b,
-	0x8b, 0xf7, 0x41, 0x40, 0xcb, 0x8d, 0xd5, 0x22, 0x65, 0x77, 0x43, 0x88, 0x10, 0x52, 0x34, 0x86,
-	0x70, 0x8c, 0xc6, 0x33, 0x9d, 0xb8, 0xb5, 0xe3, 0x99, 0xde, 0xee, 0xce, 0x64, 0x0d, 0x77, 0xce,
-	0x1c, 0xf9, 0x13, 0xfc, 0x1e, 0xfe, 0x05, 0x37, 0xee, 0xa8, 0x7b, 0x1e, 0x9e, 0x67, 0xe2, 0x48,
-	0x24, 0x37, 0x57, 0xf5, 0x37, 0x5d, 0x8f, 0xae, 0xaa, 0xaf, 0x0c, 0x0f, 0x28, 0x0b, 0x42, 0xe2,
-	0x62, 0x76, 0xca, 0xf0, 0x39, 0xe1, 0x02, 0xb3, 0x3d, 0xca, 0x02, 0x11, 0xa0, 0x61, 0x22, 0x9f,
-	0xa6, 0x08, 0x3a, 0x33, 0x9f, 0x42, 0xef, 0x10, 0x8b, 0xe3, 0x8b, 0x99, 0x47, 0x9c, 0x1f, 0xf0,
-	0xd2, 0xc2, 0xef, 0x90, 0x01, 0x5b, 0x21, 0x66, 0x9c, 0x04, 0xbe, 0xa1, 0x8d, 0xb5, 0x49, 0xd7,
-	0x4a, 0x44, 0xf3, 0x0c, 0xfa, 0x79, 0x30, 0xa7, 0xe8, 0x43, 0x68, 0xd3, 0x44, 0xa1, 0xf0, 0x1d,
-	0x6b, 0xa5, 0x40, 0x9f, 0x42, 0x37, 0x15, 0xbe, 0xb7, 0xf9, 0xdc, 0xd8, 0x50, 0x88, 0xbc, 0x12,
-	0xdd, 0x87, 0x0d, 0x42, 0x8d, 0xcd, 0xb1, 0x36, 0x69, 0x5b, 0x1b, 0x84, 0x9a, 0x7f, 0x37, 0x60,
-	0xdb, 0x8a, 0xbd, 0xbd, 0xd2, 0x23, 0x69, 0x5d, 0x90, 0x05, 0xe6, 0xc2, 0x5e, 0x50, 0x75, 0xb7,
-	0x6e, 0xad, 0x14, 0xf2, 0xd4, 0x0f, 0x5c, 0x7c, 0xe4, 0x1e, 0xf8, 0x8e, 0xba, 0xbe, 0x63, 0xad,
-	0x14, 0xc8, 0x84, 0x4e, 0xea, 0x86, 0x04, 0xe8, 0x0a, 0x90, 0xd3, 0x49, 0xff, 0xb1, 0xef, 0xb0,
-	0x25, 0x15, 0x31, 0xa8, 0x11, 0xf9, 0x9f, 0x53, 0xa2, 0x27, 0xd0, 0xbf, 0xb4, 0x3d, 0x0f, 0x8b,
-	0x7d, 0xd7, 0x65, 0x98, 0x73, 0x09, 0x6c, 0x2a, 0x60, 0x49, 0x2f, 0xad, 0xce, 0x88, 0xe7, 0x1d,
-	0x2c, 0x6c, 0xe2, 0x49, 0xdc, 0x56, 0x64, 0x35, 0xab, 0x43, 0xcf, 0x60, 0x67, 0x61, 0x13, 0x7f,
-	0x2a, 0x02, 0x66, 0x9f, 0xe3, 0x93, 0xc0, 0xbb, 0x58, 0x60, 0xa3, 0xa5, 0xa2, 0x2b, 0x1f, 0xa0,
-	0x31, 0x6c, 0x5f, 0xd0, 0x57, 0xb6, 0xef, 0x5e, 0x12, 0x57, 0xcc, 0x8d, 0xb6, 0xc2, 0x65, 0x55,
-	0x32, 0x0a, 0x37, 0xb8, 0xf4, 0x57, 0x18, 0x50, 0x98, 0xbc, 0x12, 0x4d, 0xa0, 0x27, 0x30, 0x17,
-	0x3f, 0x67, 0xee, 0xda, 0x56, 0xb8, 0xa2, 0x5a, 0xfa, 0x27, 0x55, 0x6f, 0x72, 0x77, 0x76, 0x22,
-	0xff, 0x4a, 0x07, 0x32, 0x62, 0x3b, 0xb4, 0x89, 0x67, 0xcf, 0x88, 0x47, 0xc4, 0xd2, 0xe8, 0x8e,
-	0xb5, 0x89, 0x66, 0xe5, 0x74, 0x08, 0x81, 0x4e, 0x03, 0x26, 0x8c, 0xfb, 0xea, 0x79, 0xd5, 0x6f,
-	0xf9, 0xea, 0xf3, 0x80, 0x0b, 0x99, 0xa4, 0x9e, 0x4a, 0x52, 0x22, 0xca, 0x7c, 0xbb, 0x4b, 0xdf,
-	0x5e, 0x10, 0xe7, 0x4d, 0x20, 0xf3, 0x21, 0x21, 0xfd, 0x28, 0xdf, 0x45, 0x3d, 0xda, 0x03, 0x84,
-	0xdf, 0x0b, 0x66, 0xe7, 0x93, 0xb9, 0x33, 0xde, 0x9c, 0xe8, 0x56, 0xc5, 0x49, 0xb9, 0x62, 0x51,
-	0x55, 0xc5, 0x22, 0xd0, 0x39, 0x39, 0xf7, 0x8d, 0x81, 0x3a, 0x54, 0xbf, 0x65, 0x9c, 0x4e, 0xe0,
-	0x9f, 0x11, 0xb6, 0x38, 0xf2, 0x7d, 0xcc, 0x8c, 0xe1, 0x58, 0x9b, 0xb4, 0xac, 0x9c, 0xce, 0xfc,
-	0x06, 0x3a, 0xab, 0xc2, 0xe6, 0x54, 0xde, 0xe3, 0x04, 0x2e, 0x8e, 0xcb, 0x5a, 0xfd, 0x46, 0xbb,
-	0xd0, 0xc4, 0x8c, 0xfd, 0xc8, 0xcf, 0x55, 0x41, 0xb7, 0xad, 0x58, 0x32, 0xff, 0xd4, 0x00, 0x9d,
-	0x60, 0x46, 0xce, 0x96, 0xaf, 0x92, 0x62, 0xb9, 0xba, 0x39, 0x76, 0xa1, 0x19, 0x55, 0x7b, 0xdc,
-	0x75, 0xb1, 0x94, 0x6f, 0x9a, 0xcd, 0x62, 0xd3, 0x7c, 0x04, 0x10, 0x2a, 0x2b, 0xaf, 0xa5, 0x63,
-	0xba, 0x72, 0x21, 0xa3, 0x49, 0x43, 0x6f, 0xac, 0x42, 0x37, 0xf7, 0x61, 0x50, 0xf2, 0xec, 0x86,
-	0xd1, 0x2d, 0x61, 0x60, 0x61, 0x8e, 0x7d, 0xf7, 0x24, 0x35, 0x75, 0x1b, 0xd1, 0x25, 0xde, 0xeb,
-	0x19, 0xef, 0xbf, 0x82, 0x61, 0xd9, 0x34, 0xa7, 0xd2, 0x36, 0xbf, 0x70, 0x1c, 0xcc, 0xb9, 0xb2,
-	0xdd, 0xb2, 0x12, 0xd1, 0xfc, 0x43, 0x03, 0xb4, 0xef, 0xba, 0x07, 0x99, 0xf2, 0xb9, 0x0d, 0x67,
-	0x77, 0xa1, 0x19, 0x46, 0xf5, 0xaa, 0xab, 0xa3, 0x58, 0xaa, 0x7c, 0x82, 0x2f, 0x61, 0x50, 0xf2,
-	0xe8, 0xca, 0x18, 0x96, 0x30, 0x38, 0xc4, 0xe2, 0x27, 0x66, 0x3b, 0x6f, 0x31, 0x9b, 0x62, 0x16,
-	0x5e, 0x37, 0x6b, 0xff, 0xbf, 0x84, 0x4f, 0x61, 0x58, 0x36, 0xcd, 0x29, 0x7a, 0x01, 0x4d, 0xae,
-	0x24, 0x43, 0x1b, 0x6f, 0x4e, 0xb6, 0x9f, 0x3f, 0xdc, 0xab, 0xe2, 0xac, 0xbd, 0xfc, 0x87, 0xf1,
-	0x27, 0xe6, 0x0b, 0xe8, 0xe6, 0x0e, 0xa4, 0xbf, 0xe9, 0x6d, 0xaa, 0xd2, 0x22, 0x29, 0x9d, 0x35,
-	0x1b, 0xab, 0x59, 0x63, 0xfe, 0x06, 0x1f, 0x1c, 0x62, 0xf1, 0x3a, 0xf0, 0x3c, 0xec, 0x88, 0xe0,
-	0x8e, 0xd3, 0xf1, 0x0b, 0xec, 0x56, 0x19, 0xe7, 0x14, 0xbd, 0x2c, 0x24, 0xe4, 0xb3, 0xea, 0x84,
-	0x14, 0x3f, 0x4d, 0x52, 0xf2, 0x12, 0x7a, 0x85, 0xa3, 0x1b, 0x25, 0xe5, 0x77, 0x4d, 0x4e, 0xab,
-	0x33, 0x86, 0xf9, 0xfc, 0x88, 0xde, 0x52, 0x32, 0x94, 0x51, 0x3d, 0x33, 0xf5, 0xab, 0x6a, 0xfb,
-	0x63, 0xe8, 0x66, 0xfc, 0xe0, 0x34, 0x5e, 0x18, 0xb4, 0x74, 0x61, 0x08, 0xa1, 0x3f, 0xbd, 0x24,
-	0xc2, 0x99, 0x1f, 0x33, 0x12, 0xda, 0xe2, 0xce, 0x26, 0xc7, 0x17, 0xb0, 0x53, 0xb0, 0x7b, 0x65,
-	0xcb, 0xfd, 0xab, 0x41, 0x2f, 0xc6, 0x2b, 0x36, 0xb9, 0x23, 0x37, 0xcb, 0x9c, 0xd6, 0xa8, 0xe1,
-	0x34, 0xf5, 0x1a, 0xcd, 0x6a, 0x0e, 0xde, 0xba, 0x9e, 0x83, 0x5b, 0xd5, 0x1c, 0x6c, 0x7e, 0x9b,
-	0x3e, 0x4f, 0x1c, 0xf6, 0x0d, 0xb9, 0xe1, 0x2f, 0x0d, 0x7a, 0x71, 0x86, 0xf7, 0x3d, 0x12, 0xde,
-	0xd5, 0xf3, 0xa2, 0x21, 0x34, 0x44, 0x20, 0x6c, 0x4f, 0xe5, 0x4b, 0xb7, 0x22, 0x41, 0xee, 0x5b,
-	0x0b, 0xfb, 0xfd, 0x77, 0xc4, 0xc3, 0x53, 0xf2, 0x2b, 0x56, 0xe9, 0xd2, 0xad, 0xac, 0xca, 0x44,
-	0xd0, 0xcf, 0xbb, 0xcb, 0xe9, 0xf3, 0x7f, 0x5a, 0xf0, 0xe0, 0x38, 0xee, 0xd9, 0x64, 0x05, 0x90,
-	0x3d, 0x49, 0x1c, 0x8c, 0x4e, 0xa1, 0x93, 0xdd, 0xab, 0x51, 0x4d, 0x9b, 0x17, 0x16, 0xf5, 0xd1,
-	0xa3, 0x75, 0x60, 0x9c, 0x9a, 0xf7, 0xd0, 0x14, 0x5a, 0x89, 0x4d, 0xf4, 0x49, 0xf5, 0x57, 0x99,
-	0x7d, 0x7b, 0x64, 0x5e, 0x07, 0x51, 0x97, 0xce, 0xa1, 0x57, 0x20, 0x7d, 0x34, 0xa9, 0xfe, 0xb0,
-	0xbc, 0xb5, 0x8c, 0x1e, 0xaf, 0x89, 0x54, 0x96, 0xde, 0x42, 0xbf, 0x48, 0xd0, 0xe8, 0x71, 0x9d,
-	0x8f, 0xa5, 0x1d, 0x62, 0xf4, 0x64, 0x5d, 0x68, 0x12, 0x56, 0x81, 0x48, 0xeb, 0xc2, 0x2a, 0x6f,
-	0x00, 0x75, 0x61, 0x55, 0x30, 0x73, 0x14, 0x56, 0x91, 0x06, 0xeb, 0xc2, 0xaa, 0x60, 0xea, 0xba,
-	0xb0, 0xaa, 0x98, 0xd5, 0xbc, 0x87, 0xde, 0x01, 0x2a, 0x93, 0x0c, 0x7a, 0x5a, 0x7b, 0x47, 0x99,
-	0x0b, 0x47, 0xcf, 0xd6, 0x07, 0x2b, 0x93, 0x27, 0xd0, 0x4e, 0xc7, 0x36, 0xaa, 0xad, 0xa9, 0x15,
-	0xbf, 0x8c, 0x1e, 0x5e, 0x8b, 0x51, 0xf7, 0xce, 0xa0, 0x9b, 0x9b, 0xba, 0xa8, 0xa6, 0x11, 0x8a,
-	0x94, 0x30, 0xfa, 0x7c, 0x2d, 0x9c, 0xb2, 0x71, 0x0a, 0x9d, 0xec, 0xc8, 0xaa, 0x6b, 0xc9, 0xc2,
-	0x34, 0x1f, 0x3d, 0x5a, 0x07, 0x96, 0x18, 0xc8, 0xce, 0x88, 0x3a, 0x03, 0x85, 0xb1, 0x57, 0x67,
-	0xa0, 0x38, 0x6e, 0xcc, 0x7b, 0xb3, 0xa6, 0xfa, 0xdb, 0xff, 0xf5, 0x7f, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x89, 0x74, 0x97, 0x28, 0x11, 0x10, 0x00, 0x00,
+	// 1123 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x2e, 0x6d, 0x4a, 0x96, 0xc6, 0x52, 0xa4, 0xac, 0x5c, 0x9b, 0x20, 0x8a, 0x56, 0x65, 0x5a,
+	0x57, 0x76, 0x12, 0xb7, 0x48, 0x6e, 0x0d, 0x52, 0xc0, 0x49, 0x5c, 0xd7, 0x28, 0x0a, 0x18, 0x54,
+	0xe3, 0x1e, 0x0d, 0x8a, 0x5c, 0x5b, 0x8b, 0x50, 0xe4, 0x66, 0x77, 0x2d, 0x47, 0xed, 0xbd, 0xe7,
+	0xa2, 0xa7, 0xbe, 0x44, 0x9f, 0xa7, 0x2f, 0xd0, 0x57, 0xe8, 0xbd, 0xd8, 0x15, 0x49, 0xf1, 0x57,
+	0x96, 0x0f, 0xf6, 0x4d, 0x33, 0xfb, 0xed, 0x7e, 0x33, 0xb3, 0xb3, 0x33, 0x43, 0xc1, 0x0e, 0x65,
+	0xe1, 0x94, 0x78, 0x98, 0x9d, 0x33, 0x7c, 0x49, 0xb8, 0xc0, 0xec, 0x80, 0xb2, 0x50, 0x84, 0x68,
+	0x2b, 0x96, 0xcf, 0x13, 0x04, 0x1d, 0x59, 0x8f, 0xa1, 0x73, 0x8c, 0xc5, 0xe9, 0xd5, 0xc8, 0x27,
+	0xee, 0x8f, 0x78, 0x66, 0xe3, 0xf7, 0xc8, 0x80, 0x8d, 0x29, 0x66, 0x9c, 0x84, 0x81, 0xa1, 0xf5,
+	0xb5, 0x41, 0xdb, 0x8e, 0x45, 0xeb, 0x02, 0xba, 0x59, 0x30, 0xa7, 0xe8, 0x13, 0x68, 0xd2, 0x58,
+	0xa1, 0xf0, 0x2d, 0x7b, 0xa1, 0x40, 0x5f, 0x40, 0x3b, 0x11, 0x7e, 0x70, 0xf8, 0xd8, 0x58, 0x53,
+	0x88, 0xac, 0x12, 0x3d, 0x80, 0x35, 0x42, 0x8d, 0xf5, 0xbe, 0x36, 0x68, 0xda, 0x6b, 0x84, 0x5a,
+	0xff, 0xd4, 0x60, 0xd3, 0x8e, 0xac, 0x5d, 0x6a, 0x91, 0x64, 0x17, 0x64, 0x82, 0xb9, 0x70, 0x26,
+	0x54, 0x9d, 0xad, 0xdb, 0x0b, 0x85, 0x5c, 0x0d, 0x42, 0x0f, 0x9f, 0x78, 0x47, 0x81, 0xab, 0x8e,
+	0x6f, 0xd9, 0x0b, 0x05, 0xb2, 0xa0, 0x95, 0x98, 0x21, 0x01, 0xba, 0x02, 0x64, 0x74, 0xd2, 0x7e,
+	0x1c, 0xb8, 0x6c, 0x46, 0x45, 0x04, 0xaa, 0xcd, 0xed, 0xcf, 0x28, 0xd1, 0x3e, 0x74, 0xaf, 0x1d,
+	0xdf, 0xc7, 0xe2, 0xd0, 0xf3, 0x18, 0xe6, 0x5c, 0x02, 0xeb, 0x0a, 0x58, 0xd0, 0x4b, 0xd6, 0x11,
+	0xf1, 0xfd, 0xa3, 0x89, 0x43, 0x7c, 0x89, 0xdb, 0x98, 0xb3, 0xa6, 0x75, 0xe8, 0x09, 0x3c, 0x9c,
+	0x38, 0x24, 0x18, 0x8a, 0x90, 0x39, 0x97, 0xf8, 0x2c, 0xf4, 0xaf, 0x26, 0xd8, 0x68, 0x28, 0xef,
+	0x8a, 0x0b, 0xa8, 0x0f, 0x9b, 0x57, 0xf4, 0x95, 0x13, 0x78, 0xd7, 0xc4, 0x13, 0x63, 0xa3, 0xa9,
+	0x70, 0x69, 0x95, 0xf4, 0xc2, 0x0b, 0xaf, 0x83, 0x05, 0x06, 0x14, 0x26, 0xab, 0x44, 0x03, 0xe8,
+	0x08, 0xcc, 0xc5, 0xdb, 0xd4, 0x59, 0x9b, 0x0a, 0x97, 0x57, 0x4b, 0xfb, 0xa4, 0xea, 0x4d, 0xe6,
+	0xcc, 0xd6, 0xdc, 0xbe, 0xc2, 0x82, 0xf4, 0xd8, 0x99, 0x3a, 0xc4, 0x77, 0x46, 0xc4, 0x27, 0x62,
+	0x66, 0xb4, 0xfb, 0xda, 0x40, 0xb3, 0x33, 0x3a, 0x84, 0x40, 0xa7, 0x21, 0x13, 0xc6, 0x03, 0x75,
+	0xbd, 0xea, 0xb7, 0xbc, 0xf5, 0x71, 0xc8, 0x85, 0x0c, 0x52, 0x47, 0x05, 0x29, 0x16, 0x65, 0xbc,
+	0xbd, 0x59, 0xe0, 0x4c, 0x88, 0xfb, 0x26, 0x94, 0xf1, 0x90, 0x90, 0xee, 0x3c, 0xde, 0x79, 0x3d,
+	0x3a, 0x00, 0x84, 0x3f, 0x08, 0xe6, 0x64, 0x83, 0xf9, 0xb0, 0xbf, 0x3e, 0xd0, 0xed, 0x92, 0x95,
+	0x62, 0xc6, 0xa2, 0xb2, 0x8c, 0x45, 0xa0, 0x73, 0x72, 0x19, 0x18, 0x3d, 0xb5, 0xa8, 0x7e, 0x4b,
+	0x3f, 0xdd, 0x30, 0xb8, 0x20, 0x6c, 0x72, 0x12, 0x04, 0x98, 0x19, 0x5b, 0x7d, 0x6d, 0xd0, 0xb0,
+	0x33, 0x3a, 0xeb, 0x5b, 0x68, 0x2d, 0x12, 0x9b, 0x53, 0x79, 0x8e, 0x1b, 0x7a, 0x38, 0x4a, 0x6b,
+	0xf5, 0x1b, 0x6d, 0x43, 0x1d, 0x33, 0xf6, 0x13, 0xbf, 0x54, 0x09, 0xdd, 0xb4, 0x23, 0xc9, 0xfa,
+	0x4b, 0x03, 0x74, 0x86, 0x19, 0xb9, 0x98, 0xbd, 0x8a, 0x93, 0x65, 0xf9, 0xe3, 0xd8, 0x86, 0xfa,
+	0x3c, 0xdb, 0xa3, 0x57, 0x17, 0x49, 0xd9, 0x47, 0xb3, 0x9e, 0x7f, 0x34, 0x9f, 0x02, 0x4c, 0x15,
+	0xcb, 0x6b, 0x69, 0x98, 0xae, 0x4c, 0x48, 0x69, 0x12, 0xd7, 0x6b, 0x0b, 0xd7, 0xad, 0x43, 0xe8,
+	0x15, 0x2c, 0xbb, 0xa5, 0x77, 0x33, 0xe8, 0xd9, 0x98, 0xe3, 0xc0, 0x3b, 0x4b, 0xa8, 0xee, 0xc2,
+	0xbb, 0xd8, 0x7a, 0x3d, 0x65, 0xfd, 0x37, 0xb0, 0x55, 0xa4, 0xe6, 0x54, 0x72, 0xf3, 0x2b, 0xd7,
+	0xc5, 0x9c, 0x2b, 0xee, 0x86, 0x1d, 0x8b, 0xd6, 0x1f, 0x1a, 0xa0, 0x43, 0xcf, 0x3b, 0x4a, 0xa5,
+	0xcf, 0x5d, 0x18, 0xbb, 0x0d, 0xf5, 0xe9, 0x3c, 0x5f, 0x75, 0xb5, 0x14, 0x49, 0xa5, 0x57, 0xf0,
+	0x35, 0xf4, 0x0a, 0x16, 0x2d, 0xf5, 0x61, 0x06, 0xbd, 0x63, 0x2c, 0x7e, 0x66, 0x8e, 0xfb, 0x0e,
+	0xb3, 0x21, 0x66, 0x53, 0xcc, 0xee, 0xc2, 0x87, 0xb2, 0x80, 0x0f, 0x61, 0xab, 0x48, 0xcd, 0x29,
+	0x7a, 0x01, 0x75, 0xae, 0x24, 0x43, 0xeb, 0xaf, 0x0f, 0x36, 0x9f, 0x3d, 0x3a, 0x28, 0xeb, 0x59,
+	0x07, 0xd9, 0x8d, 0xd1, 0x16, 0xeb, 0x05, 0xb4, 0x33, 0x0b, 0xd2, 0xde, 0xe4, 0x34, 0x95, 0x69,
+	0x73, 0x29, 0xa9, 0x35, 0x6b, 0x8b, 0x5a, 0x63, 0xfd, 0x06, 0x1f, 0x1f, 0x63, 0xf1, 0x3a, 0xf4,
+	0x7d, 0xec, 0x8a, 0xf0, 0x9e, 0xc3, 0xf1, 0x0b, 0x6c, 0x97, 0x91, 0x73, 0x8a, 0x5e, 0xe6, 0x02,
+	0xf2, 0x65, 0x79, 0x40, 0xf2, 0x5b, 0xe3, 0x90, 0xbc, 0x84, 0x4e, 0x6e, 0xe9, 0x56, 0x41, 0xf9,
+	0x5d, 0x93, 0xd5, 0xea, 0x82, 0x61, 0x3e, 0x3e, 0xa1, 0x77, 0x14, 0x0c, 0x45, 0xaa, 0x2f, 0x48,
+	0x4b, 0x73, 0xfb, 0x33, 0x68, 0xa7, 0xec, 0xe0, 0x34, 0x1a, 0x18, 0xb4, 0x64, 0x60, 0x98, 0x42,
+	0x77, 0x78, 0x4d, 0x84, 0x3b, 0x3e, 0x65, 0x64, 0xea, 0x88, 0x7b, 0xab, 0x1c, 0x4f, 0xe1, 0x61,
+	0x8e, 0x77, 0xe9, 0x93, 0xfb, 0x4f, 0x83, 0x4e, 0x84, 0x57, 0xdd, 0xe4, 0x9e, 0xcc, 0x2c, 0xf6,
+	0xb4, 0x5a, 0x45, 0x4f, 0x53, 0xb7, 0x51, 0x2f, 0xef, 0xc1, 0x1b, 0x37, 0xf7, 0xe0, 0x46, 0x79,
+	0x0f, 0xb6, 0xbe, 0x4b, 0xae, 0x27, 0x72, 0xfb, 0x96, 0xbd, 0xe1, 0x6f, 0x0d, 0x3a, 0x51, 0x84,
+	0x0f, 0x7d, 0x32, 0xbd, 0xaf, 0xeb, 0x45, 0x5b, 0x50, 0x13, 0xa1, 0x70, 0x7c, 0x15, 0x2f, 0xdd,
+	0x9e, 0x0b, 0x72, 0xde, 0x9a, 0x38, 0x1f, 0xbe, 0x27, 0x3e, 0x1e, 0x92, 0x5f, 0xb1, 0x0a, 0x97,
+	0x6e, 0xa7, 0x55, 0x16, 0x82, 0x6e, 0xd6, 0x5c, 0x4e, 0xad, 0x3f, 0xd7, 0x60, 0xfb, 0x2d, 0xf5,
+	0x1c, 0x81, 0x33, 0xf3, 0xc6, 0x7d, 0xb9, 0x52, 0x3a, 0x52, 0xd6, 0xaa, 0x46, 0xca, 0xf2, 0xa1,
+	0xa9, 0x5e, 0x39, 0x34, 0x25, 0x81, 0xda, 0x58, 0x12, 0xa8, 0x46, 0x31, 0x50, 0xcf, 0x61, 0xa7,
+	0x34, 0x26, 0xcb, 0x5e, 0xd1, 0xb3, 0x7f, 0x9b, 0xb0, 0x73, 0x1a, 0x55, 0xbf, 0x78, 0x98, 0x92,
+	0xd5, 0x8d, 0xb8, 0x18, 0x9d, 0x43, 0x2b, 0xfd, 0x85, 0x82, 0x2a, 0x0a, 0x66, 0xee, 0x93, 0xc7,
+	0xdc, 0x5d, 0x05, 0xc6, 0xa9, 0xf5, 0x11, 0x1a, 0x42, 0x23, 0xe6, 0x44, 0x9f, 0x97, 0xef, 0x4a,
+	0x7d, 0xb9, 0x98, 0xd6, 0x4d, 0x10, 0x75, 0xe8, 0x18, 0x3a, 0xb9, 0xf1, 0x09, 0x0d, 0xca, 0x37,
+	0x16, 0xe7, 0x3f, 0x73, 0x6f, 0x45, 0xa4, 0x62, 0x7a, 0x07, 0xdd, 0xfc, 0xa8, 0x83, 0xf6, 0xaa,
+	0x6c, 0x2c, 0x4c, 0x63, 0xe6, 0xfe, 0xaa, 0xd0, 0xd8, 0xad, 0xdc, 0x48, 0x52, 0xe5, 0x56, 0x71,
+	0x96, 0x32, 0xf7, 0x56, 0x44, 0xc6, 0x6e, 0xe5, 0x07, 0x8a, 0x2a, 0xb7, 0x4a, 0x66, 0x1e, 0x73,
+	0x7f, 0x55, 0xa8, 0x22, 0x7b, 0x0f, 0xa8, 0xd8, 0xae, 0xd1, 0xe3, 0xca, 0x33, 0x8a, 0x53, 0x85,
+	0xf9, 0x64, 0x75, 0xb0, 0xa2, 0x3c, 0x83, 0x66, 0xd2, 0x00, 0x51, 0x65, 0x4e, 0x2d, 0x3a, 0xb5,
+	0xf9, 0xe8, 0x46, 0x8c, 0x3a, 0x77, 0x04, 0xed, 0x4c, 0xff, 0x42, 0x15, 0x0f, 0x21, 0xdf, 0x5c,
+	0xcd, 0xaf, 0x56, 0xc2, 0x29, 0x8e, 0x73, 0x68, 0xa5, 0x8b, 0x7f, 0xd5, 0x93, 0xcc, 0xf5, 0x45,
+	0x73, 0x77, 0x15, 0x58, 0x4c, 0x90, 0xae, 0xb6, 0x55, 0x04, 0xb9, 0x06, 0x62, 0xee, 0xae, 0x02,
+	0x53, 0x04, 0x02, 0x7a, 0x25, 0x55, 0x0a, 0x55, 0x5c, 0x62, 0x79, 0x91, 0x37, 0x9f, 0xde, 0x02,
+	0x2d, 0x59, 0x47, 0x75, 0xf5, 0xb7, 0xcd, 0xf3, 0xff, 0x07, 0x00, 0x10, 0x23, 0x0b, 0x46, 0xd1,
+	0x11, 0x00, 0x00,
 }
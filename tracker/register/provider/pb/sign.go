@@ -197,3 +197,26 @@ func (self *PrivateAliveReq) SignReq(priKey *rsa.PrivateKey) (err error) {
 func (self *PrivateAliveReq) VerifySign(pubKey *rsa.PublicKey) error {
 	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
 }
+
+func (self *UpdateStorageVolumeReq) hash() []byte {
+	hasher := sha256.New()
+	hasher.Write(util_bytes.FromUint32(self.Version))
+	hasher.Write(self.NodeId)
+	hasher.Write(util_bytes.FromUint64(self.Timestamp))
+	hasher.Write(util_bytes.FromUint64(self.MainStorageVolume))
+	for _, val := range self.ExtraStorageVolume {
+		hasher.Write(util_bytes.FromUint64(val))
+	}
+	hasher.Write(util_bytes.FromUint64(self.Total))
+	hasher.Write(util_bytes.FromUint64(self.MaxFileSize))
+	return hasher.Sum(nil)
+}
+
+func (self *UpdateStorageVolumeReq) SignReq(priKey *rsa.PrivateKey) (err error) {
+	self.Sign, err = rsa.SignPKCS1v15(rand.Reader, priKey, crypto.SHA256, self.hash())
+	return
+}
+
+func (self *UpdateStorageVolumeReq) VerifySign(pubKey *rsa.PublicKey) error {
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, self.hash(), self.Sign)
+}
@@ -389,6 +389,7 @@ type GetProveInfoResp struct {
 	ProofId   []byte            `protobuf:"bytes,5,opt,name=proofId,proto3" json:"proofId,omitempty"`
 	ChunkSize uint32            `protobuf:"varint,1,opt,name=chunkSize" json:"chunkSize,omitempty"`
 	ChunkSeq  map[uint32][]byte `protobuf:"bytes,2,rep,name=chunkSeq" json:"chunkSeq,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Version   uint32            `protobuf:"varint,3,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *GetProveInfoResp) Reset()                    { *m = GetProveInfoResp{} }
@@ -417,6 +418,13 @@ func (m *GetProveInfoResp) GetChunkSeq() map[uint32][]byte {
 	return nil
 }
 
+func (m *GetProveInfoResp) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type FinishProveReq struct {
 	NodeId       []byte `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
 	Timestamp    uint64 `protobuf:"varint,2,opt,name=timestamp" json:"timestamp,omitempty"`
@@ -949,63 +957,63 @@ func init() { proto.RegisterFile("provider_task.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
 	// 933 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x5f, 0x6f, 0x23, 0x35,
-	0x10, 0xef, 0x26, 0x9b, 0x3f, 0x3b, 0x4d, 0xda, 0x9c, 0xdb, 0xbb, 0x5b, 0xc2, 0x09, 0x05, 0x4b,
-	0x88, 0x80, 0x50, 0x1f, 0x8a, 0x90, 0xe0, 0x78, 0x40, 0x77, 0x25, 0x1c, 0x95, 0x8e, 0x5e, 0xe5,
-	0x56, 0xf7, 0x08, 0xda, 0x26, 0xce, 0xc5, 0x4a, 0xb2, 0xde, 0xac, 0x9d, 0x88, 0xf4, 0xcb, 0xf0,
-	0x21, 0xf8, 0x10, 0xbc, 0xf0, 0xc2, 0x37, 0xe0, 0xa3, 0x20, 0x8f, 0xbd, 0x9b, 0xdd, 0x90, 0x80,
-	0x74, 0x0f, 0x7d, 0x9b, 0xdf, 0xd8, 0x3b, 0xe3, 0xdf, 0x78, 0xe6, 0xe7, 0x85, 0x93, 0x24, 0x95,
-	0x2b, 0x31, 0xe2, 0xe9, 0x2f, 0x3a, 0x52, 0xd3, 0xb3, 0x24, 0x95, 0x5a, 0x92, 0x86, 0xb5, 0xef,
-	0xa8, 0x82, 0xc3, 0xdb, 0x48, 0x4d, 0x5f, 0x0b, 0xa5, 0x19, 0x5f, 0x90, 0x27, 0x50, 0x8f, 0xe5,
-	0x88, 0x5f, 0x8e, 0x42, 0xaf, 0xe7, 0xf5, 0x5b, 0xcc, 0x21, 0xf2, 0x0c, 0x02, 0x2d, 0xe6, 0x5c,
-	0xe9, 0x68, 0x9e, 0x84, 0x95, 0x9e, 0xd7, 0xf7, 0xd9, 0xc6, 0x41, 0xba, 0xd0, 0x1c, 0x46, 0x9a,
-	0xbf, 0x93, 0xe9, 0x3a, 0xac, 0xf6, 0xbc, 0x7e, 0x9b, 0xe5, 0x98, 0x10, 0xf0, 0x95, 0x78, 0x17,
-	0x87, 0x3e, 0xc6, 0x43, 0x9b, 0x0e, 0xa1, 0xb5, 0x49, 0xaa, 0x12, 0xf2, 0x31, 0xf8, 0xe6, 0x3c,
-	0xa1, 0xd7, 0xab, 0xf6, 0x0f, 0xcf, 0xdb, 0x67, 0xee, 0x70, 0x67, 0x66, 0x13, 0xc3, 0xa5, 0xff,
-	0x39, 0x00, 0x01, 0x3f, 0x5a, 0xea, 0x09, 0x26, 0x6f, 0x31, 0xb4, 0xe9, 0x6f, 0x15, 0xf0, 0x4d,
-	0x00, 0x72, 0x04, 0x15, 0x91, 0xf1, 0xa9, 0x88, 0x11, 0x9e, 0x36, 0xe5, 0x91, 0x16, 0x32, 0x76,
-	0x91, 0x72, 0x4c, 0x3e, 0x01, 0x5f, 0xaf, 0x13, 0x8e, 0x81, 0x8e, 0xce, 0x1f, 0x95, 0x4e, 0x72,
-	0xbb, 0x4e, 0x38, 0xc3, 0x65, 0x53, 0xa6, 0xb1, 0x98, 0x99, 0x32, 0x59, 0x5a, 0x0e, 0x99, 0xd0,
-	0xc6, 0xfa, 0x31, 0x52, 0x93, 0xb0, 0x86, 0x2b, 0x39, 0xce, 0xd6, 0x6e, 0xc4, 0x3d, 0x0f, 0xeb,
-	0x36, 0x6d, 0x86, 0x0d, 0xbb, 0xbb, 0x99, 0x1c, 0x4e, 0xf1, 0xc3, 0x06, 0x7e, 0xb8, 0x71, 0xe4,
-	0xab, 0xf8, 0x69, 0xd3, 0x72, 0xcf, 0x1d, 0xe4, 0x23, 0x00, 0x99, 0x24, 0x52, 0x09, 0x6d, 0xce,
-	0x13, 0xf4, 0xaa, 0xfd, 0x80, 0x15, 0x3c, 0x24, 0x84, 0x46, 0x92, 0x4a, 0x39, 0xbe, 0x1c, 0x85,
-	0x80, 0x91, 0x33, 0x48, 0x57, 0x40, 0x5e, 0x71, 0xfd, 0x26, 0xdb, 0x1a, 0x8f, 0xe5, 0xfb, 0xb7,
-	0x40, 0x76, 0xcd, 0xd5, 0xcd, 0x35, 0x9b, 0x48, 0xa6, 0x7e, 0x9b, 0x2a, 0x59, 0x44, 0x7f, 0x86,
-	0x93, 0x7f, 0xe5, 0x55, 0x49, 0x39, 0x81, 0xb7, 0x9d, 0xe0, 0x33, 0xf0, 0x45, 0x3c, 0x96, 0x61,
-	0x05, 0x7b, 0xe4, 0x71, 0x7e, 0x33, 0xa5, 0x30, 0xb8, 0x85, 0xde, 0x43, 0xab, 0xe8, 0xdd, 0x62,
-	0x14, 0xe4, 0x8c, 0x08, 0xf8, 0x13, 0xa9, 0x34, 0x92, 0x09, 0x18, 0xda, 0xc6, 0x97, 0xc8, 0x54,
-	0xbb, 0x36, 0x46, 0x3b, 0xef, 0x2e, 0x7f, 0xd3, 0x5d, 0xc8, 0x4d, 0x0c, 0xa7, 0x5c, 0xe3, 0x3d,
-	0x07, 0xcc, 0x21, 0xaa, 0xe0, 0xf8, 0x15, 0xd7, 0xd7, 0xa9, 0x5c, 0x3d, 0x60, 0x41, 0xff, 0xf4,
-	0xa0, 0x53, 0xce, 0xaa, 0x92, 0xe2, 0xbd, 0xd7, 0x4a, 0xf7, 0x6e, 0x12, 0x0f, 0x27, 0xcb, 0xd8,
-	0xf6, 0x93, 0x87, 0x44, 0x37, 0x0e, 0x72, 0x01, 0x4d, 0x0b, 0xf8, 0xc2, 0x15, 0xfb, 0xd3, 0xbc,
-	0xd8, 0xdb, 0x49, 0xce, 0x2e, 0xdc, 0xce, 0x41, 0xac, 0xd3, 0x35, 0xcb, 0x3f, 0xec, 0x7e, 0x0b,
-	0xed, 0xd2, 0x12, 0xe9, 0x40, 0x75, 0xca, 0xd7, 0x2e, 0x9b, 0x31, 0xc9, 0x29, 0xd4, 0x56, 0xd1,
-	0x6c, 0xc9, 0x91, 0x7a, 0x8b, 0x59, 0xf0, 0xbc, 0xf2, 0xb5, 0x47, 0xff, 0xf6, 0xe0, 0xe8, 0x07,
-	0x11, 0x0b, 0x35, 0xc1, 0x64, 0x0f, 0x52, 0xc3, 0xff, 0x28, 0x17, 0x85, 0xd6, 0x18, 0x4f, 0xc3,
-	0x47, 0xb7, 0x62, 0x9e, 0x0d, 0x6f, 0xc9, 0x67, 0xa2, 0xa6, 0x5c, 0x2d, 0x67, 0xda, 0x4d, 0xaf,
-	0x43, 0xd6, 0x3f, 0x8f, 0xd2, 0x29, 0xce, 0x6d, 0xc0, 0x1c, 0xa2, 0x8f, 0xe0, 0xb8, 0xc4, 0x50,
-	0x25, 0xf4, 0x0f, 0x0f, 0xda, 0xd6, 0x87, 0xb2, 0xf7, 0x20, 0xa4, 0xb7, 0xa9, 0xd5, 0x76, 0x50,
-	0x0b, 0xa1, 0xa1, 0x96, 0xc3, 0x21, 0x57, 0x0a, 0x99, 0x37, 0x59, 0x06, 0x0b, 0xe4, 0x1a, 0x25,
-	0x72, 0x9d, 0xec, 0xfa, 0x2c, 0x11, 0x95, 0xd0, 0xaf, 0xe0, 0xd0, 0x28, 0xd9, 0x8b, 0x78, 0x84,
-	0x2d, 0x66, 0x06, 0xcf, 0x28, 0x9d, 0xa5, 0x85, 0xb6, 0x3d, 0xf6, 0x3d, 0x77, 0x7c, 0xd0, 0xa6,
-	0x7f, 0x79, 0x70, 0xfc, 0x96, 0xa7, 0x62, 0xbc, 0x7e, 0x69, 0xe4, 0x4e, 0x99, 0xa2, 0x84, 0xd0,
-	0x58, 0xf1, 0x54, 0x19, 0xf1, 0xb6, 0xcd, 0x94, 0xc1, 0x42, 0xb9, 0x2a, 0xfb, 0xcb, 0x55, 0xdd,
-	0x57, 0xae, 0xc2, 0xfb, 0x64, 0x5a, 0x73, 0xb1, 0xe4, 0xe9, 0x1a, 0xeb, 0xd1, 0x64, 0x16, 0x18,
-	0x01, 0x4f, 0x52, 0xbe, 0x12, 0x72, 0xa9, 0x32, 0x01, 0xcf, 0x30, 0xe9, 0x83, 0x3f, 0x17, 0x4a,
-	0x85, 0x0d, 0x1c, 0x98, 0xd3, 0x7c, 0x60, 0x0a, 0xac, 0x19, 0xee, 0xa0, 0x31, 0x74, 0xca, 0x94,
-	0x14, 0x9e, 0x61, 0x16, 0x29, 0xed, 0x44, 0x0f, 0x6d, 0xf2, 0x05, 0xd4, 0x51, 0xe3, 0x95, 0x1b,
-	0xc2, 0xdd, 0x31, 0xdd, 0x1e, 0x53, 0x95, 0x49, 0xa4, 0xae, 0xf8, 0xaf, 0x56, 0xb9, 0x9a, 0x2c,
-	0x83, 0x9f, 0x3f, 0x87, 0x66, 0xf6, 0x78, 0x91, 0x36, 0x04, 0x6c, 0x70, 0xfd, 0xfa, 0xf2, 0xe2,
-	0xc5, 0xed, 0xa0, 0x73, 0x40, 0x9a, 0xe0, 0xdf, 0x0c, 0xae, 0xbe, 0xef, 0x78, 0x04, 0xa0, 0xce,
-	0x06, 0x3f, 0xbd, 0x79, 0x3b, 0xe8, 0x54, 0x48, 0x00, 0xb5, 0x6b, 0x66, 0xcc, 0xea, 0xf9, 0xef,
-	0x55, 0x38, 0xb9, 0x76, 0x7f, 0x0f, 0x26, 0xc8, 0x0d, 0x4f, 0x57, 0x62, 0xc8, 0xc9, 0x37, 0x36,
-	0xa6, 0x79, 0xbf, 0xc9, 0x69, 0xe9, 0x8d, 0x74, 0xff, 0x11, 0xdd, 0xc7, 0x3b, 0xbc, 0x2a, 0xa1,
-	0x07, 0xe4, 0x0a, 0xf5, 0xb1, 0x24, 0xcf, 0x1f, 0x16, 0xe5, 0x65, 0xeb, 0x35, 0xea, 0x3e, 0xdb,
-	0xbf, 0x88, 0xf1, 0x06, 0xd0, 0x2a, 0x8a, 0x12, 0x09, 0xf7, 0x68, 0xd5, 0xa2, 0xfb, 0xc1, 0x5e,
-	0x15, 0xa3, 0x07, 0xe4, 0x25, 0x1c, 0x16, 0xe6, 0x91, 0x3c, 0xcd, 0xf7, 0x96, 0x75, 0xa8, 0x1b,
-	0xee, 0x5e, 0xc0, 0x18, 0xdf, 0x01, 0x6c, 0xda, 0x9e, 0x3c, 0xd9, 0xda, 0xe9, 0x86, 0xba, 0xfb,
-	0x74, 0xa7, 0x3f, 0xe3, 0x52, 0x6c, 0x8d, 0x02, 0x97, 0xad, 0x21, 0x28, 0x70, 0xd9, 0xee, 0x25,
-	0x7a, 0x70, 0x57, 0xc7, 0x5f, 0xbc, 0x2f, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x74, 0x91, 0xc2,
-	0x7b, 0xf9, 0x09, 0x00, 0x00,
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x51, 0x6f, 0xe3, 0x44,
+	0x10, 0xae, 0x1d, 0x27, 0xb1, 0xa7, 0x49, 0x9b, 0xdb, 0xf6, 0xee, 0x7c, 0xe1, 0x84, 0x82, 0x25,
+	0x44, 0x40, 0xa8, 0x0f, 0x45, 0x48, 0x70, 0x3c, 0xa0, 0xbb, 0x12, 0x8e, 0x4a, 0x47, 0xaf, 0xda,
+	0x56, 0xf7, 0x08, 0x72, 0x93, 0xcd, 0x65, 0x95, 0xc4, 0xeb, 0xee, 0x6e, 0x22, 0xd2, 0x3f, 0xc3,
+	0x8f, 0xe0, 0x47, 0xf0, 0xcc, 0x3f, 0xe0, 0x89, 0xdf, 0x81, 0x76, 0xbc, 0x76, 0xec, 0x90, 0x14,
+	0xe9, 0x1e, 0xfa, 0x36, 0xdf, 0xec, 0x78, 0x66, 0xbe, 0xd9, 0x99, 0x59, 0xc3, 0x51, 0x2a, 0xc5,
+	0x92, 0x8f, 0x98, 0xfc, 0x55, 0xc7, 0x6a, 0x7a, 0x92, 0x4a, 0xa1, 0x05, 0x69, 0x66, 0xf2, 0x4d,
+	0xa4, 0x60, 0xff, 0x3a, 0x56, 0xd3, 0x37, 0x5c, 0x69, 0xca, 0x6e, 0xc9, 0x13, 0x68, 0x24, 0x62,
+	0xc4, 0xce, 0x47, 0xa1, 0xd3, 0x73, 0xfa, 0x2d, 0x6a, 0x11, 0x79, 0x0e, 0x81, 0xe6, 0x73, 0xa6,
+	0x74, 0x3c, 0x4f, 0x43, 0xb7, 0xe7, 0xf4, 0x3d, 0xba, 0x56, 0x90, 0x2e, 0xf8, 0xc3, 0x58, 0xb3,
+	0xf7, 0x42, 0xae, 0xc2, 0x5a, 0xcf, 0xe9, 0xb7, 0x69, 0x81, 0x09, 0x01, 0x4f, 0xf1, 0xf7, 0x49,
+	0xe8, 0xa1, 0x3f, 0x94, 0xa3, 0x21, 0xb4, 0xd6, 0x41, 0x55, 0x4a, 0x3e, 0x01, 0xcf, 0xe4, 0x13,
+	0x3a, 0xbd, 0x5a, 0x7f, 0xff, 0xb4, 0x7d, 0x62, 0x93, 0x3b, 0x31, 0x46, 0x14, 0x8f, 0xfe, 0x27,
+	0x01, 0x02, 0x5e, 0xbc, 0xd0, 0x13, 0x0c, 0xde, 0xa2, 0x28, 0x47, 0xbf, 0xbb, 0xe0, 0x19, 0x07,
+	0xe4, 0x00, 0x5c, 0x9e, 0xf3, 0x71, 0xf9, 0x08, 0xb3, 0x95, 0x2c, 0xd6, 0x5c, 0x24, 0xd6, 0x53,
+	0x81, 0xc9, 0xa7, 0xe0, 0xe9, 0x55, 0xca, 0xd0, 0xd1, 0xc1, 0xe9, 0xa3, 0x4a, 0x26, 0xd7, 0xab,
+	0x94, 0x51, 0x3c, 0x36, 0x65, 0x1a, 0xf3, 0x99, 0x29, 0x53, 0x46, 0xcb, 0x22, 0xe3, 0xda, 0x48,
+	0x3f, 0xc5, 0x6a, 0x12, 0xd6, 0xf1, 0xa4, 0xc0, 0xf9, 0xd9, 0x15, 0xbf, 0x63, 0x61, 0x23, 0x0b,
+	0x9b, 0x63, 0xc3, 0xee, 0x66, 0x26, 0x86, 0x53, 0xfc, 0xb0, 0x89, 0x1f, 0xae, 0x15, 0xc5, 0x29,
+	0x7e, 0xea, 0x67, 0xdc, 0x0b, 0x05, 0xf9, 0x18, 0x40, 0xa4, 0xa9, 0x50, 0x5c, 0x9b, 0x7c, 0x82,
+	0x5e, 0xad, 0x1f, 0xd0, 0x92, 0x86, 0x84, 0xd0, 0x4c, 0xa5, 0x10, 0xe3, 0xf3, 0x51, 0x08, 0xe8,
+	0x39, 0x87, 0xd1, 0x12, 0xc8, 0x6b, 0xa6, 0xdf, 0xe6, 0xa6, 0xc9, 0x58, 0x7c, 0x78, 0x0b, 0xe4,
+	0xd7, 0x5c, 0x5b, 0x5f, 0xb3, 0xf1, 0x64, 0xea, 0xb7, 0xae, 0x52, 0x86, 0xa2, 0x5f, 0xe0, 0xe8,
+	0x3f, 0x71, 0x55, 0x5a, 0x0d, 0xe0, 0x6c, 0x06, 0xf8, 0x1c, 0x3c, 0x9e, 0x8c, 0x45, 0xe8, 0x62,
+	0x8f, 0x3c, 0x2e, 0x6e, 0xa6, 0xe2, 0x06, 0x4d, 0xa2, 0x3b, 0x68, 0x95, 0xb5, 0x1b, 0x8c, 0x82,
+	0x82, 0x11, 0x01, 0x6f, 0x22, 0x94, 0x46, 0x32, 0x01, 0x45, 0xd9, 0xe8, 0x52, 0x21, 0xb5, 0x6d,
+	0x63, 0x94, 0x8b, 0xee, 0xf2, 0xd6, 0xdd, 0x85, 0xdc, 0xf8, 0x70, 0xca, 0x34, 0xde, 0x73, 0x40,
+	0x2d, 0x8a, 0x14, 0x1c, 0xbe, 0x66, 0xfa, 0x52, 0x8a, 0xe5, 0x03, 0x16, 0xf4, 0x1f, 0x07, 0x3a,
+	0xd5, 0xa8, 0x2a, 0x2d, 0xdf, 0x7b, 0xbd, 0x72, 0xef, 0x26, 0xf0, 0x70, 0xb2, 0x48, 0xb2, 0x7e,
+	0x72, 0x90, 0xe8, 0x5a, 0x41, 0xce, 0xc0, 0xcf, 0x00, 0xbb, 0xb5, 0xc5, 0xfe, 0xac, 0x28, 0xf6,
+	0x66, 0x90, 0x93, 0x33, 0x6b, 0x39, 0x48, 0xb4, 0x5c, 0xd1, 0xe2, 0x43, 0x13, 0x7c, 0xc9, 0xa4,
+	0xe2, 0x22, 0x23, 0xd0, 0xa6, 0x39, 0xec, 0x7e, 0x07, 0xed, 0xca, 0x47, 0xa4, 0x03, 0xb5, 0x29,
+	0x5b, 0xd9, 0x3c, 0x8c, 0x48, 0x8e, 0xa1, 0xbe, 0x8c, 0x67, 0x0b, 0x86, 0x45, 0x69, 0xd1, 0x0c,
+	0xbc, 0x70, 0xbf, 0x71, 0xa2, 0xbf, 0x1d, 0x38, 0xf8, 0x91, 0x27, 0x5c, 0x4d, 0x30, 0x8d, 0x07,
+	0xa9, 0xee, 0x3d, 0x85, 0x8c, 0xa0, 0x35, 0xc6, 0x6c, 0xd8, 0xe8, 0x9a, 0xcf, 0xf3, 0xb1, 0xae,
+	0xe8, 0x8c, 0x57, 0xc9, 0xd4, 0x62, 0xa6, 0xed, 0x5c, 0x5b, 0x94, 0xe9, 0xe7, 0xb1, 0x9c, 0xe2,
+	0x44, 0x07, 0xd4, 0xa2, 0xe8, 0x11, 0x1c, 0x56, 0x18, 0xaa, 0x34, 0xfa, 0xd3, 0x81, 0x76, 0xa6,
+	0xc3, 0x85, 0xf8, 0x20, 0xa4, 0x37, 0xa9, 0xd5, 0xb7, 0x50, 0x0b, 0xa1, 0xa9, 0x16, 0xc3, 0x21,
+	0x53, 0x0a, 0x99, 0xfb, 0x34, 0x87, 0x25, 0x72, 0xcd, 0x0a, 0xb9, 0x0e, 0x1c, 0x94, 0x89, 0xa8,
+	0x34, 0xfa, 0x1a, 0xf6, 0xcd, 0x8e, 0x7b, 0x99, 0x8c, 0xb0, 0xf9, 0xcc, 0x48, 0x9a, 0x1d, 0x98,
+	0xd1, 0x42, 0x39, 0x4b, 0xfb, 0x8e, 0x59, 0x3e, 0x28, 0x47, 0x7f, 0x39, 0x70, 0xf8, 0x8e, 0x49,
+	0x3e, 0x5e, 0xbd, 0x32, 0x8b, 0x50, 0xd1, 0x6a, 0xcf, 0x39, 0x95, 0x9e, 0x2b, 0x95, 0xcb, 0xdd,
+	0x5d, 0xae, 0xda, 0xae, 0x72, 0x95, 0x5e, 0x2e, 0xd3, 0x9a, 0xb7, 0x0b, 0x26, 0x57, 0x58, 0x0f,
+	0x9f, 0x66, 0xc0, 0xac, 0xf6, 0x54, 0xb2, 0x25, 0x17, 0x0b, 0x95, 0xaf, 0xf6, 0x1c, 0x93, 0x3e,
+	0x78, 0x73, 0xae, 0x54, 0xd8, 0xc4, 0x51, 0x3a, 0x2e, 0x46, 0xa9, 0xc4, 0x9a, 0xa2, 0x45, 0x94,
+	0x40, 0xa7, 0x4a, 0x49, 0x61, 0x0e, 0xb3, 0x58, 0x69, 0xbb, 0x0e, 0x51, 0x26, 0x5f, 0x42, 0x03,
+	0xb7, 0xbf, 0x0a, 0xdd, 0x7b, 0x7c, 0x5a, 0x1b, 0x53, 0x95, 0x49, 0xac, 0x2e, 0xd8, 0x6f, 0xd9,
+	0x4e, 0xf3, 0x69, 0x0e, 0xbf, 0x78, 0x01, 0x7e, 0xfe, 0xac, 0x91, 0x36, 0x04, 0x74, 0x70, 0xf9,
+	0xe6, 0xfc, 0xec, 0xe5, 0xf5, 0xa0, 0xb3, 0x47, 0x7c, 0xf0, 0xae, 0x06, 0x17, 0x3f, 0x74, 0x1c,
+	0x02, 0xd0, 0xa0, 0x83, 0x9f, 0xdf, 0xbe, 0x1b, 0x74, 0x5c, 0x12, 0x40, 0xfd, 0x92, 0x1a, 0xb1,
+	0x76, 0xfa, 0x47, 0x0d, 0x8e, 0x2e, 0xed, 0x7f, 0x85, 0x71, 0x72, 0xc5, 0xe4, 0x92, 0x0f, 0x19,
+	0xf9, 0x16, 0xfc, 0xfc, 0x65, 0x27, 0xc7, 0x95, 0xd7, 0xd3, 0xfe, 0x61, 0x74, 0x1f, 0x6f, 0xd1,
+	0xaa, 0x34, 0xda, 0x23, 0x17, 0xb8, 0x39, 0x2b, 0x8b, 0xfb, 0xa3, 0xf2, 0xe2, 0xd9, 0x78, 0xa7,
+	0xba, 0xcf, 0x77, 0x1f, 0xa2, 0xbf, 0x01, 0xb4, 0xca, 0xeb, 0x8a, 0x84, 0x3b, 0xb6, 0xd8, 0x6d,
+	0xf7, 0xd9, 0xce, 0xfd, 0x16, 0xed, 0x91, 0x57, 0xb0, 0x5f, 0x9a, 0x47, 0xf2, 0xb4, 0xb0, 0xad,
+	0xee, 0xa1, 0x6e, 0xb8, 0xfd, 0x00, 0x7d, 0x7c, 0x0f, 0xb0, 0x6e, 0x7b, 0xf2, 0x64, 0xc3, 0xd2,
+	0x0e, 0x75, 0xf7, 0xe9, 0x56, 0x7d, 0xce, 0xa5, 0xdc, 0x1a, 0x25, 0x2e, 0x1b, 0x43, 0xd0, 0x7d,
+	0xb6, 0xe3, 0xc4, 0xb8, 0xb9, 0x69, 0xe0, 0xcf, 0xdf, 0x57, 0xff, 0x0e, 0x00, 0x20, 0x87, 0x04,
+	0x3b, 0x13, 0x0a, 0x00, 0x00,
 }
@@ -1,58 +1,74 @@
+//go:build !nopbc
+// +build !nopbc
+
 package filecheck
 
 import (
 	"crypto/sha256"
+	"errors"
 	"io"
 	"math/big"
 	"os"
 
 	"github.com/Nik-U/pbc"
 	util_bytes "github.com/samoslab/nebula/util/bytes"
+	"github.com/samoslab/nebula/util/por"
 )
 
+// GenMetadata gen metadata of por.VersionPBC
 func GenMetadata(filepath string, chunkSize uint32) (paramStr string, generator []byte, pubKeyBytes []byte, random []byte, phi [][]byte, er error) {
+	return GenMetadataWithParams(filepath, chunkSize, "")
+}
+
+// GenMetadataWithParams gen metadata with given pairing params, params are generated if params is empty
+func GenMetadataWithParams(filepath string, chunkSize uint32, params string) (paramStr string, generator []byte, pubKeyBytes []byte, random []byte, phi [][]byte, er error) {
 	file, err := os.Open(filepath)
 	if err != nil {
 		er = err
 		return
 	}
 	defer file.Close()
-	fi, err := file.Stat()
-	if err != nil {
-		er = err
+	md, er := genMetadata(file, chunkSize, params)
+	if er != nil {
+		return
+	}
+	return md.ParamStr, md.Generator, md.PubKey, md.Random, md.Phi, nil
+}
+
+func genMetadata(r io.Reader, chunkSize uint32, params string) (md *por.Metadata, er error) {
+	var pbcParams *pbc.Params
+	if params == "" {
+		pbcParams = pbc.GenerateA(160, 512)
+	} else if pbcParams, er = pbc.NewParamsFromString(params); er != nil {
 		return
 	}
-	size := (fi.Size() + int64(chunkSize) - 1) / int64(chunkSize)
-	phi = make([][]byte, 0, size)
-	params := pbc.GenerateA(160, 512)
-	paramStr = params.String()
-	pairing := params.NewPairing()
+	md = &por.Metadata{ParamStr: pbcParams.String()}
+	pairing := pbcParams.NewPairing()
 	g := pairing.NewG2().Rand()
-	generator = g.Bytes()
+	md.Generator = g.Bytes()
 	priKey := pairing.NewZr().Rand()
 	pubKey := pairing.NewG2().PowZn(g, priKey)
-	pubKeyBytes = pubKey.Bytes()
+	md.PubKey = pubKey.Bytes()
 	u := pairing.NewG1().Rand()
-	random = u.Bytes()
+	md.Random = u.Bytes()
 	uPower := u.PreparePower()
 	buf := make([]byte, chunkSize)
 	i := 0
 	for {
-		bytesRead, err := file.Read(buf)
-		if err != nil && err != io.EOF {
-			er = err
-			return
+		bytesRead, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, err
 		}
 		if bytesRead > 0 {
 			i++
-			e1 := pairing.NewG1().SetFromHash(hash(pubKeyBytes, uint32(i)))
+			e1 := pairing.NewG1().SetFromHash(hash(md.PubKey, uint32(i)))
 			bm := new(big.Int)
 			bm.SetBytes(buf[:bytesRead])
 			e2 := pairing.NewG1()
 			uPower.PowZn(e2, pairing.NewZr().SetBig(bm))
 			e1.Mul(e1, e2)
 			e1.PowZn(e1, priKey)
-			phi = append(phi, e1.Bytes())
+			md.Phi = append(md.Phi, e1.Bytes())
 		}
 		if bytesRead < int(chunkSize) {
 			break
@@ -61,6 +77,53 @@ func GenMetadata(filepath string, chunkSize uint32) (paramStr string, generator
 	return
 }
 
+// pbcScheme por.VersionPBC scheme, e(Π φᵢ^vᵢ, g) = e(Π H(i)^vᵢ · u^μ, pubKey)
+type pbcScheme struct {
+	por.ProofScheme
+}
+
+func init() {
+	prover, _ := por.Lookup(por.VersionPBC)
+	por.Register(pbcScheme{ProofScheme: prover})
+}
+
+func (pbcScheme) GenMetadata(r io.Reader, chunkSize uint32) (*por.Metadata, error) {
+	return genMetadata(r, chunkSize, "")
+}
+
+func (pbcScheme) Verify(md *por.Metadata, ch por.Challenge, proof []byte) error {
+	if len(ch) == 0 {
+		return errors.New("empty challenge")
+	}
+	pairing, err := pbc.NewPairingFromString(md.ParamStr)
+	if err != nil {
+		return err
+	}
+	g := pairing.NewG2().SetBytes(md.Generator)
+	pubKey := pairing.NewG2().SetBytes(md.PubKey)
+	u := pairing.NewG1().SetBytes(md.Random)
+	var sigma, agg *pbc.Element
+	for seq, v := range ch {
+		if seq < 1 || int(seq) > len(md.Phi) {
+			return por.ErrProofMismatch
+		}
+		vi := new(big.Int).SetBytes(v)
+		s := pairing.NewG1().PowBig(pairing.NewG1().SetBytes(md.Phi[seq-1]), vi)
+		h := pairing.NewG1().PowBig(pairing.NewG1().SetFromHash(hash(md.PubKey, seq)), vi)
+		if sigma == nil {
+			sigma, agg = s, h
+		} else {
+			sigma.Mul(sigma, s)
+			agg.Mul(agg, h)
+		}
+	}
+	agg.Mul(agg, pairing.NewG1().PowBig(u, new(big.Int).SetBytes(proof)))
+	if !pairing.NewGT().Pair(sigma, g).Equals(pairing.NewGT().Pair(agg, pubKey)) {
+		return por.ErrProofMismatch
+	}
+	return nil
+}
+
 func hash(pubKeyBytes []byte, i uint32) []byte {
 	hasher := sha256.New()
 	hasher.Write(pubKeyBytes)
@@ -0,0 +1,37 @@
+//go:build nopbc
+// +build nopbc
+
+package filecheck
+
+import (
+	"os"
+	"sync"
+
+	"github.com/samoslab/nebula/util/por"
+	log "github.com/sirupsen/logrus"
+)
+
+var fallbackOnce sync.Once
+
+// GenMetadata gen metadata of por.VersionMAC because pbc is not built
+func GenMetadata(filepath string, chunkSize uint32) (paramStr string, generator []byte, pubKeyBytes []byte, random []byte, phi [][]byte, er error) {
+	return GenMetadataWithParams(filepath, chunkSize, "")
+}
+
+// GenMetadataWithParams gen metadata of por.VersionMAC, params is ignored
+func GenMetadataWithParams(filepath string, chunkSize uint32, params string) (paramStr string, generator []byte, pubKeyBytes []byte, random []byte, phi [][]byte, er error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		er = err
+		return
+	}
+	defer file.Close()
+	fallbackOnce.Do(func() {
+		log.Warnf("built with nopbc, metadata of blocks is generated by proof scheme version %d instead of pbc", por.VersionMAC)
+	})
+	md, er := por.MACScheme{}.GenMetadata(file, chunkSize)
+	if er != nil {
+		return
+	}
+	return md.ParamStr, md.Generator, md.PubKey, md.Random, md.Phi, nil
+}
@@ -0,0 +1,40 @@
+//go:build !nopbc
+// +build !nopbc
+
+package filecheck
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/samoslab/nebula/util/por"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPBCSchemeRegistered(t *testing.T) {
+	scheme, err := por.Lookup(por.VersionPBC)
+	require.NoError(t, err)
+	_, ok := scheme.(pbcScheme)
+	require.True(t, ok)
+
+	data := bytes.Repeat([]byte{1, 2, 3}, 1000)
+	md, err := scheme.GenMetadata(bytes.NewReader(data), 1024)
+	require.NoError(t, err)
+	require.Len(t, md.Phi, 3)
+	for i := 0; i < 3; i++ {
+		ch, err := scheme.Challenge(3, 2)
+		require.NoError(t, err)
+		proof, err := scheme.Prove(bytes.NewReader(data), int64(len(data)), 1024, ch)
+		require.NoError(t, err)
+		require.NoError(t, por.Verify(md, ch, proof))
+	}
+
+	// provider lost a byte of a challenged chunk
+	ch := por.Challenge{2: []byte{9}, 3: []byte{7}}
+	broken := append([]byte{}, data...)
+	broken[1024+10] ^= 1
+	proof, err := scheme.Prove(bytes.NewReader(broken), int64(len(broken)), 1024, ch)
+	require.NoError(t, err)
+	require.Equal(t, por.ErrProofMismatch, por.Verify(md, ch, proof))
+	require.Equal(t, por.ErrProofMismatch, por.Verify(md, por.Challenge{4: []byte{1}}, proof))
+}
@@ -0,0 +1,244 @@
+package filecheck
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// WorkerEnv environment variable which makes the process serve as metadata worker
+const WorkerEnv = "NEBULA_METADATA_WORKER"
+
+// MaxCachedParams max number of files whose pairing params are cached
+var MaxCachedParams = 256
+
+// ErrGeneratorClosed generator is closed
+var ErrGeneratorClosed = errors.New("metadata generator closed")
+
+type workerReq struct {
+	Path      string
+	ChunkSize uint32
+	Params    string
+}
+
+type workerResp struct {
+	ParamStr  string
+	Generator []byte
+	PubKey    []byte
+	Random    []byte
+	Phi       [][]byte
+	Err       string
+}
+
+// ServeWorker serve metadata requests from stdin if the process is started as worker by Generator,
+// it exits the process when stdin is closed, and returns immediately if not started as worker.
+// It should be called at the beginning of main of the program which uses Generator.
+func ServeWorker() {
+	if os.Getenv(WorkerEnv) == "" {
+		return
+	}
+	serveWorker(os.Stdin, os.Stdout)
+	os.Exit(0)
+}
+
+func serveWorker(r io.Reader, w io.Writer) {
+	dec := gob.NewDecoder(r)
+	enc := gob.NewEncoder(w)
+	for {
+		var req workerReq
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		resp := &workerResp{}
+		var err error
+		resp.ParamStr, resp.Generator, resp.PubKey, resp.Random, resp.Phi, err = GenMetadataWithParams(req.Path, req.ChunkSize, req.Params)
+		if err != nil {
+			resp.Err = err.Error()
+		}
+		if err = enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+type worker struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	enc   *gob.Encoder
+	dec   *gob.Decoder
+}
+
+func startWorker(exe string) (*worker, error) {
+	cmd := exec.Command(exe)
+	cmd.Env = append(os.Environ(), WorkerEnv+"=1")
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &worker{cmd: cmd, stdin: stdin, enc: gob.NewEncoder(stdin), dec: gob.NewDecoder(stdout)}, nil
+}
+
+func (w *worker) call(req *workerReq) (*workerResp, error) {
+	if err := w.enc.Encode(req); err != nil {
+		return nil, err
+	}
+	resp := &workerResp{}
+	if err := w.dec.Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (w *worker) stop() {
+	w.stdin.Close()
+	w.cmd.Wait()
+}
+
+func (w *worker) kill() {
+	w.cmd.Process.Kill()
+	w.stop()
+}
+
+type fileParams struct {
+	ready    chan struct{}
+	paramStr string
+}
+
+// Generator gen metadata in parallel, every generation runs in a worker process because pbc
+// crashes if it is used by multiple goroutines, a crashed worker only fails its own generation.
+// Pairing params are generated once for each file and shared by all blocks of the file.
+type Generator struct {
+	exe    string
+	idle   chan *worker // nil means worker to be started
+	quit   chan struct{}
+	mutex  sync.Mutex
+	params map[string]*fileParams
+	keys   []string
+}
+
+// NewGenerator create generator with at most workers worker processes, which are started on demand
+func NewGenerator(workers int) (*Generator, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	g := &Generator{exe: exe, idle: make(chan *worker, workers), quit: make(chan struct{}), params: map[string]*fileParams{}}
+	for i := 0; i < workers; i++ {
+		g.idle <- nil
+	}
+	return g, nil
+}
+
+// fileParams returns params of file, owner is true if caller should generate the params and call paramsDone
+func (g *Generator) fileParams(key string) (fp *fileParams, owner bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if fp, ok := g.params[key]; ok {
+		return fp, false
+	}
+	fp = &fileParams{ready: make(chan struct{})}
+	g.params[key] = fp
+	g.keys = append(g.keys, key)
+	if len(g.keys) > MaxCachedParams {
+		delete(g.params, g.keys[0])
+		g.keys = g.keys[1:]
+	}
+	return fp, true
+}
+
+func (g *Generator) paramsDone(key string, fp *fileParams, paramStr string) {
+	g.mutex.Lock()
+	fp.paramStr = paramStr
+	if paramStr == "" && g.params[key] == fp {
+		// generation failed, next block of the file will try again
+		g.forget(key)
+	}
+	g.mutex.Unlock()
+	close(fp.ready)
+}
+
+// Gen gen metadata of block file path, key identifies the file which the block belongs to
+func (g *Generator) Gen(key string, path string, chunkSize uint32) (paramStr string, generator []byte, pubKey []byte, random []byte, phi [][]byte, err error) {
+	fp, owner := g.fileParams(key)
+	if owner {
+		defer func() { g.paramsDone(key, fp, paramStr) }()
+	} else {
+		select {
+		case <-fp.ready:
+		case <-g.quit:
+			return "", nil, nil, nil, nil, ErrGeneratorClosed
+		}
+	}
+	var w *worker
+	select {
+	case w = <-g.idle:
+	case <-g.quit:
+		return "", nil, nil, nil, nil, ErrGeneratorClosed
+	}
+	if w == nil {
+		if w, err = startWorker(g.exe); err != nil {
+			g.idle <- nil
+			return
+		}
+	}
+	resp, err := w.call(&workerReq{Path: path, ChunkSize: chunkSize, Params: fp.paramStr})
+	if err != nil {
+		w.kill()
+		g.idle <- nil
+		return
+	}
+	g.idle <- w
+	if resp.Err != "" {
+		return "", nil, nil, nil, nil, errors.New(resp.Err)
+	}
+	return resp.ParamStr, resp.Generator, resp.PubKey, resp.Random, resp.Phi, nil
+}
+
+// Forget drop cached params of file
+func (g *Generator) Forget(key string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.forget(key)
+}
+
+func (g *Generator) forget(key string) {
+	if _, ok := g.params[key]; !ok {
+		return
+	}
+	delete(g.params, key)
+	for i, k := range g.keys {
+		if k == key {
+			g.keys = append(g.keys[:i], g.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Close stop all workers after running generations finish
+func (g *Generator) Close() {
+	select {
+	case <-g.quit:
+		return
+	default:
+	}
+	close(g.quit)
+	for i := 0; i < cap(g.idle); i++ {
+		if w := <-g.idle; w != nil {
+			w.stop()
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package filecheck
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// test binary serves as metadata worker of Generator
+	ServeWorker()
+	os.Exit(m.Run())
+}
+
+// blockFiles create n block files of size bytes
+func blockFiles(t testing.TB, n int, size int) (string, []string) {
+	dir, err := ioutil.TempDir("", "filecheck")
+	require.NoError(t, err)
+	paths := make([]string, n)
+	for i := range paths {
+		buf := make([]byte, size)
+		rand.Read(buf)
+		paths[i] = filepath.Join(dir, fmt.Sprintf("block%d", i))
+		require.NoError(t, ioutil.WriteFile(paths[i], buf, 0600))
+	}
+	return dir, paths
+}
+
+func TestGenerator(t *testing.T) {
+	dir, paths := blockFiles(t, 6, 64*1024)
+	defer os.RemoveAll(dir)
+	g, err := NewGenerator(3)
+	require.NoError(t, err)
+	defer g.Close()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(paths))
+	for i, p := range paths {
+		wg.Add(1)
+		go func(i int, p string) {
+			defer wg.Done()
+			_, _, _, _, _, errs[i] = g.Gen("file", p, 32*1024)
+		}(i, p)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	_, _, _, _, _, err = g.Gen("file", filepath.Join(dir, "missing"), 32*1024)
+	require.Error(t, err)
+
+	// a crashed worker fails its generation only, it is started again for next generation
+	w := <-g.idle
+	if w == nil {
+		w, err = startWorker(g.exe)
+		require.NoError(t, err)
+	}
+	w.cmd.Process.Kill()
+	g.idle <- w
+	for i := 0; i < cap(g.idle); i++ {
+		g.Gen("file", paths[0], 32*1024)
+	}
+	_, _, _, _, _, err = g.Gen("file", paths[0], 32*1024)
+	require.NoError(t, err)
+
+	g.Close()
+	_, _, _, _, _, err = g.Gen("file", paths[0], 32*1024)
+	require.Equal(t, ErrGeneratorClosed, err)
+}
+
+func TestGeneratorParams(t *testing.T) {
+	g := &Generator{params: map[string]*fileParams{}}
+	fp, owner := g.fileParams("a")
+	require.True(t, owner)
+	fp2, owner := g.fileParams("a")
+	require.False(t, owner)
+	require.True(t, fp == fp2)
+
+	g.paramsDone("a", fp, "params")
+	<-fp2.ready
+	require.Equal(t, "params", fp2.paramStr)
+
+	// failed generation is not cached
+	fp, _ = g.fileParams("b")
+	g.paramsDone("b", fp, "")
+	_, owner = g.fileParams("b")
+	require.True(t, owner)
+
+	old := MaxCachedParams
+	MaxCachedParams = 2
+	defer func() { MaxCachedParams = old }()
+	g.fileParams("c")
+	require.Equal(t, []string{"b", "c"}, g.keys)
+	g.Forget("b")
+	require.Equal(t, []string{"c"}, g.keys)
+}
+
+const (
+	benchBlocks    = 8
+	benchBlockSize = 1024 * 1024
+	benchChunkSize = 32 * 1024
+)
+
+// BenchmarkGenMetadataInOrder current path, blocks are generated one by one with params of each block
+func BenchmarkGenMetadataInOrder(b *testing.B) {
+	dir, paths := blockFiles(b, benchBlocks, benchBlockSize)
+	defer os.RemoveAll(dir)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range paths {
+			if _, _, _, _, _, err := GenMetadata(p, benchChunkSize); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkGenerator blocks are generated by worker processes in parallel with params shared by the file
+func BenchmarkGenerator(b *testing.B) {
+	dir, paths := blockFiles(b, benchBlocks, benchBlockSize)
+	defer os.RemoveAll(dir)
+	g, err := NewGenerator(runtime.NumCPU())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer g.Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := fmt.Sprintf("file%d", i)
+		var wg sync.WaitGroup
+		for _, p := range paths {
+			wg.Add(1)
+			go func(p string) {
+				defer wg.Done()
+				if _, _, _, _, _, err := g.Gen(key, p, benchChunkSize); err != nil {
+					b.Error(err)
+				}
+			}(p)
+		}
+		wg.Wait()
+	}
+}
@@ -13,6 +13,40 @@ type MIME struct {
 
 type SupportType map[string]MIME
 
+// incompressible types which are compressed by their format
+var incompressible = map[string]bool{
+	"application/zip":                       true,
+	"application/epub+zip":                  true,
+	"application/gzip":                      true,
+	"application/x-bzip2":                   true,
+	"application/x-7z-compressed":           true,
+	"application/x-xz":                      true,
+	"application/x-rar-compressed":          true,
+	"application/x-compress":                true,
+	"application/x-lzip":                    true,
+	"application/vnd.ms-cab-compressed":     true,
+	"application/x-google-chrome-extension": true,
+	"application/x-deb":                     true,
+	"application/x-rpm":                     true,
+	"application/pdf":                       true,
+	"application/font-woff":                 true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+}
+
+// Compressible file of the type becomes smaller by compression, most images, audio, video and archives
+// are compressed already
+func (m MIME) Compressible() bool {
+	switch m.Type {
+	case "video":
+		return false
+	case "image", "audio":
+		return m.Value == "image/bmp" || m.Value == "image/tiff" || m.Value == "audio/x-wav"
+	}
+	return !incompressible[m.Value]
+}
+
 func FileType(filename string) MIME {
 	kind, unknown := filetype.MatchFile(filename)
 	if unknown != nil {
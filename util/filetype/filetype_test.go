@@ -11,3 +11,12 @@ func TestFileType(t *testing.T) {
 	assert.Equal(t, ft.Type, "unknown")
 	assert.Equal(t, ft.Extension, "unknown")
 }
+
+func TestCompressible(t *testing.T) {
+	assert.True(t, FileType("not-exists.file").Compressible())
+	assert.True(t, MIME{Type: "image", Value: "image/bmp"}.Compressible())
+	assert.False(t, MIME{Type: "image", Value: "image/jpeg"}.Compressible())
+	assert.False(t, MIME{Type: "video", Value: "video/mp4"}.Compressible())
+	assert.False(t, MIME{Type: "application", Value: "application/zip"}.Compressible())
+	assert.True(t, MIME{Type: "application", Value: "application/x-sqlite3"}.Compressible())
+}
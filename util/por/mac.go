@@ -0,0 +1,182 @@
+package por
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	util_bytes "github.com/samoslab/nebula/util/bytes"
+)
+
+const (
+	macKeySize = 32
+	// macSectorSize bytes of sector, a sector is less than macPrime as integer, so it is never reduced
+	macSectorSize = 31
+	// macElementSize bytes of element mod macPrime
+	macElementSize = 32
+
+	sectorsPrefix = "sectors "
+)
+
+// macPrime prime modulus of MACScheme, 2^255 - 19
+var macPrime, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+
+// MACScheme pure go scheme of VersionMAC, the private scheme of Shacham and Waters. Chunk i is split
+// into s sectors mᵢⱼ of 31 bytes, tag of chunk i is f(i) + Σⱼ αⱼ·mᵢⱼ mod p, f is HMAC-SHA256 with key k
+// and αⱼ is HMAC-SHA256 of j with key a. Proof is μⱼ = Σᵢ vᵢ·mᵢⱼ mod p of every sector, so the whole
+// chunk is needed to prove, not only its value mod p. PubKey of metadata is a and k, it is secret and
+// only the verifier (tracker) should keep it.
+type MACScheme struct{}
+
+// Version version of scheme
+func (MACScheme) Version() uint32 {
+	return VersionMAC
+}
+
+// Challenge choose count random chunks
+func (MACScheme) Challenge(chunks uint32, count int) (Challenge, error) {
+	return sumProver{}.Challenge(chunks, count)
+}
+
+func macPRF(key []byte, seq uint32) *big.Int {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(util_bytes.FromUint32(seq))
+	f := new(big.Int).SetBytes(mac.Sum(nil))
+	return f.Mod(f, macPrime)
+}
+
+func padded(n *big.Int) []byte {
+	b := make([]byte, macElementSize)
+	nb := n.Bytes()
+	copy(b[len(b)-len(nb):], nb)
+	return b
+}
+
+// macSectors number of sectors of chunk
+func macSectors(chunkSize uint32) int {
+	return (int(chunkSize) + macSectorSize - 1) / macSectorSize
+}
+
+// sector j of chunk as integer, sector beyond the chunk is zero
+func sector(chunk []byte, j int) *big.Int {
+	start := j * macSectorSize
+	if start >= len(chunk) {
+		return new(big.Int)
+	}
+	end := start + macSectorSize
+	if end > len(chunk) {
+		end = len(chunk)
+	}
+	return new(big.Int).SetBytes(chunk[start:end])
+}
+
+// parseSectors number of sectors in ParamStr of metadata
+func parseSectors(paramStr string) (int, error) {
+	lines := strings.Split(paramStr, "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[1], sectorsPrefix) {
+		return 0, errors.New("no sectors in params")
+	}
+	s, err := strconv.Atoi(strings.TrimSpace(lines[1][len(sectorsPrefix):]))
+	if err != nil || s <= 0 {
+		return 0, fmt.Errorf("invalid sectors in params: %s", lines[1])
+	}
+	return s, nil
+}
+
+// GenMetadata gen tags of chunks with new random keys
+func (MACScheme) GenMetadata(r io.Reader, chunkSize uint32) (*Metadata, error) {
+	alphaKey := make([]byte, macKeySize)
+	key := make([]byte, macKeySize)
+	if _, err := rand.Read(alphaKey); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	s := macSectors(chunkSize)
+	alpha := make([]*big.Int, s)
+	for j := range alpha {
+		alpha[j] = macPRF(alphaKey, uint32(j))
+	}
+	md := &Metadata{
+		ParamStr: versionHeader(VersionMAC) + "\n" + sectorsPrefix + strconv.Itoa(s),
+		PubKey:   append(alphaKey, key...),
+	}
+	err := readChunks(r, chunkSize, func(seq uint32, chunk []byte) {
+		tag := macPRF(key, seq)
+		for j := 0; j*macSectorSize < len(chunk); j++ {
+			m := sector(chunk, j)
+			tag.Add(tag, m.Mul(m, alpha[j]))
+		}
+		md.Phi = append(md.Phi, padded(tag.Mod(tag, macPrime)))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return md, nil
+}
+
+// Prove compute μⱼ of every sector of the challenged chunks
+func (MACScheme) Prove(r io.ReaderAt, size int64, chunkSize uint32, ch Challenge) ([]byte, error) {
+	mu := make([]*big.Int, macSectors(chunkSize))
+	for j := range mu {
+		mu[j] = new(big.Int)
+	}
+	buf := make([]byte, chunkSize)
+	for _, k := range sortedSeq(ch) {
+		chunk, err := readChunk(r, size, chunkSize, k, buf)
+		if err != nil {
+			return nil, err
+		}
+		v := new(big.Int).SetBytes(ch[k])
+		for j := 0; j*macSectorSize < len(chunk); j++ {
+			m := sector(chunk, j)
+			mu[j].Add(mu[j], m.Mul(m, v))
+		}
+	}
+	proof := make([]byte, 0, len(mu)*macElementSize)
+	for _, m := range mu {
+		proof = append(proof, padded(m.Mod(m, macPrime))...)
+	}
+	return proof, nil
+}
+
+// Verify check Σ vᵢ·σᵢ = Σⱼ αⱼ·μⱼ + Σ vᵢ·f(i) mod p
+func (MACScheme) Verify(md *Metadata, ch Challenge, proof []byte) error {
+	if len(md.PubKey) != 2*macKeySize {
+		return errors.New("invalid verification key")
+	}
+	s, err := parseSectors(md.ParamStr)
+	if err != nil {
+		return err
+	}
+	if len(proof) != s*macElementSize {
+		return ErrProofMismatch
+	}
+	alphaKey, key := md.PubKey[:macKeySize], md.PubKey[macKeySize:]
+	expect := big.NewInt(0)
+	for j := 0; j < s; j++ {
+		mu := new(big.Int).SetBytes(proof[j*macElementSize : (j+1)*macElementSize])
+		expect.Add(expect, mu.Mul(mu, macPRF(alphaKey, uint32(j))))
+	}
+	sigma := big.NewInt(0)
+	for _, k := range sortedSeq(ch) {
+		if k < 1 || int(k) > len(md.Phi) {
+			return ErrProofMismatch
+		}
+		v := new(big.Int).SetBytes(ch[k])
+		tag := new(big.Int).SetBytes(md.Phi[k-1])
+		sigma.Add(sigma, tag.Mul(tag, v))
+		expect.Add(expect, v.Mul(v, macPRF(key, k)))
+	}
+	if sigma.Mod(sigma, macPrime).Cmp(expect.Mod(expect, macPrime)) != 0 {
+		return ErrProofMismatch
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Package por proof of retrievability of blocks stored by providers.
+//
+// Client generates metadata (tags) of a block when uploading, tracker keeps the metadata and
+// challenges provider with random chunks and coefficients, provider proves by the challenged chunks
+// combined with the coefficients, and tracker verifies the proof with the metadata.
+package por
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// VersionPBC pairing based scheme of github.com/Nik-U/pbc, metadata without version is this version
+	VersionPBC uint32 = 1
+	// VersionMAC pure go scheme with private verification key
+	VersionMAC uint32 = 2
+
+	versionPrefix = "version "
+
+	// CoefficientSize size of challenge coefficient in bytes
+	CoefficientSize = 20
+)
+
+var (
+	// ErrUnknownVersion proof scheme of version is not registered
+	ErrUnknownVersion = errors.New("unknown proof scheme version")
+	// ErrProofMismatch proof is not consistent with metadata
+	ErrProofMismatch = errors.New("proof mismatch")
+	// ErrNoPairing pbc pairing is not linked
+	ErrNoPairing = errors.New("pbc pairing is not available, import util/filecheck to link it")
+)
+
+// Metadata metadata of a block, fields are stored as StoreBlock of tracker, meaning of fields depends on scheme
+type Metadata struct {
+	ParamStr  string
+	Generator []byte
+	PubKey    []byte
+	Random    []byte
+	Phi       [][]byte
+}
+
+// Challenge chunks to prove, key is sequence of chunk starting from 1, value is coefficient
+type Challenge map[uint32][]byte
+
+// ProofScheme scheme of tag generation, challenge, prove and verify
+type ProofScheme interface {
+	// Version version of scheme, which is the "version N" header of ParamStr
+	Version() uint32
+	// GenMetadata gen metadata of block read from r
+	GenMetadata(r io.Reader, chunkSize uint32) (*Metadata, error)
+	// Challenge choose count random chunks of chunks in total
+	Challenge(chunks uint32, count int) (Challenge, error)
+	// Prove compute proof of block in r
+	Prove(r io.ReaderAt, size int64, chunkSize uint32, ch Challenge) ([]byte, error)
+	// Verify verify proof with metadata, returns ErrProofMismatch if proof is wrong
+	Verify(md *Metadata, ch Challenge, proof []byte) error
+}
+
+var (
+	schemesMutex sync.RWMutex
+	schemes      = map[uint32]ProofScheme{}
+)
+
+func init() {
+	Register(pbcProver{})
+	Register(MACScheme{})
+}
+
+// Register register scheme, scheme of same version is replaced
+func Register(s ProofScheme) {
+	schemesMutex.Lock()
+	defer schemesMutex.Unlock()
+	schemes[s.Version()] = s
+}
+
+// Lookup get scheme of version, 0 means VersionPBC
+func Lookup(version uint32) (ProofScheme, error) {
+	if version == 0 {
+		version = VersionPBC
+	}
+	schemesMutex.RLock()
+	defer schemesMutex.RUnlock()
+	s, ok := schemes[version]
+	if !ok {
+		return nil, fmt.Errorf("%v: %d", ErrUnknownVersion, version)
+	}
+	return s, nil
+}
+
+// VersionOf version of scheme which generated ParamStr
+func VersionOf(paramStr string) (uint32, error) {
+	if !strings.HasPrefix(paramStr, versionPrefix) {
+		return VersionPBC, nil
+	}
+	line := strings.SplitN(paramStr[len(versionPrefix):], "\n", 2)[0]
+	v, err := strconv.ParseUint(strings.TrimSpace(line), 10, 32)
+	if err != nil || v == 0 {
+		return 0, fmt.Errorf("%v: %s", ErrUnknownVersion, line)
+	}
+	return uint32(v), nil
+}
+
+// versionHeader ParamStr header of version
+func versionHeader(version uint32) string {
+	return versionPrefix + strconv.FormatUint(uint64(version), 10)
+}
+
+// Verify verify proof by scheme selected by ParamStr of metadata
+func Verify(md *Metadata, ch Challenge, proof []byte) error {
+	version, err := VersionOf(md.ParamStr)
+	if err != nil {
+		return err
+	}
+	s, err := Lookup(version)
+	if err != nil {
+		return err
+	}
+	return s.Verify(md, ch, proof)
+}
+
+// sortedSeq sequences of challenge in ascending order
+func sortedSeq(ch Challenge) []uint32 {
+	seq := make([]uint32, 0, len(ch))
+	for k := range ch {
+		seq = append(seq, k)
+	}
+	sort.Slice(seq, func(i, j int) bool { return seq[i] < seq[j] })
+	return seq
+}
+
+// sumProver Σ mᵢ·vᵢ prover of VersionPBC and random challenge shared by schemes
+type sumProver struct{}
+
+func (sumProver) Challenge(chunks uint32, count int) (Challenge, error) {
+	if chunks == 0 {
+		return nil, errors.New("no chunk to challenge")
+	}
+	if count > int(chunks) {
+		count = int(chunks)
+	}
+	ch := make(Challenge, count)
+	max := big.NewInt(int64(chunks))
+	for len(ch) < count {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return nil, err
+		}
+		seq := uint32(n.Int64()) + 1
+		if _, ok := ch[seq]; ok {
+			continue
+		}
+		v := make([]byte, CoefficientSize)
+		if _, err = rand.Read(v); err != nil {
+			return nil, err
+		}
+		ch[seq] = v
+	}
+	return ch, nil
+}
+
+func (sumProver) Prove(r io.ReaderAt, size int64, chunkSize uint32, ch Challenge) ([]byte, error) {
+	res := big.NewInt(0)
+	buf := make([]byte, chunkSize)
+	for _, k := range sortedSeq(ch) {
+		chunk, err := readChunk(r, size, chunkSize, k, buf)
+		if err != nil {
+			return nil, err
+		}
+		bm := new(big.Int).SetBytes(chunk)
+		bm.Mul(bm, new(big.Int).SetBytes(ch[k]))
+		res.Add(res, bm)
+	}
+	return res.Bytes(), nil
+}
+
+// readChunk read chunk of sequence k into buf, the last chunk may be shorter
+func readChunk(r io.ReaderAt, size int64, chunkSize uint32, k uint32, buf []byte) ([]byte, error) {
+	start := int64(k-1) * int64(chunkSize)
+	if k < 1 || start >= size {
+		return nil, fmt.Errorf("seq out of range: %d", k)
+	}
+	end := start + int64(chunkSize)
+	if end > size {
+		end = size
+	}
+	if _, err := r.ReadAt(buf[:end-start], start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf[:end-start], nil
+}
+
+// readChunks call fn with every chunk of r, sequence starts from 1
+func readChunks(r io.Reader, chunkSize uint32, fn func(seq uint32, chunk []byte)) error {
+	buf := make([]byte, chunkSize)
+	for seq := uint32(1); ; seq++ {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			fn(seq, buf[:n])
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// pbcProver prover of VersionPBC, which does not need pbc, util/filecheck registers the full scheme
+type pbcProver struct {
+	sumProver
+}
+
+func (pbcProver) Version() uint32 {
+	return VersionPBC
+}
+
+func (pbcProver) GenMetadata(r io.Reader, chunkSize uint32) (*Metadata, error) {
+	return nil, ErrNoPairing
+}
+
+func (pbcProver) Verify(md *Metadata, ch Challenge, proof []byte) error {
+	return ErrNoPairing
+}
@@ -0,0 +1,112 @@
+package por
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionOf(t *testing.T) {
+	v, err := VersionOf("type a\nq 123\n")
+	require.NoError(t, err)
+	require.Equal(t, VersionPBC, v)
+	v, err = VersionOf(versionHeader(VersionMAC))
+	require.NoError(t, err)
+	require.Equal(t, VersionMAC, v)
+	v, err = VersionOf("version 7\nparams")
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), v)
+	_, err = VersionOf("version x")
+	require.Error(t, err)
+
+	s, err := Lookup(0)
+	require.NoError(t, err)
+	require.Equal(t, VersionPBC, s.Version())
+	_, err = Lookup(7)
+	require.Error(t, err)
+}
+
+func TestChallenge(t *testing.T) {
+	ch, err := MACScheme{}.Challenge(10, 4)
+	require.NoError(t, err)
+	require.Len(t, ch, 4)
+	for seq, v := range ch {
+		require.True(t, seq >= 1 && seq <= 10)
+		require.Len(t, v, CoefficientSize)
+	}
+	ch, err = MACScheme{}.Challenge(3, 10)
+	require.NoError(t, err)
+	require.Len(t, ch, 3)
+	_, err = MACScheme{}.Challenge(0, 1)
+	require.Error(t, err)
+}
+
+func TestMACScheme(t *testing.T) {
+	const chunkSize = 1024
+	data := make([]byte, 10*chunkSize+100)
+	rand.Read(data)
+	scheme, err := Lookup(VersionMAC)
+	require.NoError(t, err)
+	md, err := scheme.GenMetadata(bytes.NewReader(data), chunkSize)
+	require.NoError(t, err)
+	require.Len(t, md.Phi, 11)
+
+	for i := 0; i < 5; i++ {
+		ch, err := scheme.Challenge(uint32(len(md.Phi)), 4)
+		require.NoError(t, err)
+		proof, err := scheme.Prove(bytes.NewReader(data), int64(len(data)), chunkSize, ch)
+		require.NoError(t, err)
+		require.NoError(t, Verify(md, ch, proof))
+	}
+
+	// last partial chunk
+	ch := Challenge{11: []byte{1, 2, 3}}
+	proof, err := scheme.Prove(bytes.NewReader(data), int64(len(data)), chunkSize, ch)
+	require.NoError(t, err)
+	require.NoError(t, Verify(md, ch, proof))
+
+	// provider lost a byte of a challenged chunk
+	ch = Challenge{2: []byte{9}, 5: []byte{7}}
+	broken := append([]byte{}, data...)
+	broken[4*chunkSize+10] ^= 1
+	proof, err = scheme.Prove(bytes.NewReader(broken), int64(len(broken)), chunkSize, ch)
+	require.NoError(t, err)
+	require.Equal(t, ErrProofMismatch, Verify(md, ch, proof))
+
+	_, err = scheme.Prove(bytes.NewReader(data), int64(len(data)), chunkSize, Challenge{12: []byte{1}})
+	require.Error(t, err)
+	require.Equal(t, ErrProofMismatch, scheme.Verify(md, Challenge{12: []byte{1}}, proof))
+}
+
+func TestMACSchemeReducedChunks(t *testing.T) {
+	const chunkSize = 1024
+	data := make([]byte, 4*chunkSize)
+	rand.Read(data)
+	scheme := MACScheme{}
+	md, err := scheme.GenMetadata(bytes.NewReader(data), chunkSize)
+	require.NoError(t, err)
+
+	// provider keeps only every chunk mod p, 32 bytes instead of 1024, which is the same number mod p
+	reduced := make([]byte, len(data))
+	for i := 0; i < len(data); i += chunkSize {
+		m := new(big.Int).SetBytes(data[i : i+chunkSize])
+		copy(reduced[i+chunkSize-macElementSize:i+chunkSize], padded(m.Mod(m, macPrime)))
+	}
+	for i := 0; i < 5; i++ {
+		ch, err := scheme.Challenge(uint32(len(md.Phi)), 2)
+		require.NoError(t, err)
+		proof, err := scheme.Prove(bytes.NewReader(reduced), int64(len(reduced)), chunkSize, ch)
+		require.NoError(t, err)
+		require.Equal(t, ErrProofMismatch, Verify(md, ch, proof))
+	}
+
+	// proof of fewer sectors
+	ch := Challenge{1: []byte{1}}
+	proof, err := scheme.Prove(bytes.NewReader(data), int64(len(data)), chunkSize, ch)
+	require.NoError(t, err)
+	require.NoError(t, Verify(md, ch, proof))
+	require.Equal(t, ErrProofMismatch, Verify(md, ch, proof[macElementSize:]))
+}
@@ -0,0 +1,439 @@
+package trackerpool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// RefreshInterval interval of refreshing tracker list
+	RefreshInterval = 10 * time.Minute
+	// CheckInterval interval of health checking trackers
+	CheckInterval = 30 * time.Second
+	// CheckTimeout timeout of one health check
+	CheckTimeout = 5 * time.Second
+	// CallTimeout timeout of RPC on one tracker, tracker which does not answer in time is marked unhealthy
+	// and RPC is retried on the next tracker, deadline of caller applies to all of the attempts
+	CallTimeout = 30 * time.Second
+
+	// ErrNoServer no server address given
+	ErrNoServer = errors.New("no tracker server")
+)
+
+// HealthFunc checks whether server behind conn is healthy
+type HealthFunc func(ctx context.Context, conn *grpc.ClientConn) error
+
+// ServerFunc returns addresses of all trackers, conn routes to a healthy one
+type ServerFunc func(ctx context.Context, conn *grpc.ClientConn) ([]string, error)
+
+// PingTracker health check by Ping of metadata service
+func PingTracker(ctx context.Context, conn *grpc.ClientConn) error {
+	_, err := mpb.NewMatadataServiceClient(conn).Ping(ctx, &mpb.PingReq{Version: 1})
+	return err
+}
+
+// Split split comma separated server addresses
+func Split(servers string) []string {
+	addrs := []string{}
+	for _, s := range strings.Split(servers, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			addrs = append(addrs, s)
+		}
+	}
+	return addrs
+}
+
+type pinKey struct{}
+
+// pinned returns context under which RPC is not routed by pool but sent on the connection it is called on
+func pinned(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pinKey{}, true)
+}
+
+type member struct {
+	addr    string
+	conn    *grpc.ClientConn
+	seed    bool
+	healthy bool
+}
+
+// Pool a set of trackers, every RPC through connection of pool is routed to a healthy
+// tracker, and retried on next tracker if the tracker is unavailable or timeout
+type Pool struct {
+	log     logrus.FieldLogger
+	health  HealthFunc
+	servers ServerFunc
+	mutex   sync.Mutex
+	members []*member
+	current int
+	entry   *grpc.ClientConn
+	header  []string
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New create pool of seed addresses, health nil means checking connection state only,
+// servers nil means tracker list is not refreshed
+func New(log logrus.FieldLogger, addrs []string, health HealthFunc, servers ServerFunc) (*Pool, error) {
+	p := &Pool{log: log, health: health, servers: servers, quit: make(chan struct{})}
+	for _, addr := range addrs {
+		if addr == "" || p.find(addr) != nil {
+			continue
+		}
+		conn, err := p.dial(addr)
+		if err != nil {
+			p.closeConns()
+			return nil, err
+		}
+		p.members = append(p.members, &member{addr: addr, conn: conn, seed: true, healthy: true})
+	}
+	if len(p.members) == 0 {
+		return nil, ErrNoServer
+	}
+	p.entry = p.members[0].conn
+	return p, nil
+}
+
+// Dial create pool of comma separated addresses without background refresh, for short-lived use
+func Dial(servers string) (*Pool, error) {
+	return New(logrus.StandardLogger(), Split(servers), nil, nil)
+}
+
+func (p *Pool) dial(addr string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr, grpc.WithInsecure(), grpc.WithUnaryInterceptor(p.invoke), grpc.WithStreamInterceptor(p.stream))
+}
+
+// SetHeader set grpc metadata sent with every RPC through pool, empty value removes it
+func (p *Pool) SetHeader(key, value string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	header := []string{}
+	for i := 0; i < len(p.header); i += 2 {
+		if p.header[i] != key {
+			header = append(header, p.header[i], p.header[i+1])
+		}
+	}
+	if value != "" {
+		header = append(header, key, value)
+	}
+	p.header = header
+}
+
+// outgoing context carrying header of pool
+func (p *Pool) outgoing(ctx context.Context) context.Context {
+	p.mutex.Lock()
+	header := p.header
+	p.mutex.Unlock()
+	if len(header) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, header...)
+}
+
+// Conn connection routed by pool, it is closed by Close of pool
+func (p *Pool) Conn() *grpc.ClientConn {
+	return p.entry
+}
+
+// Servers addresses of trackers in pool
+func (p *Pool) Servers() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	addrs := make([]string, 0, len(p.members))
+	for _, m := range p.members {
+		addrs = append(addrs, m.addr)
+	}
+	return addrs
+}
+
+// Current address of tracker which RPC is routed to
+func (p *Pool) Current() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.members[p.current].addr
+}
+
+// Start refresh tracker list and check health in background
+func (p *Pool) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.refresh()
+		p.check()
+		refreshTicker := time.NewTicker(RefreshInterval)
+		defer refreshTicker.Stop()
+		checkTicker := time.NewTicker(CheckInterval)
+		defer checkTicker.Stop()
+		for {
+			select {
+			case <-p.quit:
+				return
+			case <-refreshTicker.C:
+				p.refresh()
+			case <-checkTicker.C:
+				p.check()
+			}
+		}
+	}()
+}
+
+// Close stop background refresh and close all connections
+func (p *Pool) Close() error {
+	select {
+	case <-p.quit:
+		return nil
+	default:
+	}
+	close(p.quit)
+	p.wg.Wait()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closeConns()
+	return nil
+}
+
+func (p *Pool) closeConns() {
+	for _, m := range p.members {
+		if m.conn != p.entry {
+			m.conn.Close()
+		}
+	}
+	if p.entry != nil {
+		p.entry.Close()
+	}
+}
+
+func (p *Pool) find(addr string) *member {
+	for _, m := range p.members {
+		if m.addr == addr {
+			return m
+		}
+	}
+	return nil
+}
+
+// pick choose healthy member not tried from current one, unhealthy member is chosen only if no healthy one
+func (p *Pool) pick(tried map[*member]bool) *member {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	candidate := -1
+	for i := 0; i < len(p.members); i++ {
+		idx := (p.current + i) % len(p.members)
+		m := p.members[idx]
+		if tried[m] {
+			continue
+		}
+		if m.healthy {
+			candidate = idx
+			break
+		}
+		if candidate == -1 {
+			candidate = idx
+		}
+	}
+	if candidate == -1 {
+		return nil
+	}
+	p.current = candidate
+	return p.members[candidate]
+}
+
+func (p *Pool) setHealthy(m *member, healthy bool, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if m.healthy == healthy {
+		return
+	}
+	m.healthy = healthy
+	if healthy {
+		p.log.Infof("Tracker %s is healthy", m.addr)
+	} else {
+		p.log.WithError(err).Warnf("Tracker %s is unhealthy", m.addr)
+	}
+}
+
+// shouldFailover returns whether RPC should be retried on another tracker, ctx is of the caller,
+// so timeout of the attempt fails over while the caller still waits
+func shouldFailover(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// noTracker error after all trackers are tried, err is of the last attempt
+func noTracker(method string, err error) error {
+	if err != nil {
+		return err
+	}
+	return status.Errorf(codes.Unavailable, "no tracker available for %s", method)
+}
+
+func (p *Pool) invoke(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = p.outgoing(ctx)
+	if ctx.Value(pinKey{}) != nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	tried := map[*member]bool{}
+	var last error
+	for {
+		m := p.pick(tried)
+		if m == nil {
+			return noTracker(method, last)
+		}
+		tried[m] = true
+		attempt, cancel := context.WithTimeout(ctx, CallTimeout)
+		err := invoker(attempt, method, req, reply, m.conn, opts...)
+		cancel()
+		last = err
+		failover := shouldFailover(ctx, err)
+		if failover || status.Code(err) == codes.Unavailable {
+			p.setHealthy(m, false, err)
+		}
+		if !failover {
+			return err
+		}
+		p.log.WithError(err).Infof("Call %s on tracker %s failed, try next tracker", method, m.addr)
+	}
+}
+
+func (p *Pool) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx = p.outgoing(ctx)
+	if ctx.Value(pinKey{}) != nil {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	tried := map[*member]bool{}
+	var last error
+	for {
+		m := p.pick(tried)
+		if m == nil {
+			return nil, noTracker(method, last)
+		}
+		tried[m] = true
+		// only opening the stream is bounded by CallTimeout, the stream lives until it ends
+		attempt, cancel := context.WithCancel(ctx)
+		timer := time.AfterFunc(CallTimeout, cancel)
+		cs, err := streamer(attempt, desc, m.conn, method, opts...)
+		opened := timer.Stop()
+		if err == nil && opened {
+			return &cancelStream{ClientStream: cs, cancel: cancel}, nil
+		}
+		cancel()
+		if err == nil {
+			err = status.Errorf(codes.DeadlineExceeded, "open stream %s timeout", method)
+		} else if !opened && status.Code(err) == codes.Canceled {
+			err = status.Errorf(codes.DeadlineExceeded, "open stream %s timeout: %v", method, err)
+		}
+		last = err
+		if !shouldFailover(ctx, err) {
+			return nil, err
+		}
+		p.setHealthy(m, false, err)
+	}
+}
+
+// cancelStream release context of stream when the stream ends
+type cancelStream struct {
+	grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+func (s *cancelStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.cancel()
+	}
+	return err
+}
+
+func (p *Pool) snapshot() []*member {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]*member{}, p.members...)
+}
+
+// check health of all members
+func (p *Pool) check() {
+	var wg sync.WaitGroup
+	for _, m := range p.snapshot() {
+		wg.Add(1)
+		go func(m *member) {
+			defer wg.Done()
+			var err error
+			if p.health != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), CheckTimeout)
+				err = p.health(pinned(ctx), m.conn)
+				cancel()
+			} else if state := m.conn.GetState(); state == connectivity.TransientFailure || state == connectivity.Shutdown {
+				err = errors.New(state.String())
+			}
+			p.setHealthy(m, err == nil, err)
+		}(m)
+	}
+	wg.Wait()
+}
+
+// refresh tracker list, trackers no longer listed are removed except seeds, entry connection
+// belongs to a seed so it is never closed here
+func (p *Pool) refresh() {
+	if p.servers == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), CheckTimeout)
+	addrs, err := p.servers(ctx, p.entry)
+	cancel()
+	if err != nil {
+		p.log.WithError(err).Warn("Get tracker list failed")
+		return
+	}
+	if len(addrs) == 0 {
+		return
+	}
+	listed := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		listed[addr] = true
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	current := p.members[p.current]
+	members := []*member{}
+	for _, m := range p.members {
+		if m.seed || listed[m.addr] {
+			members = append(members, m)
+		} else {
+			p.log.Infof("Tracker %s removed", m.addr)
+			m.conn.Close()
+		}
+	}
+	p.members = members
+	for _, addr := range addrs {
+		if p.find(addr) != nil {
+			continue
+		}
+		conn, err := p.dial(addr)
+		if err != nil {
+			p.log.WithError(err).Warnf("Dial tracker %s failed", addr)
+			continue
+		}
+		p.log.Infof("Tracker %s added", addr)
+		p.members = append(p.members, &member{addr: addr, conn: conn, healthy: true})
+	}
+	p.current = 0
+	for i, m := range p.members {
+		if m == current {
+			p.current = i
+		}
+	}
+}
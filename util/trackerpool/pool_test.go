@@ -0,0 +1,181 @@
+package trackerpool
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	mpb "github.com/samoslab/nebula/tracker/metadata/pb"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeTracker struct {
+	mpb.MatadataServiceServer
+	mutex  sync.Mutex
+	pings  int
+	device []byte
+	addr   string
+	srv    *grpc.Server
+	hang   bool // accept RPC but never answer
+}
+
+func (t *fakeTracker) Ping(ctx context.Context, req *mpb.PingReq) (*mpb.PingResp, error) {
+	t.mutex.Lock()
+	hang := t.hang
+	t.mutex.Unlock()
+	if hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.pings++
+	t.device = mpb.DeviceIdFromContext(ctx)
+	return &mpb.PingResp{}, nil
+}
+
+func (t *fakeTracker) count() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.pings
+}
+
+func startTracker(t *testing.T) *fakeTracker {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ft := &fakeTracker{addr: lis.Addr().String(), srv: grpc.NewServer()}
+	mpb.RegisterMatadataServiceServer(ft.srv, ft)
+	go ft.srv.Serve(lis)
+	return ft
+}
+
+func ping(conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return PingTracker(ctx, conn)
+}
+
+func TestSplit(t *testing.T) {
+	require.Equal(t, []string{"a:1", "b:2"}, Split(" a:1, ,b:2 "))
+	require.Empty(t, Split(""))
+	_, err := New(logrus.New(), nil, nil, nil)
+	require.Equal(t, ErrNoServer, err)
+}
+
+func TestPoolFailover(t *testing.T) {
+	t1 := startTracker(t)
+	t2 := startTracker(t)
+	defer t2.srv.Stop()
+	p, err := New(logrus.New(), []string{t1.addr, t2.addr, t1.addr}, PingTracker, nil)
+	require.NoError(t, err)
+	defer p.Close()
+	require.Equal(t, []string{t1.addr, t2.addr}, p.Servers())
+
+	require.NoError(t, ping(p.Conn()))
+	require.Equal(t, 1, t1.count())
+	require.Equal(t, t1.addr, p.Current())
+
+	// first tracker down, call is routed to the second one transparently
+	t1.srv.Stop()
+	require.NoError(t, ping(p.Conn()))
+	require.Equal(t, 1, t2.count())
+	require.Equal(t, t2.addr, p.Current())
+
+	p.check()
+	p.mutex.Lock()
+	require.False(t, p.members[0].healthy)
+	require.True(t, p.members[1].healthy)
+	p.mutex.Unlock()
+
+	t2.srv.Stop()
+	err = ping(p.Conn())
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestPoolCallTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { CallTimeout = timeout }(CallTimeout)
+	CallTimeout = 100 * time.Millisecond
+	t1 := startTracker(t)
+	defer t1.srv.Stop()
+	t1.hang = true
+	t2 := startTracker(t)
+	defer t2.srv.Stop()
+	p, err := New(logrus.New(), []string{t1.addr, t2.addr}, PingTracker, nil)
+	require.NoError(t, err)
+	defer p.Close()
+
+	// caller without deadline does not hang on tracker which never answers
+	require.NoError(t, PingTracker(context.Background(), p.Conn()))
+	require.Equal(t, 1, t2.count())
+	require.Equal(t, t2.addr, p.Current())
+	p.mutex.Lock()
+	require.False(t, p.members[0].healthy)
+	p.mutex.Unlock()
+
+	// deadline of caller expires first, no failover
+	t2.mutex.Lock()
+	t2.hang = true
+	t2.mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = PingTracker(ctx, p.Conn())
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	p.mutex.Lock()
+	require.True(t, p.members[1].healthy)
+	p.mutex.Unlock()
+
+	// all trackers time out
+	err = PingTracker(context.Background(), p.Conn())
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestPoolRefresh(t *testing.T) {
+	t1 := startTracker(t)
+	defer t1.srv.Stop()
+	t2 := startTracker(t)
+	defer t2.srv.Stop()
+	listed := []string{t1.addr, t2.addr}
+	servers := func(ctx context.Context, conn *grpc.ClientConn) ([]string, error) {
+		return listed, nil
+	}
+	p, err := New(logrus.New(), []string{t1.addr}, PingTracker, servers)
+	require.NoError(t, err)
+	defer p.Close()
+
+	p.refresh()
+	require.Equal(t, []string{t1.addr, t2.addr}, p.Servers())
+
+	// seed stays even if not listed
+	listed = []string{t2.addr}
+	p.refresh()
+	require.Equal(t, []string{t1.addr, t2.addr}, p.Servers())
+
+	listed = []string{"127.0.0.1:1"}
+	p.refresh()
+	require.Equal(t, []string{t1.addr, "127.0.0.1:1"}, p.Servers())
+}
+
+func TestPoolHeader(t *testing.T) {
+	t1 := startTracker(t)
+	defer t1.srv.Stop()
+	p, err := New(logrus.New(), []string{t1.addr}, nil, nil)
+	require.NoError(t, err)
+	defer p.Close()
+
+	require.NoError(t, ping(p.Conn()))
+	require.Nil(t, t1.device)
+
+	p.SetHeader(mpb.DeviceIdHeader, "0a0b")
+	require.NoError(t, ping(p.Conn()))
+	require.Equal(t, []byte{0x0a, 0x0b}, t1.device)
+
+	p.SetHeader(mpb.DeviceIdHeader, "")
+	require.NoError(t, ping(p.Conn()))
+	require.Nil(t, t1.device)
+}